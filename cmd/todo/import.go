@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sethvargo/go-envconfig"
+
+	"github.com/henryhall897/golang-todo-app/database"
+	"github.com/henryhall897/golang-todo-app/internal/config"
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+)
+
+// importCommand is the `todo import` subcommand name.
+const importCommand = "import"
+
+// mappingFlags collects repeated -map field=column flags
+type mappingFlags []string
+
+func (m *mappingFlags) String() string { return strings.Join(*m, ",") }
+
+func (m *mappingFlags) Set(value string) error {
+	*m = append(*m, value)
+	return nil
+}
+
+// runImport imports a CSV or JSON file of tasks into a list and prints the per-row report as JSON.
+// Only the database and logging settings are read from the environment.
+func runImport(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet(importCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: todo import -list <id> -user <id> [-format csv|json] [-map field=column]... [-dry-run] <file>")
+		flags.PrintDefaults()
+	}
+
+	listIDStr := flags.String("list", "", "ID of the list to import into")
+	userIDStr := flags.String("user", "", "ID of the user owning the list")
+	format := flags.String("format", "", "file format, csv or json (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate and import inside a transaction that is rolled back")
+	var mappings mappingFlags
+	flags.Var(&mappings, "map", "CSV column for a task field as field=column (repeatable)")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected exactly one file")
+	}
+	path := flags.Arg(0)
+
+	listID, err := uuid.Parse(*listIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid -list: %w", err)
+	}
+	userID, err := uuid.Parse(*userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid -user: %w", err)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	mapping, err := tasks.ParseImportMapping(mappings)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	importTasks, rowErrors, err := tasks.ParseImport(*format, file, mapping, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	result := tasks.ImportResult{
+		Failed: len(rowErrors),
+		DryRun: *dryRun,
+		Errors: rowErrors,
+	}
+	if result.Errors == nil {
+		result.Errors = []tasks.ImportRowError{}
+	}
+
+	if len(importTasks) > 0 {
+		var loggingCfg config.LoggingConfig
+		if err := envconfig.Process(ctx, &loggingCfg); err != nil {
+			return fmt.Errorf("failed to load logging config: %w", err)
+		}
+		logger := logging.InitializeLogger(loggingCfg.Level, loggingCfg.Format).Sugar()
+		defer func() { _ = logger.Sync() }()
+
+		pool, err := database.InitializeDatabasePool(logger)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer pool.Close()
+
+		result.Imported, err = tasks.New(pool).ImportTasks(ctx, tasks.ImportTasksParams{
+			ListID: listID,
+			UserID: userID,
+			Tasks:  importTasks,
+			DryRun: *dryRun,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import tasks: %w", err)
+		}
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
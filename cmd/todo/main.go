@@ -3,12 +3,17 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"expvar"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Resolve user time zones on hosts without a zoneinfo database
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -16,10 +21,62 @@ import (
 	"github.com/henryhall897/golang-todo-app/database"
 	"github.com/henryhall897/golang-todo-app/internal/config"
 	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+	"github.com/henryhall897/golang-todo-app/internal/events"
 	"github.com/henryhall897/golang-todo-app/internal/middleware"
+	"github.com/henryhall897/golang-todo-app/internal/notify"
 	"github.com/henryhall897/golang-todo-app/internal/router"
+	"github.com/henryhall897/golang-todo-app/internal/scheduler"
 	"github.com/henryhall897/golang-todo-app/internal/server"
 
+	// Audit packages
+	audithandlers "github.com/henryhall897/golang-todo-app/internal/audit/handler"
+	auditrepo "github.com/henryhall897/golang-todo-app/internal/audit/repository"
+	auditroutes "github.com/henryhall897/golang-todo-app/internal/audit/routes"
+	auditservices "github.com/henryhall897/golang-todo-app/internal/audit/services"
+
+	// Auth packages
+	authdomain "github.com/henryhall897/golang-todo-app/internal/auth/domain"
+	authrepo "github.com/henryhall897/golang-todo-app/internal/auth/repository"
+
+	// Cache admin packages
+	cacheadminhandlers "github.com/henryhall897/golang-todo-app/internal/cacheadmin/handler"
+	cacheadminroutes "github.com/henryhall897/golang-todo-app/internal/cacheadmin/routes"
+
+	// CalDAV packages
+	caldavhandlers "github.com/henryhall897/golang-todo-app/internal/caldav/handler"
+	caldavroutes "github.com/henryhall897/golang-todo-app/internal/caldav/routes"
+
+	// Health packages
+	healthhandlers "github.com/henryhall897/golang-todo-app/internal/health/handler"
+
+	// Privacy packages
+	privacycache "github.com/henryhall897/golang-todo-app/internal/privacy/cache"
+	privacyexport "github.com/henryhall897/golang-todo-app/internal/privacy/export"
+	privacyhandlers "github.com/henryhall897/golang-todo-app/internal/privacy/handler"
+	privacyrepo "github.com/henryhall897/golang-todo-app/internal/privacy/repository"
+	privacyroutes "github.com/henryhall897/golang-todo-app/internal/privacy/routes"
+	privacyservices "github.com/henryhall897/golang-todo-app/internal/privacy/services"
+
+	// Realtime packages
+	"github.com/henryhall897/golang-todo-app/internal/realtime"
+	realtimehandlers "github.com/henryhall897/golang-todo-app/internal/realtime/handler"
+	realtimeroutes "github.com/henryhall897/golang-todo-app/internal/realtime/routes"
+
+	// Task packages
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	taskhandlers "github.com/henryhall897/golang-todo-app/internal/tasks/handler"
+	taskroutes "github.com/henryhall897/golang-todo-app/internal/tasks/routes"
+
+	// Todo list packages
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+
+	// Webhook packages
+	webhookdelivery "github.com/henryhall897/golang-todo-app/internal/webhooks/delivery"
+	webhookhandlers "github.com/henryhall897/golang-todo-app/internal/webhooks/handler"
+	webhookrepo "github.com/henryhall897/golang-todo-app/internal/webhooks/repository"
+	webhookroutes "github.com/henryhall897/golang-todo-app/internal/webhooks/routes"
+	webhookservices "github.com/henryhall897/golang-todo-app/internal/webhooks/services"
+
 	//User packages
 	usercache "github.com/henryhall897/golang-todo-app/internal/users/cache"
 	userdomains "github.com/henryhall897/golang-todo-app/internal/users/domain"
@@ -49,6 +106,14 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// Subcommands only need part of the configuration, so dispatch before loading it
+	if len(os.Args) > 1 && os.Args[1] == importCommand {
+		if err := runImport(ctx, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+			log.Fatalf("Import failed: %v", err)
+		}
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfig(ctx)
 	if err != nil {
@@ -113,46 +178,148 @@ func run(ctx context.Context, logger *zap.SugaredLogger, cfg *config.AppConfig)
 
 	// Initialize Redis Client
 	logger.Info("Initializing Redis connection")
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     cfg.Redis.Address,
-		Password: cfg.Redis.Password, // Leave empty if no password
-		DB:       cfg.Redis.DB,       // Use default DB
-	})
-
-	// Check Redis connectivity
-	_, err = redisClient.Ping(ctx).Result()
+	redisClient, err := newRedisClient(cfg.Redis)
 	if err != nil {
-		return fmt.Errorf("failed to connect to Redis: %w", err)
+		return err
+	}
+	defer redisClient.Close()
+
+	// Check Redis connectivity; without it, caches fall through to the database until it recovers
+	breaker := rediswrapper.NewBreaker(redisClient, cfg.Redis.BreakerThreshold, cfg.Redis.BreakerInterval, logger)
+	if err := breaker.Probe(ctx); err != nil {
+		logger.Warnw("Redis unavailable; starting without the cache", "error", err)
+	} else {
+		logger.Info("Redis connection established successfully")
 	}
-	logger.Info("Redis connection established successfully")
+	go breaker.Run(ctx)
 
 	// Initialize Redis cache
+	codec, err := rediswrapper.CodecByName(cfg.Redis.Codec)
+	if err != nil {
+		return err
+	}
 	// Generic redis cache
-	genericCache := rediswrapper.NewJSONCache(redisClient, userdomains.RedisPrefix, logger)
-	//User specific redis cache
-	userCache := usercache.NewRedisUser(genericCache)
+	genericCache := rediswrapper.NewCacheWithCodec(redisClient, userdomains.RedisPrefix, codec, logger).WithBreaker(breaker)
+	//User specific redis cache, optionally fronted by an in-process cache
+	var userBackend rediswrapper.Cache = genericCache
+	if cfg.Redis.LocalCacheSize > 0 {
+		tieredCache := rediswrapper.NewTieredCache(genericCache, cfg.Redis.LocalCacheSize, cfg.Redis.LocalCacheTTL)
+		go runWithRetry(ctx, "Cache invalidation subscription", cfg.Redis.BreakerInterval, logger, tieredCache.Run)
+		expvar.Publish("cache.users", expvar.Func(func() any { return tieredCache.Stats() }))
+		userBackend = tieredCache
+	}
+	userCache := usercache.NewRedisUser(userBackend)
+	// Task statistics cache
+	statsCache := rediswrapper.NewCacheWithCodec(redisClient, tasks.StatsRedisPrefix, codec, logger).WithBreaker(breaker)
+	// Reminder de-duplication markers
+	reminderCache := rediswrapper.NewCacheWithCodec(redisClient, scheduler.ReminderRedisPrefix, codec, logger).WithBreaker(breaker)
+	// Daily digest de-duplication markers
+	digestCache := rediswrapper.NewCacheWithCodec(redisClient, scheduler.DigestRedisPrefix, codec, logger).WithBreaker(breaker)
 
 	// Initialize stores
+	auditStore := auditrepo.New(pool)
 	userStore := userrepo.New(pool)
+	taskStore := tasks.New(pool)
+	webhookStore := webhookrepo.New(pool)
+	listStore := todolist.New(pool)
+	privacyStore := privacyrepo.New(pool)
+
+	// Realtime hub fanning list events out to this replica's streams
+	realtimeHub := realtime.NewHub(redisClient, logger)
+	presence := realtime.NewPresence(redisClient, logger)
 
 	// Initialize services
-	userService := userservices.New(userStore, userCache, logger)
+	auditService := auditservices.New(auditStore, logger)
+	authStore := authrepo.New(pool, auditService)
+	userService := userservices.New(userStore, userCache, auditService, logger)
+	taskStats := tasks.NewCachedStats(taskStore, statsCache, logger)
+	webhookService := webhookservices.New(webhookStore, logger)
+	cachePurger := privacycache.NewPurger(userCache, statsCache, digestCache, redisClient)
+	privacyService := privacyservices.New(privacyStore, userStore, cachePurger, auditService, logger)
 
 	// Initialize HTTP handlers
 	userHandler := userhandlers.New(userService, logger)
+	taskHandler := taskhandlers.New(taskStore, taskStats, listStore, logger)
+	auditHandler := audithandlers.New(auditService, logger)
+	webhookHandler := webhookhandlers.New(webhookService, logger)
+	privacyHandler := privacyhandlers.New(privacyService, logger)
+	realtimeHandler := realtimehandlers.New(realtimeHub, presence, listStore, taskStore, cfg.Server.CorsOrigin, logger)
+	caldavHandler := caldavhandlers.New(taskStore, listStore, logger)
+	healthHandler := healthhandlers.New(pool, breaker, logger)
+	cacheAdminHandler := cacheadminhandlers.New(map[string]rediswrapper.Cache{
+		"users":     userBackend,
+		"stats":     statsCache,
+		"reminders": reminderCache,
+		"digests":   digestCache,
+	}, userService, logger)
+
+	// Admin-only routes require the caller's auth identity to have the admin role
+	requireAdmin := middleware.RequireRole(authStore, authdomain.RoleAdmin)
 
 	// Register route functions
 	routeFuncs := []router.RouteRegisterFunc{
-		userroutes.RegisterRoutes,
+		func(mux *http.ServeMux) { userroutes.RegisterRoutes(mux, userHandler) },
+		func(mux *http.ServeMux) { taskroutes.RegisterRoutes(mux, taskHandler) },
+		func(mux *http.ServeMux) { auditroutes.RegisterRoutes(mux, auditHandler, requireAdmin) },
+		func(mux *http.ServeMux) { webhookroutes.RegisterRoutes(mux, webhookHandler) },
+		func(mux *http.ServeMux) { realtimeroutes.RegisterRoutes(mux, realtimeHandler) },
+		func(mux *http.ServeMux) { privacyroutes.RegisterRoutes(mux, privacyHandler) },
+		func(mux *http.ServeMux) { caldavroutes.RegisterRoutes(mux, caldavHandler) },
+		func(mux *http.ServeMux) { cacheadminroutes.RegisterRoutes(mux, cacheAdminHandler, requireAdmin) },
+		// Handle `/healthz` (Service status, including the Redis circuit breaker's state)
+		func(mux *http.ServeMux) { mux.HandleFunc("GET /healthz", healthHandler.HealthHandler) },
+		// Handle `/admin/metrics` (Process metrics, including cache hit counts)
+		func(mux *http.ServeMux) { mux.Handle("GET /admin/metrics", requireAdmin(expvar.Handler())) },
 	}
 
 	// Initialize the router
-	rt := router.NewRouter(routeFuncs, []userhandlers.Handler{*userHandler})
+	rt := router.NewRouter(routeFuncs)
+
+	// TODO - Add more route modules here (e.g., lists)
+
+	// Initialize the notifier
+	notifier, err := newNotifier(cfg.Notifier, logger)
+	if err != nil {
+		return err
+	}
+
+	// Initialize the domain event bus
+	bus, err := newEventBus(cfg.Events, redisClient, logger)
+	if err != nil {
+		return err
+	}
+
+	// Broadcast task changes to the list's realtime subscribers on every replica
+	broadcaster := realtime.NewBroadcaster(redisClient, logger)
+	go func() {
+		if err := bus.Subscribe(ctx, broadcaster.Handle); err != nil && ctx.Err() == nil {
+			logger.Errorw("Event subscription stopped", "error", err)
+		}
+	}()
+	go runWithRetry(ctx, "Realtime hub", cfg.Redis.BreakerInterval, logger, realtimeHub.Run)
+
+	// Start background jobs; they stop when ctx is cancelled
+	jobs := scheduler.New(logger)
+	jobs.Add(scheduler.NewReminderJob(taskStore, reminderCache, notifier, cfg.Scheduler.ReminderLeadTime, logger), cfg.Scheduler.ReminderInterval)
+	jobs.Add(scheduler.NewDigestJob(userStore, taskStore, digestCache, notifier, cfg.Scheduler.DigestHour, logger), cfg.Scheduler.DigestInterval)
+	jobs.Add(webhookdelivery.NewWorker(webhookStore, nil, logger), cfg.Scheduler.WebhookInterval)
+	jobs.Add(privacyexport.NewWorker(privacyStore, userStore, cfg.Privacy.ExportDir, cfg.Privacy.ExportRetention, logger), cfg.Privacy.ExportInterval)
+	jobs.Add(events.NewRelay(events.NewOutboxStore(pool), bus, cfg.Events.OutboxRetention, logger), cfg.Events.RelayInterval)
+	go jobs.Run(ctx)
 
-	// TODO - Add more route modules here (e.g., tasks, lists)
+	// Preload the most recently active users so their lookups hit the cache after a deploy
+	if cfg.Redis.WarmUsers > 0 {
+		go func() {
+			if _, err := userService.WarmCache(ctx, cfg.Redis.WarmUsers); err != nil {
+				logger.Warnw("Cache warming failed", "error", err)
+			}
+		}()
+	}
 
-	// Apply CORS middleware to router
-	corsWrappedHandler := middleware.CORS(cfg.Server.CorsOrigin)(rt.LimitedHandler)
+	// Tag each request with an ID and verify the caller's identity, then apply CORS middleware to router
+	identityHandler := middleware.Identity([]byte(cfg.Server.IdentitySecret))(rt.LimitedHandler)
+	requestIDHandler := middleware.RequestID(identityHandler)
+	corsWrappedHandler := middleware.CORS(cfg.Server.CorsOrigin)(requestIDHandler)
 
 	// Start the HTTP server
 	srv := server.NewHTTPServer(&config.ServerConfig{
@@ -162,3 +329,145 @@ func run(ctx context.Context, logger *zap.SugaredLogger, cfg *config.AppConfig)
 	})
 	return srv.Serve(ctx, corsWrappedHandler)
 }
+
+// runWithRetry runs a Redis subscription until ctx is cancelled, restarting it after delay when it stops,
+// so subscriptions that fail while Redis is unavailable resume once it recovers.
+func runWithRetry(ctx context.Context, name string, delay time.Duration, logger *zap.SugaredLogger, run func(ctx context.Context) error) {
+	for {
+		err := run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Errorw(name+" stopped; restarting", "error", err, "retry_in", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// newNotifier builds the Notifier selected by the configuration.
+func newNotifier(cfg config.NotifierConfig, logger *zap.SugaredLogger) (notify.Notifier, error) {
+	switch cfg.Driver {
+	case "log":
+		return notify.NewLogNotifier(logger), nil
+	case "smtp":
+		return notify.NewSMTPNotifier(notify.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}), nil
+	case "outbox":
+		return notify.NewFileOutbox(cfg.OutboxDir, cfg.SMTPFrom)
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Driver)
+	}
+}
+
+// newRedisClient builds the Redis client for the deployment selected by the configuration.
+func newRedisClient(cfg config.RedisConfig) (redis.UniversalClient, error) {
+	if len(cfg.Address) == 0 {
+		return nil, fmt.Errorf("no Redis address configured")
+	}
+	tlsConfig, err := newRedisTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Mode {
+	case "standalone":
+		if len(cfg.Address) > 1 {
+			return nil, fmt.Errorf("standalone Redis takes one address, got %d", len(cfg.Address))
+		}
+		return redis.NewClient(&redis.Options{
+			Addr:      cfg.Address[0],
+			Username:  cfg.Username,
+			Password:  cfg.Password, // Leave empty if no password
+			DB:        cfg.DB,
+			TLSConfig: tlsConfig,
+		}), nil
+	case "sentinel":
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("sentinel Redis requires REDIS_SENTINEL_MASTER")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.Address,
+			SentinelUsername: cfg.SentinelUsername,
+			SentinelPassword: cfg.SentinelPassword,
+			Username:         cfg.Username,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+			TLSConfig:        tlsConfig,
+		}), nil
+	case "cluster":
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     cfg.Address,
+			Username:  cfg.Username,
+			Password:  cfg.Password,
+			TLSConfig: tlsConfig,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown Redis mode %q", cfg.Mode)
+	}
+}
+
+// newRedisTLSConfig builds the TLS configuration for Redis connections, or nil when TLS is not enabled.
+func newRedisTLSConfig(cfg config.RedisConfig) (*tls.Config, error) {
+	if !cfg.TLS && cfg.TLSCAFile == "" && cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.TLSServerName,
+	}
+	if cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Redis CA file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in Redis CA file %s", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// newEventBus builds the EventBus selected by the configuration.
+func newEventBus(cfg config.EventsConfig, client redis.UniversalClient, logger *zap.SugaredLogger) (events.EventBus, error) {
+	switch cfg.Bus {
+	case "memory":
+		return events.NewInProcessBus(), nil
+	case "redis":
+		consumer := cfg.Consumer
+		if consumer == "" {
+			host, err := os.Hostname()
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve event consumer name: %w", err)
+			}
+			consumer = host
+		}
+		return events.NewRedisStreamBus(client, events.RedisStreamConfig{
+			Stream:   cfg.Stream,
+			Group:    cfg.Group,
+			Consumer: consumer,
+			MaxLen:   cfg.StreamMaxLen,
+		}, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown event bus %q", cfg.Bus)
+	}
+}
@@ -0,0 +1,2 @@
+-- Drop the task_events table if it exists
+DROP TABLE IF EXISTS task_events;
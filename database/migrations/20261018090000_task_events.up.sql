@@ -0,0 +1,17 @@
+-- 20261018090000_task_events.up.sql
+
+-- Create the task_events table holding the change history of tasks.
+-- task_id has no foreign key so that history outlives deleted tasks.
+CREATE TABLE IF NOT EXISTS task_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL,
+    list_id UUID NOT NULL REFERENCES todolists(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    event_type TEXT NOT NULL,               -- e.g., "updated", "completed", "priority_changed", "deleted"
+    changes JSONB NOT NULL DEFAULT '{}',    -- field name -> {"old": ..., "new": ...}
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index used to page through a task's history, newest first
+CREATE INDEX IF NOT EXISTS task_events_task_id_created_at_idx
+    ON task_events (task_id, created_at DESC);
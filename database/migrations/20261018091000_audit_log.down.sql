@@ -0,0 +1,2 @@
+-- Drop the audit_log table if it exists
+DROP TABLE IF EXISTS audit_log;
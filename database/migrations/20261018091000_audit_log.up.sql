@@ -0,0 +1,23 @@
+-- 20261018091000_audit_log.up.sql
+
+-- Create the append-only audit_log table recording mutating operations.
+-- actor_id has no foreign key so that entries outlive deleted users.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID,                          -- NULL for system or unauthenticated actions
+    action TEXT NOT NULL,                   -- e.g., "user.create", "auth_identity.update_role"
+    target_type TEXT NOT NULL,              -- e.g., "user", "auth_identity"
+    target_id TEXT NOT NULL,
+    before JSONB,                           -- State before the change (NULL on create)
+    after JSONB,                            -- State after the change (NULL on delete)
+    request_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes used by the admin query filters, newest first
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx
+    ON audit_log (created_at DESC);
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_created_at_idx
+    ON audit_log (actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS audit_log_target_created_at_idx
+    ON audit_log (target_type, target_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Drop the full-text search index and column
+DROP INDEX IF EXISTS tasks_search_vector_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,13 @@
+-- 20261018092000_task_search.up.sql
+
+-- Add a generated full-text search vector over task titles (weight A) and descriptions (weight B)
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+-- GIN index used by full-text task search
+CREATE INDEX IF NOT EXISTS tasks_search_vector_idx
+    ON tasks USING GIN (search_vector);
@@ -0,0 +1,3 @@
+-- Drop the task tags index and column
+DROP INDEX IF EXISTS tasks_tags_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,9 @@
+-- 20261018093000_task_tags.up.sql
+
+-- Add free-form tags to tasks
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- GIN index used by tag containment filters
+CREATE INDEX IF NOT EXISTS tasks_tags_idx
+    ON tasks USING GIN (tags);
@@ -0,0 +1,4 @@
+-- 20261018094000_user_preferences.down.sql
+
+DROP INDEX IF EXISTS user_preferences_digest_idx;
+DROP TABLE IF EXISTS user_preferences;
@@ -0,0 +1,15 @@
+-- 20261018094000_user_preferences.up.sql
+
+-- Per-user notification settings
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    digest_enabled BOOLEAN NOT NULL DEFAULT false,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Partial index used to find digest subscribers
+CREATE INDEX IF NOT EXISTS user_preferences_digest_idx
+    ON user_preferences (user_id)
+    WHERE digest_enabled;
@@ -0,0 +1,7 @@
+-- 20261018095000_webhooks.down.sql
+
+DROP INDEX IF EXISTS webhook_deliveries_subscription_id_created_at_idx;
+DROP INDEX IF EXISTS webhook_deliveries_pending_idx;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP INDEX IF EXISTS webhook_subscriptions_user_id_idx;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,43 @@
+-- 20261018095000_webhooks.up.sql
+
+-- Create the webhook_subscriptions table: endpoints users registered for push notifications
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,                   -- HMAC-SHA256 signing key
+    event_types TEXT[] NOT NULL,            -- e.g., {"task.created", "task.completed"}
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_subscriptions_user_id_idx
+    ON webhook_subscriptions (user_id);
+
+-- Create the webhook_deliveries table: the durable delivery queue and its log.
+-- A row is enqueued per matching subscription in the transaction that produced the event.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,                 -- Shared by every delivery of the same event
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending', -- "pending", "succeeded" or "failed"
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INT,                   -- HTTP status of the last attempt, NULL if no response
+    last_error TEXT,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index used by the delivery worker to claim due deliveries
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx
+    ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+
+-- Index used by the delivery log, newest first
+CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_id_created_at_idx
+    ON webhook_deliveries (subscription_id, created_at DESC);
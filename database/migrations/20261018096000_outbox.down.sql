@@ -0,0 +1,5 @@
+-- 20261018096000_outbox.down.sql
+
+DROP INDEX IF EXISTS outbox_events_published_at_idx;
+DROP INDEX IF EXISTS outbox_events_unpublished_idx;
+DROP TABLE IF EXISTS outbox_events;
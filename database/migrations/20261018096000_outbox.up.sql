@@ -0,0 +1,23 @@
+-- 20261018096000_outbox.up.sql
+
+-- Create the outbox_events table: domain events written in the same transaction as the change
+-- that produced them, and published to the event bus by the outbox relay.
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    aggregate_type TEXT NOT NULL,           -- e.g., 'user', 'list', 'task'
+    aggregate_id UUID NOT NULL,
+    event_type TEXT NOT NULL,               -- e.g., 'task.created'
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP                  -- NULL until the relay has published the event
+);
+
+-- The relay scans unpublished events in commit order
+CREATE INDEX IF NOT EXISTS outbox_events_unpublished_idx
+    ON outbox_events (created_at, id)
+    WHERE published_at IS NULL;
+
+-- Published events are pruned by age
+CREATE INDEX IF NOT EXISTS outbox_events_published_at_idx
+    ON outbox_events (published_at)
+    WHERE published_at IS NOT NULL;
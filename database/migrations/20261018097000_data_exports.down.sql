@@ -0,0 +1,5 @@
+-- 20261018097000_data_exports.down.sql
+
+DROP INDEX IF EXISTS data_exports_pending_idx;
+DROP INDEX IF EXISTS data_exports_user_id_idx;
+DROP TABLE IF EXISTS data_exports;
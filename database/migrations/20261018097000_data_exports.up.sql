@@ -0,0 +1,24 @@
+-- 20261018097000_data_exports.up.sql
+
+-- Create the data_exports table: queued "export my data" requests and the archives they produced
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending', -- "pending", "running", "completed" or "failed"
+    file_path TEXT,                         -- Location of the zip archive once completed
+    size_bytes BIGINT,
+    error TEXT,
+    lease_until TIMESTAMP,                  -- A running export whose lease expired is claimed again
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP                    -- The archive is deleted after this time
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_user_id_idx
+    ON data_exports (user_id);
+
+-- Index used by the export worker to claim queued exports
+CREATE INDEX IF NOT EXISTS data_exports_pending_idx
+    ON data_exports (created_at)
+    WHERE status IN ('pending', 'running');
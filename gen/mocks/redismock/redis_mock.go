@@ -20,21 +20,57 @@ var _ redis.Cache = &CacheMock{}
 //
 //		// make and configure a mocked redis.Cache
 //		mockedCache := &CacheMock{
+//			CoalesceFunc: func(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+//				panic("mock out the Coalesce method")
+//			},
+//			CountByPrefixFunc: func(ctx context.Context, prefix string) (int64, error) {
+//				panic("mock out the CountByPrefix method")
+//			},
 //			DeleteFunc: func(ctx context.Context, key string) error {
 //				panic("mock out the Delete method")
 //			},
+//			DeleteByPrefixFunc: func(ctx context.Context, prefix string) (int64, error) {
+//				panic("mock out the DeleteByPrefix method")
+//			},
+//			DeleteManyFunc: func(ctx context.Context, keys []string) (int64, error) {
+//				panic("mock out the DeleteMany method")
+//			},
 //			GetFunc: func(ctx context.Context, key string, dest interface{}) error {
 //				panic("mock out the Get method")
 //			},
+//			GetByPointerFunc: func(ctx context.Context, pointer string, dest interface{}) error {
+//				panic("mock out the GetByPointer method")
+//			},
+//			GetManyFunc: func(ctx context.Context, keys []string, dest interface{}) ([]int, error) {
+//				panic("mock out the GetMany method")
+//			},
+//			GetOrLoadFunc: func(ctx context.Context, key string, dest interface{}, opts redis.LoadOptions, load func(ctx context.Context) (any, error)) error {
+//				panic("mock out the GetOrLoad method")
+//			},
 //			GetPointerFunc: func(ctx context.Context, key string) (string, error) {
 //				panic("mock out the GetPointer method")
 //			},
+//			IncrFunc: func(ctx context.Context, key string) (int64, error) {
+//				panic("mock out the Incr method")
+//			},
 //			SetFunc: func(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 //				panic("mock out the Set method")
 //			},
+//			SetCounterIfNotExistsFunc: func(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+//				panic("mock out the SetCounterIfNotExists method")
+//			},
+//			SetIfNotExistsFunc: func(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+//				panic("mock out the SetIfNotExists method")
+//			},
+//			SetManyFunc: func(ctx context.Context, entries map[string]interface{}, ttl time.Duration) error {
+//				panic("mock out the SetMany method")
+//			},
 //			SetPointerFunc: func(ctx context.Context, key string, targetKey string, ttl time.Duration) error {
 //				panic("mock out the SetPointer method")
 //			},
+//			SetWithPointersFunc: func(ctx context.Context, key string, value interface{}, pointers []string, ttl time.Duration) error {
+//				panic("mock out the SetWithPointers method")
+//			},
 //		}
 //
 //		// use mockedCache in code that requires redis.Cache
@@ -42,23 +78,75 @@ var _ redis.Cache = &CacheMock{}
 //
 //	}
 type CacheMock struct {
+	// CoalesceFunc mocks the Coalesce method.
+	CoalesceFunc func(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error)
+
+	// CountByPrefixFunc mocks the CountByPrefix method.
+	CountByPrefixFunc func(ctx context.Context, prefix string) (int64, error)
+
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, key string) error
 
+	// DeleteByPrefixFunc mocks the DeleteByPrefix method.
+	DeleteByPrefixFunc func(ctx context.Context, prefix string) (int64, error)
+
+	// DeleteManyFunc mocks the DeleteMany method.
+	DeleteManyFunc func(ctx context.Context, keys []string) (int64, error)
+
 	// GetFunc mocks the Get method.
 	GetFunc func(ctx context.Context, key string, dest interface{}) error
 
+	// GetByPointerFunc mocks the GetByPointer method.
+	GetByPointerFunc func(ctx context.Context, pointer string, dest interface{}) error
+
+	// GetManyFunc mocks the GetMany method.
+	GetManyFunc func(ctx context.Context, keys []string, dest interface{}) ([]int, error)
+
+	// GetOrLoadFunc mocks the GetOrLoad method.
+	GetOrLoadFunc func(ctx context.Context, key string, dest interface{}, opts redis.LoadOptions, load func(ctx context.Context) (any, error)) error
+
 	// GetPointerFunc mocks the GetPointer method.
 	GetPointerFunc func(ctx context.Context, key string) (string, error)
 
+	// IncrFunc mocks the Incr method.
+	IncrFunc func(ctx context.Context, key string) (int64, error)
+
 	// SetFunc mocks the Set method.
 	SetFunc func(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 
+	// SetCounterIfNotExistsFunc mocks the SetCounterIfNotExists method.
+	SetCounterIfNotExistsFunc func(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error)
+
+	// SetIfNotExistsFunc mocks the SetIfNotExists method.
+	SetIfNotExistsFunc func(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// SetManyFunc mocks the SetMany method.
+	SetManyFunc func(ctx context.Context, entries map[string]interface{}, ttl time.Duration) error
+
 	// SetPointerFunc mocks the SetPointer method.
 	SetPointerFunc func(ctx context.Context, key string, targetKey string, ttl time.Duration) error
 
+	// SetWithPointersFunc mocks the SetWithPointers method.
+	SetWithPointersFunc func(ctx context.Context, key string, value interface{}, pointers []string, ttl time.Duration) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// Coalesce holds details about calls to the Coalesce method.
+		Coalesce []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Fn is the fn argument value.
+			Fn func(ctx context.Context) (any, error)
+		}
+		// CountByPrefix holds details about calls to the CountByPrefix method.
+		CountByPrefix []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prefix is the prefix argument value.
+			Prefix string
+		}
 		// Delete holds details about calls to the Delete method.
 		Delete []struct {
 			// Ctx is the ctx argument value.
@@ -66,6 +154,20 @@ type CacheMock struct {
 			// Key is the key argument value.
 			Key string
 		}
+		// DeleteByPrefix holds details about calls to the DeleteByPrefix method.
+		DeleteByPrefix []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prefix is the prefix argument value.
+			Prefix string
+		}
+		// DeleteMany holds details about calls to the DeleteMany method.
+		DeleteMany []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Keys is the keys argument value.
+			Keys []string
+		}
 		// Get holds details about calls to the Get method.
 		Get []struct {
 			// Ctx is the ctx argument value.
@@ -75,6 +177,37 @@ type CacheMock struct {
 			// Dest is the dest argument value.
 			Dest interface{}
 		}
+		// GetByPointer holds details about calls to the GetByPointer method.
+		GetByPointer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pointer is the pointer argument value.
+			Pointer string
+			// Dest is the dest argument value.
+			Dest interface{}
+		}
+		// GetMany holds details about calls to the GetMany method.
+		GetMany []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Keys is the keys argument value.
+			Keys []string
+			// Dest is the dest argument value.
+			Dest interface{}
+		}
+		// GetOrLoad holds details about calls to the GetOrLoad method.
+		GetOrLoad []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Dest is the dest argument value.
+			Dest interface{}
+			// Opts is the opts argument value.
+			Opts redis.LoadOptions
+			// Load is the load argument value.
+			Load func(ctx context.Context) (any, error)
+		}
 		// GetPointer holds details about calls to the GetPointer method.
 		GetPointer []struct {
 			// Ctx is the ctx argument value.
@@ -82,6 +215,13 @@ type CacheMock struct {
 			// Key is the key argument value.
 			Key string
 		}
+		// Incr holds details about calls to the Incr method.
+		Incr []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
 		// Set holds details about calls to the Set method.
 		Set []struct {
 			// Ctx is the ctx argument value.
@@ -93,6 +233,37 @@ type CacheMock struct {
 			// TTL is the ttl argument value.
 			TTL time.Duration
 		}
+		// SetCounterIfNotExists holds details about calls to the SetCounterIfNotExists method.
+		SetCounterIfNotExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value int64
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// SetIfNotExists holds details about calls to the SetIfNotExists method.
+		SetIfNotExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value interface{}
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// SetMany holds details about calls to the SetMany method.
+		SetMany []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entries is the entries argument value.
+			Entries map[string]interface{}
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
 		// SetPointer holds details about calls to the SetPointer method.
 		SetPointer []struct {
 			// Ctx is the ctx argument value.
@@ -104,12 +275,113 @@ type CacheMock struct {
 			// TTL is the ttl argument value.
 			TTL time.Duration
 		}
+		// SetWithPointers holds details about calls to the SetWithPointers method.
+		SetWithPointers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value interface{}
+			// Pointers is the pointers argument value.
+			Pointers []string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+	}
+	lockCoalesce              sync.RWMutex
+	lockCountByPrefix         sync.RWMutex
+	lockDelete                sync.RWMutex
+	lockDeleteByPrefix        sync.RWMutex
+	lockDeleteMany            sync.RWMutex
+	lockGet                   sync.RWMutex
+	lockGetByPointer          sync.RWMutex
+	lockGetMany               sync.RWMutex
+	lockGetOrLoad             sync.RWMutex
+	lockGetPointer            sync.RWMutex
+	lockIncr                  sync.RWMutex
+	lockSet                   sync.RWMutex
+	lockSetCounterIfNotExists sync.RWMutex
+	lockSetIfNotExists        sync.RWMutex
+	lockSetMany               sync.RWMutex
+	lockSetPointer            sync.RWMutex
+	lockSetWithPointers       sync.RWMutex
+}
+
+// Coalesce calls CoalesceFunc.
+func (mock *CacheMock) Coalesce(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	if mock.CoalesceFunc == nil {
+		panic("CacheMock.CoalesceFunc: method is nil but Cache.Coalesce was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+		Fn  func(ctx context.Context) (any, error)
+	}{
+		Ctx: ctx,
+		Key: key,
+		Fn:  fn,
+	}
+	mock.lockCoalesce.Lock()
+	mock.calls.Coalesce = append(mock.calls.Coalesce, callInfo)
+	mock.lockCoalesce.Unlock()
+	return mock.CoalesceFunc(ctx, key, fn)
+}
+
+// CoalesceCalls gets all the calls that were made to Coalesce.
+// Check the length with:
+//
+//	len(mockedCache.CoalesceCalls())
+func (mock *CacheMock) CoalesceCalls() []struct {
+	Ctx context.Context
+	Key string
+	Fn  func(ctx context.Context) (any, error)
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+		Fn  func(ctx context.Context) (any, error)
+	}
+	mock.lockCoalesce.RLock()
+	calls = mock.calls.Coalesce
+	mock.lockCoalesce.RUnlock()
+	return calls
+}
+
+// CountByPrefix calls CountByPrefixFunc.
+func (mock *CacheMock) CountByPrefix(ctx context.Context, prefix string) (int64, error) {
+	if mock.CountByPrefixFunc == nil {
+		panic("CacheMock.CountByPrefixFunc: method is nil but Cache.CountByPrefix was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Prefix string
+	}{
+		Ctx:    ctx,
+		Prefix: prefix,
+	}
+	mock.lockCountByPrefix.Lock()
+	mock.calls.CountByPrefix = append(mock.calls.CountByPrefix, callInfo)
+	mock.lockCountByPrefix.Unlock()
+	return mock.CountByPrefixFunc(ctx, prefix)
+}
+
+// CountByPrefixCalls gets all the calls that were made to CountByPrefix.
+// Check the length with:
+//
+//	len(mockedCache.CountByPrefixCalls())
+func (mock *CacheMock) CountByPrefixCalls() []struct {
+	Ctx    context.Context
+	Prefix string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Prefix string
 	}
-	lockDelete     sync.RWMutex
-	lockGet        sync.RWMutex
-	lockGetPointer sync.RWMutex
-	lockSet        sync.RWMutex
-	lockSetPointer sync.RWMutex
+	mock.lockCountByPrefix.RLock()
+	calls = mock.calls.CountByPrefix
+	mock.lockCountByPrefix.RUnlock()
+	return calls
 }
 
 // Delete calls DeleteFunc.
@@ -148,6 +420,78 @@ func (mock *CacheMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DeleteByPrefix calls DeleteByPrefixFunc.
+func (mock *CacheMock) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	if mock.DeleteByPrefixFunc == nil {
+		panic("CacheMock.DeleteByPrefixFunc: method is nil but Cache.DeleteByPrefix was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Prefix string
+	}{
+		Ctx:    ctx,
+		Prefix: prefix,
+	}
+	mock.lockDeleteByPrefix.Lock()
+	mock.calls.DeleteByPrefix = append(mock.calls.DeleteByPrefix, callInfo)
+	mock.lockDeleteByPrefix.Unlock()
+	return mock.DeleteByPrefixFunc(ctx, prefix)
+}
+
+// DeleteByPrefixCalls gets all the calls that were made to DeleteByPrefix.
+// Check the length with:
+//
+//	len(mockedCache.DeleteByPrefixCalls())
+func (mock *CacheMock) DeleteByPrefixCalls() []struct {
+	Ctx    context.Context
+	Prefix string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Prefix string
+	}
+	mock.lockDeleteByPrefix.RLock()
+	calls = mock.calls.DeleteByPrefix
+	mock.lockDeleteByPrefix.RUnlock()
+	return calls
+}
+
+// DeleteMany calls DeleteManyFunc.
+func (mock *CacheMock) DeleteMany(ctx context.Context, keys []string) (int64, error) {
+	if mock.DeleteManyFunc == nil {
+		panic("CacheMock.DeleteManyFunc: method is nil but Cache.DeleteMany was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Keys []string
+	}{
+		Ctx:  ctx,
+		Keys: keys,
+	}
+	mock.lockDeleteMany.Lock()
+	mock.calls.DeleteMany = append(mock.calls.DeleteMany, callInfo)
+	mock.lockDeleteMany.Unlock()
+	return mock.DeleteManyFunc(ctx, keys)
+}
+
+// DeleteManyCalls gets all the calls that were made to DeleteMany.
+// Check the length with:
+//
+//	len(mockedCache.DeleteManyCalls())
+func (mock *CacheMock) DeleteManyCalls() []struct {
+	Ctx  context.Context
+	Keys []string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Keys []string
+	}
+	mock.lockDeleteMany.RLock()
+	calls = mock.calls.DeleteMany
+	mock.lockDeleteMany.RUnlock()
+	return calls
+}
+
 // Get calls GetFunc.
 func (mock *CacheMock) Get(ctx context.Context, key string, dest interface{}) error {
 	if mock.GetFunc == nil {
@@ -188,6 +532,134 @@ func (mock *CacheMock) GetCalls() []struct {
 	return calls
 }
 
+// GetByPointer calls GetByPointerFunc.
+func (mock *CacheMock) GetByPointer(ctx context.Context, pointer string, dest interface{}) error {
+	if mock.GetByPointerFunc == nil {
+		panic("CacheMock.GetByPointerFunc: method is nil but Cache.GetByPointer was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Pointer string
+		Dest    interface{}
+	}{
+		Ctx:     ctx,
+		Pointer: pointer,
+		Dest:    dest,
+	}
+	mock.lockGetByPointer.Lock()
+	mock.calls.GetByPointer = append(mock.calls.GetByPointer, callInfo)
+	mock.lockGetByPointer.Unlock()
+	return mock.GetByPointerFunc(ctx, pointer, dest)
+}
+
+// GetByPointerCalls gets all the calls that were made to GetByPointer.
+// Check the length with:
+//
+//	len(mockedCache.GetByPointerCalls())
+func (mock *CacheMock) GetByPointerCalls() []struct {
+	Ctx     context.Context
+	Pointer string
+	Dest    interface{}
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Pointer string
+		Dest    interface{}
+	}
+	mock.lockGetByPointer.RLock()
+	calls = mock.calls.GetByPointer
+	mock.lockGetByPointer.RUnlock()
+	return calls
+}
+
+// GetMany calls GetManyFunc.
+func (mock *CacheMock) GetMany(ctx context.Context, keys []string, dest interface{}) ([]int, error) {
+	if mock.GetManyFunc == nil {
+		panic("CacheMock.GetManyFunc: method is nil but Cache.GetMany was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Keys []string
+		Dest interface{}
+	}{
+		Ctx:  ctx,
+		Keys: keys,
+		Dest: dest,
+	}
+	mock.lockGetMany.Lock()
+	mock.calls.GetMany = append(mock.calls.GetMany, callInfo)
+	mock.lockGetMany.Unlock()
+	return mock.GetManyFunc(ctx, keys, dest)
+}
+
+// GetManyCalls gets all the calls that were made to GetMany.
+// Check the length with:
+//
+//	len(mockedCache.GetManyCalls())
+func (mock *CacheMock) GetManyCalls() []struct {
+	Ctx  context.Context
+	Keys []string
+	Dest interface{}
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Keys []string
+		Dest interface{}
+	}
+	mock.lockGetMany.RLock()
+	calls = mock.calls.GetMany
+	mock.lockGetMany.RUnlock()
+	return calls
+}
+
+// GetOrLoad calls GetOrLoadFunc.
+func (mock *CacheMock) GetOrLoad(ctx context.Context, key string, dest interface{}, opts redis.LoadOptions, load func(ctx context.Context) (any, error)) error {
+	if mock.GetOrLoadFunc == nil {
+		panic("CacheMock.GetOrLoadFunc: method is nil but Cache.GetOrLoad was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Key  string
+		Dest interface{}
+		Opts redis.LoadOptions
+		Load func(ctx context.Context) (any, error)
+	}{
+		Ctx:  ctx,
+		Key:  key,
+		Dest: dest,
+		Opts: opts,
+		Load: load,
+	}
+	mock.lockGetOrLoad.Lock()
+	mock.calls.GetOrLoad = append(mock.calls.GetOrLoad, callInfo)
+	mock.lockGetOrLoad.Unlock()
+	return mock.GetOrLoadFunc(ctx, key, dest, opts, load)
+}
+
+// GetOrLoadCalls gets all the calls that were made to GetOrLoad.
+// Check the length with:
+//
+//	len(mockedCache.GetOrLoadCalls())
+func (mock *CacheMock) GetOrLoadCalls() []struct {
+	Ctx  context.Context
+	Key  string
+	Dest interface{}
+	Opts redis.LoadOptions
+	Load func(ctx context.Context) (any, error)
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Key  string
+		Dest interface{}
+		Opts redis.LoadOptions
+		Load func(ctx context.Context) (any, error)
+	}
+	mock.lockGetOrLoad.RLock()
+	calls = mock.calls.GetOrLoad
+	mock.lockGetOrLoad.RUnlock()
+	return calls
+}
+
 // GetPointer calls GetPointerFunc.
 func (mock *CacheMock) GetPointer(ctx context.Context, key string) (string, error) {
 	if mock.GetPointerFunc == nil {
@@ -224,6 +696,42 @@ func (mock *CacheMock) GetPointerCalls() []struct {
 	return calls
 }
 
+// Incr calls IncrFunc.
+func (mock *CacheMock) Incr(ctx context.Context, key string) (int64, error) {
+	if mock.IncrFunc == nil {
+		panic("CacheMock.IncrFunc: method is nil but Cache.Incr was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockIncr.Lock()
+	mock.calls.Incr = append(mock.calls.Incr, callInfo)
+	mock.lockIncr.Unlock()
+	return mock.IncrFunc(ctx, key)
+}
+
+// IncrCalls gets all the calls that were made to Incr.
+// Check the length with:
+//
+//	len(mockedCache.IncrCalls())
+func (mock *CacheMock) IncrCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockIncr.RLock()
+	calls = mock.calls.Incr
+	mock.lockIncr.RUnlock()
+	return calls
+}
+
 // Set calls SetFunc.
 func (mock *CacheMock) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	if mock.SetFunc == nil {
@@ -268,6 +776,134 @@ func (mock *CacheMock) SetCalls() []struct {
 	return calls
 }
 
+// SetCounterIfNotExists calls SetCounterIfNotExistsFunc.
+func (mock *CacheMock) SetCounterIfNotExists(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+	if mock.SetCounterIfNotExistsFunc == nil {
+		panic("CacheMock.SetCounterIfNotExistsFunc: method is nil but Cache.SetCounterIfNotExists was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Key   string
+		Value int64
+		TTL   time.Duration
+	}{
+		Ctx:   ctx,
+		Key:   key,
+		Value: value,
+		TTL:   ttl,
+	}
+	mock.lockSetCounterIfNotExists.Lock()
+	mock.calls.SetCounterIfNotExists = append(mock.calls.SetCounterIfNotExists, callInfo)
+	mock.lockSetCounterIfNotExists.Unlock()
+	return mock.SetCounterIfNotExistsFunc(ctx, key, value, ttl)
+}
+
+// SetCounterIfNotExistsCalls gets all the calls that were made to SetCounterIfNotExists.
+// Check the length with:
+//
+//	len(mockedCache.SetCounterIfNotExistsCalls())
+func (mock *CacheMock) SetCounterIfNotExistsCalls() []struct {
+	Ctx   context.Context
+	Key   string
+	Value int64
+	TTL   time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Key   string
+		Value int64
+		TTL   time.Duration
+	}
+	mock.lockSetCounterIfNotExists.RLock()
+	calls = mock.calls.SetCounterIfNotExists
+	mock.lockSetCounterIfNotExists.RUnlock()
+	return calls
+}
+
+// SetIfNotExists calls SetIfNotExistsFunc.
+func (mock *CacheMock) SetIfNotExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if mock.SetIfNotExistsFunc == nil {
+		panic("CacheMock.SetIfNotExistsFunc: method is nil but Cache.SetIfNotExists was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Key   string
+		Value interface{}
+		TTL   time.Duration
+	}{
+		Ctx:   ctx,
+		Key:   key,
+		Value: value,
+		TTL:   ttl,
+	}
+	mock.lockSetIfNotExists.Lock()
+	mock.calls.SetIfNotExists = append(mock.calls.SetIfNotExists, callInfo)
+	mock.lockSetIfNotExists.Unlock()
+	return mock.SetIfNotExistsFunc(ctx, key, value, ttl)
+}
+
+// SetIfNotExistsCalls gets all the calls that were made to SetIfNotExists.
+// Check the length with:
+//
+//	len(mockedCache.SetIfNotExistsCalls())
+func (mock *CacheMock) SetIfNotExistsCalls() []struct {
+	Ctx   context.Context
+	Key   string
+	Value interface{}
+	TTL   time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Key   string
+		Value interface{}
+		TTL   time.Duration
+	}
+	mock.lockSetIfNotExists.RLock()
+	calls = mock.calls.SetIfNotExists
+	mock.lockSetIfNotExists.RUnlock()
+	return calls
+}
+
+// SetMany calls SetManyFunc.
+func (mock *CacheMock) SetMany(ctx context.Context, entries map[string]interface{}, ttl time.Duration) error {
+	if mock.SetManyFunc == nil {
+		panic("CacheMock.SetManyFunc: method is nil but Cache.SetMany was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Entries map[string]interface{}
+		TTL     time.Duration
+	}{
+		Ctx:     ctx,
+		Entries: entries,
+		TTL:     ttl,
+	}
+	mock.lockSetMany.Lock()
+	mock.calls.SetMany = append(mock.calls.SetMany, callInfo)
+	mock.lockSetMany.Unlock()
+	return mock.SetManyFunc(ctx, entries, ttl)
+}
+
+// SetManyCalls gets all the calls that were made to SetMany.
+// Check the length with:
+//
+//	len(mockedCache.SetManyCalls())
+func (mock *CacheMock) SetManyCalls() []struct {
+	Ctx     context.Context
+	Entries map[string]interface{}
+	TTL     time.Duration
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Entries map[string]interface{}
+		TTL     time.Duration
+	}
+	mock.lockSetMany.RLock()
+	calls = mock.calls.SetMany
+	mock.lockSetMany.RUnlock()
+	return calls
+}
+
 // SetPointer calls SetPointerFunc.
 func (mock *CacheMock) SetPointer(ctx context.Context, key string, targetKey string, ttl time.Duration) error {
 	if mock.SetPointerFunc == nil {
@@ -311,3 +947,51 @@ func (mock *CacheMock) SetPointerCalls() []struct {
 	mock.lockSetPointer.RUnlock()
 	return calls
 }
+
+// SetWithPointers calls SetWithPointersFunc.
+func (mock *CacheMock) SetWithPointers(ctx context.Context, key string, value interface{}, pointers []string, ttl time.Duration) error {
+	if mock.SetWithPointersFunc == nil {
+		panic("CacheMock.SetWithPointersFunc: method is nil but Cache.SetWithPointers was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Key      string
+		Value    interface{}
+		Pointers []string
+		TTL      time.Duration
+	}{
+		Ctx:      ctx,
+		Key:      key,
+		Value:    value,
+		Pointers: pointers,
+		TTL:      ttl,
+	}
+	mock.lockSetWithPointers.Lock()
+	mock.calls.SetWithPointers = append(mock.calls.SetWithPointers, callInfo)
+	mock.lockSetWithPointers.Unlock()
+	return mock.SetWithPointersFunc(ctx, key, value, pointers, ttl)
+}
+
+// SetWithPointersCalls gets all the calls that were made to SetWithPointers.
+// Check the length with:
+//
+//	len(mockedCache.SetWithPointersCalls())
+func (mock *CacheMock) SetWithPointersCalls() []struct {
+	Ctx      context.Context
+	Key      string
+	Value    interface{}
+	Pointers []string
+	TTL      time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Key      string
+		Value    interface{}
+		Pointers []string
+		TTL      time.Duration
+	}
+	mock.lockSetWithPointers.RLock()
+	calls = mock.calls.SetWithPointers
+	mock.lockSetWithPointers.RUnlock()
+	return calls
+}
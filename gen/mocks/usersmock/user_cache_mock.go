@@ -23,24 +23,45 @@ var _ domain.Cache = &CacheMock{}
 //			CacheUserFunc: func(ctx context.Context, user domain.User) error {
 //				panic("mock out the CacheUser method")
 //			},
-//			CacheUserByPaginationFunc: func(ctx context.Context, users []domain.User, params domain.GetUsersParams) error {
+//			CacheUserByPaginationFunc: func(ctx context.Context, users []domain.User, params domain.GetUsersParams, generation int64) error {
 //				panic("mock out the CacheUserByPagination method")
 //			},
+//			DeleteMissingUserFunc: func(ctx context.Context, user domain.User) error {
+//				panic("mock out the DeleteMissingUser method")
+//			},
 //			DeleteUserByEmailFunc: func(ctx context.Context, email string) error {
 //				panic("mock out the DeleteUserByEmail method")
 //			},
 //			DeleteUserByIDFunc: func(ctx context.Context, id uuid.UUID) error {
 //				panic("mock out the DeleteUserByID method")
 //			},
+//			DeleteUserPagesFunc: func(ctx context.Context) error {
+//				panic("mock out the DeleteUserPages method")
+//			},
 //			GetUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
 //				panic("mock out the GetUserByEmail method")
 //			},
 //			GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
 //				panic("mock out the GetUserByID method")
 //			},
-//			GetUserByPaginationFunc: func(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error) {
+//			GetUserByPaginationFunc: func(ctx context.Context, params domain.GetUsersParams, generation int64) ([]domain.User, error) {
 //				panic("mock out the GetUserByPagination method")
 //			},
+//			InvalidateUserPagesFunc: func(ctx context.Context) error {
+//				panic("mock out the InvalidateUserPages method")
+//			},
+//			LoadUserByEmailFunc: func(ctx context.Context, email string, load func(ctx context.Context) (domain.User, error)) (domain.User, error) {
+//				panic("mock out the LoadUserByEmail method")
+//			},
+//			LoadUserByIDFunc: func(ctx context.Context, id uuid.UUID, load func(ctx context.Context) (domain.User, error)) (domain.User, error) {
+//				panic("mock out the LoadUserByID method")
+//			},
+//			LoadUserByPaginationFunc: func(ctx context.Context, params domain.GetUsersParams, generation int64, load func(ctx context.Context) ([]domain.User, error)) ([]domain.User, error) {
+//				panic("mock out the LoadUserByPagination method")
+//			},
+//			PageGenerationFunc: func(ctx context.Context) (int64, error) {
+//				panic("mock out the PageGeneration method")
+//			},
 //		}
 //
 //		// use mockedCache in code that requires domain.Cache
@@ -52,7 +73,10 @@ type CacheMock struct {
 	CacheUserFunc func(ctx context.Context, user domain.User) error
 
 	// CacheUserByPaginationFunc mocks the CacheUserByPagination method.
-	CacheUserByPaginationFunc func(ctx context.Context, users []domain.User, params domain.GetUsersParams) error
+	CacheUserByPaginationFunc func(ctx context.Context, users []domain.User, params domain.GetUsersParams, generation int64) error
+
+	// DeleteMissingUserFunc mocks the DeleteMissingUser method.
+	DeleteMissingUserFunc func(ctx context.Context, user domain.User) error
 
 	// DeleteUserByEmailFunc mocks the DeleteUserByEmail method.
 	DeleteUserByEmailFunc func(ctx context.Context, email string) error
@@ -60,6 +84,9 @@ type CacheMock struct {
 	// DeleteUserByIDFunc mocks the DeleteUserByID method.
 	DeleteUserByIDFunc func(ctx context.Context, id uuid.UUID) error
 
+	// DeleteUserPagesFunc mocks the DeleteUserPages method.
+	DeleteUserPagesFunc func(ctx context.Context) error
+
 	// GetUserByEmailFunc mocks the GetUserByEmail method.
 	GetUserByEmailFunc func(ctx context.Context, email string) (domain.User, error)
 
@@ -67,7 +94,22 @@ type CacheMock struct {
 	GetUserByIDFunc func(ctx context.Context, id uuid.UUID) (domain.User, error)
 
 	// GetUserByPaginationFunc mocks the GetUserByPagination method.
-	GetUserByPaginationFunc func(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error)
+	GetUserByPaginationFunc func(ctx context.Context, params domain.GetUsersParams, generation int64) ([]domain.User, error)
+
+	// InvalidateUserPagesFunc mocks the InvalidateUserPages method.
+	InvalidateUserPagesFunc func(ctx context.Context) error
+
+	// LoadUserByEmailFunc mocks the LoadUserByEmail method.
+	LoadUserByEmailFunc func(ctx context.Context, email string, load func(ctx context.Context) (domain.User, error)) (domain.User, error)
+
+	// LoadUserByIDFunc mocks the LoadUserByID method.
+	LoadUserByIDFunc func(ctx context.Context, id uuid.UUID, load func(ctx context.Context) (domain.User, error)) (domain.User, error)
+
+	// LoadUserByPaginationFunc mocks the LoadUserByPagination method.
+	LoadUserByPaginationFunc func(ctx context.Context, params domain.GetUsersParams, generation int64, load func(ctx context.Context) ([]domain.User, error)) ([]domain.User, error)
+
+	// PageGenerationFunc mocks the PageGeneration method.
+	PageGenerationFunc func(ctx context.Context) (int64, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -86,6 +128,15 @@ type CacheMock struct {
 			Users []domain.User
 			// Params is the params argument value.
 			Params domain.GetUsersParams
+			// Generation is the generation argument value.
+			Generation int64
+		}
+		// DeleteMissingUser holds details about calls to the DeleteMissingUser method.
+		DeleteMissingUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User domain.User
 		}
 		// DeleteUserByEmail holds details about calls to the DeleteUserByEmail method.
 		DeleteUserByEmail []struct {
@@ -101,6 +152,11 @@ type CacheMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// DeleteUserPages holds details about calls to the DeleteUserPages method.
+		DeleteUserPages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetUserByEmail holds details about calls to the GetUserByEmail method.
 		GetUserByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -121,15 +177,63 @@ type CacheMock struct {
 			Ctx context.Context
 			// Params is the params argument value.
 			Params domain.GetUsersParams
+			// Generation is the generation argument value.
+			Generation int64
+		}
+		// InvalidateUserPages holds details about calls to the InvalidateUserPages method.
+		InvalidateUserPages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// LoadUserByEmail holds details about calls to the LoadUserByEmail method.
+		LoadUserByEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+			// Load is the load argument value.
+			Load func(ctx context.Context) (domain.User, error)
+		}
+		// LoadUserByID holds details about calls to the LoadUserByID method.
+		LoadUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Load is the load argument value.
+			Load func(ctx context.Context) (domain.User, error)
+		}
+		// LoadUserByPagination holds details about calls to the LoadUserByPagination method.
+		LoadUserByPagination []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.GetUsersParams
+			// Generation is the generation argument value.
+			Generation int64
+			// Load is the load argument value.
+			Load func(ctx context.Context) ([]domain.User, error)
+		}
+		// PageGeneration holds details about calls to the PageGeneration method.
+		PageGeneration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 		}
 	}
 	lockCacheUser             sync.RWMutex
 	lockCacheUserByPagination sync.RWMutex
+	lockDeleteMissingUser     sync.RWMutex
 	lockDeleteUserByEmail     sync.RWMutex
 	lockDeleteUserByID        sync.RWMutex
+	lockDeleteUserPages       sync.RWMutex
 	lockGetUserByEmail        sync.RWMutex
 	lockGetUserByID           sync.RWMutex
 	lockGetUserByPagination   sync.RWMutex
+	lockInvalidateUserPages   sync.RWMutex
+	lockLoadUserByEmail       sync.RWMutex
+	lockLoadUserByID          sync.RWMutex
+	lockLoadUserByPagination  sync.RWMutex
+	lockPageGeneration        sync.RWMutex
 }
 
 // CacheUser calls CacheUserFunc.
@@ -169,23 +273,25 @@ func (mock *CacheMock) CacheUserCalls() []struct {
 }
 
 // CacheUserByPagination calls CacheUserByPaginationFunc.
-func (mock *CacheMock) CacheUserByPagination(ctx context.Context, users []domain.User, params domain.GetUsersParams) error {
+func (mock *CacheMock) CacheUserByPagination(ctx context.Context, users []domain.User, params domain.GetUsersParams, generation int64) error {
 	if mock.CacheUserByPaginationFunc == nil {
 		panic("CacheMock.CacheUserByPaginationFunc: method is nil but Cache.CacheUserByPagination was just called")
 	}
 	callInfo := struct {
-		Ctx    context.Context
-		Users  []domain.User
-		Params domain.GetUsersParams
+		Ctx        context.Context
+		Users      []domain.User
+		Params     domain.GetUsersParams
+		Generation int64
 	}{
-		Ctx:    ctx,
-		Users:  users,
-		Params: params,
+		Ctx:        ctx,
+		Users:      users,
+		Params:     params,
+		Generation: generation,
 	}
 	mock.lockCacheUserByPagination.Lock()
 	mock.calls.CacheUserByPagination = append(mock.calls.CacheUserByPagination, callInfo)
 	mock.lockCacheUserByPagination.Unlock()
-	return mock.CacheUserByPaginationFunc(ctx, users, params)
+	return mock.CacheUserByPaginationFunc(ctx, users, params, generation)
 }
 
 // CacheUserByPaginationCalls gets all the calls that were made to CacheUserByPagination.
@@ -193,14 +299,16 @@ func (mock *CacheMock) CacheUserByPagination(ctx context.Context, users []domain
 //
 //	len(mockedCache.CacheUserByPaginationCalls())
 func (mock *CacheMock) CacheUserByPaginationCalls() []struct {
-	Ctx    context.Context
-	Users  []domain.User
-	Params domain.GetUsersParams
+	Ctx        context.Context
+	Users      []domain.User
+	Params     domain.GetUsersParams
+	Generation int64
 } {
 	var calls []struct {
-		Ctx    context.Context
-		Users  []domain.User
-		Params domain.GetUsersParams
+		Ctx        context.Context
+		Users      []domain.User
+		Params     domain.GetUsersParams
+		Generation int64
 	}
 	mock.lockCacheUserByPagination.RLock()
 	calls = mock.calls.CacheUserByPagination
@@ -208,6 +316,42 @@ func (mock *CacheMock) CacheUserByPaginationCalls() []struct {
 	return calls
 }
 
+// DeleteMissingUser calls DeleteMissingUserFunc.
+func (mock *CacheMock) DeleteMissingUser(ctx context.Context, user domain.User) error {
+	if mock.DeleteMissingUserFunc == nil {
+		panic("CacheMock.DeleteMissingUserFunc: method is nil but Cache.DeleteMissingUser was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		User domain.User
+	}{
+		Ctx:  ctx,
+		User: user,
+	}
+	mock.lockDeleteMissingUser.Lock()
+	mock.calls.DeleteMissingUser = append(mock.calls.DeleteMissingUser, callInfo)
+	mock.lockDeleteMissingUser.Unlock()
+	return mock.DeleteMissingUserFunc(ctx, user)
+}
+
+// DeleteMissingUserCalls gets all the calls that were made to DeleteMissingUser.
+// Check the length with:
+//
+//	len(mockedCache.DeleteMissingUserCalls())
+func (mock *CacheMock) DeleteMissingUserCalls() []struct {
+	Ctx  context.Context
+	User domain.User
+} {
+	var calls []struct {
+		Ctx  context.Context
+		User domain.User
+	}
+	mock.lockDeleteMissingUser.RLock()
+	calls = mock.calls.DeleteMissingUser
+	mock.lockDeleteMissingUser.RUnlock()
+	return calls
+}
+
 // DeleteUserByEmail calls DeleteUserByEmailFunc.
 func (mock *CacheMock) DeleteUserByEmail(ctx context.Context, email string) error {
 	if mock.DeleteUserByEmailFunc == nil {
@@ -280,6 +424,38 @@ func (mock *CacheMock) DeleteUserByIDCalls() []struct {
 	return calls
 }
 
+// DeleteUserPages calls DeleteUserPagesFunc.
+func (mock *CacheMock) DeleteUserPages(ctx context.Context) error {
+	if mock.DeleteUserPagesFunc == nil {
+		panic("CacheMock.DeleteUserPagesFunc: method is nil but Cache.DeleteUserPages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDeleteUserPages.Lock()
+	mock.calls.DeleteUserPages = append(mock.calls.DeleteUserPages, callInfo)
+	mock.lockDeleteUserPages.Unlock()
+	return mock.DeleteUserPagesFunc(ctx)
+}
+
+// DeleteUserPagesCalls gets all the calls that were made to DeleteUserPages.
+// Check the length with:
+//
+//	len(mockedCache.DeleteUserPagesCalls())
+func (mock *CacheMock) DeleteUserPagesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockDeleteUserPages.RLock()
+	calls = mock.calls.DeleteUserPages
+	mock.lockDeleteUserPages.RUnlock()
+	return calls
+}
+
 // GetUserByEmail calls GetUserByEmailFunc.
 func (mock *CacheMock) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
 	if mock.GetUserByEmailFunc == nil {
@@ -353,21 +529,23 @@ func (mock *CacheMock) GetUserByIDCalls() []struct {
 }
 
 // GetUserByPagination calls GetUserByPaginationFunc.
-func (mock *CacheMock) GetUserByPagination(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error) {
+func (mock *CacheMock) GetUserByPagination(ctx context.Context, params domain.GetUsersParams, generation int64) ([]domain.User, error) {
 	if mock.GetUserByPaginationFunc == nil {
 		panic("CacheMock.GetUserByPaginationFunc: method is nil but Cache.GetUserByPagination was just called")
 	}
 	callInfo := struct {
-		Ctx    context.Context
-		Params domain.GetUsersParams
+		Ctx        context.Context
+		Params     domain.GetUsersParams
+		Generation int64
 	}{
-		Ctx:    ctx,
-		Params: params,
+		Ctx:        ctx,
+		Params:     params,
+		Generation: generation,
 	}
 	mock.lockGetUserByPagination.Lock()
 	mock.calls.GetUserByPagination = append(mock.calls.GetUserByPagination, callInfo)
 	mock.lockGetUserByPagination.Unlock()
-	return mock.GetUserByPaginationFunc(ctx, params)
+	return mock.GetUserByPaginationFunc(ctx, params, generation)
 }
 
 // GetUserByPaginationCalls gets all the calls that were made to GetUserByPagination.
@@ -375,15 +553,205 @@ func (mock *CacheMock) GetUserByPagination(ctx context.Context, params domain.Ge
 //
 //	len(mockedCache.GetUserByPaginationCalls())
 func (mock *CacheMock) GetUserByPaginationCalls() []struct {
-	Ctx    context.Context
-	Params domain.GetUsersParams
+	Ctx        context.Context
+	Params     domain.GetUsersParams
+	Generation int64
 } {
 	var calls []struct {
-		Ctx    context.Context
-		Params domain.GetUsersParams
+		Ctx        context.Context
+		Params     domain.GetUsersParams
+		Generation int64
 	}
 	mock.lockGetUserByPagination.RLock()
 	calls = mock.calls.GetUserByPagination
 	mock.lockGetUserByPagination.RUnlock()
 	return calls
 }
+
+// InvalidateUserPages calls InvalidateUserPagesFunc.
+func (mock *CacheMock) InvalidateUserPages(ctx context.Context) error {
+	if mock.InvalidateUserPagesFunc == nil {
+		panic("CacheMock.InvalidateUserPagesFunc: method is nil but Cache.InvalidateUserPages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockInvalidateUserPages.Lock()
+	mock.calls.InvalidateUserPages = append(mock.calls.InvalidateUserPages, callInfo)
+	mock.lockInvalidateUserPages.Unlock()
+	return mock.InvalidateUserPagesFunc(ctx)
+}
+
+// InvalidateUserPagesCalls gets all the calls that were made to InvalidateUserPages.
+// Check the length with:
+//
+//	len(mockedCache.InvalidateUserPagesCalls())
+func (mock *CacheMock) InvalidateUserPagesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockInvalidateUserPages.RLock()
+	calls = mock.calls.InvalidateUserPages
+	mock.lockInvalidateUserPages.RUnlock()
+	return calls
+}
+
+// LoadUserByEmail calls LoadUserByEmailFunc.
+func (mock *CacheMock) LoadUserByEmail(ctx context.Context, email string, load func(ctx context.Context) (domain.User, error)) (domain.User, error) {
+	if mock.LoadUserByEmailFunc == nil {
+		panic("CacheMock.LoadUserByEmailFunc: method is nil but Cache.LoadUserByEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+		Load  func(ctx context.Context) (domain.User, error)
+	}{
+		Ctx:   ctx,
+		Email: email,
+		Load:  load,
+	}
+	mock.lockLoadUserByEmail.Lock()
+	mock.calls.LoadUserByEmail = append(mock.calls.LoadUserByEmail, callInfo)
+	mock.lockLoadUserByEmail.Unlock()
+	return mock.LoadUserByEmailFunc(ctx, email, load)
+}
+
+// LoadUserByEmailCalls gets all the calls that were made to LoadUserByEmail.
+// Check the length with:
+//
+//	len(mockedCache.LoadUserByEmailCalls())
+func (mock *CacheMock) LoadUserByEmailCalls() []struct {
+	Ctx   context.Context
+	Email string
+	Load  func(ctx context.Context) (domain.User, error)
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+		Load  func(ctx context.Context) (domain.User, error)
+	}
+	mock.lockLoadUserByEmail.RLock()
+	calls = mock.calls.LoadUserByEmail
+	mock.lockLoadUserByEmail.RUnlock()
+	return calls
+}
+
+// LoadUserByID calls LoadUserByIDFunc.
+func (mock *CacheMock) LoadUserByID(ctx context.Context, id uuid.UUID, load func(ctx context.Context) (domain.User, error)) (domain.User, error) {
+	if mock.LoadUserByIDFunc == nil {
+		panic("CacheMock.LoadUserByIDFunc: method is nil but Cache.LoadUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   uuid.UUID
+		Load func(ctx context.Context) (domain.User, error)
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Load: load,
+	}
+	mock.lockLoadUserByID.Lock()
+	mock.calls.LoadUserByID = append(mock.calls.LoadUserByID, callInfo)
+	mock.lockLoadUserByID.Unlock()
+	return mock.LoadUserByIDFunc(ctx, id, load)
+}
+
+// LoadUserByIDCalls gets all the calls that were made to LoadUserByID.
+// Check the length with:
+//
+//	len(mockedCache.LoadUserByIDCalls())
+func (mock *CacheMock) LoadUserByIDCalls() []struct {
+	Ctx  context.Context
+	ID   uuid.UUID
+	Load func(ctx context.Context) (domain.User, error)
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   uuid.UUID
+		Load func(ctx context.Context) (domain.User, error)
+	}
+	mock.lockLoadUserByID.RLock()
+	calls = mock.calls.LoadUserByID
+	mock.lockLoadUserByID.RUnlock()
+	return calls
+}
+
+// LoadUserByPagination calls LoadUserByPaginationFunc.
+func (mock *CacheMock) LoadUserByPagination(ctx context.Context, params domain.GetUsersParams, generation int64, load func(ctx context.Context) ([]domain.User, error)) ([]domain.User, error) {
+	if mock.LoadUserByPaginationFunc == nil {
+		panic("CacheMock.LoadUserByPaginationFunc: method is nil but Cache.LoadUserByPagination was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Params     domain.GetUsersParams
+		Generation int64
+		Load       func(ctx context.Context) ([]domain.User, error)
+	}{
+		Ctx:        ctx,
+		Params:     params,
+		Generation: generation,
+		Load:       load,
+	}
+	mock.lockLoadUserByPagination.Lock()
+	mock.calls.LoadUserByPagination = append(mock.calls.LoadUserByPagination, callInfo)
+	mock.lockLoadUserByPagination.Unlock()
+	return mock.LoadUserByPaginationFunc(ctx, params, generation, load)
+}
+
+// LoadUserByPaginationCalls gets all the calls that were made to LoadUserByPagination.
+// Check the length with:
+//
+//	len(mockedCache.LoadUserByPaginationCalls())
+func (mock *CacheMock) LoadUserByPaginationCalls() []struct {
+	Ctx        context.Context
+	Params     domain.GetUsersParams
+	Generation int64
+	Load       func(ctx context.Context) ([]domain.User, error)
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Params     domain.GetUsersParams
+		Generation int64
+		Load       func(ctx context.Context) ([]domain.User, error)
+	}
+	mock.lockLoadUserByPagination.RLock()
+	calls = mock.calls.LoadUserByPagination
+	mock.lockLoadUserByPagination.RUnlock()
+	return calls
+}
+
+// PageGeneration calls PageGenerationFunc.
+func (mock *CacheMock) PageGeneration(ctx context.Context) (int64, error) {
+	if mock.PageGenerationFunc == nil {
+		panic("CacheMock.PageGenerationFunc: method is nil but Cache.PageGeneration was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPageGeneration.Lock()
+	mock.calls.PageGeneration = append(mock.calls.PageGeneration, callInfo)
+	mock.lockPageGeneration.Unlock()
+	return mock.PageGenerationFunc(ctx)
+}
+
+// PageGenerationCalls gets all the calls that were made to PageGeneration.
+// Check the length with:
+//
+//	len(mockedCache.PageGenerationCalls())
+func (mock *CacheMock) PageGenerationCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPageGeneration.RLock()
+	calls = mock.calls.PageGeneration
+	mock.lockPageGeneration.RUnlock()
+	return calls
+}
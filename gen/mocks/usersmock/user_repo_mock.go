@@ -23,9 +23,12 @@ var _ domain.Repository = &RepositoryMock{}
 //			CreateUserFunc: func(ctx context.Context, newUserParams domain.CreateUserParams) (domain.User, error) {
 //				panic("mock out the CreateUser method")
 //			},
-//			DeleteUserFunc: func(ctx context.Context, id uuid.UUID) error {
+//			DeleteUserFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
 //				panic("mock out the DeleteUser method")
 //			},
+//			GetPreferencesFunc: func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+//				panic("mock out the GetPreferences method")
+//			},
 //			GetUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
 //				panic("mock out the GetUserByEmail method")
 //			},
@@ -35,9 +38,18 @@ var _ domain.Repository = &RepositoryMock{}
 //			GetUsersFunc: func(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error) {
 //				panic("mock out the GetUsers method")
 //			},
-//			UpdateUserFunc: func(ctx context.Context, updateUserparams domain.UpdateUserParams) (domain.User, error) {
+//			ListDigestSubscribersFunc: func(ctx context.Context) ([]domain.DigestSubscriber, error) {
+//				panic("mock out the ListDigestSubscribers method")
+//			},
+//			ListRecentlyActiveUsersFunc: func(ctx context.Context, limit int) ([]domain.User, error) {
+//				panic("mock out the ListRecentlyActiveUsers method")
+//			},
+//			UpdateUserFunc: func(ctx context.Context, updateUserparams domain.UpdateUserParams) (domain.User, domain.User, error) {
 //				panic("mock out the UpdateUser method")
 //			},
+//			UpsertPreferencesFunc: func(ctx context.Context, prefs domain.Preferences) (domain.Preferences, error) {
+//				panic("mock out the UpsertPreferences method")
+//			},
 //		}
 //
 //		// use mockedRepository in code that requires domain.Repository
@@ -49,7 +61,10 @@ type RepositoryMock struct {
 	CreateUserFunc func(ctx context.Context, newUserParams domain.CreateUserParams) (domain.User, error)
 
 	// DeleteUserFunc mocks the DeleteUser method.
-	DeleteUserFunc func(ctx context.Context, id uuid.UUID) error
+	DeleteUserFunc func(ctx context.Context, id uuid.UUID) (domain.User, error)
+
+	// GetPreferencesFunc mocks the GetPreferences method.
+	GetPreferencesFunc func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error)
 
 	// GetUserByEmailFunc mocks the GetUserByEmail method.
 	GetUserByEmailFunc func(ctx context.Context, email string) (domain.User, error)
@@ -60,8 +75,17 @@ type RepositoryMock struct {
 	// GetUsersFunc mocks the GetUsers method.
 	GetUsersFunc func(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error)
 
+	// ListDigestSubscribersFunc mocks the ListDigestSubscribers method.
+	ListDigestSubscribersFunc func(ctx context.Context) ([]domain.DigestSubscriber, error)
+
+	// ListRecentlyActiveUsersFunc mocks the ListRecentlyActiveUsers method.
+	ListRecentlyActiveUsersFunc func(ctx context.Context, limit int) ([]domain.User, error)
+
 	// UpdateUserFunc mocks the UpdateUser method.
-	UpdateUserFunc func(ctx context.Context, updateUserparams domain.UpdateUserParams) (domain.User, error)
+	UpdateUserFunc func(ctx context.Context, updateUserparams domain.UpdateUserParams) (domain.User, domain.User, error)
+
+	// UpsertPreferencesFunc mocks the UpsertPreferences method.
+	UpsertPreferencesFunc func(ctx context.Context, prefs domain.Preferences) (domain.Preferences, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -79,6 +103,13 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// GetPreferences holds details about calls to the GetPreferences method.
+		GetPreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
 		// GetUserByEmail holds details about calls to the GetUserByEmail method.
 		GetUserByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -100,6 +131,18 @@ type RepositoryMock struct {
 			// Params is the params argument value.
 			Params domain.GetUsersParams
 		}
+		// ListDigestSubscribers holds details about calls to the ListDigestSubscribers method.
+		ListDigestSubscribers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListRecentlyActiveUsers holds details about calls to the ListRecentlyActiveUsers method.
+		ListRecentlyActiveUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// UpdateUser holds details about calls to the UpdateUser method.
 		UpdateUser []struct {
 			// Ctx is the ctx argument value.
@@ -107,13 +150,24 @@ type RepositoryMock struct {
 			// UpdateUserparams is the updateUserparams argument value.
 			UpdateUserparams domain.UpdateUserParams
 		}
+		// UpsertPreferences holds details about calls to the UpsertPreferences method.
+		UpsertPreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prefs is the prefs argument value.
+			Prefs domain.Preferences
+		}
 	}
-	lockCreateUser     sync.RWMutex
-	lockDeleteUser     sync.RWMutex
-	lockGetUserByEmail sync.RWMutex
-	lockGetUserByID    sync.RWMutex
-	lockGetUsers       sync.RWMutex
-	lockUpdateUser     sync.RWMutex
+	lockCreateUser              sync.RWMutex
+	lockDeleteUser              sync.RWMutex
+	lockGetPreferences          sync.RWMutex
+	lockGetUserByEmail          sync.RWMutex
+	lockGetUserByID             sync.RWMutex
+	lockGetUsers                sync.RWMutex
+	lockListDigestSubscribers   sync.RWMutex
+	lockListRecentlyActiveUsers sync.RWMutex
+	lockUpdateUser              sync.RWMutex
+	lockUpsertPreferences       sync.RWMutex
 }
 
 // CreateUser calls CreateUserFunc.
@@ -153,7 +207,7 @@ func (mock *RepositoryMock) CreateUserCalls() []struct {
 }
 
 // DeleteUser calls DeleteUserFunc.
-func (mock *RepositoryMock) DeleteUser(ctx context.Context, id uuid.UUID) error {
+func (mock *RepositoryMock) DeleteUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
 	if mock.DeleteUserFunc == nil {
 		panic("RepositoryMock.DeleteUserFunc: method is nil but Repository.DeleteUser was just called")
 	}
@@ -188,6 +242,42 @@ func (mock *RepositoryMock) DeleteUserCalls() []struct {
 	return calls
 }
 
+// GetPreferences calls GetPreferencesFunc.
+func (mock *RepositoryMock) GetPreferences(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+	if mock.GetPreferencesFunc == nil {
+		panic("RepositoryMock.GetPreferencesFunc: method is nil but Repository.GetPreferences was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetPreferences.Lock()
+	mock.calls.GetPreferences = append(mock.calls.GetPreferences, callInfo)
+	mock.lockGetPreferences.Unlock()
+	return mock.GetPreferencesFunc(ctx, userID)
+}
+
+// GetPreferencesCalls gets all the calls that were made to GetPreferences.
+// Check the length with:
+//
+//	len(mockedRepository.GetPreferencesCalls())
+func (mock *RepositoryMock) GetPreferencesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetPreferences.RLock()
+	calls = mock.calls.GetPreferences
+	mock.lockGetPreferences.RUnlock()
+	return calls
+}
+
 // GetUserByEmail calls GetUserByEmailFunc.
 func (mock *RepositoryMock) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
 	if mock.GetUserByEmailFunc == nil {
@@ -296,8 +386,76 @@ func (mock *RepositoryMock) GetUsersCalls() []struct {
 	return calls
 }
 
+// ListDigestSubscribers calls ListDigestSubscribersFunc.
+func (mock *RepositoryMock) ListDigestSubscribers(ctx context.Context) ([]domain.DigestSubscriber, error) {
+	if mock.ListDigestSubscribersFunc == nil {
+		panic("RepositoryMock.ListDigestSubscribersFunc: method is nil but Repository.ListDigestSubscribers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListDigestSubscribers.Lock()
+	mock.calls.ListDigestSubscribers = append(mock.calls.ListDigestSubscribers, callInfo)
+	mock.lockListDigestSubscribers.Unlock()
+	return mock.ListDigestSubscribersFunc(ctx)
+}
+
+// ListDigestSubscribersCalls gets all the calls that were made to ListDigestSubscribers.
+// Check the length with:
+//
+//	len(mockedRepository.ListDigestSubscribersCalls())
+func (mock *RepositoryMock) ListDigestSubscribersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListDigestSubscribers.RLock()
+	calls = mock.calls.ListDigestSubscribers
+	mock.lockListDigestSubscribers.RUnlock()
+	return calls
+}
+
+// ListRecentlyActiveUsers calls ListRecentlyActiveUsersFunc.
+func (mock *RepositoryMock) ListRecentlyActiveUsers(ctx context.Context, limit int) ([]domain.User, error) {
+	if mock.ListRecentlyActiveUsersFunc == nil {
+		panic("RepositoryMock.ListRecentlyActiveUsersFunc: method is nil but Repository.ListRecentlyActiveUsers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockListRecentlyActiveUsers.Lock()
+	mock.calls.ListRecentlyActiveUsers = append(mock.calls.ListRecentlyActiveUsers, callInfo)
+	mock.lockListRecentlyActiveUsers.Unlock()
+	return mock.ListRecentlyActiveUsersFunc(ctx, limit)
+}
+
+// ListRecentlyActiveUsersCalls gets all the calls that were made to ListRecentlyActiveUsers.
+// Check the length with:
+//
+//	len(mockedRepository.ListRecentlyActiveUsersCalls())
+func (mock *RepositoryMock) ListRecentlyActiveUsersCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockListRecentlyActiveUsers.RLock()
+	calls = mock.calls.ListRecentlyActiveUsers
+	mock.lockListRecentlyActiveUsers.RUnlock()
+	return calls
+}
+
 // UpdateUser calls UpdateUserFunc.
-func (mock *RepositoryMock) UpdateUser(ctx context.Context, updateUserparams domain.UpdateUserParams) (domain.User, error) {
+func (mock *RepositoryMock) UpdateUser(ctx context.Context, updateUserparams domain.UpdateUserParams) (domain.User, domain.User, error) {
 	if mock.UpdateUserFunc == nil {
 		panic("RepositoryMock.UpdateUserFunc: method is nil but Repository.UpdateUser was just called")
 	}
@@ -331,3 +489,39 @@ func (mock *RepositoryMock) UpdateUserCalls() []struct {
 	mock.lockUpdateUser.RUnlock()
 	return calls
 }
+
+// UpsertPreferences calls UpsertPreferencesFunc.
+func (mock *RepositoryMock) UpsertPreferences(ctx context.Context, prefs domain.Preferences) (domain.Preferences, error) {
+	if mock.UpsertPreferencesFunc == nil {
+		panic("RepositoryMock.UpsertPreferencesFunc: method is nil but Repository.UpsertPreferences was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Prefs domain.Preferences
+	}{
+		Ctx:   ctx,
+		Prefs: prefs,
+	}
+	mock.lockUpsertPreferences.Lock()
+	mock.calls.UpsertPreferences = append(mock.calls.UpsertPreferences, callInfo)
+	mock.lockUpsertPreferences.Unlock()
+	return mock.UpsertPreferencesFunc(ctx, prefs)
+}
+
+// UpsertPreferencesCalls gets all the calls that were made to UpsertPreferences.
+// Check the length with:
+//
+//	len(mockedRepository.UpsertPreferencesCalls())
+func (mock *RepositoryMock) UpsertPreferencesCalls() []struct {
+	Ctx   context.Context
+	Prefs domain.Preferences
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Prefs domain.Preferences
+	}
+	mock.lockUpsertPreferences.RLock()
+	calls = mock.calls.UpsertPreferences
+	mock.lockUpsertPreferences.RUnlock()
+	return calls
+}
@@ -26,6 +26,15 @@ var _ domain.Service = &ServiceMock{}
 //			DeleteUserFunc: func(ctx context.Context, id uuid.UUID) error {
 //				panic("mock out the DeleteUser method")
 //			},
+//			EvictUserFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the EvictUser method")
+//			},
+//			GetCachedUserFunc: func(ctx context.Context, id uuid.UUID) (domain.CachedUser, error) {
+//				panic("mock out the GetCachedUser method")
+//			},
+//			GetPreferencesFunc: func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+//				panic("mock out the GetPreferences method")
+//			},
 //			GetUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
 //				panic("mock out the GetUserByEmail method")
 //			},
@@ -35,9 +44,15 @@ var _ domain.Service = &ServiceMock{}
 //			GetUsersFunc: func(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error) {
 //				panic("mock out the GetUsers method")
 //			},
+//			UpdatePreferencesFunc: func(ctx context.Context, params domain.UpdatePreferencesParams) (domain.Preferences, error) {
+//				panic("mock out the UpdatePreferences method")
+//			},
 //			UpdateUserFunc: func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
 //				panic("mock out the UpdateUser method")
 //			},
+//			WarmCacheFunc: func(ctx context.Context, limit int) (int, error) {
+//				panic("mock out the WarmCache method")
+//			},
 //		}
 //
 //		// use mockedService in code that requires domain.Service
@@ -51,6 +66,15 @@ type ServiceMock struct {
 	// DeleteUserFunc mocks the DeleteUser method.
 	DeleteUserFunc func(ctx context.Context, id uuid.UUID) error
 
+	// EvictUserFunc mocks the EvictUser method.
+	EvictUserFunc func(ctx context.Context, id uuid.UUID) error
+
+	// GetCachedUserFunc mocks the GetCachedUser method.
+	GetCachedUserFunc func(ctx context.Context, id uuid.UUID) (domain.CachedUser, error)
+
+	// GetPreferencesFunc mocks the GetPreferences method.
+	GetPreferencesFunc func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error)
+
 	// GetUserByEmailFunc mocks the GetUserByEmail method.
 	GetUserByEmailFunc func(ctx context.Context, email string) (domain.User, error)
 
@@ -60,9 +84,15 @@ type ServiceMock struct {
 	// GetUsersFunc mocks the GetUsers method.
 	GetUsersFunc func(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error)
 
+	// UpdatePreferencesFunc mocks the UpdatePreferences method.
+	UpdatePreferencesFunc func(ctx context.Context, params domain.UpdatePreferencesParams) (domain.Preferences, error)
+
 	// UpdateUserFunc mocks the UpdateUser method.
 	UpdateUserFunc func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error)
 
+	// WarmCacheFunc mocks the WarmCache method.
+	WarmCacheFunc func(ctx context.Context, limit int) (int, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateUser holds details about calls to the CreateUser method.
@@ -79,6 +109,27 @@ type ServiceMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// EvictUser holds details about calls to the EvictUser method.
+		EvictUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetCachedUser holds details about calls to the GetCachedUser method.
+		GetCachedUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetPreferences holds details about calls to the GetPreferences method.
+		GetPreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
 		// GetUserByEmail holds details about calls to the GetUserByEmail method.
 		GetUserByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -100,6 +151,13 @@ type ServiceMock struct {
 			// Params is the params argument value.
 			Params domain.GetUsersParams
 		}
+		// UpdatePreferences holds details about calls to the UpdatePreferences method.
+		UpdatePreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.UpdatePreferencesParams
+		}
 		// UpdateUser holds details about calls to the UpdateUser method.
 		UpdateUser []struct {
 			// Ctx is the ctx argument value.
@@ -107,13 +165,25 @@ type ServiceMock struct {
 			// Params is the params argument value.
 			Params domain.UpdateUserParams
 		}
+		// WarmCache holds details about calls to the WarmCache method.
+		WarmCache []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
 	}
-	lockCreateUser     sync.RWMutex
-	lockDeleteUser     sync.RWMutex
-	lockGetUserByEmail sync.RWMutex
-	lockGetUserByID    sync.RWMutex
-	lockGetUsers       sync.RWMutex
-	lockUpdateUser     sync.RWMutex
+	lockCreateUser        sync.RWMutex
+	lockDeleteUser        sync.RWMutex
+	lockEvictUser         sync.RWMutex
+	lockGetCachedUser     sync.RWMutex
+	lockGetPreferences    sync.RWMutex
+	lockGetUserByEmail    sync.RWMutex
+	lockGetUserByID       sync.RWMutex
+	lockGetUsers          sync.RWMutex
+	lockUpdatePreferences sync.RWMutex
+	lockUpdateUser        sync.RWMutex
+	lockWarmCache         sync.RWMutex
 }
 
 // CreateUser calls CreateUserFunc.
@@ -188,6 +258,114 @@ func (mock *ServiceMock) DeleteUserCalls() []struct {
 	return calls
 }
 
+// EvictUser calls EvictUserFunc.
+func (mock *ServiceMock) EvictUser(ctx context.Context, id uuid.UUID) error {
+	if mock.EvictUserFunc == nil {
+		panic("ServiceMock.EvictUserFunc: method is nil but Service.EvictUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockEvictUser.Lock()
+	mock.calls.EvictUser = append(mock.calls.EvictUser, callInfo)
+	mock.lockEvictUser.Unlock()
+	return mock.EvictUserFunc(ctx, id)
+}
+
+// EvictUserCalls gets all the calls that were made to EvictUser.
+// Check the length with:
+//
+//	len(mockedService.EvictUserCalls())
+func (mock *ServiceMock) EvictUserCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockEvictUser.RLock()
+	calls = mock.calls.EvictUser
+	mock.lockEvictUser.RUnlock()
+	return calls
+}
+
+// GetCachedUser calls GetCachedUserFunc.
+func (mock *ServiceMock) GetCachedUser(ctx context.Context, id uuid.UUID) (domain.CachedUser, error) {
+	if mock.GetCachedUserFunc == nil {
+		panic("ServiceMock.GetCachedUserFunc: method is nil but Service.GetCachedUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetCachedUser.Lock()
+	mock.calls.GetCachedUser = append(mock.calls.GetCachedUser, callInfo)
+	mock.lockGetCachedUser.Unlock()
+	return mock.GetCachedUserFunc(ctx, id)
+}
+
+// GetCachedUserCalls gets all the calls that were made to GetCachedUser.
+// Check the length with:
+//
+//	len(mockedService.GetCachedUserCalls())
+func (mock *ServiceMock) GetCachedUserCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetCachedUser.RLock()
+	calls = mock.calls.GetCachedUser
+	mock.lockGetCachedUser.RUnlock()
+	return calls
+}
+
+// GetPreferences calls GetPreferencesFunc.
+func (mock *ServiceMock) GetPreferences(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+	if mock.GetPreferencesFunc == nil {
+		panic("ServiceMock.GetPreferencesFunc: method is nil but Service.GetPreferences was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetPreferences.Lock()
+	mock.calls.GetPreferences = append(mock.calls.GetPreferences, callInfo)
+	mock.lockGetPreferences.Unlock()
+	return mock.GetPreferencesFunc(ctx, userID)
+}
+
+// GetPreferencesCalls gets all the calls that were made to GetPreferences.
+// Check the length with:
+//
+//	len(mockedService.GetPreferencesCalls())
+func (mock *ServiceMock) GetPreferencesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetPreferences.RLock()
+	calls = mock.calls.GetPreferences
+	mock.lockGetPreferences.RUnlock()
+	return calls
+}
+
 // GetUserByEmail calls GetUserByEmailFunc.
 func (mock *ServiceMock) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
 	if mock.GetUserByEmailFunc == nil {
@@ -296,6 +474,42 @@ func (mock *ServiceMock) GetUsersCalls() []struct {
 	return calls
 }
 
+// UpdatePreferences calls UpdatePreferencesFunc.
+func (mock *ServiceMock) UpdatePreferences(ctx context.Context, params domain.UpdatePreferencesParams) (domain.Preferences, error) {
+	if mock.UpdatePreferencesFunc == nil {
+		panic("ServiceMock.UpdatePreferencesFunc: method is nil but Service.UpdatePreferences was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.UpdatePreferencesParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockUpdatePreferences.Lock()
+	mock.calls.UpdatePreferences = append(mock.calls.UpdatePreferences, callInfo)
+	mock.lockUpdatePreferences.Unlock()
+	return mock.UpdatePreferencesFunc(ctx, params)
+}
+
+// UpdatePreferencesCalls gets all the calls that were made to UpdatePreferences.
+// Check the length with:
+//
+//	len(mockedService.UpdatePreferencesCalls())
+func (mock *ServiceMock) UpdatePreferencesCalls() []struct {
+	Ctx    context.Context
+	Params domain.UpdatePreferencesParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.UpdatePreferencesParams
+	}
+	mock.lockUpdatePreferences.RLock()
+	calls = mock.calls.UpdatePreferences
+	mock.lockUpdatePreferences.RUnlock()
+	return calls
+}
+
 // UpdateUser calls UpdateUserFunc.
 func (mock *ServiceMock) UpdateUser(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
 	if mock.UpdateUserFunc == nil {
@@ -331,3 +545,39 @@ func (mock *ServiceMock) UpdateUserCalls() []struct {
 	mock.lockUpdateUser.RUnlock()
 	return calls
 }
+
+// WarmCache calls WarmCacheFunc.
+func (mock *ServiceMock) WarmCache(ctx context.Context, limit int) (int, error) {
+	if mock.WarmCacheFunc == nil {
+		panic("ServiceMock.WarmCacheFunc: method is nil but Service.WarmCache was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockWarmCache.Lock()
+	mock.calls.WarmCache = append(mock.calls.WarmCache, callInfo)
+	mock.lockWarmCache.Unlock()
+	return mock.WarmCacheFunc(ctx, limit)
+}
+
+// WarmCacheCalls gets all the calls that were made to WarmCache.
+// Check the length with:
+//
+//	len(mockedService.WarmCacheCalls())
+func (mock *ServiceMock) WarmCacheCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockWarmCache.RLock()
+	calls = mock.calls.WarmCache
+	mock.lockWarmCache.RUnlock()
+	return calls
+}
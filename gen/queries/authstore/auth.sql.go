@@ -43,17 +43,24 @@ func (q *Queries) CreateAuthIdentity(ctx context.Context, arg CreateAuthIdentity
 	return i, err
 }
 
-const deleteAuthIdentityByAuthID = `-- name: DeleteAuthIdentityByAuthID :execrows
+const deleteAuthIdentityByAuthID = `-- name: DeleteAuthIdentityByAuthID :one
 DELETE FROM auth_identities
 WHERE auth_id = $1
+RETURNING auth_id, provider, user_id, role, created_at, updated_at
 `
 
-func (q *Queries) DeleteAuthIdentityByAuthID(ctx context.Context, authID string) (int64, error) {
-	result, err := q.db.Exec(ctx, deleteAuthIdentityByAuthID, authID)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected(), nil
+func (q *Queries) DeleteAuthIdentityByAuthID(ctx context.Context, authID string) (AuthIdentity, error) {
+	row := q.db.QueryRow(ctx, deleteAuthIdentityByAuthID, authID)
+	var i AuthIdentity
+	err := row.Scan(
+		&i.AuthID,
+		&i.Provider,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
 }
 
 const getAuthIdentityByAuthID = `-- name: GetAuthIdentityByAuthID :one
@@ -75,6 +82,26 @@ func (q *Queries) GetAuthIdentityByAuthID(ctx context.Context, authID string) (A
 	return i, err
 }
 
+const getAuthIdentityByAuthIDForUpdate = `-- name: GetAuthIdentityByAuthIDForUpdate :one
+SELECT auth_id, provider, user_id, role, created_at, updated_at FROM auth_identities
+WHERE auth_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetAuthIdentityByAuthIDForUpdate(ctx context.Context, authID string) (AuthIdentity, error) {
+	row := q.db.QueryRow(ctx, getAuthIdentityByAuthIDForUpdate, authID)
+	var i AuthIdentity
+	err := row.Scan(
+		&i.AuthID,
+		&i.Provider,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getAuthIdentityByUserID = `-- name: GetAuthIdentityByUserID :one
 SELECT auth_id, provider, user_id, role, created_at, updated_at FROM auth_identities
 WHERE user_id = $1
@@ -12,8 +12,9 @@ import (
 
 type Querier interface {
 	CreateAuthIdentity(ctx context.Context, arg CreateAuthIdentityParams) (AuthIdentity, error)
-	DeleteAuthIdentityByAuthID(ctx context.Context, authID string) (int64, error)
+	DeleteAuthIdentityByAuthID(ctx context.Context, authID string) (AuthIdentity, error)
 	GetAuthIdentityByAuthID(ctx context.Context, authID string) (AuthIdentity, error)
+	GetAuthIdentityByAuthIDForUpdate(ctx context.Context, authID string) (AuthIdentity, error)
 	GetAuthIdentityByUserID(ctx context.Context, userID pgtype.UUID) (AuthIdentity, error)
 	UpdateAuthIdentityRole(ctx context.Context, arg UpdateAuthIdentityRoleParams) (AuthIdentity, error)
 }
@@ -9,12 +9,18 @@ import (
 )
 
 type Querier interface {
+	// Record a domain event for the outbox relay
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
 	// Create a new todo list
 	CreateTodoList(ctx context.Context, arg CreateTodoListParams) (Todolist, error)
 	// Delete one, multiple, or all todo lists for a specific user
 	DeleteTodoLists(ctx context.Context, arg DeleteTodoListsParams) (int64, error)
+	// Queue a webhook delivery for each of the list owner's subscriptions to the event
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error)
 	// Retrieve a todo list by ID, ensuring it belongs to the user
 	GetTodoListByID(ctx context.Context, arg GetTodoListByIDParams) (Todolist, error)
+	// Lock the todo lists matched by DeleteTodoLists
+	GetTodoListsForDelete(ctx context.Context, arg GetTodoListsForDeleteParams) ([]Todolist, error)
 	// Retrieve todo lists with pagination
 	ListTodoListsWithPagination(ctx context.Context, arg ListTodoListsWithPaginationParams) ([]Todolist, error)
 	// Update an existing todo list for a specific user
@@ -11,6 +11,33 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateOutboxEventParams struct {
+	ID            pgtype.UUID      `json:"id"`
+	AggregateType string           `json:"aggregate_type"`
+	AggregateID   pgtype.UUID      `json:"aggregate_id"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+// Record a domain event for the outbox relay
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, createOutboxEvent,
+		arg.ID,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Payload,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createTodoList = `-- name: CreateTodoList :one
 INSERT INTO todolists (user_id, title, description)
 VALUES ($1, $2, $3)
@@ -58,6 +85,37 @@ func (q *Queries) DeleteTodoLists(ctx context.Context, arg DeleteTodoListsParams
 	return result.RowsAffected(), nil
 }
 
+const enqueueWebhookDeliveries = `-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT webhook_subscriptions.id, $1, $2, $3
+FROM webhook_subscriptions
+JOIN todolists ON todolists.user_id = webhook_subscriptions.user_id
+WHERE todolists.id = $4
+  AND webhook_subscriptions.active
+  AND $2::text = ANY(webhook_subscriptions.event_types)
+`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID   pgtype.UUID `json:"event_id"`
+	EventType string      `json:"event_type"`
+	Payload   []byte      `json:"payload"`
+	ListID    pgtype.UUID `json:"list_id"`
+}
+
+// Queue a webhook delivery for each of the list owner's subscriptions to the event
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enqueueWebhookDeliveries,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.ListID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getTodoListByID = `-- name: GetTodoListByID :one
 SELECT id, user_id, title, description, created_at, updated_at
 FROM todolists
@@ -84,6 +142,47 @@ func (q *Queries) GetTodoListByID(ctx context.Context, arg GetTodoListByIDParams
 	return i, err
 }
 
+const getTodoListsForDelete = `-- name: GetTodoListsForDelete :many
+SELECT id, user_id, title, description, created_at, updated_at
+FROM todolists
+WHERE user_id = $1
+AND ($2::uuid[] IS NULL OR id = ANY($2::uuid[]))
+FOR UPDATE
+`
+
+type GetTodoListsForDeleteParams struct {
+	UserID  pgtype.UUID   `json:"user_id"`
+	Column2 []pgtype.UUID `json:"column_2"`
+}
+
+// Lock the todo lists matched by DeleteTodoLists
+func (q *Queries) GetTodoListsForDelete(ctx context.Context, arg GetTodoListsForDeleteParams) ([]Todolist, error) {
+	rows, err := q.db.Query(ctx, getTodoListsForDelete, arg.UserID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Todolist
+	for rows.Next() {
+		var i Todolist
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTodoListsWithPagination = `-- name: ListTodoListsWithPagination :many
 SELECT id, user_id, title, description, created_at, updated_at
 FROM todolists
@@ -11,18 +11,30 @@ import (
 )
 
 type Querier interface {
+	// Record a domain event for the outbox relay
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
 	// Create a new user
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	// Delete a user by ID
-	DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteUser(ctx context.Context, id pgtype.UUID) (User, error)
 	// Retrieve a user by email
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	// Retrieve a user by ID
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	// Retrieve and lock a user by ID, to record their previous state in an update's transaction
+	GetUserByIDForUpdate(ctx context.Context, id pgtype.UUID) (User, error)
+	// Retrieve a user's notification preferences
+	GetUserPreferences(ctx context.Context, userID pgtype.UUID) (UserPreference, error)
 	// Get all users with pagination
 	GetUsers(ctx context.Context, arg GetUsersParams) ([]User, error)
+	// List users who opted in to the daily digest
+	ListDigestSubscribers(ctx context.Context) ([]ListDigestSubscribersRow, error)
+	// List the users active most recently, by their latest task or profile change
+	ListRecentlyActiveUsers(ctx context.Context, limit int32) ([]User, error)
 	// Update user details
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	// Create or replace a user's notification preferences
+	UpsertUserPreferences(ctx context.Context, arg UpsertUserPreferencesParams) (UserPreference, error)
 }
 
 var _ Querier = (*Queries)(nil)
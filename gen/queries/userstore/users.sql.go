@@ -11,6 +11,33 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateOutboxEventParams struct {
+	ID            pgtype.UUID      `json:"id"`
+	AggregateType string           `json:"aggregate_type"`
+	AggregateID   pgtype.UUID      `json:"aggregate_id"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+// Record a domain event for the outbox relay
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, createOutboxEvent,
+		arg.ID,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Payload,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (name, email)
 VALUES ($1, $2)
@@ -36,18 +63,24 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 	return i, err
 }
 
-const deleteUser = `-- name: DeleteUser :execrows
+const deleteUser = `-- name: DeleteUser :one
 DELETE FROM users
 WHERE id = $1
+RETURNING id, name, email, created_at, updated_at
 `
 
 // Delete a user by ID
-func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error) {
-	result, err := q.db.Exec(ctx, deleteUser, id)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected(), nil
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, deleteUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
@@ -90,6 +123,47 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 	return i, err
 }
 
+const getUserByIDForUpdate = `-- name: GetUserByIDForUpdate :one
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE id = $1
+FOR UPDATE
+`
+
+// Retrieve and lock a user by ID, to record their previous state in an update's transaction
+func (q *Queries) GetUserByIDForUpdate(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByIDForUpdate, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserPreferences = `-- name: GetUserPreferences :one
+SELECT user_id, digest_enabled, timezone, created_at, updated_at
+FROM user_preferences
+WHERE user_id = $1
+`
+
+// Retrieve a user's notification preferences
+func (q *Queries) GetUserPreferences(ctx context.Context, userID pgtype.UUID) (UserPreference, error) {
+	row := q.db.QueryRow(ctx, getUserPreferences, userID)
+	var i UserPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.DigestEnabled,
+		&i.Timezone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getUsers = `-- name: GetUsers :many
 SELECT id, name, email, created_at, updated_at
 FROM users
@@ -129,6 +203,87 @@ func (q *Queries) GetUsers(ctx context.Context, arg GetUsersParams) ([]User, err
 	return items, nil
 }
 
+const listDigestSubscribers = `-- name: ListDigestSubscribers :many
+SELECT users.id, users.name, users.email, user_preferences.timezone
+FROM user_preferences
+JOIN users ON user_preferences.user_id = users.id
+WHERE user_preferences.digest_enabled
+ORDER BY users.id
+`
+
+type ListDigestSubscribersRow struct {
+	ID       pgtype.UUID `json:"id"`
+	Name     string      `json:"name"`
+	Email    string      `json:"email"`
+	Timezone string      `json:"timezone"`
+}
+
+// List users who opted in to the daily digest
+func (q *Queries) ListDigestSubscribers(ctx context.Context) ([]ListDigestSubscribersRow, error) {
+	rows, err := q.db.Query(ctx, listDigestSubscribers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDigestSubscribersRow
+	for rows.Next() {
+		var i ListDigestSubscribersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentlyActiveUsers = `-- name: ListRecentlyActiveUsers :many
+SELECT users.id, users.name, users.email, users.created_at, users.updated_at
+FROM users
+LEFT JOIN (
+    SELECT todolists.user_id, MAX(tasks.updated_at) AS last_active
+    FROM tasks
+    JOIN todolists ON tasks.list_id = todolists.id
+    GROUP BY todolists.user_id
+) AS activity ON activity.user_id = users.id
+ORDER BY GREATEST(activity.last_active, users.updated_at) DESC
+LIMIT $1
+`
+
+// List the users active most recently, by their latest task or profile change
+func (q *Queries) ListRecentlyActiveUsers(ctx context.Context, limit int32) ([]User, error) {
+	rows, err := q.db.Query(ctx, listRecentlyActiveUsers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET 
@@ -158,3 +313,34 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 	)
 	return i, err
 }
+
+const upsertUserPreferences = `-- name: UpsertUserPreferences :one
+INSERT INTO user_preferences (user_id, digest_enabled, timezone)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET 
+    digest_enabled = EXCLUDED.digest_enabled,
+    timezone = EXCLUDED.timezone,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING user_id, digest_enabled, timezone, created_at, updated_at
+`
+
+type UpsertUserPreferencesParams struct {
+	UserID        pgtype.UUID `json:"user_id"`
+	DigestEnabled bool        `json:"digest_enabled"`
+	Timezone      string      `json:"timezone"`
+}
+
+// Create or replace a user's notification preferences
+func (q *Queries) UpsertUserPreferences(ctx context.Context, arg UpsertUserPreferencesParams) (UserPreference, error) {
+	row := q.db.QueryRow(ctx, upsertUserPreferences, arg.UserID, arg.DigestEnabled, arg.Timezone)
+	var i UserPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.DigestEnabled,
+		&i.Timezone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,26 @@
+package domain
+
+// Audited actions, named "<target type>.<verb>"
+const (
+	ActionUserCreate             = "user.create"
+	ActionUserUpdate             = "user.update"
+	ActionUserDelete             = "user.delete"
+	ActionUserUpdatePreferences  = "user.update_preferences"
+	ActionUserExport             = "user.export"
+	ActionUserErase              = "user.erase"
+	ActionAuthIdentityCreate     = "auth_identity.create"
+	ActionAuthIdentityUpdateRole = "auth_identity.update_role"
+	ActionAuthIdentityDelete     = "auth_identity.delete"
+)
+
+// Audited target types
+const (
+	TargetUser         = "user"
+	TargetAuthIdentity = "auth_identity"
+)
+
+const (
+	DefaultLimit  = 50
+	DefaultOffset = 0
+	MaxLimit      = 500
+)
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+)
+
+// Auditor records mutating operations in the audit log.
+// Recording never fails the caller's operation; implementations log their own errors.
+//
+//go:generate moq -out=../../../gen/mocks/auditmock/auditor_mock.go -pkg=auditmock . Auditor
+type Auditor interface {
+	Record(ctx context.Context, params RecordParams)
+}
+
+// Repository defines the methods required for audit log storage. Entries are append-only.
+//
+//go:generate moq -out=../../../gen/mocks/auditmock/audit_repo_mock.go -pkg=auditmock . Repository
+type Repository interface {
+	CreateEntry(ctx context.Context, params CreateEntryParams) (Entry, error)
+	ListEntries(ctx context.Context, params ListEntriesParams) ([]Entry, error)
+}
+
+//go:generate moq -out=../../../gen/mocks/auditmock/audit_service_mock.go -pkg=auditmock . Service
+type Service interface {
+	Auditor
+	ListEntries(ctx context.Context, params ListEntriesParams) ([]Entry, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry is a single record in the audit log.
+type Entry struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    uuid.UUID       `json:"actor_id"` // uuid.Nil for system or unauthenticated actions
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// RecordParams describes a mutation to be recorded. Before and After are marshalled to JSON;
+// the actor and request ID are taken from the request context.
+type RecordParams struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+}
+
+// CreateEntryParams holds the fully resolved values of a new audit entry.
+type CreateEntryParams struct {
+	ActorID    uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	RequestID  string
+}
+
+// ListEntriesParams defines the filters for querying the audit log. Zero values are ignored.
+type ListEntriesParams struct {
+	ActorID       uuid.UUID
+	Action        string
+	TargetType    string
+	TargetID      string
+	RequestID     string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Limit         int
+	Offset        int
+}
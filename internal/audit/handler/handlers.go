@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service domain.Service
+	logger  *zap.SugaredLogger
+}
+
+// New initializes a new audit Handler instance
+func New(service domain.Service, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListEntriesHandler handles querying the audit log.
+// Supported filters: actor_id, action, target_type, target_id, request_id, from, to (RFC 3339), limit, offset.
+func (h *Handler) ListEntriesHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+	query := r.URL.Query()
+
+	params := domain.ListEntriesParams{
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		TargetID:   query.Get("target_id"),
+		RequestID:  query.Get("request_id"),
+		Limit:      domain.DefaultLimit,
+		Offset:     domain.DefaultOffset,
+	}
+
+	// Parse actor_id parameter if provided
+	if actorStr := query.Get("actor_id"); actorStr != "" {
+		actorID, err := uuid.Parse(actorStr)
+		if err != nil {
+			logger.Warnw("ListEntriesHandler failed: invalid actor_id parameter", "actor_id", actorStr)
+			http.Error(w, "Invalid actor_id parameter", http.StatusBadRequest)
+			return
+		}
+		params.ActorID = actorID
+	}
+
+	// Parse time range parameters if provided
+	for name, dest := range map[string]**time.Time{"from": &params.CreatedAfter, "to": &params.CreatedBefore} {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				logger.Warnw("ListEntriesHandler failed: invalid time parameter", name, value)
+				http.Error(w, "Invalid "+name+" parameter", http.StatusBadRequest)
+				return
+			}
+			*dest = &parsed
+		}
+	}
+
+	// Parse limit parameter if provided
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > domain.MaxLimit {
+			logger.Warnw("ListEntriesHandler failed: invalid limit parameter", "limit", limitStr)
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		params.Limit = parsedLimit
+	}
+
+	// Parse offset parameter if provided
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.ParseInt(offsetStr, 10, 32)
+		if err != nil || parsedOffset < 0 {
+			logger.Warnw("ListEntriesHandler failed: invalid offset parameter", "offset", offsetStr)
+			http.Error(w, "Invalid offset parameter", http.StatusBadRequest)
+			return
+		}
+		params.Offset = int(parsedOffset)
+	}
+
+	// Call the service layer
+	entries, err := h.service.ListEntries(r.Context(), params)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Ensure an empty array instead of nil
+	if len(entries) == 0 {
+		entries = []domain.Entry{}
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		logger.Errorw("ListEntriesHandler failed: failed to encode response", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
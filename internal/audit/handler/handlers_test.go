@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/auditmock"
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	authdomain "github.com/henryhall897/golang-todo-app/internal/auth/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+	"github.com/henryhall897/golang-todo-app/internal/middleware/testutils"
+
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roleLookup is a fixed in-memory RoleLookup
+type roleLookup map[uuid.UUID]string
+
+func (l roleLookup) GetAuthIdentityByUserID(ctx context.Context, userID uuid.UUID) (authdomain.AuthIdentity, error) {
+	role, ok := l[userID]
+	if !ok {
+		return authdomain.AuthIdentity{}, common.ErrNotFound
+	}
+	return authdomain.AuthIdentity{UserID: userID, Role: role}, nil
+}
+
+// HandlerTestSuite holds shared test dependencies
+type HandlerTestSuite struct {
+	mockService *auditmock.ServiceMock
+	router      http.Handler
+	adminID     uuid.UUID
+	userID      uuid.UUID
+}
+
+// SetupSuite wires the handler behind the identity and admin role middleware
+func SetupSuite() *HandlerTestSuite {
+	mockService := &auditmock.ServiceMock{}
+	handler := New(mockService, zap.NewNop().Sugar())
+
+	adminID, userID := uuid.New(), uuid.New()
+	lookup := roleLookup{adminID: authdomain.RoleAdmin, userID: authdomain.RoleUser}
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/audit", middleware.RequireRole(lookup, authdomain.RoleAdmin)(http.HandlerFunc(handler.ListEntriesHandler)))
+
+	return &HandlerTestSuite{
+		mockService: mockService,
+		router:      testutils.Identity(mux),
+		adminID:     adminID,
+		userID:      userID,
+	}
+}
+
+func (s *HandlerTestSuite) do(path string, callerID uuid.UUID) *httptest.ResponseRecorder {
+	req := testutils.NewRequest(http.MethodGet, path, nil, callerID)
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestListEntriesHandler(t *testing.T) {
+	t.Run("success - filters passed to service", func(t *testing.T) {
+		suite := SetupSuite()
+		actorID := uuid.New()
+		entry := domain.Entry{ID: uuid.New(), ActorID: actorID, Action: domain.ActionUserUpdate, Before: json.RawMessage(`{"name":"Old"}`), After: json.RawMessage(`{"name":"New"}`)}
+
+		suite.mockService.ListEntriesFunc = func(ctx context.Context, params domain.ListEntriesParams) ([]domain.Entry, error) {
+			assert.Equal(t, actorID, params.ActorID)
+			assert.Equal(t, domain.ActionUserUpdate, params.Action)
+			assert.Equal(t, domain.TargetUser, params.TargetType)
+			assert.Equal(t, "req-1", params.RequestID)
+			require.NotNil(t, params.CreatedAfter)
+			assert.True(t, params.CreatedAfter.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+			assert.Nil(t, params.CreatedBefore)
+			assert.Equal(t, 5, params.Limit)
+			assert.Equal(t, 10, params.Offset)
+			return []domain.Entry{entry}, nil
+		}
+
+		rr := suite.do("/admin/audit?actor_id="+actorID.String()+"&action=user.update&target_type=user&request_id=req-1&from=2026-01-01T00:00:00Z&limit=5&offset=10", suite.adminID)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var entries []domain.Entry
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, entry.ID, entries[0].ID)
+		assert.JSONEq(t, `{"name":"New"}`, string(entries[0].After))
+	})
+
+	t.Run("success - defaults and empty result", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.ListEntriesFunc = func(ctx context.Context, params domain.ListEntriesParams) ([]domain.Entry, error) {
+			assert.Equal(t, domain.DefaultLimit, params.Limit)
+			assert.Equal(t, domain.DefaultOffset, params.Offset)
+			assert.Equal(t, uuid.Nil, params.ActorID)
+			return nil, nil
+		}
+
+		rr := suite.do("/admin/audit", suite.adminID)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, "[]", rr.Body.String())
+	})
+
+	t.Run("failure - invalid filters", func(t *testing.T) {
+		suite := SetupSuite()
+		for _, query := range []string{"actor_id=nope", "from=yesterday", "to=2026-13-01", "limit=0", "limit=100000", "offset=-1"} {
+			rr := suite.do("/admin/audit?"+query, suite.adminID)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+		assert.Empty(t, suite.mockService.ListEntriesCalls())
+	})
+
+	t.Run("failure - anonymous caller", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do("/admin/audit", uuid.Nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("failure - unsigned admin ID", func(t *testing.T) {
+		suite := SetupSuite()
+		req := httptest.NewRequest(http.MethodGet, "/admin/audit", nil)
+		req.Header.Set(middleware.UserIDHeader, suite.adminID.String())
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Empty(t, suite.mockService.ListEntriesCalls())
+	})
+
+	t.Run("failure - non-admin caller", func(t *testing.T) {
+		suite := SetupSuite()
+		assert.Equal(t, http.StatusForbidden, suite.do("/admin/audit", suite.userID).Code)
+		assert.Equal(t, http.StatusForbidden, suite.do("/admin/audit", uuid.New()).Code)
+		assert.Empty(t, suite.mockService.ListEntriesCalls())
+	})
+
+	t.Run("failure - service error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.ListEntriesFunc = func(ctx context.Context, params domain.ListEntriesParams) ([]domain.Entry, error) {
+			return nil, errors.New("database timeout")
+		}
+
+		rr := suite.do("/admin/audit", suite.adminID)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/auditstore"
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type repository struct {
+	pool  *pgxpool.Pool
+	query *auditstore.Queries
+}
+
+func New(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool:  pool,
+		query: auditstore.New(pool),
+	}
+}
+
+// CreateEntry appends an entry to the audit log.
+func (r *repository) CreateEntry(ctx context.Context, params domain.CreateEntryParams) (domain.Entry, error) {
+	// Convert the CreateEntryParams to auditstore.CreateAuditEntryParams
+	pgParams := createEntryParamsToPG(params)
+
+	// Execute the insert query
+	entry, err := r.query.CreateAuditEntry(ctx, pgParams)
+	if err != nil {
+		return domain.Entry{}, fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	result, err := pgToEntry(entry)
+	if err != nil {
+		return domain.Entry{}, err
+	}
+
+	return result, nil
+}
+
+// ListEntries retrieves audit entries matching the given filters, newest first.
+func (r *repository) ListEntries(ctx context.Context, params domain.ListEntriesParams) ([]domain.Entry, error) {
+	// Execute the query with the optional filters
+	entries, err := r.query.ListAuditEntries(ctx, listEntriesParamsToPG(params))
+	if err != nil {
+		return nil, fmt.Errorf("audit entries: %w", common.ErrInternalServerError)
+	}
+
+	// Convert the raw database results into the domain.Entry type
+	results := make([]domain.Entry, 0, len(entries))
+	for _, entry := range entries {
+		result, err := pgToEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,130 @@
+//go:build unit
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/dbpool"
+	"github.com/henryhall897/golang-todo-app/pkg/dbtest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+	pgt        *dbtest.PostgresTest
+	ctx        context.Context
+	repository domain.Repository
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, &AuditTestSuite{})
+}
+
+func (a *AuditTestSuite) SetupSuite() {
+	a.ctx = context.Background()
+
+	var err error
+	a.pgt, err = dbtest.NewPostgresTest(a.ctx, zap.L(), "../../../database/migrations", &dbpool.Config{
+		Logging:      false,
+		Host:         "localhost",
+		Port:         "5432",
+		User:         "testuser",
+		Password:     "1234",
+		DatabaseName: "audittestdb",
+		MaxConns:     1,
+		MinConns:     1,
+	})
+	a.Require().NoError(err)
+
+	err = a.pgt.MigrateUp()
+	a.Require().NoError(err)
+
+	a.repository = New(a.pgt.DB())
+}
+
+func (a *AuditTestSuite) TearDownSuite() {
+	a.Require().NoError(a.pgt.TearDown())
+}
+
+func (a *AuditTestSuite) TearDownTest() {
+	_, err := a.pgt.DB().Exec(a.ctx, "TRUNCATE TABLE audit_log;")
+	a.Require().NoError(err)
+}
+
+func (a *AuditTestSuite) TestCreateEntry() {
+	actorID := uuid.New()
+	params := domain.CreateEntryParams{
+		ActorID:    actorID,
+		Action:     domain.ActionUserUpdate,
+		TargetType: domain.TargetUser,
+		TargetID:   uuid.NewString(),
+		Before:     json.RawMessage(`{"name":"Old"}`),
+		After:      json.RawMessage(`{"name":"New"}`),
+		RequestID:  "req-1",
+	}
+
+	entry, err := a.repository.CreateEntry(a.ctx, params)
+
+	a.Require().NoError(err)
+	a.NotEqual(uuid.Nil, entry.ID)
+	a.Equal(actorID, entry.ActorID)
+	a.Equal(params.Action, entry.Action)
+	a.Equal(params.TargetID, entry.TargetID)
+	a.JSONEq(`{"name":"Old"}`, string(entry.Before))
+	a.JSONEq(`{"name":"New"}`, string(entry.After))
+	a.Equal("req-1", entry.RequestID)
+	a.WithinDuration(time.Now().UTC(), entry.CreatedAt, 5*time.Second)
+}
+
+func (a *AuditTestSuite) TestListEntries() {
+	// Arrange: Two actors acting on two targets
+	actorA, actorB := uuid.New(), uuid.New()
+	for i, params := range []domain.CreateEntryParams{
+		{ActorID: actorA, Action: domain.ActionUserCreate, TargetType: domain.TargetUser, TargetID: "u1", After: json.RawMessage(`{}`)},
+		{ActorID: actorA, Action: domain.ActionUserUpdate, TargetType: domain.TargetUser, TargetID: "u1", RequestID: "req-2"},
+		{ActorID: actorB, Action: domain.ActionAuthIdentityUpdateRole, TargetType: domain.TargetAuthIdentity, TargetID: "auth0|1"},
+		{Action: domain.ActionUserDelete, TargetType: domain.TargetUser, TargetID: "u2"},
+	} {
+		_, err := a.repository.CreateEntry(a.ctx, params)
+		a.Require().NoError(err, "entry %d", i)
+	}
+
+	list := func(params domain.ListEntriesParams) []domain.Entry {
+		if params.Limit == 0 {
+			params.Limit = domain.DefaultLimit
+		}
+		entries, err := a.repository.ListEntries(a.ctx, params)
+		a.Require().NoError(err)
+		return entries
+	}
+
+	// No filters returns everything, newest first
+	all := list(domain.ListEntriesParams{})
+	a.Require().Len(all, 4)
+	a.Equal(domain.ActionUserDelete, all[0].Action)
+	a.Equal(uuid.Nil, all[0].ActorID)
+
+	// Individual filters
+	a.Len(list(domain.ListEntriesParams{ActorID: actorA}), 2)
+	a.Len(list(domain.ListEntriesParams{Action: domain.ActionAuthIdentityUpdateRole}), 1)
+	a.Len(list(domain.ListEntriesParams{TargetType: domain.TargetUser, TargetID: "u1"}), 2)
+	a.Len(list(domain.ListEntriesParams{RequestID: "req-2"}), 1)
+
+	// Time range filters
+	future := time.Now().Add(time.Hour)
+	a.Empty(list(domain.ListEntriesParams{CreatedAfter: &future}))
+	a.Len(list(domain.ListEntriesParams{CreatedBefore: &future}), 4)
+
+	// Pagination
+	page := list(domain.ListEntriesParams{Limit: 2, Offset: 2})
+	a.Require().Len(page, 2)
+	a.Equal(all[2].ID, page[0].ID)
+}
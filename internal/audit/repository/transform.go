@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/auditstore"
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pgToEntry converts an auditstore.AuditLog to a domain.Entry
+func pgToEntry(pg auditstore.AuditLog) (domain.Entry, error) {
+	id, err := common.FromPgUUID(pg.ID)
+	if err != nil {
+		return domain.Entry{}, fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	actorID, err := common.FromPgUUID(pg.ActorID)
+	if err != nil {
+		return domain.Entry{}, fmt.Errorf("failed to transform actor_id uuid: %w", err)
+	}
+
+	var requestID string
+	if pg.RequestID.Valid {
+		requestID = pg.RequestID.String
+	}
+
+	return domain.Entry{
+		ID:         id,
+		ActorID:    actorID,
+		Action:     pg.Action,
+		TargetType: pg.TargetType,
+		TargetID:   pg.TargetID,
+		Before:     rawJSON(pg.Before),
+		After:      rawJSON(pg.After),
+		RequestID:  requestID,
+		CreatedAt:  common.FromPgTimestamp(pg.CreatedAt),
+	}, nil
+}
+
+// createEntryParamsToPG converts a domain.CreateEntryParams to auditstore.CreateAuditEntryParams
+func createEntryParamsToPG(params domain.CreateEntryParams) auditstore.CreateAuditEntryParams {
+	return auditstore.CreateAuditEntryParams{
+		ActorID:    optionalUUID(params.ActorID),
+		Action:     params.Action,
+		TargetType: params.TargetType,
+		TargetID:   params.TargetID,
+		Before:     nullableJSON(params.Before),
+		After:      nullableJSON(params.After),
+		RequestID:  optionalText(params.RequestID),
+	}
+}
+
+// listEntriesParamsToPG converts a domain.ListEntriesParams to auditstore.ListAuditEntriesParams
+func listEntriesParamsToPG(params domain.ListEntriesParams) auditstore.ListAuditEntriesParams {
+	return auditstore.ListAuditEntriesParams{
+		ActorID:       optionalUUID(params.ActorID),
+		Action:        optionalText(params.Action),
+		TargetType:    optionalText(params.TargetType),
+		TargetID:      optionalText(params.TargetID),
+		RequestID:     optionalText(params.RequestID),
+		CreatedAfter:  common.ToPgTimestamp(params.CreatedAfter),
+		CreatedBefore: common.ToPgTimestamp(params.CreatedBefore),
+		Limit:         int32(params.Limit),
+		Offset:        int32(params.Offset),
+	}
+}
+
+// optionalUUID maps uuid.Nil to SQL NULL
+func optionalUUID(id uuid.UUID) pgtype.UUID {
+	if id == uuid.Nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+// optionalText maps an empty string to SQL NULL
+func optionalText(s string) pgtype.Text {
+	if s == "" {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: s, Valid: true}
+}
+
+// nullableJSON maps an empty or JSON null document to SQL NULL
+func nullableJSON(raw json.RawMessage) []byte {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return raw
+}
+
+// rawJSON maps SQL NULL to a JSON null document
+func rawJSON(b []byte) json.RawMessage {
+	if len(b) == 0 {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(b)
+}
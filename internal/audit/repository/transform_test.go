@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/auditstore"
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+)
+
+type transformTestSuite struct {
+	suite.Suite
+}
+
+func TestTransform(t *testing.T) {
+	suite.Run(t, new(transformTestSuite))
+}
+
+func (suite *transformTestSuite) TestPGToEntry() {
+	// Arrange: Create a base valid entry
+	validUUID := uuid.New()
+	actorID := uuid.New()
+	validTime := time.Now().UTC()
+
+	pgEntry := auditstore.AuditLog{
+		ID:         pgtype.UUID{Bytes: validUUID, Valid: true},
+		ActorID:    pgtype.UUID{Bytes: actorID, Valid: true},
+		Action:     domain.ActionUserUpdate,
+		TargetType: domain.TargetUser,
+		TargetID:   uuid.NewString(),
+		Before:     []byte(`{"name":"Old"}`),
+		After:      []byte(`{"name":"New"}`),
+		RequestID:  pgtype.Text{String: "req-1", Valid: true},
+		CreatedAt:  pgtype.Timestamp{Time: validTime, Valid: true},
+	}
+
+	suite.Run("valid entry", func() {
+		entry, err := pgToEntry(pgEntry)
+		suite.Require().NoError(err)
+		suite.Equal(validUUID, entry.ID)
+		suite.Equal(actorID, entry.ActorID)
+		suite.Equal(pgEntry.Action, entry.Action)
+		suite.Equal(pgEntry.TargetID, entry.TargetID)
+		suite.JSONEq(`{"name":"Old"}`, string(entry.Before))
+		suite.JSONEq(`{"name":"New"}`, string(entry.After))
+		suite.Equal("req-1", entry.RequestID)
+		suite.Equal(validTime, entry.CreatedAt)
+	})
+
+	suite.Run("null actor, states and request ID", func() {
+		pg := pgEntry
+		pg.ActorID = pgtype.UUID{}
+		pg.Before = nil
+		pg.RequestID = pgtype.Text{}
+
+		entry, err := pgToEntry(pg)
+		suite.Require().NoError(err)
+		suite.Equal(uuid.Nil, entry.ActorID)
+		suite.Equal(json.RawMessage("null"), entry.Before)
+		suite.Empty(entry.RequestID)
+
+		// The entry still encodes as valid JSON
+		_, err = json.Marshal(entry)
+		suite.NoError(err)
+	})
+}
+
+func (suite *transformTestSuite) TestCreateEntryParamsToPG() {
+	suite.Run("empty values map to NULL", func() {
+		pg := createEntryParamsToPG(domain.CreateEntryParams{
+			Action:     domain.ActionUserDelete,
+			TargetType: domain.TargetUser,
+			TargetID:   "target",
+			Before:     json.RawMessage(`{"name":"Old"}`),
+			After:      json.RawMessage("null"),
+		})
+
+		suite.False(pg.ActorID.Valid)
+		suite.False(pg.RequestID.Valid)
+		suite.Nil(pg.After)
+		suite.JSONEq(`{"name":"Old"}`, string(pg.Before))
+	})
+
+	suite.Run("actor and request ID are set", func() {
+		actorID := uuid.New()
+		pg := createEntryParamsToPG(domain.CreateEntryParams{
+			ActorID:   actorID,
+			RequestID: "req-1",
+		})
+
+		suite.True(pg.ActorID.Valid)
+		suite.Equal(actorID, uuid.UUID(pg.ActorID.Bytes))
+		suite.Equal(pgtype.Text{String: "req-1", Valid: true}, pg.RequestID)
+	})
+}
+
+func (suite *transformTestSuite) TestListEntriesParamsToPG() {
+	from := time.Now().Add(-time.Hour)
+
+	pg := listEntriesParamsToPG(domain.ListEntriesParams{
+		Action:       domain.ActionUserCreate,
+		CreatedAfter: &from,
+		Limit:        10,
+		Offset:       20,
+	})
+
+	suite.False(pg.ActorID.Valid)
+	suite.Equal(pgtype.Text{String: domain.ActionUserCreate, Valid: true}, pg.Action)
+	suite.False(pg.TargetType.Valid)
+	suite.True(pg.CreatedAfter.Valid)
+	suite.False(pg.CreatedBefore.Valid)
+	suite.Equal(int32(10), pg.Limit)
+	suite.Equal(int32(20), pg.Offset)
+}
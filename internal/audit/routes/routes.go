@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/henryhall897/golang-todo-app/internal/audit/handler"
+)
+
+// RegisterRoutes sets up audit log routes. requireAdmin guards every route.
+func RegisterRoutes(router *http.ServeMux, h *handler.Handler, requireAdmin func(http.Handler) http.Handler) {
+	// Handle `/admin/audit` (Query audit log)
+	router.Handle("/admin/audit", requireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			h.ListEntriesHandler(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})))
+}
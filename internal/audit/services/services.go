@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+
+	"go.uber.org/zap"
+)
+
+type service struct {
+	repo   domain.Repository
+	logger *zap.SugaredLogger
+}
+
+func New(repo domain.Repository, logger *zap.SugaredLogger) domain.Service {
+	return &service{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Record appends a mutation to the audit log, resolving the actor and request ID from the context.
+// Failures are logged rather than returned so auditing never fails the audited operation.
+func (s *service) Record(ctx context.Context, params domain.RecordParams) {
+	before, err := marshalState(params.Before)
+	if err != nil {
+		s.logger.Errorw("Audit record failed: invalid before state", "action", params.Action, "target_id", params.TargetID, "error", err)
+		return
+	}
+
+	after, err := marshalState(params.After)
+	if err != nil {
+		s.logger.Errorw("Audit record failed: invalid after state", "action", params.Action, "target_id", params.TargetID, "error", err)
+		return
+	}
+
+	// Unauthenticated or system actions are recorded without an actor
+	actorID, _ := middleware.UserIDFromContext(ctx)
+
+	entry := domain.CreateEntryParams{
+		ActorID:    actorID,
+		Action:     params.Action,
+		TargetType: params.TargetType,
+		TargetID:   params.TargetID,
+		Before:     before,
+		After:      after,
+		RequestID:  middleware.RequestIDFromContext(ctx),
+	}
+
+	if _, err := s.repo.CreateEntry(ctx, entry); err != nil {
+		s.logger.Errorw("Audit record failed: could not store entry",
+			"action", entry.Action,
+			"target_type", entry.TargetType,
+			"target_id", entry.TargetID,
+			"request_id", entry.RequestID,
+			"error", err,
+		)
+		return
+	}
+
+	s.logger.Debugw("Audit entry recorded", "action", entry.Action, "target_id", entry.TargetID)
+}
+
+// ListEntries retrieves audit entries matching the given filters
+func (s *service) ListEntries(ctx context.Context, params domain.ListEntriesParams) ([]domain.Entry, error) {
+	entries, err := s.repo.ListEntries(ctx, params)
+	if err != nil {
+		s.logger.Errorw("ListEntries failed: internal server error", "params", params, "error", err)
+		return nil, common.ErrInternalServerError
+	}
+	return entries, nil
+}
+
+// marshalState encodes a before/after state, leaving nil states empty
+func marshalState(state interface{}) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
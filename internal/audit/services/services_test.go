@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/auditmock"
+	"github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRecord(t *testing.T) {
+	type state struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("success - actor and request ID taken from context", func(t *testing.T) {
+		mockRepo := &auditmock.RepositoryMock{
+			CreateEntryFunc: func(ctx context.Context, params domain.CreateEntryParams) (domain.Entry, error) {
+				return domain.Entry{}, nil
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		actorID := uuid.New()
+		ctx := middleware.WithUserID(context.Background(), actorID)
+		ctx = middleware.WithRequestID(ctx, "req-1")
+
+		svc.Record(ctx, domain.RecordParams{
+			Action:     domain.ActionUserUpdate,
+			TargetType: domain.TargetUser,
+			TargetID:   "target",
+			Before:     state{Name: "Old"},
+			After:      state{Name: "New"},
+		})
+
+		calls := mockRepo.CreateEntryCalls()
+		require.Len(t, calls, 1)
+		params := calls[0].Params
+		assert.Equal(t, actorID, params.ActorID)
+		assert.Equal(t, "req-1", params.RequestID)
+		assert.Equal(t, domain.ActionUserUpdate, params.Action)
+		assert.JSONEq(t, `{"name":"Old"}`, string(params.Before))
+		assert.JSONEq(t, `{"name":"New"}`, string(params.After))
+	})
+
+	t.Run("success - missing states and anonymous actor", func(t *testing.T) {
+		mockRepo := &auditmock.RepositoryMock{
+			CreateEntryFunc: func(ctx context.Context, params domain.CreateEntryParams) (domain.Entry, error) {
+				return domain.Entry{}, nil
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		svc.Record(context.Background(), domain.RecordParams{
+			Action: domain.ActionUserCreate,
+			After:  state{Name: "New"},
+		})
+
+		calls := mockRepo.CreateEntryCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, uuid.Nil, calls[0].Params.ActorID)
+		assert.Empty(t, calls[0].Params.RequestID)
+		assert.Nil(t, calls[0].Params.Before)
+	})
+
+	t.Run("failure - repository error is swallowed", func(t *testing.T) {
+		mockRepo := &auditmock.RepositoryMock{
+			CreateEntryFunc: func(ctx context.Context, params domain.CreateEntryParams) (domain.Entry, error) {
+				return domain.Entry{}, errors.New("database timeout")
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		assert.NotPanics(t, func() {
+			svc.Record(context.Background(), domain.RecordParams{Action: domain.ActionUserDelete})
+		})
+		assert.Len(t, mockRepo.CreateEntryCalls(), 1)
+	})
+
+	t.Run("failure - unencodable state is not stored", func(t *testing.T) {
+		mockRepo := &auditmock.RepositoryMock{}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		svc.Record(context.Background(), domain.RecordParams{
+			Action: domain.ActionUserUpdate,
+			After:  make(chan int),
+		})
+
+		assert.Empty(t, mockRepo.CreateEntryCalls())
+	})
+}
+
+func TestListEntries(t *testing.T) {
+	t.Run("success - entries returned", func(t *testing.T) {
+		expected := []domain.Entry{{ID: uuid.New(), Action: domain.ActionUserCreate}}
+		mockRepo := &auditmock.RepositoryMock{
+			ListEntriesFunc: func(ctx context.Context, params domain.ListEntriesParams) ([]domain.Entry, error) {
+				return expected, nil
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		entries, err := svc.ListEntries(context.Background(), domain.ListEntriesParams{Limit: domain.DefaultLimit})
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, entries)
+	})
+
+	t.Run("failure - repository error is masked", func(t *testing.T) {
+		mockRepo := &auditmock.RepositoryMock{
+			ListEntriesFunc: func(ctx context.Context, params domain.ListEntriesParams) ([]domain.Entry, error) {
+				return nil, errors.New("database timeout")
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		_, err := svc.ListEntries(context.Background(), domain.ListEntriesParams{})
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, common.ErrInternalServerError))
+	})
+}
@@ -0,0 +1,7 @@
+package domain
+
+// Roles stored on auth identities
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
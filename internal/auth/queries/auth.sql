@@ -7,6 +7,11 @@ RETURNING *;
 SELECT * FROM auth_identities
 WHERE auth_id = $1;
 
+-- name: GetAuthIdentityByAuthIDForUpdate :one
+SELECT * FROM auth_identities
+WHERE auth_id = $1
+FOR UPDATE;
+
 -- name: GetAuthIdentityByUserID :one
 SELECT * FROM auth_identities
 WHERE user_id = $1;
@@ -17,7 +22,8 @@ SET role = $2, updated_at = NOW()
 WHERE auth_id = $1
 RETURNING *;
 
--- name: DeleteAuthIdentityByAuthID :execrows
+-- name: DeleteAuthIdentityByAuthID :one
 DELETE FROM auth_identities
-WHERE auth_id = $1;
+WHERE auth_id = $1
+RETURNING *;
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/henryhall897/golang-todo-app/gen/queries/authstore"
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
 	"github.com/henryhall897/golang-todo-app/internal/auth/domain"
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
 	"github.com/jackc/pgx/v5"
@@ -15,14 +16,16 @@ import (
 )
 
 type repository struct {
-	pool  *pgxpool.Pool
-	query *authstore.Queries
+	pool    *pgxpool.Pool
+	query   *authstore.Queries
+	auditor auditdomain.Auditor
 }
 
-func New(pool *pgxpool.Pool) *repository {
+func New(pool *pgxpool.Pool, auditor auditdomain.Auditor) *repository {
 	return &repository{
-		pool:  pool,
-		query: authstore.New(pool),
+		pool:    pool,
+		query:   authstore.New(pool),
+		auditor: auditor,
 	}
 }
 func (r *repository) CreateAuthIdentity(ctx context.Context, input domain.CreateAuthIdentityParams) (domain.AuthIdentity, error) {
@@ -47,6 +50,13 @@ func (r *repository) CreateAuthIdentity(ctx context.Context, input domain.Create
 		return domain.AuthIdentity{}, err
 	}
 
+	r.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionAuthIdentityCreate,
+		TargetType: auditdomain.TargetAuthIdentity,
+		TargetID:   result.AuthID,
+		After:      result,
+	})
+
 	return result, nil
 }
 
@@ -95,31 +105,76 @@ func (r *repository) GetAuthIdentityByUserID(ctx context.Context, userID uuid.UU
 
 // UpdateAuthIdentityRole updates the role for a given auth identity.
 func (r *repository) UpdateAuthIdentityRole(ctx context.Context, params domain.UpdateAuthIdentityParams) (domain.AuthIdentity, error) {
+	// Read the previous role and update it in one transaction so the audit entry is accurate
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("auth identity %s: %w", params.AuthID, common.ErrInternalServerError)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := r.query.WithTx(tx)
+
+	previous, err := query.GetAuthIdentityByAuthIDForUpdate(ctx, params.AuthID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AuthIdentity{}, fmt.Errorf("auth identity %s: %w", params.AuthID, common.ErrNotFound)
+	} else if err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("auth identity %s: %w", params.AuthID, common.ErrInternalServerError)
+	}
+
 	// Convert the UpdateAuthIdentityParams to UpdateAuthIdentityRoleParams
 	pgParams := updateAuthIdentityParamsToPG(params)
-	result, err := r.query.UpdateAuthIdentityRole(ctx, pgParams)
+	result, err := query.UpdateAuthIdentityRole(ctx, pgParams)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return domain.AuthIdentity{}, fmt.Errorf("auth identity %s: %w", params.AuthID, common.ErrNotFound)
 	} else if err != nil {
 		return domain.AuthIdentity{}, fmt.Errorf("auth identity %s: %w", params.AuthID, common.ErrInternalServerError)
 	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("auth identity %s: %w", params.AuthID, common.ErrInternalServerError)
+	}
+
+	oldAuthIdentity, err := pgToAuthIdentity(previous)
+	if err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("failed to transform auth identity: %w", err)
+	}
 	newAuthIdentity, err := pgToAuthIdentity(result)
 	if err != nil {
 		return domain.AuthIdentity{}, fmt.Errorf("failed to transform auth identity: %w", err)
 	}
+
+	r.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionAuthIdentityUpdateRole,
+		TargetType: auditdomain.TargetAuthIdentity,
+		TargetID:   params.AuthID,
+		Before:     oldAuthIdentity,
+		After:      newAuthIdentity,
+	})
+
 	return newAuthIdentity, nil
 }
 
 // DeleteAuthIdentityByAuthID deletes an auth identity by its AuthID and verifies it existed.
 func (r *repository) DeleteAuthIdentityByAuthID(ctx context.Context, authID string) error {
-	// Execute the delete and get number of rows affected
-	rowsAffected, err := r.query.DeleteAuthIdentityByAuthID(ctx, authID)
-	if err != nil {
+	// Execute the delete and get the removed row
+	deleted, err := r.query.DeleteAuthIdentityByAuthID(ctx, authID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("auth identity %s: %w", authID, common.ErrNotFound)
+	} else if err != nil {
 		return fmt.Errorf("auth identity %s: %w", authID, common.ErrInternalServerError)
 	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("auth identity %s: %w", authID, common.ErrNotFound)
+
+	previous, err := pgToAuthIdentity(deleted)
+	if err != nil {
+		return fmt.Errorf("failed to transform auth identity: %w", err)
 	}
 
+	r.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionAuthIdentityDelete,
+		TargetType: auditdomain.TargetAuthIdentity,
+		TargetID:   authID,
+		Before:     previous,
+	})
+
 	return nil
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 
+	"github.com/henryhall897/golang-todo-app/gen/mocks/auditmock"
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
 	"github.com/henryhall897/golang-todo-app/internal/auth/domain"
 	"github.com/henryhall897/golang-todo-app/internal/auth/testutils"
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
@@ -23,6 +25,7 @@ type AuthTestSuite struct {
 	pgt        *dbtest.PostgresTest
 	ctx        context.Context
 	repository domain.Repository
+	auditor    *auditmock.AuditorMock
 }
 
 func TestAuth(t *testing.T) {
@@ -48,7 +51,10 @@ func (a *AuthTestSuite) SetupSuite() {
 	err = a.pgt.MigrateUp()
 	a.Require().NoError(err)
 
-	a.repository = New(a.pgt.DB())
+	a.auditor = &auditmock.AuditorMock{
+		RecordFunc: func(ctx context.Context, params auditdomain.RecordParams) {},
+	}
+	a.repository = New(a.pgt.DB(), a.auditor)
 }
 
 func (a *AuthTestSuite) TearDownSuite() {
@@ -208,6 +214,15 @@ func (a *AuthTestSuite) TestUpdateAuthIdentityRole() {
 		a.Equal(created.Provider, updated.Provider)
 		a.Equal(created.UserID, updated.UserID)
 		a.Equal(updateParams.Role, updated.Role) // Role should be updated
+
+		// The role change is recorded with the previous and new role
+		calls := a.auditor.RecordCalls()
+		a.Require().NotEmpty(calls)
+		last := calls[len(calls)-1].Params
+		a.Equal(auditdomain.ActionAuthIdentityUpdateRole, last.Action)
+		a.Equal(created.AuthID, last.TargetID)
+		a.Equal(created.Role, last.Before.(domain.AuthIdentity).Role)
+		a.Equal(updateParams.Role, last.After.(domain.AuthIdentity).Role)
 	})
 
 	t.Run("Return error if auth ID does not exist", func(t *testing.T) {
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+	"github.com/henryhall897/golang-todo-app/internal/users/services"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+)
+
+// CountsResponse reports the number of cached keys in each namespace, optionally limited to a prefix
+type CountsResponse struct {
+	Prefix     string           `json:"prefix,omitempty"`
+	Namespaces map[string]int64 `json:"namespaces"`
+}
+
+// NamespaceResponse reports the number of cached keys in one namespace
+type NamespaceResponse struct {
+	Namespace string `json:"namespace"`
+	Prefix    string `json:"prefix,omitempty"`
+	Keys      int64  `json:"keys"`
+}
+
+// FlushResponse reports the keys deleted from one namespace
+type FlushResponse struct {
+	Namespace string `json:"namespace"`
+	Prefix    string `json:"prefix,omitempty"`
+	Deleted   int64  `json:"deleted"`
+}
+
+type Handler struct {
+	namespaces map[string]redispkg.Cache
+	users      domain.Service
+	logger     *zap.SugaredLogger
+}
+
+// New initializes a new cache admin Handler over the named cache namespaces, using the user
+// service to inspect and evict a user's entries.
+func New(namespaces map[string]redispkg.Cache, users domain.Service, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		namespaces: namespaces,
+		users:      users,
+		logger:     logger,
+	}
+}
+
+// CountsHandler handles counting the cached keys in every namespace.
+// Supported filters: prefix, matching keys within each namespace.
+func (h *Handler) CountsHandler(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+
+	resp := CountsResponse{Prefix: prefix, Namespaces: make(map[string]int64, len(h.namespaces))}
+	for name, cache := range h.namespaces {
+		count, err := cache.CountByPrefix(r.Context(), prefix)
+		if err != nil {
+			h.logger.Warnw("CountsHandler failed: cache unavailable", "namespace", name, "error", err)
+			http.Error(w, "Cache unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		resp.Namespaces[name] = count
+	}
+
+	h.writeJSON(w, "CountsHandler", resp)
+}
+
+// NamespaceCountHandler handles counting the cached keys in one namespace.
+// Supported filters: prefix, matching keys within the namespace.
+func (h *Handler) NamespaceCountHandler(w http.ResponseWriter, r *http.Request) {
+	name, cache, ok := h.namespace(r)
+	if !ok {
+		http.Error(w, "Unknown cache namespace", http.StatusNotFound)
+		return
+	}
+	prefix := r.URL.Query().Get("prefix")
+
+	count, err := cache.CountByPrefix(r.Context(), prefix)
+	if err != nil {
+		h.logger.Warnw("NamespaceCountHandler failed: cache unavailable", "namespace", name, "error", err)
+		http.Error(w, "Cache unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	h.writeJSON(w, "NamespaceCountHandler", NamespaceResponse{Namespace: name, Prefix: prefix, Keys: count})
+}
+
+// FlushNamespaceHandler handles deleting the cached keys in one namespace, or only those starting
+// with the prefix parameter. Keys are found with SCAN, so Redis keeps serving other clients.
+func (h *Handler) FlushNamespaceHandler(w http.ResponseWriter, r *http.Request) {
+	name, cache, ok := h.namespace(r)
+	if !ok {
+		http.Error(w, "Unknown cache namespace", http.StatusNotFound)
+		return
+	}
+	prefix := r.URL.Query().Get("prefix")
+
+	deleted, err := cache.DeleteByPrefix(r.Context(), prefix)
+	if err != nil {
+		h.logger.Warnw("FlushNamespaceHandler failed: cache unavailable", "namespace", name, "prefix", prefix, "deleted", deleted, "error", err)
+		http.Error(w, "Cache unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	h.logger.Infow("Cache namespace flushed", "namespace", name, "prefix", prefix, "deleted", deleted)
+
+	h.writeJSON(w, "FlushNamespaceHandler", FlushResponse{Namespace: name, Prefix: prefix, Deleted: deleted})
+}
+
+// GetCachedUserHandler handles fetching a user's cache entries without loading them into the cache
+func (h *Handler) GetCachedUserHandler(w http.ResponseWriter, r *http.Request) {
+	// Extract validated user ID from context
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw("GetCachedUserHandler failed: user ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	entries, err := h.users.GetCachedUser(r.Context(), userID)
+	if err != nil {
+		h.writeUserError(w, "GetCachedUserHandler", userID, err)
+		return
+	}
+
+	h.writeJSON(w, "GetCachedUserHandler", entries)
+}
+
+// EvictUserHandler handles removing a user's cache entries
+func (h *Handler) EvictUserHandler(w http.ResponseWriter, r *http.Request) {
+	// Extract validated user ID from context
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw("EvictUserHandler failed: user ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.users.EvictUser(r.Context(), userID); err != nil {
+		h.writeUserError(w, "EvictUserHandler", userID, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// namespace returns the cache named by `/admin/cache/{namespace}`
+func (h *Handler) namespace(r *http.Request) (string, redispkg.Cache, bool) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) != 3 {
+		return "", nil, false
+	}
+	cache, ok := h.namespaces[segments[2]]
+	return segments[2], cache, ok
+}
+
+// writeUserError maps a user service error to a response
+func (h *Handler) writeUserError(w http.ResponseWriter, op string, userID uuid.UUID, err error) {
+	if errors.Is(err, services.ErrCacheUnavailable) {
+		h.logger.Warnw(op+" failed: cache unavailable", "user_id", userID, "error", err)
+		http.Error(w, "Cache unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	h.logger.Errorw(op+" failed: internal server error", "user_id", userID, "error", err)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// writeJSON writes resp as a JSON response
+func (h *Handler) writeJSON(w http.ResponseWriter, op string, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Errorw(op+" failed: failed to encode response", "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/redismock"
+	"github.com/henryhall897/golang-todo-app/gen/mocks/usersmock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+	"github.com/henryhall897/golang-todo-app/internal/users/services"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// HandlerTestSuite holds shared test dependencies
+type HandlerTestSuite struct {
+	users     *redismock.CacheMock
+	stats     *redismock.CacheMock
+	mockUsers *usersmock.ServiceMock
+	router    http.Handler
+}
+
+// SetupSuite wires the handlers over two mocked namespaces
+func SetupSuite() *HandlerTestSuite {
+	users := &redismock.CacheMock{}
+	stats := &redismock.CacheMock{}
+	mockUsers := &usersmock.ServiceMock{}
+	handler := New(map[string]redispkg.Cache{"users": users, "stats": stats}, mockUsers, zap.NewNop().Sugar())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/cache", handler.CountsHandler)
+	mux.HandleFunc("GET /admin/cache/{namespace}", handler.NamespaceCountHandler)
+	mux.HandleFunc("DELETE /admin/cache/{namespace}", handler.FlushNamespaceHandler)
+	mux.HandleFunc("GET /admin/cache/users/{id}", VerifyUserPath(handler.GetCachedUserHandler))
+	mux.HandleFunc("DELETE /admin/cache/users/{id}", VerifyUserPath(handler.EvictUserHandler))
+
+	return &HandlerTestSuite{
+		users:     users,
+		stats:     stats,
+		mockUsers: mockUsers,
+		router:    mux,
+	}
+}
+
+func (s *HandlerTestSuite) do(method, path string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+	return rr
+}
+
+// countFunc returns a CountByPrefix mock reporting count keys for every prefix
+func countFunc(count int64, err error) func(ctx context.Context, prefix string) (int64, error) {
+	return func(ctx context.Context, prefix string) (int64, error) {
+		return count, err
+	}
+}
+
+func TestCountsHandler(t *testing.T) {
+	t.Run("success - counts every namespace", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.users.CountByPrefixFunc = countFunc(12, nil)
+		suite.stats.CountByPrefixFunc = countFunc(3, nil)
+
+		rr := suite.do(http.MethodGet, "/admin/cache?prefix=id:")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp CountsResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, "id:", resp.Prefix)
+		assert.Equal(t, map[string]int64{"users": 12, "stats": 3}, resp.Namespaces)
+		assert.Equal(t, "id:", suite.users.CountByPrefixCalls()[0].Prefix)
+	})
+
+	t.Run("failure - cache unavailable", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.users.CountByPrefixFunc = countFunc(0, redispkg.ErrCircuitOpen)
+		suite.stats.CountByPrefixFunc = countFunc(0, redispkg.ErrCircuitOpen)
+
+		rr := suite.do(http.MethodGet, "/admin/cache")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+}
+
+func TestNamespaceHandlers(t *testing.T) {
+	t.Run("success - counts one namespace", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.stats.CountByPrefixFunc = countFunc(3, nil)
+
+		rr := suite.do(http.MethodGet, "/admin/cache/stats")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp NamespaceResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, NamespaceResponse{Namespace: "stats", Keys: 3}, resp)
+		assert.Empty(t, suite.users.CountByPrefixCalls())
+	})
+
+	t.Run("success - flushes a prefix", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.users.DeleteByPrefixFunc = func(ctx context.Context, prefix string) (int64, error) {
+			return 7, nil
+		}
+
+		rr := suite.do(http.MethodDelete, "/admin/cache/users?prefix=page:")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp FlushResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, FlushResponse{Namespace: "users", Prefix: "page:", Deleted: 7}, resp)
+		require.Len(t, suite.users.DeleteByPrefixCalls(), 1)
+		assert.Equal(t, "page:", suite.users.DeleteByPrefixCalls()[0].Prefix)
+	})
+
+	t.Run("failure - unknown namespace", func(t *testing.T) {
+		suite := SetupSuite()
+
+		assert.Equal(t, http.StatusNotFound, suite.do(http.MethodGet, "/admin/cache/sessions").Code)
+		assert.Equal(t, http.StatusNotFound, suite.do(http.MethodDelete, "/admin/cache/sessions").Code)
+	})
+
+	t.Run("failure - cache unavailable", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.users.DeleteByPrefixFunc = func(ctx context.Context, prefix string) (int64, error) {
+			return 0, errors.New("connection refused")
+		}
+
+		rr := suite.do(http.MethodDelete, "/admin/cache/users")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+}
+
+func TestCachedUserHandlers(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success - returns the user's entries", func(t *testing.T) {
+		suite := SetupSuite()
+		user := domain.User{ID: userID, Name: "Test User", Email: "test@example.com"}
+		suite.mockUsers.GetCachedUserFunc = func(ctx context.Context, id uuid.UUID) (domain.CachedUser, error) {
+			return domain.CachedUser{UserID: id, Email: user.Email, ByID: &user}, nil
+		}
+
+		rr := suite.do(http.MethodGet, "/admin/cache/users/"+userID.String())
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp domain.CachedUser
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, userID, resp.UserID)
+		require.NotNil(t, resp.ByID)
+		assert.Equal(t, user.Email, resp.ByID.Email)
+		assert.Nil(t, resp.ByEmail)
+	})
+
+	t.Run("success - evicts the user", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockUsers.EvictUserFunc = func(ctx context.Context, id uuid.UUID) error {
+			return nil
+		}
+
+		rr := suite.do(http.MethodDelete, "/admin/cache/users/"+userID.String())
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		require.Len(t, suite.mockUsers.EvictUserCalls(), 1)
+		assert.Equal(t, userID, suite.mockUsers.EvictUserCalls()[0].ID)
+	})
+
+	t.Run("failure - invalid user ID", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := suite.do(http.MethodGet, "/admin/cache/users/not-a-uuid")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - service errors", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockUsers.GetCachedUserFunc = func(ctx context.Context, id uuid.UUID) (domain.CachedUser, error) {
+			return domain.CachedUser{}, common.ErrInternalServerError
+		}
+		suite.mockUsers.EvictUserFunc = func(ctx context.Context, id uuid.UUID) error {
+			return services.ErrCacheUnavailable
+		}
+
+		assert.Equal(t, http.StatusInternalServerError, suite.do(http.MethodGet, "/admin/cache/users/"+userID.String()).Code)
+		assert.Equal(t, http.StatusServiceUnavailable, suite.do(http.MethodDelete, "/admin/cache/users/"+userID.String()).Code)
+	})
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const userIDKey = contextKey("userID")
+
+// VerifyUserPath extracts and validates the user UUID from `/admin/cache/users/{id}`
+func VerifyUserPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) != 4 || segments[0] != "admin" || segments[1] != "cache" || segments[2] != "users" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(segments[3])
+		if err != nil || id == uuid.Nil {
+			logger.Warnw("VerifyUserPath failed: invalid user ID", "id", segments[3])
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), userIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
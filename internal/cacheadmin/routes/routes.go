@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/cacheadmin/handler"
+)
+
+// RegisterRoutes sets up cache management routes. requireAdmin guards every route.
+func RegisterRoutes(router *http.ServeMux, h *handler.Handler, requireAdmin func(http.Handler) http.Handler) {
+	// Handle `/admin/cache` (Count keys in every namespace)
+	router.Handle("/admin/cache", requireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			h.CountsHandler(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})))
+
+	router.Handle("/admin/cache/", requireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Extract path segments
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		// Handle `/admin/cache/{namespace}` (Count keys, Flush namespace)
+		if len(segments) == 3 {
+			if r.Method == http.MethodGet {
+				h.NamespaceCountHandler(w, r)
+				return
+			}
+
+			if r.Method == http.MethodDelete {
+				h.FlushNamespaceHandler(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Handle `/admin/cache/users/{id}` (Get cache entries, Evict user)
+		if len(segments) == 4 && segments[2] == "users" {
+			if r.Method == http.MethodGet {
+				handler.VerifyUserPath(h.GetCachedUserHandler).ServeHTTP(w, r)
+				return
+			}
+
+			if r.Method == http.MethodDelete {
+				handler.VerifyUserPath(h.EvictUserHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Return 404 for invalid paths
+		http.NotFound(w, r)
+	})))
+}
@@ -0,0 +1,78 @@
+// Package caldav exposes todo lists to native task apps as CalDAV (RFC 4791) calendar collections.
+// Each list is a calendar holding one VTODO calendar object resource per task, named after the task ID.
+package caldav
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RootPath is the principal URL of every caller; clients discover it through /.well-known/caldav.
+	RootPath = "/dav/"
+	// CalendarsPath is the calendar home set holding one calendar collection per list.
+	CalendarsPath = "/dav/calendars/"
+	// ObjectExtension ends the name of every calendar object resource.
+	ObjectExtension = ".ics"
+
+	// Capabilities is advertised in the DAV header: class 1 and 3 WebDAV, without locking, and calendar-access.
+	Capabilities = "1, 3, calendar-access"
+	// AllowedMethods is advertised in the Allow header.
+	AllowedMethods = "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT"
+	// CalendarContentType is the media type of calendar object resources.
+	CalendarContentType = "text/calendar; charset=utf-8; component=VTODO"
+
+	// NamespaceDAV, NamespaceCalDAV and NamespaceCalendarServer are the XML namespaces of the properties served.
+	NamespaceDAV            = "DAV:"
+	NamespaceCalDAV         = "urn:ietf:params:xml:ns:caldav"
+	NamespaceCalendarServer = "http://calendarserver.org/ns/"
+)
+
+// CalendarPath returns the href of a list's calendar collection.
+func CalendarPath(listID uuid.UUID) string {
+	return CalendarsPath + listID.String() + "/"
+}
+
+// ObjectPath returns the href of a task's calendar object resource.
+func ObjectPath(listID, taskID uuid.UUID) string {
+	return CalendarPath(listID) + taskID.String() + ObjectExtension
+}
+
+// ParseObjectName returns the task ID a calendar object resource is named after.
+func ParseObjectName(name string) (uuid.UUID, bool) {
+	base, ok := strings.CutSuffix(name, ObjectExtension)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(base)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// ETag returns the strong entity tag of a task's calendar object resource; it changes whenever the task is updated.
+func ETag(task tasks.FullTask) string {
+	sum := sha256.Sum256([]byte(task.ID.String() + "|" + task.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+	return `"` + hex.EncodeToString(sum[:12]) + `"`
+}
+
+// CTag returns the collection tag of a calendar holding the given tasks.
+// It changes whenever a task is created, updated or deleted, so clients can skip unchanged calendars.
+func CTag(list []tasks.FullTask) string {
+	etags := make([]string, len(list))
+	for i, task := range list {
+		etags[i] = ETag(task)
+	}
+	slices.Sort(etags) // Independent of listing order
+
+	sum := sha256.Sum256([]byte(strings.Join(etags, ",")))
+	return `"` + hex.EncodeToString(sum[:12]) + `"`
+}
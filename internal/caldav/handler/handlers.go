@@ -0,0 +1,657 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/caldav"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// listPageSize is the number of lists read per page when listing the calendar home set
+const listPageSize = 100
+
+type Handler struct {
+	tasks  caldav.TaskStore
+	lists  caldav.ListStore
+	logger *zap.SugaredLogger
+}
+
+// New initializes a new CalDAV Handler instance.
+// Callers are identified by the gateway like every other route; clients authenticate against the gateway.
+func New(tasks caldav.TaskStore, lists caldav.ListStore, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		tasks:  tasks,
+		lists:  lists,
+		logger: logger,
+	}
+}
+
+// WellKnownHandler redirects service discovery to the principal URL (RFC 6764 section 5).
+func (h *Handler) WellKnownHandler(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, caldav.RootPath, http.StatusMovedPermanently)
+}
+
+// OptionsHandler advertises the DAV classes and methods supported.
+func (h *Handler) OptionsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", caldav.Capabilities)
+	w.Header().Set("Allow", caldav.AllowedMethods)
+	w.WriteHeader(http.StatusOK)
+}
+
+// PropfindRootHandler describes the caller's principal, pointing clients at their calendar home set.
+func (h *Handler) PropfindRootHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.caller(w, r, "PropfindRootHandler"); !ok {
+		return
+	}
+	req, ok := h.decodePropfind(w, r, "PropfindRootHandler")
+	if !ok {
+		return
+	}
+
+	props := []property{
+		{XMLName: propResourceType, Inner: principalResourceType},
+		textProperty(propDisplayName, "Todo lists"),
+		hrefProperty(propCurrentUserPrincipal, caldav.RootPath),
+		hrefProperty(propPrincipalURL, caldav.RootPath),
+		hrefProperty(propCalendarHomeSet, caldav.CalendarsPath),
+	}
+	h.writeMultistatus(w, "PropfindRootHandler", []response{req.response(caldav.RootPath, props)})
+}
+
+// PropfindHomeHandler describes the calendar home set and, at depth 1, a calendar collection per list.
+func (h *Handler) PropfindHomeHandler(w http.ResponseWriter, r *http.Request) {
+	callerID, ok := h.caller(w, r, "PropfindHomeHandler")
+	if !ok {
+		return
+	}
+	req, ok := h.decodePropfind(w, r, "PropfindHomeHandler")
+	if !ok {
+		return
+	}
+
+	props := []property{
+		{XMLName: propResourceType, Inner: collectionResourceType},
+		textProperty(propDisplayName, "Todo lists"),
+		hrefProperty(propCurrentUserPrincipal, caldav.RootPath),
+		{XMLName: propCurrentUserPrivileges, Inner: readPrivileges},
+	}
+	responses := []response{req.response(caldav.CalendarsPath, props)}
+
+	if depth(r) > 0 {
+		for offset := int32(0); ; offset += listPageSize {
+			lists, err := h.lists.ListTodoListsWithPagination(r.Context(), todolist.ListTodoListsWithPaginationParams{
+				UserID: callerID,
+				Limit:  listPageSize,
+				Offset: offset,
+			})
+			if err != nil {
+				h.logger.Errorw("PropfindHomeHandler failed: failed to list todo lists", "callerID", callerID, "error", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			for _, list := range lists {
+				props, err := h.calendarProps(r.Context(), list, callerID, req.propRequest, nil)
+				if err != nil {
+					h.logger.Errorw("PropfindHomeHandler failed: failed to list tasks", "listID", list.ID, "error", err)
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+				responses = append(responses, req.response(caldav.CalendarPath(list.ID), props))
+			}
+			if len(lists) < listPageSize {
+				break
+			}
+		}
+	}
+
+	h.writeMultistatus(w, "PropfindHomeHandler", responses)
+}
+
+// PropfindCalendarHandler describes a list's calendar collection and, at depth 1, its calendar object resources.
+func (h *Handler) PropfindCalendarHandler(w http.ResponseWriter, r *http.Request) {
+	callerID, list, ok := h.authorizeList(w, r, "PropfindCalendarHandler")
+	if !ok {
+		return
+	}
+	req, ok := h.decodePropfind(w, r, "PropfindCalendarHandler")
+	if !ok {
+		return
+	}
+
+	// Both the collection tag and the member resources are computed from the list's tasks
+	listed, err := h.tasks.ListTasks(r.Context(), tasks.TaskListParams{ListID: list.ID, UserID: callerID})
+	if err != nil {
+		h.logger.Errorw("PropfindCalendarHandler failed: failed to list tasks", "listID", list.ID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	props, err := h.calendarProps(r.Context(), list, callerID, req.propRequest, listed)
+	if err != nil {
+		h.logger.Errorw("PropfindCalendarHandler failed: failed to describe calendar", "listID", list.ID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	responses := []response{req.response(caldav.CalendarPath(list.ID), props)}
+
+	if depth(r) > 0 {
+		for _, task := range listed {
+			props, err := objectProps(task, req.propRequest)
+			if err != nil {
+				h.logger.Errorw("PropfindCalendarHandler failed: failed to encode task", "taskID", task.ID, "error", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			responses = append(responses, req.response(caldav.ObjectPath(list.ID, task.ID), props))
+		}
+	}
+
+	h.writeMultistatus(w, "PropfindCalendarHandler", responses)
+}
+
+// ReportCalendarHandler answers calendar-query and calendar-multiget reports on a list's calendar collection.
+// Queries are matched on their component and on COMPLETED and STATUS property filters; time ranges are not
+// evaluated, so clients may receive tasks outside the range asked for.
+func (h *Handler) ReportCalendarHandler(w http.ResponseWriter, r *http.Request) {
+	callerID, list, ok := h.authorizeList(w, r, "ReportCalendarHandler")
+	if !ok {
+		return
+	}
+
+	var req reportRequest
+	if err := decodeRequestBody(r, &req); err != nil {
+		h.logger.Warnw("ReportCalendarHandler failed: invalid request body", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if req.XMLName != reportCalendarQuery && req.XMLName != reportCalendarMultiget {
+		h.logger.Warnw("ReportCalendarHandler failed: unsupported report", "report", req.XMLName.Local)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	listed, err := h.tasks.ListTasks(r.Context(), tasks.TaskListParams{ListID: list.ID, UserID: callerID})
+	if err != nil {
+		h.logger.Errorw("ReportCalendarHandler failed: failed to list tasks", "listID", list.ID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	var responses []response
+	if req.XMLName == reportCalendarMultiget {
+		for _, href := range req.Hrefs {
+			task, ok := findTask(listed, objectID(href, list.ID))
+			if !ok {
+				responses = append(responses, response{Href: href, Status: statusNotFound})
+				continue
+			}
+			props, err := objectProps(task, req.propRequest)
+			if err != nil {
+				h.logger.Errorw("ReportCalendarHandler failed: failed to encode task", "taskID", task.ID, "error", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			responses = append(responses, req.response(href, props))
+		}
+	} else {
+		for _, task := range listed {
+			if !matchesFilter(req.Filter, task) {
+				continue
+			}
+			props, err := objectProps(task, req.propRequest)
+			if err != nil {
+				h.logger.Errorw("ReportCalendarHandler failed: failed to encode task", "taskID", task.ID, "error", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			responses = append(responses, req.response(caldav.ObjectPath(list.ID, task.ID), props))
+		}
+	}
+
+	h.writeMultistatus(w, "ReportCalendarHandler", responses)
+}
+
+// PropfindObjectHandler describes a single calendar object resource.
+func (h *Handler) PropfindObjectHandler(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.loadObject(w, r, "PropfindObjectHandler")
+	if !ok {
+		return
+	}
+	req, ok := h.decodePropfind(w, r, "PropfindObjectHandler")
+	if !ok {
+		return
+	}
+
+	props, err := objectProps(task, req.propRequest)
+	if err != nil {
+		h.logger.Errorw("PropfindObjectHandler failed: failed to encode task", "taskID", task.ID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	h.writeMultistatus(w, "PropfindObjectHandler", []response{req.response(caldav.ObjectPath(task.ListID, task.ID), props)})
+}
+
+// GetObjectHandler returns a task as a calendar object resource.
+func (h *Handler) GetObjectHandler(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.loadObject(w, r, "GetObjectHandler")
+	if !ok {
+		return
+	}
+
+	etag := caldav.ETag(task)
+	if matchesETag(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tasks.WriteCalendarObject(&buf, task, time.Now().UTC()); err != nil {
+		h.logger.Errorw("GetObjectHandler failed: failed to encode task", "taskID", task.ID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", caldav.CalendarContentType)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", task.UpdatedAt.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		h.logger.Warnw("GetObjectHandler failed: failed to write response", "taskID", task.ID, "error", err)
+	}
+}
+
+// PutObjectHandler creates or replaces the task held by a calendar object resource.
+// If-Match and If-None-Match let clients avoid overwriting changes made elsewhere.
+func (h *Handler) PutObjectHandler(w http.ResponseWriter, r *http.Request) {
+	callerID, list, ok := h.authorizeList(w, r, "PutObjectHandler")
+	if !ok {
+		return
+	}
+
+	// Extract validated task ID from context
+	taskID, ok := r.Context().Value(taskIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw("PutObjectHandler failed: task ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "text/calendar" {
+			h.logger.Warnw("PutObjectHandler failed: unsupported content type", "contentType", contentType)
+			http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+			return
+		}
+	}
+
+	object, err := tasks.ParseCalendarObject(r.Body, time.Now().UTC())
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.logger.Warnw("PutObjectHandler failed: invalid calendar object", "taskID", taskID, "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	existing, err := h.tasks.GetTask(r.Context(), tasks.GetTaskParams{ID: taskID, ListID: list.ID, UserID: callerID})
+	exists := err == nil
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		h.logger.Errorw("PutObjectHandler failed: failed to get task", "taskID", taskID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !checkPreconditions(r, existing, exists) {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+
+	if !exists {
+		created, err := h.tasks.CreateTaskWithID(r.Context(), taskID, tasks.CreateTaskParams{
+			ListID:      list.ID,
+			Title:       &object.Title,
+			Description: object.Description,
+			Status:      &object.Status,
+			DueDate:     object.DueDate,
+			Priority:    object.Priority,
+			CompletedAt: object.CompletedAt,
+			Tags:        object.Tags,
+		})
+		if err != nil {
+			// The ID names a task in another list
+			if errors.Is(err, tasks.ErrTaskExists) {
+				h.logger.Warnw("PutObjectHandler failed: task ID already in use", "taskID", taskID)
+				http.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict)
+				return
+			}
+			h.logger.Errorw("PutObjectHandler failed: failed to create task", "taskID", taskID, "error", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("ETag", caldav.ETag(created))
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	// The whole resource is replaced; properties missing from the object are cleared where the store allows it
+	description := ""
+	if object.Description != nil {
+		description = *object.Description
+	}
+	tags := object.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	params := tasks.UpdateTaskParams{
+		ID:          taskID,
+		ListID:      list.ID,
+		UserID:      callerID,
+		Title:       &object.Title,
+		Description: &description,
+		Status:      &object.Status,
+		DueDate:     object.DueDate,
+		CompletedAt: object.CompletedAt,
+		Tags:        tags,
+	}
+	if object.Priority != 0 {
+		params.Priority = &object.Priority
+	}
+
+	updated, err := h.tasks.UpdateTask(r.Context(), params)
+	if err != nil {
+		// The task was deleted since it was looked up
+		if errors.Is(err, common.ErrNotFound) {
+			h.logger.Warnw("PutObjectHandler failed: task no longer exists", "taskID", taskID)
+			if r.Header.Get("If-Match") != "" {
+				http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		h.logger.Errorw("PutObjectHandler failed: failed to update task", "taskID", taskID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", caldav.ETag(updated))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteObjectHandler deletes the task held by a calendar object resource.
+func (h *Handler) DeleteObjectHandler(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.loadObject(w, r, "DeleteObjectHandler")
+	if !ok {
+		return
+	}
+	if !checkPreconditions(r, task, true) {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+
+	callerID, _ := middleware.UserIDFromContext(r.Context())
+	if _, err := h.tasks.DeleteTasks(r.Context(), tasks.DeleteTasksParams{
+		IDs:    []uuid.UUID{task.ID},
+		ListID: task.ListID,
+		UserID: callerID,
+	}); err != nil {
+		h.logger.Errorw("DeleteObjectHandler failed: failed to delete task", "taskID", task.ID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// caller returns the identified caller.
+// It writes an error response and returns false when the request carries no identity.
+func (h *Handler) caller(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw(name + " failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+	return callerID, true
+}
+
+// authorizeList returns the caller and the list in the request context.
+// It writes an error response and returns false unless the caller owns the list.
+func (h *Handler) authorizeList(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, todolist.TodoList, bool) {
+	callerID, ok := h.caller(w, r, name)
+	if !ok {
+		return uuid.Nil, todolist.TodoList{}, false
+	}
+
+	// Extract validated list ID from context
+	listID, ok := r.Context().Value(listIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw(name + " failed: list ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return uuid.Nil, todolist.TodoList{}, false
+	}
+
+	// Lists belonging to other users are reported as missing
+	list, err := h.lists.GetTodoListByID(r.Context(), todolist.GetTodoListByIDParams{ID: listID, UserID: callerID})
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			h.logger.Warnw(name+" failed: list not found", "listID", listID, "callerID", callerID)
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return uuid.Nil, todolist.TodoList{}, false
+		}
+		h.logger.Errorw(name+" failed: internal server error", "listID", listID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return uuid.Nil, todolist.TodoList{}, false
+	}
+
+	return callerID, list, true
+}
+
+// loadObject returns the task held by the calendar object resource in the request context.
+// It writes an error response and returns false unless the caller owns the list and the task is in it.
+func (h *Handler) loadObject(w http.ResponseWriter, r *http.Request, name string) (tasks.FullTask, bool) {
+	callerID, list, ok := h.authorizeList(w, r, name)
+	if !ok {
+		return tasks.FullTask{}, false
+	}
+
+	// Extract validated task ID from context
+	taskID, ok := r.Context().Value(taskIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw(name + " failed: task ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return tasks.FullTask{}, false
+	}
+
+	task, err := h.tasks.GetTask(r.Context(), tasks.GetTaskParams{ID: taskID, ListID: list.ID, UserID: callerID})
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return tasks.FullTask{}, false
+		}
+		h.logger.Errorw(name+" failed: failed to get task", "taskID", taskID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return tasks.FullTask{}, false
+	}
+
+	return task, true
+}
+
+// decodePropfind decodes a PROPFIND body.
+// It writes an error response and returns false when the body is not a propfind document.
+func (h *Handler) decodePropfind(w http.ResponseWriter, r *http.Request, name string) (propfindRequest, bool) {
+	var req propfindRequest
+	if err := decodeRequestBody(r, &req); err != nil {
+		h.logger.Warnw(name+" failed: invalid request body", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return propfindRequest{}, false
+	}
+	return req, true
+}
+
+// calendarProps returns the properties of a list's calendar collection.
+// The list's tasks are only read, when listed is nil, if the collection tag is asked for.
+func (h *Handler) calendarProps(ctx context.Context, list todolist.TodoList, callerID uuid.UUID, req propRequest, listed []tasks.FullTask) ([]property, error) {
+	props := []property{
+		{XMLName: propResourceType, Inner: calendarResourceType},
+		textProperty(propDisplayName, list.Title),
+		textProperty(propCalendarDescription, list.Description),
+		{XMLName: propSupportedComponentSet, Inner: todoComponentSet},
+		{XMLName: propSupportedReports, Inner: calendarReports},
+		{XMLName: propCurrentUserPrivileges, Inner: readWritePrivileges},
+		hrefProperty(propCurrentUserPrincipal, caldav.RootPath),
+	}
+
+	if req.wants(propGetCTag) {
+		if listed == nil {
+			var err error
+			if listed, err = h.tasks.ListTasks(ctx, tasks.TaskListParams{ListID: list.ID, UserID: callerID}); err != nil {
+				return nil, err
+			}
+		}
+		props = append(props, textProperty(propGetCTag, caldav.CTag(listed)))
+	}
+	return props, nil
+}
+
+// objectProps returns the properties of a task's calendar object resource
+func objectProps(task tasks.FullTask, req propRequest) ([]property, error) {
+	props := []property{
+		{XMLName: propResourceType},
+		textProperty(propGetETag, caldav.ETag(task)),
+		textProperty(propGetContentType, caldav.CalendarContentType),
+		textProperty(propGetLastModified, task.UpdatedAt.UTC().Format(http.TimeFormat)),
+	}
+
+	if req.wants(propCalendarData) {
+		var buf bytes.Buffer
+		if err := tasks.WriteCalendarObject(&buf, task, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+		props = append(props, textProperty(propCalendarData, buf.String()))
+	}
+	return props, nil
+}
+
+// matchesFilter reports whether a task matches a calendar-query filter.
+// Every task is a VTODO inside a VCALENDAR; COMPLETED and STATUS property filters are evaluated, others are ignored.
+func matchesFilter(filter *compFilter, task tasks.FullTask) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.Name != "VCALENDAR" {
+		return false
+	}
+
+	for _, comp := range filter.Comps {
+		if comp.Name != "VTODO" {
+			return false
+		}
+		for _, prop := range comp.PropFilters {
+			var value string
+			defined := true
+			switch strings.ToUpper(prop.Name) {
+			case "COMPLETED":
+				defined = task.CompletedAt != nil && !task.CompletedAt.IsZero()
+			case "STATUS":
+				value = tasks.CalendarStatus(task)
+			default:
+				continue
+			}
+
+			if prop.IsNotDefined != nil {
+				if defined {
+					return false
+				}
+				continue
+			}
+			if !defined {
+				return false
+			}
+			if prop.TextMatch != nil {
+				// The default i;ascii-casemap collation matches substrings case-insensitively
+				matched := strings.Contains(strings.ToUpper(value), strings.ToUpper(strings.TrimSpace(prop.TextMatch.Text)))
+				if matched == (prop.TextMatch.NegateCondition == "yes") {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// checkPreconditions evaluates If-Match and If-None-Match against a resource's current state
+func checkPreconditions(r *http.Request, task tasks.FullTask, exists bool) bool {
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if !exists || !matchesETag(ifMatch, caldav.ETag(task)) {
+			return false
+		}
+	}
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && exists {
+		if matchesETag(ifNoneMatch, caldav.ETag(task)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesETag reports whether a comma-separated If-Match or If-None-Match header names etag or is "*"
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// depth returns the Depth header of a PROPFIND; infinity is treated as 1, the deepest level served
+func depth(r *http.Request) int {
+	if r.Header.Get("Depth") == "0" {
+		return 0
+	}
+	return 1
+}
+
+// objectID returns the task ID a multiget href names within a list's calendar collection
+func objectID(href string, listID uuid.UUID) uuid.UUID {
+	parsed, err := url.Parse(href)
+	if err != nil || path.Dir(parsed.Path)+"/" != caldav.CalendarPath(listID) {
+		return uuid.Nil
+	}
+	id, ok := caldav.ParseObjectName(path.Base(parsed.Path))
+	if !ok {
+		return uuid.Nil
+	}
+	return id
+}
+
+// findTask returns the task with the given ID
+func findTask(listed []tasks.FullTask, id uuid.UUID) (tasks.FullTask, bool) {
+	for _, task := range listed {
+		if task.ID == id && id != uuid.Nil {
+			return task, true
+		}
+	}
+	return tasks.FullTask{}, false
+}
@@ -0,0 +1,437 @@
+package handler
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/caldavmock"
+	"github.com/henryhall897/golang-todo-app/internal/caldav"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware/testutils"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+)
+
+// HandlerTestSuite holds shared test dependencies
+type HandlerTestSuite struct {
+	mockTasks *caldavmock.TaskStoreMock
+	mockLists *caldavmock.ListStoreMock
+	server    *httptest.Server
+	callerID  uuid.UUID
+	list      todolist.TodoList
+	open      tasks.FullTask
+	done      tasks.FullTask
+}
+
+// SetupSuite serves the handler behind the identity middleware with a list holding an open and a completed task
+func SetupSuite(t *testing.T) *HandlerTestSuite {
+	updated := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	completed := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	var unset time.Time
+
+	s := &HandlerTestSuite{
+		mockTasks: &caldavmock.TaskStoreMock{},
+		mockLists: &caldavmock.ListStoreMock{},
+		callerID:  uuid.New(),
+	}
+	s.list = todolist.TodoList{ID: uuid.New(), UserID: s.callerID, Title: "Groceries & more", Description: "Weekly shop"}
+	s.open = tasks.FullTask{
+		ID: uuid.New(), ListID: s.list.ID, Title: common.Ptr("Buy milk"), Status: common.Ptr("pending"),
+		CompletedAt: &unset, CreatedAt: updated, UpdatedAt: updated, Tags: []string{"home"},
+	}
+	s.done = tasks.FullTask{
+		ID: uuid.New(), ListID: s.list.ID, Title: common.Ptr("Return bottles"), Status: common.Ptr(tasks.StatusCompleted),
+		CompletedAt: &completed, CreatedAt: updated, UpdatedAt: completed, Tags: []string{},
+	}
+
+	s.mockLists.GetTodoListByIDFunc = func(ctx context.Context, params todolist.GetTodoListByIDParams) (todolist.TodoList, error) {
+		if params.ID == s.list.ID && params.UserID == s.callerID {
+			return s.list, nil
+		}
+		return todolist.TodoList{}, common.ErrNotFound
+	}
+	s.mockLists.ListTodoListsWithPaginationFunc = func(ctx context.Context, params todolist.ListTodoListsWithPaginationParams) ([]todolist.TodoList, error) {
+		if params.UserID == s.callerID && params.Offset == 0 {
+			return []todolist.TodoList{s.list}, nil
+		}
+		return nil, nil
+	}
+	s.mockTasks.ListTasksFunc = func(ctx context.Context, params tasks.TaskListParams) ([]tasks.FullTask, error) {
+		if params.ListID == s.list.ID && params.UserID == s.callerID {
+			return []tasks.FullTask{s.open, s.done}, nil
+		}
+		return nil, nil
+	}
+
+	s.mockTasks.GetTaskFunc = func(ctx context.Context, params tasks.GetTaskParams) (tasks.FullTask, error) {
+		if params.ListID == s.list.ID && params.UserID == s.callerID {
+			for _, task := range []tasks.FullTask{s.open, s.done} {
+				if task.ID == params.ID {
+					return task, nil
+				}
+			}
+		}
+		return tasks.FullTask{}, common.ErrNotFound
+	}
+
+	handler := New(s.mockTasks, s.mockLists, zap.NewNop().Sugar())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("OPTIONS /dav/", handler.OptionsHandler)
+	mux.HandleFunc("PROPFIND /dav/{$}", handler.PropfindRootHandler)
+	mux.HandleFunc("PROPFIND /dav/calendars/{$}", handler.PropfindHomeHandler)
+	mux.HandleFunc("PROPFIND /dav/calendars/{id}/{$}", VerifyCalendarPath(handler.PropfindCalendarHandler))
+	mux.HandleFunc("REPORT /dav/calendars/{id}/{$}", VerifyCalendarPath(handler.ReportCalendarHandler))
+	mux.HandleFunc("GET /dav/calendars/{id}/{object}", VerifyObjectPath(handler.GetObjectHandler))
+	mux.HandleFunc("PUT /dav/calendars/{id}/{object}", VerifyObjectPath(handler.PutObjectHandler))
+	mux.HandleFunc("DELETE /dav/calendars/{id}/{object}", VerifyObjectPath(handler.DeleteObjectHandler))
+	s.server = httptest.NewServer(testutils.Identity(mux))
+
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// do sends a request as the caller; the response body is read and closed
+func (s *HandlerTestSuite) do(t *testing.T, method, path, body string, headers map[string]string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, s.server.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	testutils.SignAs(req.Header, s.callerID)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(raw)
+}
+
+// decodeMultistatus decodes a 207 response into its responses, keyed by href
+func decodeMultistatus(t *testing.T, resp *http.Response, body string) map[string]response {
+	t.Helper()
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode, body)
+	var ms multistatus
+	require.NoError(t, xml.Unmarshal([]byte(body), &ms))
+
+	byHref := make(map[string]response, len(ms.Responses))
+	for _, r := range ms.Responses {
+		byHref[r.Href] = r
+	}
+	return byHref
+}
+
+// propValue returns the inner XML of a property in a response's 200 propstat
+func propValue(r response, name xml.Name) (string, bool) {
+	for _, ps := range r.Propstats {
+		if ps.Status != statusOK {
+			continue
+		}
+		for _, prop := range ps.Prop.Props {
+			if prop.XMLName == name {
+				return prop.Inner, true
+			}
+		}
+	}
+	return "", false
+}
+
+func TestOptions(t *testing.T) {
+	s := SetupSuite(t)
+
+	resp, _ := s.do(t, http.MethodOptions, "/dav/calendars/", "", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("DAV"), "calendar-access")
+	assert.Contains(t, resp.Header.Get("Allow"), "REPORT")
+}
+
+func TestPropfindRequiresCaller(t *testing.T) {
+	s := SetupSuite(t)
+
+	req, err := http.NewRequest("PROPFIND", s.server.URL+"/dav/", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestPropfindRoot(t *testing.T) {
+	s := SetupSuite(t)
+
+	resp, body := s.do(t, "PROPFIND", "/dav/", `<?xml version="1.0"?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:current-user-principal/><c:calendar-home-set/><d:getetag/></d:prop>
+</d:propfind>`, map[string]string{"Depth": "0"})
+	responses := decodeMultistatus(t, resp, body)
+
+	root := responses[caldav.RootPath]
+	home, ok := propValue(root, propCalendarHomeSet)
+	require.True(t, ok)
+	assert.Contains(t, home, caldav.CalendarsPath)
+
+	// Properties a resource lacks are reported as not found
+	require.Len(t, root.Propstats, 2)
+	assert.Equal(t, statusNotFound, root.Propstats[1].Status)
+	assert.Equal(t, propGetETag, root.Propstats[1].Prop.Props[0].XMLName)
+}
+
+func TestPropfindHome(t *testing.T) {
+	s := SetupSuite(t)
+
+	resp, body := s.do(t, "PROPFIND", "/dav/calendars/", "", map[string]string{"Depth": "1"})
+	responses := decodeMultistatus(t, resp, body)
+	require.Len(t, responses, 2)
+
+	calendar := responses[caldav.CalendarPath(s.list.ID)]
+	name, _ := propValue(calendar, propDisplayName)
+	assert.Equal(t, "Groceries &amp; more", name)
+	resourceType, _ := propValue(calendar, propResourceType)
+	assert.Contains(t, resourceType, "calendar")
+	ctag, ok := propValue(calendar, propGetCTag)
+	require.True(t, ok)
+	assert.Equal(t, strings.ReplaceAll(caldav.CTag([]tasks.FullTask{s.done, s.open}), `"`, "&#34;"), ctag)
+}
+
+func TestPropfindCalendar(t *testing.T) {
+	s := SetupSuite(t)
+
+	t.Run("depth 1 lists objects", func(t *testing.T) {
+		resp, body := s.do(t, "PROPFIND", caldav.CalendarPath(s.list.ID), `<propfind xmlns="DAV:"><prop><getetag/></prop></propfind>`, map[string]string{"Depth": "1"})
+		responses := decodeMultistatus(t, resp, body)
+		require.Len(t, responses, 3)
+
+		etag, ok := propValue(responses[caldav.ObjectPath(s.list.ID, s.open.ID)], propGetETag)
+		require.True(t, ok)
+		assert.Equal(t, strings.ReplaceAll(caldav.ETag(s.open), `"`, "&#34;"), etag)
+	})
+
+	t.Run("depth 0", func(t *testing.T) {
+		resp, body := s.do(t, "PROPFIND", caldav.CalendarPath(s.list.ID), "", map[string]string{"Depth": "0"})
+		assert.Len(t, decodeMultistatus(t, resp, body), 1)
+	})
+
+	t.Run("other user's list", func(t *testing.T) {
+		resp, _ := s.do(t, "PROPFIND", caldav.CalendarPath(uuid.New()), "", nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		resp, _ := s.do(t, "PROPFIND", caldav.CalendarPath(s.list.ID), "<propfind", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestReportCalendarQuery(t *testing.T) {
+	s := SetupSuite(t)
+
+	resp, body := s.do(t, "REPORT", caldav.CalendarPath(s.list.ID), `<?xml version="1.0"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VTODO">
+        <c:prop-filter name="COMPLETED"><c:is-not-defined/></c:prop-filter>
+      </c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`, map[string]string{"Depth": "1"})
+	responses := decodeMultistatus(t, resp, body)
+	require.Len(t, responses, 1, "completed tasks are filtered out")
+
+	data, ok := propValue(responses[caldav.ObjectPath(s.list.ID, s.open.ID)], propCalendarData)
+	require.True(t, ok)
+	assert.Contains(t, data, "SUMMARY:Buy milk")
+
+	t.Run("events are not stored", func(t *testing.T) {
+		resp, body := s.do(t, "REPORT", caldav.CalendarPath(s.list.ID), `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT"/></c:comp-filter></c:filter>
+</c:calendar-query>`, nil)
+		assert.Empty(t, decodeMultistatus(t, resp, body))
+	})
+
+	t.Run("status text match", func(t *testing.T) {
+		resp, body := s.do(t, "REPORT", caldav.CalendarPath(s.list.ID), `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO">
+    <c:prop-filter name="STATUS"><c:text-match negate-condition="yes">COMPLETED</c:text-match></c:prop-filter>
+  </c:comp-filter></c:comp-filter></c:filter>
+</c:calendar-query>`, nil)
+		responses := decodeMultistatus(t, resp, body)
+		assert.Contains(t, responses, caldav.ObjectPath(s.list.ID, s.open.ID))
+		assert.Len(t, responses, 1)
+	})
+}
+
+func TestReportCalendarMultiget(t *testing.T) {
+	s := SetupSuite(t)
+	missing := caldav.ObjectPath(s.list.ID, uuid.New())
+
+	resp, body := s.do(t, "REPORT", caldav.CalendarPath(s.list.ID), `<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <d:href>`+caldav.ObjectPath(s.list.ID, s.done.ID)+`</d:href>
+  <d:href>`+missing+`</d:href>
+</c:calendar-multiget>`, nil)
+	responses := decodeMultistatus(t, resp, body)
+	require.Len(t, responses, 2)
+
+	data, ok := propValue(responses[caldav.ObjectPath(s.list.ID, s.done.ID)], propCalendarData)
+	require.True(t, ok)
+	assert.Contains(t, data, "STATUS:COMPLETED")
+	assert.Equal(t, statusNotFound, responses[missing].Status)
+
+	t.Run("unsupported report", func(t *testing.T) {
+		resp, _ := s.do(t, "REPORT", caldav.CalendarPath(s.list.ID), `<sync-collection xmlns="DAV:"/>`, nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestGetObject(t *testing.T) {
+	s := SetupSuite(t)
+
+	resp, body := s.do(t, http.MethodGet, caldav.ObjectPath(s.list.ID, s.open.ID), "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, caldav.CalendarContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, caldav.ETag(s.open), resp.Header.Get("ETag"))
+	assert.Contains(t, body, "UID:"+s.open.ID.String())
+	assert.NotContains(t, body, "METHOD:")
+
+	resp, _ = s.do(t, http.MethodGet, caldav.ObjectPath(s.list.ID, s.open.ID), "", map[string]string{"If-None-Match": caldav.ETag(s.open)})
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	resp, _ = s.do(t, http.MethodGet, caldav.ObjectPath(s.list.ID, uuid.New()), "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = s.do(t, http.MethodGet, caldav.CalendarPath(s.list.ID)+"not-a-uuid.ics", "", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+const putObject = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\nUID:client-uid\r\nSUMMARY:Call the bank\r\n" +
+	"PRIORITY:3\r\nCATEGORIES:finance\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+
+func TestPutObjectCreates(t *testing.T) {
+	s := SetupSuite(t)
+	taskID := uuid.New()
+
+	var created tasks.CreateTaskParams
+	s.mockTasks.CreateTaskWithIDFunc = func(ctx context.Context, id uuid.UUID, params tasks.CreateTaskParams) (tasks.FullTask, error) {
+		assert.Equal(t, taskID, id)
+		created = params
+		return tasks.FullTask{ID: id, ListID: params.ListID, UpdatedAt: time.Now()}, nil
+	}
+
+	resp, _ := s.do(t, http.MethodPut, caldav.ObjectPath(s.list.ID, taskID), putObject, map[string]string{
+		"Content-Type":  "text/calendar; charset=utf-8",
+		"If-None-Match": "*",
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+	assert.Equal(t, s.list.ID, created.ListID)
+	assert.Equal(t, "Call the bank", *created.Title)
+	assert.Equal(t, tasks.StatusCompleted, *created.Status)
+	assert.Equal(t, int32(3), created.Priority)
+	assert.NotNil(t, created.CompletedAt)
+	assert.Equal(t, []string{"finance"}, created.Tags)
+
+	t.Run("id used by another list", func(t *testing.T) {
+		s.mockTasks.CreateTaskWithIDFunc = func(ctx context.Context, id uuid.UUID, params tasks.CreateTaskParams) (tasks.FullTask, error) {
+			return tasks.FullTask{}, tasks.ErrTaskExists
+		}
+		resp, _ := s.do(t, http.MethodPut, caldav.ObjectPath(s.list.ID, taskID), putObject, nil)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("invalid object", func(t *testing.T) {
+		resp, _ := s.do(t, http.MethodPut, caldav.ObjectPath(s.list.ID, taskID), "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		resp, _ := s.do(t, http.MethodPut, caldav.ObjectPath(s.list.ID, taskID), putObject, map[string]string{"Content-Type": "application/json"})
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+}
+
+func TestPutObjectUpdates(t *testing.T) {
+	s := SetupSuite(t)
+	path := caldav.ObjectPath(s.list.ID, s.open.ID)
+
+	var updated tasks.UpdateTaskParams
+	s.mockTasks.UpdateTaskFunc = func(ctx context.Context, params tasks.UpdateTaskParams) (tasks.FullTask, error) {
+		updated = params
+		return tasks.FullTask{ID: params.ID, ListID: params.ListID, UpdatedAt: time.Now()}, nil
+	}
+
+	t.Run("stale etag", func(t *testing.T) {
+		resp, _ := s.do(t, http.MethodPut, path, putObject, map[string]string{"If-Match": `"stale"`})
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		assert.Empty(t, s.mockTasks.UpdateTaskCalls())
+	})
+
+	t.Run("existing resource with If-None-Match", func(t *testing.T) {
+		resp, _ := s.do(t, http.MethodPut, path, putObject, map[string]string{"If-None-Match": "*"})
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	})
+
+	t.Run("matching etag", func(t *testing.T) {
+		resp, _ := s.do(t, http.MethodPut, path, putObject, map[string]string{"If-Match": caldav.ETag(s.open)})
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+		assert.Equal(t, s.open.ID, updated.ID)
+		assert.Equal(t, s.callerID, updated.UserID)
+		assert.Equal(t, "Call the bank", *updated.Title)
+		assert.Equal(t, "", *updated.Description, "a missing description is cleared")
+		assert.Equal(t, int32(3), *updated.Priority)
+		assert.Equal(t, tasks.StatusCompleted, *updated.Status)
+		assert.NotNil(t, updated.CompletedAt)
+		assert.Empty(t, s.mockTasks.CreateTaskWithIDCalls())
+	})
+
+	t.Run("task deleted meanwhile", func(t *testing.T) {
+		s.mockTasks.UpdateTaskFunc = func(ctx context.Context, params tasks.UpdateTaskParams) (tasks.FullTask, error) {
+			return tasks.FullTask{}, common.ErrNotFound
+		}
+
+		resp, _ := s.do(t, http.MethodPut, path, putObject, map[string]string{"If-Match": caldav.ETag(s.open)})
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		resp, _ = s.do(t, http.MethodPut, path, putObject, nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestDeleteObject(t *testing.T) {
+	s := SetupSuite(t)
+	s.mockTasks.DeleteTasksFunc = func(ctx context.Context, params tasks.DeleteTasksParams) ([]tasks.FullTask, error) {
+		return []tasks.FullTask{s.done}, nil
+	}
+
+	resp, _ := s.do(t, http.MethodDelete, caldav.ObjectPath(s.list.ID, s.done.ID), "", map[string]string{"If-Match": `"stale"`})
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp, _ = s.do(t, http.MethodDelete, caldav.ObjectPath(s.list.ID, s.done.ID), "", map[string]string{"If-Match": caldav.ETag(s.done)})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	calls := s.mockTasks.DeleteTasksCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, tasks.DeleteTasksParams{IDs: []uuid.UUID{s.done.ID}, ListID: s.list.ID, UserID: s.callerID}, calls[0].Params)
+
+	resp, _ = s.do(t, http.MethodDelete, caldav.ObjectPath(s.list.ID, uuid.New()), "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/caldav"
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const (
+	listIDKey = contextKey("listID")
+	taskIDKey = contextKey("taskID")
+)
+
+// VerifyCalendarPath extracts and validates the list UUID from `/dav/calendars/{id}/...`
+func VerifyCalendarPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 3 || segments[0] != "dav" || segments[1] != "calendars" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(segments[2])
+		if err != nil || id == uuid.Nil {
+			logger.Warnw("VerifyCalendarPath failed: invalid list ID", "id", segments[2])
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), listIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// VerifyObjectPath extracts and validates the list and task UUIDs from `/dav/calendars/{id}/{taskID}.ics`.
+// Resources are named after the task they hold, so clients must name new resources with a UUID.
+func VerifyObjectPath(next http.HandlerFunc) http.HandlerFunc {
+	return VerifyCalendarPath(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract the resource name from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) != 4 {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, ok := caldav.ParseObjectName(segments[3])
+		if !ok {
+			logger.Warnw("VerifyObjectPath failed: invalid resource name", "name", segments[3])
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), taskIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/caldav"
+)
+
+// Property and report names served
+var (
+	propResourceType          = xml.Name{Space: caldav.NamespaceDAV, Local: "resourcetype"}
+	propDisplayName           = xml.Name{Space: caldav.NamespaceDAV, Local: "displayname"}
+	propCurrentUserPrincipal  = xml.Name{Space: caldav.NamespaceDAV, Local: "current-user-principal"}
+	propPrincipalURL          = xml.Name{Space: caldav.NamespaceDAV, Local: "principal-URL"}
+	propCurrentUserPrivileges = xml.Name{Space: caldav.NamespaceDAV, Local: "current-user-privilege-set"}
+	propSupportedReports      = xml.Name{Space: caldav.NamespaceDAV, Local: "supported-report-set"}
+	propGetETag               = xml.Name{Space: caldav.NamespaceDAV, Local: "getetag"}
+	propGetContentType        = xml.Name{Space: caldav.NamespaceDAV, Local: "getcontenttype"}
+	propGetLastModified       = xml.Name{Space: caldav.NamespaceDAV, Local: "getlastmodified"}
+	propCalendarHomeSet       = xml.Name{Space: caldav.NamespaceCalDAV, Local: "calendar-home-set"}
+	propCalendarDescription   = xml.Name{Space: caldav.NamespaceCalDAV, Local: "calendar-description"}
+	propSupportedComponentSet = xml.Name{Space: caldav.NamespaceCalDAV, Local: "supported-calendar-component-set"}
+	propCalendarData          = xml.Name{Space: caldav.NamespaceCalDAV, Local: "calendar-data"}
+	propGetCTag               = xml.Name{Space: caldav.NamespaceCalendarServer, Local: "getctag"}
+
+	reportCalendarQuery    = xml.Name{Space: caldav.NamespaceCalDAV, Local: "calendar-query"}
+	reportCalendarMultiget = xml.Name{Space: caldav.NamespaceCalDAV, Local: "calendar-multiget"}
+)
+
+// Inner XML of the property values shared by every resource of a kind
+const (
+	principalResourceType  = `<collection xmlns="DAV:"/><principal xmlns="DAV:"/>`
+	collectionResourceType = `<collection xmlns="DAV:"/>`
+	calendarResourceType   = `<collection xmlns="DAV:"/><calendar xmlns="urn:ietf:params:xml:ns:caldav"/>`
+	todoComponentSet       = `<comp xmlns="urn:ietf:params:xml:ns:caldav" name="VTODO"/>`
+	readPrivileges         = `<privilege xmlns="DAV:"><read/></privilege>`
+	readWritePrivileges    = `<privilege xmlns="DAV:"><read/></privilege><privilege xmlns="DAV:"><write/></privilege>` +
+		`<privilege xmlns="DAV:"><write-content/></privilege><privilege xmlns="DAV:"><bind/></privilege>` +
+		`<privilege xmlns="DAV:"><unbind/></privilege>`
+	calendarReports = `<supported-report xmlns="DAV:"><report><calendar-query xmlns="urn:ietf:params:xml:ns:caldav"/></report></supported-report>` +
+		`<supported-report xmlns="DAV:"><report><calendar-multiget xmlns="urn:ietf:params:xml:ns:caldav"/></report></supported-report>`
+)
+
+const (
+	statusOK       = "HTTP/1.1 200 OK"
+	statusNotFound = "HTTP/1.1 404 Not Found"
+
+	multistatusContentType = "application/xml; charset=utf-8"
+)
+
+// errRequestBody is returned for a request body that is not the expected XML document
+var errRequestBody = errors.New("malformed request body")
+
+// property is a single WebDAV property and its value
+type property struct {
+	XMLName xml.Name
+	Inner   string `xml:",innerxml"`
+}
+
+// textProperty returns a property holding escaped text
+func textProperty(name xml.Name, text string) property {
+	var buf strings.Builder
+	_ = xml.EscapeText(&buf, []byte(text)) // strings.Builder never fails
+	return property{XMLName: name, Inner: buf.String()}
+}
+
+// hrefProperty returns a property holding a single href
+func hrefProperty(name xml.Name, href string) property {
+	var buf strings.Builder
+	buf.WriteString(`<href xmlns="DAV:">`)
+	_ = xml.EscapeText(&buf, []byte(href))
+	buf.WriteString(`</href>`)
+	return property{XMLName: name, Inner: buf.String()}
+}
+
+type propstat struct {
+	Prop   propList `xml:"DAV: prop"`
+	Status string   `xml:"DAV: status"`
+}
+
+type propList struct {
+	Props []property `xml:",any"`
+}
+
+type response struct {
+	Href      string     `xml:"DAV: href"`
+	Propstats []propstat `xml:"DAV: propstat,omitempty"`
+	Status    string     `xml:"DAV: status,omitempty"`
+}
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"DAV: multistatus"`
+	Responses []response `xml:"DAV: response"`
+}
+
+// propName is a property named in a request
+type propName struct {
+	XMLName xml.Name
+}
+
+// propRequest is the set of properties a PROPFIND or REPORT asks for
+type propRequest struct {
+	AllProp  *struct{}  `xml:"DAV: allprop"`
+	PropName *struct{}  `xml:"DAV: propname"`
+	Prop     *propNames `xml:"DAV: prop"`
+}
+
+type propNames struct {
+	Names []propName `xml:",any"`
+}
+
+// names returns the properties asked for by name, or nil for allprop and propname requests
+func (p propRequest) names() []propName {
+	if p.Prop == nil {
+		return nil
+	}
+	return p.Prop.Names
+}
+
+// wants reports whether a property should be computed; calendar data is only computed when named,
+// as it is not part of allprop (RFC 4791 section 9.6).
+func (p propRequest) wants(name xml.Name) bool {
+	names := p.names()
+	if len(names) == 0 {
+		return name != propCalendarData
+	}
+	return slices.ContainsFunc(names, func(n propName) bool { return n.XMLName == name })
+}
+
+// response returns the response for a resource: named properties it lacks are reported as not found.
+func (p propRequest) response(href string, props []property) response {
+	if p.PropName != nil {
+		for i := range props {
+			props[i].Inner = ""
+		}
+	}
+	names := p.names()
+	if len(names) == 0 {
+		return response{Href: href, Propstats: []propstat{{Prop: propList{props}, Status: statusOK}}}
+	}
+
+	var found, missing []property
+	for _, name := range names {
+		i := slices.IndexFunc(props, func(prop property) bool { return prop.XMLName == name.XMLName })
+		if i < 0 {
+			missing = append(missing, property{XMLName: name.XMLName})
+			continue
+		}
+		found = append(found, props[i])
+	}
+
+	resp := response{Href: href}
+	if len(found) > 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Prop: propList{found}, Status: statusOK})
+	}
+	if len(missing) > 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Prop: propList{missing}, Status: statusNotFound})
+	}
+	return resp
+}
+
+// propfindRequest is the body of a PROPFIND; an empty body asks for all properties
+type propfindRequest struct {
+	XMLName xml.Name `xml:"DAV: propfind"`
+	propRequest
+}
+
+// reportRequest is the body of a calendar-query or calendar-multiget REPORT
+type reportRequest struct {
+	XMLName xml.Name
+	propRequest
+	Hrefs  []string    `xml:"DAV: href"`
+	Filter *compFilter `xml:"urn:ietf:params:xml:ns:caldav filter>comp-filter"`
+}
+
+// compFilter matches calendar components; only VTODO components are stored
+type compFilter struct {
+	Name        string       `xml:"name,attr"`
+	Comps       []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	PropFilters []propFilter `xml:"urn:ietf:params:xml:ns:caldav prop-filter"`
+}
+
+// propFilter matches a component property by its presence or its text
+type propFilter struct {
+	Name         string     `xml:"name,attr"`
+	IsNotDefined *struct{}  `xml:"urn:ietf:params:xml:ns:caldav is-not-defined"`
+	TextMatch    *textMatch `xml:"urn:ietf:params:xml:ns:caldav text-match"`
+}
+
+type textMatch struct {
+	Text            string `xml:",chardata"`
+	NegateCondition string `xml:"negate-condition,attr"`
+}
+
+// decodeRequestBody decodes an XML request body into v; an empty body leaves v unchanged
+func decodeRequestBody(r *http.Request, v any) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if err := xml.Unmarshal(body, v); err != nil {
+		return errors.Join(errRequestBody, err)
+	}
+	return nil
+}
+
+// writeMultistatus writes a 207 Multi-Status response
+func (h *Handler) writeMultistatus(w http.ResponseWriter, name string, responses []response) {
+	body, err := xml.Marshal(multistatus{Responses: responses})
+	if err != nil {
+		h.logger.Errorw(name+" failed: failed to encode response", "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", multistatusContentType)
+	w.WriteHeader(http.StatusMultiStatus)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return
+	}
+	if _, err := w.Write(body); err != nil {
+		h.logger.Warnw(name+" failed: failed to write response", "error", err)
+	}
+}
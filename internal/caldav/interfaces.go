@@ -0,0 +1,33 @@
+package caldav
+
+import (
+	"context"
+
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+
+	"github.com/google/uuid"
+)
+
+// TaskStore holds the task operations calendar object resources are mapped onto.
+//
+//go:generate moq -out=../../gen/mocks/caldavmock/task_store_mock.go -pkg=caldavmock . TaskStore
+type TaskStore interface {
+	GetTask(ctx context.Context, params tasks.GetTaskParams) (tasks.FullTask, error)
+	ListTasks(ctx context.Context, params tasks.TaskListParams) ([]tasks.FullTask, error)
+	CreateTaskWithID(ctx context.Context, id uuid.UUID, params tasks.CreateTaskParams) (tasks.FullTask, error)
+	UpdateTask(ctx context.Context, params tasks.UpdateTaskParams) (tasks.FullTask, error)
+	DeleteTasks(ctx context.Context, params tasks.DeleteTasksParams) ([]tasks.FullTask, error)
+}
+
+var _ TaskStore = (*tasks.Store)(nil)
+
+// ListStore looks up the lists exposed as calendar collections; callers only see their own lists.
+//
+//go:generate moq -out=../../gen/mocks/caldavmock/list_store_mock.go -pkg=caldavmock . ListStore
+type ListStore interface {
+	GetTodoListByID(ctx context.Context, params todolist.GetTodoListByIDParams) (todolist.TodoList, error)
+	ListTodoListsWithPagination(ctx context.Context, params todolist.ListTodoListsWithPaginationParams) ([]todolist.TodoList, error)
+}
+
+var _ ListStore = (*todolist.Store)(nil)
@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/henryhall897/golang-todo-app/internal/caldav/handler"
+)
+
+// Extension methods used by WebDAV and CalDAV
+const (
+	methodPropfind = "PROPFIND"
+	methodReport   = "REPORT"
+)
+
+// RegisterRoutes sets up CalDAV routes
+func RegisterRoutes(router *http.ServeMux, h *handler.Handler) {
+	// Handle `/.well-known/caldav` (Service Discovery)
+	router.HandleFunc("/.well-known/caldav", h.WellKnownHandler)
+
+	// Handle `/dav/` (Principal)
+	router.HandleFunc("/dav/{$}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodOptions:
+			h.OptionsHandler(w, r)
+		case methodPropfind:
+			h.PropfindRootHandler(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Handle `/dav/calendars/` (Calendar Home Set)
+	router.HandleFunc("/dav/calendars/{$}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodOptions:
+			h.OptionsHandler(w, r)
+		case methodPropfind:
+			h.PropfindHomeHandler(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Handle `/dav/calendars/{id}/` (Calendar Collection), with or without its trailing slash
+	calendar := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodOptions:
+			h.OptionsHandler(w, r)
+		case methodPropfind:
+			handler.VerifyCalendarPath(h.PropfindCalendarHandler).ServeHTTP(w, r)
+		case methodReport:
+			handler.VerifyCalendarPath(h.ReportCalendarHandler).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	}
+	router.HandleFunc("/dav/calendars/{id}", calendar)
+	router.HandleFunc("/dav/calendars/{id}/{$}", calendar)
+
+	// Handle `/dav/calendars/{id}/{taskID}.ics` (Calendar Object Resource)
+	router.HandleFunc("/dav/calendars/{id}/{object}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodOptions:
+			h.OptionsHandler(w, r)
+		case http.MethodGet, http.MethodHead:
+			handler.VerifyObjectPath(h.GetObjectHandler).ServeHTTP(w, r)
+		case http.MethodPut:
+			handler.VerifyObjectPath(h.PutObjectHandler).ServeHTTP(w, r)
+		case http.MethodDelete:
+			handler.VerifyObjectPath(h.DeleteObjectHandler).ServeHTTP(w, r)
+		case methodPropfind:
+			handler.VerifyObjectPath(h.PropfindObjectHandler).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sethvargo/go-envconfig"
 	"go.uber.org/zap"
@@ -16,11 +17,13 @@ type DatabaseConfig struct {
 }
 
 // ServerConfig holds server configuration.
+// IdentitySecret is shared with the gateway, which signs the identity of each authenticated caller with it.
 type ServerConfig struct {
-	BindAddress string `env:"BIND_ADDRESS,default=0.0.0.0"`
-	Port        string `env:"SERVER_PORT,default=8080"`
-	CorsOrigin  string `env:"CORS_ORIGIN,required"`
-	Logger      *zap.SugaredLogger
+	BindAddress    string `env:"BIND_ADDRESS,default=0.0.0.0"`
+	Port           string `env:"SERVER_PORT,default=8080"`
+	CorsOrigin     string `env:"CORS_ORIGIN,required"`
+	IdentitySecret string `env:"IDENTITY_SECRET,required"`
+	Logger         *zap.SugaredLogger
 }
 
 // LoggingConfig holds logging configuration.
@@ -30,18 +33,91 @@ type LoggingConfig struct {
 }
 
 // RedisConfig holds Redis configuration.
+// Mode selects the deployment: "standalone", "sentinel" or "cluster". Address is a comma-separated list:
+// the server in standalone mode, the sentinels in sentinel mode and seed nodes in cluster mode.
+// Username and Password authenticate with Redis ACLs; SentinelUsername and SentinelPassword with the sentinels.
+// TLS is enabled by TLS or by any TLS file; TLSCAFile verifies the servers instead of the system roots
+// and TLSCertFile with TLSKeyFile authenticate the client.
+// After BreakerThreshold consecutive failed calls, cache calls skip Redis and fall through to the database,
+// while Redis is probed every BreakerInterval until it recovers.
+// Cached users are also kept in process memory, up to LocalCacheSize values for LocalCacheTTL;
+// a size of 0 disables the in-process cache.
+// Codec selects how cached values are written: "json", "msgpack", "json+gzip" or "msgpack+gzip".
+// Values written with any codec stay readable, so it can be changed without flushing Redis.
+// At startup the WarmUsers most recently active users are cached; 0 disables warming.
 type RedisConfig struct {
-	Address  string `env:"REDIS_ADDRESS,required"`
-	Password string `env:"REDIS_PASSWORD,default="`
-	DB       int    `env:"REDIS_DB,default=0"`
+	Address          []string      `env:"REDIS_ADDRESS,required"`
+	Mode             string        `env:"REDIS_MODE,default=standalone"`
+	Username         string        `env:"REDIS_USERNAME,default="`
+	Password         string        `env:"REDIS_PASSWORD,default="`
+	DB               int           `env:"REDIS_DB,default=0"` // Ignored in cluster mode
+	MasterName       string        `env:"REDIS_SENTINEL_MASTER,default="`
+	SentinelUsername string        `env:"REDIS_SENTINEL_USERNAME,default="`
+	SentinelPassword string        `env:"REDIS_SENTINEL_PASSWORD,default="`
+	TLS              bool          `env:"REDIS_TLS,default=false"`
+	TLSCAFile        string        `env:"REDIS_TLS_CA_FILE,default="`
+	TLSCertFile      string        `env:"REDIS_TLS_CERT_FILE,default="`
+	TLSKeyFile       string        `env:"REDIS_TLS_KEY_FILE,default="`
+	TLSServerName    string        `env:"REDIS_TLS_SERVER_NAME,default="`
+	BreakerThreshold int           `env:"REDIS_BREAKER_THRESHOLD,default=5"`
+	BreakerInterval  time.Duration `env:"REDIS_BREAKER_INTERVAL,default=5s"`
+	LocalCacheSize   int           `env:"REDIS_LOCAL_CACHE_SIZE,default=10000"`
+	LocalCacheTTL    time.Duration `env:"REDIS_LOCAL_CACHE_TTL,default=10s"`
+	Codec            string        `env:"REDIS_CODEC,default=json"`
+	WarmUsers        int           `env:"REDIS_WARM_USERS,default=0"`
+}
+
+// SchedulerConfig holds background job configuration.
+type SchedulerConfig struct {
+	ReminderInterval time.Duration `env:"REMINDER_INTERVAL,default=5m"`
+	ReminderLeadTime time.Duration `env:"REMINDER_LEAD_TIME,default=1h"`
+	DigestInterval   time.Duration `env:"DIGEST_INTERVAL,default=15m"`
+	DigestHour       int           `env:"DIGEST_HOUR,default=7"` // Local hour (0-23) from which digests are sent
+	WebhookInterval  time.Duration `env:"WEBHOOK_INTERVAL,default=15s"`
+}
+
+// EventsConfig holds domain event configuration.
+// Bus selects the EventBus implementation: "memory" or "redis" (Redis Streams).
+type EventsConfig struct {
+	Bus             string        `env:"EVENT_BUS,default=memory"`
+	Stream          string        `env:"EVENT_STREAM,default=events"`
+	Group           string        `env:"EVENT_GROUP,default=todo-app"`
+	Consumer        string        `env:"EVENT_CONSUMER,default="` // Defaults to the host name
+	StreamMaxLen    int64         `env:"EVENT_STREAM_MAXLEN,default=100000"`
+	RelayInterval   time.Duration `env:"OUTBOX_RELAY_INTERVAL,default=1s"`
+	OutboxRetention time.Duration `env:"OUTBOX_RETENTION,default=168h"`
+}
+
+// NotifierConfig holds notification delivery configuration.
+// Driver selects the Notifier implementation: "log", "smtp" or "outbox".
+type NotifierConfig struct {
+	Driver       string `env:"NOTIFIER,default=log"`
+	SMTPHost     string `env:"SMTP_HOST,default=localhost"`
+	SMTPPort     string `env:"SMTP_PORT,default=587"`
+	SMTPUsername string `env:"SMTP_USERNAME,default="`
+	SMTPPassword string `env:"SMTP_PASSWORD,default="`
+	SMTPFrom     string `env:"SMTP_FROM,default=noreply@localhost"`
+	OutboxDir    string `env:"OUTBOX_DIR,default=outbox"`
+}
+
+// PrivacyConfig holds data export configuration.
+// Export archives are written to ExportDir and deleted ExportRetention after they complete.
+type PrivacyConfig struct {
+	ExportDir       string        `env:"DATA_EXPORT_DIR,default=exports"`
+	ExportRetention time.Duration `env:"DATA_EXPORT_RETENTION,default=168h"`
+	ExportInterval  time.Duration `env:"DATA_EXPORT_INTERVAL,default=10s"`
 }
 
 // AppConfig holds the complete application configuration
 type AppConfig struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	Logger   LoggingConfig
-	Redis    RedisConfig
+	Database  DatabaseConfig
+	Server    ServerConfig
+	Logger    LoggingConfig
+	Redis     RedisConfig
+	Scheduler SchedulerConfig
+	Notifier  NotifierConfig
+	Events    EventsConfig
+	Privacy   PrivacyConfig
 }
 
 // LoadConfig loads the entire configuration from environment variables
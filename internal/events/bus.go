@@ -0,0 +1,17 @@
+package events
+
+import "context"
+
+// Handler processes a published event. Returning an error leaves the event to be redelivered
+// where the bus supports it.
+type Handler func(ctx context.Context, event Event) error
+
+// EventBus publishes domain events to their subscribers.
+//
+//go:generate moq -out=../../gen/mocks/eventsmock/bus_mock.go -pkg=eventsmock . EventBus
+type EventBus interface {
+	// Publish delivers an event to the bus. A nil error means the bus has accepted the event.
+	Publish(ctx context.Context, event Event) error
+	// Subscribe passes published events to handler until ctx is cancelled.
+	Subscribe(ctx context.Context, handler Handler) error
+}
@@ -0,0 +1,61 @@
+// Package events defines the domain events recorded in the transactional outbox
+// and the buses they are published on.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Aggregate types, the kind of entity an event is about
+const (
+	AggregateUser = "user"
+	AggregateList = "list"
+	AggregateTask = "task"
+)
+
+// Event types, named "<aggregate>.<verb>"
+const (
+	UserCreated   = "user.created"
+	UserUpdated   = "user.updated"
+	UserDeleted   = "user.deleted"
+	ListCreated   = "list.created"
+	ListUpdated   = "list.updated"
+	ListDeleted   = "list.deleted"
+	TaskCreated   = "task.created"
+	TaskUpdated   = "task.updated"
+	TaskCompleted = "task.completed"
+	TaskDeleted   = "task.deleted"
+)
+
+// Event is a domain event. It is recorded in the outbox within the transaction of the change it
+// describes and published to the EventBus afterwards, at least once: consumers must tolerate duplicates,
+// which they can detect by ID.
+type Event struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// New builds an event about the given aggregate, marshalling data as its body.
+func New(eventType, aggregateType string, aggregateID uuid.UUID, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to marshal %s event data: %w", eventType, err)
+	}
+
+	return Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		OccurredAt:    time.Now().UTC(),
+		Data:          raw,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/outboxstore"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToOutboxRow(t *testing.T) {
+	aggregateID := uuid.New()
+	event, err := New(TaskCreated, AggregateTask, aggregateID, map[string]string{"title": "Write tests"})
+	require.NoError(t, err)
+
+	row, err := ToOutboxRow(event)
+	require.NoError(t, err)
+	assert.Equal(t, TaskCreated, row.EventType)
+	assert.Equal(t, AggregateTask, row.AggregateType)
+	assert.JSONEq(t, `{"title":"Write tests"}`, string(row.Payload))
+
+	// The row reads back as the same event
+	read, err := fromOutboxRow(outboxstore.OutboxEvent{
+		ID:            row.ID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		EventType:     row.EventType,
+		Payload:       row.Payload,
+		CreatedAt:     row.CreatedAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, read.ID)
+	assert.Equal(t, aggregateID, read.AggregateID)
+	assert.True(t, event.OccurredAt.Equal(read.OccurredAt))
+}
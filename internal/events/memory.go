@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// InProcessBus delivers events synchronously to the subscribers of this process.
+// It suits single-replica deployments and tests; events published with no subscriber are dropped.
+type InProcessBus struct {
+	mu       sync.RWMutex
+	handlers map[int]Handler
+	nextID   int
+}
+
+// NewInProcessBus initializes an InProcessBus with no subscribers.
+func NewInProcessBus() *InProcessBus {
+	return &InProcessBus{handlers: make(map[int]Handler)}
+}
+
+// Publish passes the event to every subscriber, returning their joined errors.
+func (b *InProcessBus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Subscribe registers handler and blocks until ctx is cancelled.
+func (b *InProcessBus) Subscribe(ctx context.Context, handler Handler) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return ctx.Err()
+}
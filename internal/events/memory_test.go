@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subscribe starts a subscription in the background and waits until it is registered
+func subscribe(t *testing.T, bus *InProcessBus, handler Handler) context.CancelFunc {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	before := subscriberCount(bus)
+	go func() { _ = bus.Subscribe(ctx, handler) }()
+	require.Eventually(t, func() bool { return subscriberCount(bus) == before+1 }, time.Second, time.Millisecond)
+	return cancel
+}
+
+func subscriberCount(bus *InProcessBus) int {
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
+	return len(bus.handlers)
+}
+
+func TestInProcessBus(t *testing.T) {
+	event, err := New(TaskCreated, AggregateTask, uuid.New(), map[string]string{"title": "Write tests"})
+	require.NoError(t, err)
+
+	t.Run("success - every subscriber receives the event", func(t *testing.T) {
+		bus := NewInProcessBus()
+		var mu sync.Mutex
+		var received []uuid.UUID
+		record := func(ctx context.Context, e Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, e.ID)
+			return nil
+		}
+		defer subscribe(t, bus, record)()
+		defer subscribe(t, bus, record)()
+
+		require.NoError(t, bus.Publish(context.Background(), event))
+
+		assert.Equal(t, []uuid.UUID{event.ID, event.ID}, received)
+	})
+
+	t.Run("success - no subscribers", func(t *testing.T) {
+		assert.NoError(t, NewInProcessBus().Publish(context.Background(), event))
+	})
+
+	t.Run("failure - handler errors are returned", func(t *testing.T) {
+		bus := NewInProcessBus()
+		defer subscribe(t, bus, func(ctx context.Context, e Event) error { return errors.New("handler failed") })()
+
+		assert.Error(t, bus.Publish(context.Background(), event))
+	})
+
+	t.Run("success - cancelled subscription is removed", func(t *testing.T) {
+		bus := NewInProcessBus()
+		cancel := subscribe(t, bus, func(ctx context.Context, e Event) error { return errors.New("should not be called") })
+		cancel()
+
+		require.Eventually(t, func() bool { return subscriberCount(bus) == 0 }, time.Second, time.Millisecond)
+		assert.NoError(t, bus.Publish(context.Background(), event))
+	})
+}
@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/outboxstore"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxStore reads the outbox table on behalf of the relay.
+// Events are written to the outbox by the stores that own the aggregates, within their own transactions.
+type OutboxStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewOutboxStore initializes an OutboxStore with the provided connection pool.
+func NewOutboxStore(pool *pgxpool.Pool) *OutboxStore {
+	return &OutboxStore{pool: pool}
+}
+
+// PublishBatch locks up to limit of the oldest unpublished events and passes them, in order, to publish.
+// Publishing stops at the first failure; the events published until then are marked as published
+// and the failure is returned. Events locked by a concurrent relay are skipped.
+func (s *OutboxStore) PublishBatch(ctx context.Context, limit int, publish func(context.Context, Event) error) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := outboxstore.New(tx)
+
+	rows, err := query.ClaimOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	var published []pgtype.UUID
+	var publishErr error
+	for _, row := range rows {
+		event, err := fromOutboxRow(row)
+		if err != nil {
+			return 0, err
+		}
+		if publishErr = publish(ctx, event); publishErr != nil {
+			break
+		}
+		published = append(published, row.ID)
+	}
+
+	if len(published) > 0 {
+		now := time.Now()
+		if err := query.MarkOutboxEventsPublished(ctx, outboxstore.MarkOutboxEventsPublishedParams{
+			PublishedAt: common.ToPgTimestamp(&now),
+			Column2:     published,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to mark outbox events published: %w", err)
+		}
+	}
+
+	// Commit the transaction even when publishing failed, so the events already published stay marked
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(published), publishErr
+}
+
+// Prune deletes events published before the cutoff.
+func (s *OutboxStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	rows, err := outboxstore.New(s.pool).DeletePublishedOutboxEvents(ctx, common.ToPgTimestamp(&before))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune outbox events: %w", err)
+	}
+	return rows, nil
+}
+
+// ToOutboxRow transforms an event into the parameters of its outbox row. The stores recording events
+// insert it with their own CreateOutboxEvent query, within the transaction of the change.
+func ToOutboxRow(event Event) (outboxstore.CreateOutboxEventParams, error) {
+	id, err := common.ToPgUUID(event.ID)
+	if err != nil {
+		return outboxstore.CreateOutboxEventParams{}, fmt.Errorf("failed to transform event id: %w", err)
+	}
+
+	aggregateID, err := common.ToPgUUID(event.AggregateID)
+	if err != nil {
+		return outboxstore.CreateOutboxEventParams{}, fmt.Errorf("failed to transform event aggregate id: %w", err)
+	}
+
+	return outboxstore.CreateOutboxEventParams{
+		ID:            id,
+		AggregateType: event.AggregateType,
+		AggregateID:   aggregateID,
+		EventType:     event.Type,
+		Payload:       event.Data,
+		CreatedAt:     common.ToPgTimestamp(&event.OccurredAt),
+	}, nil
+}
+
+// fromOutboxRow converts an outbox row to an Event
+func fromOutboxRow(row outboxstore.OutboxEvent) (Event, error) {
+	id, err := common.FromPgUUID(row.ID)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	aggregateID, err := common.FromPgUUID(row.AggregateID)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to transform aggregate_id uuid: %w", err)
+	}
+
+	return Event{
+		ID:            id,
+		Type:          row.EventType,
+		AggregateType: row.AggregateType,
+		AggregateID:   aggregateID,
+		OccurredAt:    common.FromPgTimestamp(row.CreatedAt),
+		Data:          row.Payload,
+	}, nil
+}
@@ -0,0 +1,132 @@
+//go:build unit
+
+// The outbox is written by the feature stores, which import this package, so the test lives outside it.
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/dbpool"
+	"github.com/henryhall897/golang-todo-app/internal/events"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+	userrepo "github.com/henryhall897/golang-todo-app/internal/users/repository"
+	"github.com/henryhall897/golang-todo-app/pkg/dbtest"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OutboxTestSuite struct {
+	suite.Suite
+	pgt    *dbtest.PostgresTest
+	ctx    context.Context
+	outbox *events.OutboxStore
+	users  domain.Repository
+	lists  *todolist.Store
+}
+
+func TestOutbox(t *testing.T) {
+	suite.Run(t, &OutboxTestSuite{})
+}
+
+func (o *OutboxTestSuite) SetupSuite() {
+	o.ctx = context.Background()
+
+	var err error
+	o.pgt, err = dbtest.NewPostgresTest(o.ctx, zap.L(), "../../database/migrations", &dbpool.Config{
+		Logging:      false,
+		Host:         "localhost",
+		Port:         "5432",
+		User:         "testuser",
+		Password:     "1234",
+		DatabaseName: "outboxtestdb",
+		MaxConns:     2,
+		MinConns:     1,
+	})
+	o.Require().NoError(err)
+
+	err = o.pgt.MigrateUp()
+	o.Require().NoError(err)
+
+	o.outbox = events.NewOutboxStore(o.pgt.DB())
+	o.users = userrepo.New(o.pgt.DB())
+	o.lists = todolist.New(o.pgt.DB())
+}
+
+func (o *OutboxTestSuite) TearDownSuite() {
+	o.Require().NoError(o.pgt.TearDown())
+}
+
+func (o *OutboxTestSuite) TearDownTest() {
+	_, err := o.pgt.DB().Exec(o.ctx, "TRUNCATE TABLE users, outbox_events CASCADE;")
+	o.Require().NoError(err)
+}
+
+// drain publishes every pending event and returns them in order
+func (o *OutboxTestSuite) drain() []events.Event {
+	var published []events.Event
+	_, err := o.outbox.PublishBatch(o.ctx, 100, func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+	o.Require().NoError(err)
+	return published
+}
+
+func (o *OutboxTestSuite) TestMutationsRecordEvents() {
+	user, err := o.users.CreateUser(o.ctx, domain.CreateUserParams{Name: "Outbox Owner", Email: "outbox@example.com"})
+	o.Require().NoError(err)
+
+	list, err := o.lists.CreateTodoList(o.ctx, todolist.CreateTodoListParams{UserID: user.ID, Title: "Groceries"})
+	o.Require().NoError(err)
+
+	published := o.drain()
+	o.Require().Len(published, 2)
+	o.Equal(events.UserCreated, published[0].Type)
+	o.Equal(events.AggregateUser, published[0].AggregateType)
+	o.Equal(user.ID, published[0].AggregateID)
+	o.Equal(events.ListCreated, published[1].Type)
+	o.Equal(list.ID, published[1].AggregateID)
+	o.Contains(string(published[1].Data), "Groceries")
+
+	// Published events are not handed out again
+	o.Empty(o.drain())
+}
+
+func (o *OutboxTestSuite) TestPublishFailureKeepsRemainingEvents() {
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		_, err := o.users.CreateUser(o.ctx, domain.CreateUserParams{Name: "Outbox User", Email: email})
+		o.Require().NoError(err)
+	}
+
+	calls := 0
+	count, err := o.outbox.PublishBatch(o.ctx, 100, func(ctx context.Context, event events.Event) error {
+		calls++
+		if calls == 2 {
+			return errors.New("bus unavailable")
+		}
+		return nil
+	})
+	o.Error(err)
+	o.Equal(1, count)
+
+	o.Len(o.drain(), 2)
+}
+
+func (o *OutboxTestSuite) TestPrune() {
+	_, err := o.users.CreateUser(o.ctx, domain.CreateUserParams{Name: "Outbox Owner", Email: "outbox@example.com"})
+	o.Require().NoError(err)
+	o.Require().Len(o.drain(), 1)
+
+	pruned, err := o.outbox.Prune(o.ctx, time.Now().Add(-time.Hour))
+	o.Require().NoError(err)
+	o.Zero(pruned)
+
+	pruned, err = o.outbox.Prune(o.ctx, time.Now().Add(time.Minute))
+	o.Require().NoError(err)
+	o.Equal(int64(1), pruned)
+}
@@ -0,0 +1,25 @@
+-- Record a domain event for the outbox relay
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- Lock the oldest unpublished events for publishing.
+-- Rows locked by another relay are skipped.
+-- name: ClaimOutboxEvents :many
+SELECT *
+FROM outbox_events
+WHERE published_at IS NULL
+ORDER BY created_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- Mark events as published
+-- name: MarkOutboxEventsPublished :exec
+UPDATE outbox_events
+SET published_at = $1
+WHERE id = ANY($2::uuid[]);
+
+-- Prune events published before the cutoff
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE published_at < $1;
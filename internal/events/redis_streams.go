@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// streamField holds the JSON-encoded event in each stream entry
+	streamField = "event"
+	// streamReadCount bounds the entries read per XREADGROUP call
+	streamReadCount = 50
+	// streamBlock bounds how long a read waits for new entries, so cancellation is noticed promptly
+	streamBlock = 2 * time.Second
+	// streamRetryDelay is the pause before unacknowledged entries are read again after a handler failure
+	streamRetryDelay = time.Second
+)
+
+// RedisStreamConfig configures a RedisStreamBus.
+type RedisStreamConfig struct {
+	Stream   string // Stream key events are appended to
+	Group    string // Consumer group; each group receives every event once
+	Consumer string // Name of this process within the group
+	MaxLen   int64  // Approximate number of entries the stream retains; zero keeps every entry
+}
+
+// RedisStreamBus publishes events to a Redis Stream and consumes them through a consumer group,
+// so every group sees each event and replicas in a group share the work.
+// An entry is acknowledged only after its handler succeeds; entries left unacknowledged, by a failed
+// handler or a crashed process, are delivered again to the same consumer.
+type RedisStreamBus struct {
+	client redis.UniversalClient
+	cfg    RedisStreamConfig
+	logger *zap.SugaredLogger
+}
+
+// NewRedisStreamBus initializes a RedisStreamBus on the given stream.
+func NewRedisStreamBus(client redis.UniversalClient, cfg RedisStreamConfig, logger *zap.SugaredLogger) *RedisStreamBus {
+	return &RedisStreamBus{
+		client: client,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Publish appends the event to the stream.
+func (b *RedisStreamBus) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+	}
+
+	args := &redis.XAddArgs{
+		Stream: b.cfg.Stream,
+		Values: map[string]interface{}{streamField: payload},
+	}
+	if b.cfg.MaxLen > 0 {
+		args.MaxLen = b.cfg.MaxLen
+		args.Approx = true
+	}
+
+	if err := b.client.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("failed to append event %s to stream %s: %w", event.ID, b.cfg.Stream, err)
+	}
+	return nil
+}
+
+// Subscribe consumes the stream as this bus's consumer until ctx is cancelled.
+// The consumer group is created on first use and starts from the beginning of the stream.
+// Entries still pending for this consumer from an earlier run are handled before new ones.
+func (b *RedisStreamBus) Subscribe(ctx context.Context, handler Handler) error {
+	err := b.client.XGroupCreateMkStream(ctx, b.cfg.Stream, b.cfg.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", b.cfg.Group, err)
+	}
+
+	// "0" re-reads this consumer's unacknowledged entries, ">" reads entries never delivered to the group
+	cursor := "0"
+	for ctx.Err() == nil {
+		streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    b.cfg.Group,
+			Consumer: b.cfg.Consumer,
+			Streams:  []string{b.cfg.Stream, cursor},
+			Count:    streamReadCount,
+			Block:    streamBlock,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			b.logger.Errorw("Event stream read failed", "stream", b.cfg.Stream, "group", b.cfg.Group, "error", err)
+			sleep(ctx, streamRetryDelay)
+			continue
+		}
+
+		var messages []redis.XMessage
+		for _, stream := range streams {
+			messages = append(messages, stream.Messages...)
+		}
+
+		// Once the backlog of pending entries is handled, switch to new entries
+		if cursor == "0" && len(messages) == 0 {
+			cursor = ">"
+			continue
+		}
+
+		if failed := b.handle(ctx, messages, handler); failed {
+			cursor = "0"
+			sleep(ctx, streamRetryDelay)
+		}
+	}
+	return ctx.Err()
+}
+
+// handle passes each entry to handler and acknowledges those it accepts.
+// It reports whether any entry was left unacknowledged for redelivery.
+func (b *RedisStreamBus) handle(ctx context.Context, messages []redis.XMessage, handler Handler) bool {
+	failed := false
+	for _, message := range messages {
+		event, err := decodeStreamEvent(message)
+		if err != nil {
+			// An undecodable entry would be redelivered forever; acknowledge and drop it
+			b.logger.Errorw("Event stream entry dropped: invalid event", "stream", b.cfg.Stream, "entry_id", message.ID, "error", err)
+		} else if err := handler(ctx, event); err != nil {
+			b.logger.Warnw("Event handler failed; entry will be redelivered", "event_id", event.ID, "event_type", event.Type, "error", err)
+			failed = true
+			continue
+		}
+
+		if err := b.client.XAck(ctx, b.cfg.Stream, b.cfg.Group, message.ID).Err(); err != nil {
+			b.logger.Errorw("Event stream ack failed", "stream", b.cfg.Stream, "entry_id", message.ID, "error", err)
+		}
+	}
+	return failed
+}
+
+// decodeStreamEvent extracts the event from a stream entry
+func decodeStreamEvent(message redis.XMessage) (Event, error) {
+	raw, ok := message.Values[streamField].(string)
+	if !ok {
+		return Event{}, fmt.Errorf("missing %q field", streamField)
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		return Event{}, err
+	}
+	return event, nil
+}
+
+// sleep waits for d or until ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
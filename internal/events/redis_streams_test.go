@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newStreamBus(t *testing.T, client *redis.Client, consumer string) *RedisStreamBus {
+	t.Helper()
+	return NewRedisStreamBus(client, RedisStreamConfig{
+		Stream:   "events",
+		Group:    "test",
+		Consumer: consumer,
+		MaxLen:   1000,
+	}, zap.NewNop().Sugar())
+}
+
+// collector records the IDs of handled events, failing the first failures calls
+type collector struct {
+	mu       sync.Mutex
+	ids      []uuid.UUID
+	failures int
+}
+
+func (c *collector) handle(ctx context.Context, event Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("handler failed")
+	}
+	c.ids = append(c.ids, event.ID)
+	return nil
+}
+
+func (c *collector) received() []uuid.UUID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]uuid.UUID(nil), c.ids...)
+}
+
+func TestRedisStreamBus(t *testing.T) {
+	t.Run("success - published events are consumed and acknowledged", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		bus := newStreamBus(t, client, "consumer-1")
+
+		first, err := New(ListCreated, AggregateList, uuid.New(), map[string]string{"title": "Groceries"})
+		require.NoError(t, err)
+		second, err := New(ListDeleted, AggregateList, first.AggregateID, nil)
+		require.NoError(t, err)
+
+		// Events published before the group exists are still delivered
+		require.NoError(t, bus.Publish(context.Background(), first))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		c := &collector{}
+		done := make(chan error, 1)
+		go func() { done <- bus.Subscribe(ctx, c.handle) }()
+
+		require.NoError(t, bus.Publish(context.Background(), second))
+
+		require.Eventually(t, func() bool { return len(c.received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []uuid.UUID{first.ID, second.ID}, c.received())
+
+		pending, err := client.XPending(context.Background(), "events", "test").Result()
+		require.NoError(t, err)
+		assert.Zero(t, pending.Count)
+
+		cancel()
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Subscribe did not return after cancellation")
+		}
+	})
+
+	t.Run("success - failed event is redelivered", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		bus := newStreamBus(t, client, "consumer-1")
+
+		event, err := New(TaskCompleted, AggregateTask, uuid.New(), nil)
+		require.NoError(t, err)
+		require.NoError(t, bus.Publish(context.Background(), event))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		c := &collector{failures: 1}
+		go func() { _ = bus.Subscribe(ctx, c.handle) }()
+
+		require.Eventually(t, func() bool { return len(c.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, event.ID, c.received()[0])
+	})
+
+	t.Run("success - invalid entry is dropped", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		bus := newStreamBus(t, client, "consumer-1")
+
+		require.NoError(t, client.XAdd(context.Background(), &redis.XAddArgs{Stream: "events", Values: map[string]interface{}{"event": "not json"}}).Err())
+		event, err := New(UserCreated, AggregateUser, uuid.New(), nil)
+		require.NoError(t, err)
+		require.NoError(t, bus.Publish(context.Background(), event))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		c := &collector{}
+		go func() { _ = bus.Subscribe(ctx, c.handle) }()
+
+		require.Eventually(t, func() bool { return len(c.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, event.ID, c.received()[0])
+	})
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RelayBatchSize bounds the events published per outbox transaction.
+const RelayBatchSize = 100
+
+// Outbox is the relay's view of the outbox table.
+type Outbox interface {
+	PublishBatch(ctx context.Context, limit int, publish func(context.Context, Event) error) (int, error)
+	Prune(ctx context.Context, before time.Time) (int64, error)
+}
+
+var _ Outbox = (*OutboxStore)(nil)
+
+// Relay publishes outbox events to an EventBus, oldest first.
+// An event is marked published only after the bus accepts it, so a crash between the two
+// publishes it again: delivery is at least once.
+type Relay struct {
+	outbox    Outbox
+	bus       EventBus
+	retention time.Duration
+	logger    *zap.SugaredLogger
+	now       func() time.Time
+}
+
+// NewRelay initializes a Relay. Published events are pruned once older than retention.
+func NewRelay(outbox Outbox, bus EventBus, retention time.Duration, logger *zap.SugaredLogger) *Relay {
+	return &Relay{
+		outbox:    outbox,
+		bus:       bus,
+		retention: retention,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Name identifies the job in logs.
+func (r *Relay) Name() string {
+	return "outbox-relay"
+}
+
+// Run publishes batches of unpublished events until the outbox is drained, then prunes old events.
+// A publishing failure ends the run; the failed event is retried first on the next run.
+func (r *Relay) Run(ctx context.Context) error {
+	total := 0
+	for ctx.Err() == nil {
+		published, err := r.outbox.PublishBatch(ctx, RelayBatchSize, r.bus.Publish)
+		total += published
+		if err != nil {
+			return fmt.Errorf("outbox relay stopped after %d events: %w", total, err)
+		}
+		if published < RelayBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		r.logger.Debugw("Outbox events published", "count", total)
+	}
+
+	pruned, err := r.outbox.Prune(ctx, r.now().Add(-r.retention))
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		r.logger.Debugw("Outbox events pruned", "count", pruned)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryOutbox is an in-memory Outbox holding unpublished events in order
+type memoryOutbox struct {
+	unpublished []Event
+	published   []Event
+	prunedAt    time.Time
+}
+
+func (o *memoryOutbox) PublishBatch(ctx context.Context, limit int, publish func(context.Context, Event) error) (int, error) {
+	count := 0
+	for count < limit && len(o.unpublished) > 0 {
+		if err := publish(ctx, o.unpublished[0]); err != nil {
+			return count, err
+		}
+		o.published = append(o.published, o.unpublished[0])
+		o.unpublished = o.unpublished[1:]
+		count++
+	}
+	return count, nil
+}
+
+func (o *memoryOutbox) Prune(ctx context.Context, before time.Time) (int64, error) {
+	o.prunedAt = before
+	return 0, nil
+}
+
+// failingBus accepts events until failAt have been published
+type failingBus struct {
+	InProcessBus
+	accepted []uuid.UUID
+	failAt   int
+}
+
+func (b *failingBus) Publish(ctx context.Context, event Event) error {
+	if b.failAt > 0 && len(b.accepted) == b.failAt {
+		return errors.New("bus unavailable")
+	}
+	b.accepted = append(b.accepted, event.ID)
+	return nil
+}
+
+func newEvents(t *testing.T, n int) []Event {
+	t.Helper()
+	result := make([]Event, n)
+	for i := range result {
+		event, err := New(TaskUpdated, AggregateTask, uuid.New(), nil)
+		require.NoError(t, err)
+		result[i] = event
+	}
+	return result
+}
+
+func TestRelayRun(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success - outbox drained in order across batches", func(t *testing.T) {
+		pending := newEvents(t, RelayBatchSize+3)
+		outbox := &memoryOutbox{unpublished: pending}
+		bus := &failingBus{}
+		relay := NewRelay(outbox, bus, 24*time.Hour, zap.NewNop().Sugar())
+		relay.now = func() time.Time { return now }
+
+		require.NoError(t, relay.Run(context.Background()))
+
+		assert.Empty(t, outbox.unpublished)
+		require.Len(t, bus.accepted, len(pending))
+		for i, event := range pending {
+			assert.Equal(t, event.ID, bus.accepted[i])
+		}
+		assert.Equal(t, now.Add(-24*time.Hour), outbox.prunedAt)
+	})
+
+	t.Run("failure - bus error stops the run and keeps the event", func(t *testing.T) {
+		pending := newEvents(t, 5)
+		outbox := &memoryOutbox{unpublished: pending}
+		bus := &failingBus{failAt: 2}
+		relay := NewRelay(outbox, bus, time.Hour, zap.NewNop().Sugar())
+
+		err := relay.Run(context.Background())
+
+		assert.Error(t, err)
+		assert.Len(t, outbox.published, 2)
+		require.Len(t, outbox.unpublished, 3)
+		assert.Equal(t, pending[2].ID, outbox.unpublished[0].ID)
+	})
+}
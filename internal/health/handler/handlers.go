@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"go.uber.org/zap"
+)
+
+// pingTimeout bounds the database check of a health request
+const pingTimeout = 2 * time.Second
+
+// Health statuses: the service is degraded while the cache is unavailable, as requests fall through
+// to the database, and unavailable without the database.
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+// Pinger checks that a dependency is reachable; *pgxpool.Pool is one.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Response reports the service's status and that of its dependencies.
+type Response struct {
+	Status   string      `json:"status"`
+	Database string      `json:"database"`
+	Redis    RedisStatus `json:"redis"`
+}
+
+// RedisStatus reports the Redis circuit breaker's state. OpenedAt is when it last opened, if ever.
+type RedisStatus struct {
+	Breaker  redispkg.BreakerState `json:"breaker"`
+	OpenedAt *time.Time            `json:"opened_at,omitempty"`
+}
+
+type Handler struct {
+	db      Pinger
+	breaker *redispkg.Breaker
+	logger  *zap.SugaredLogger
+}
+
+// New initializes a new health Handler checking the database and reporting the Redis breaker's state.
+func New(db Pinger, breaker *redispkg.Breaker, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		db:      db,
+		breaker: breaker,
+		logger:  logger,
+	}
+}
+
+// HealthHandler reports the service's status: 200 while it can serve requests, even without Redis,
+// and 503 when the database is unreachable.
+func (h *Handler) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	resp := Response{
+		Status:   StatusOK,
+		Database: StatusOK,
+		Redis:    RedisStatus{Breaker: h.breaker.State()},
+	}
+	if openedAt := h.breaker.OpenedAt(); !openedAt.IsZero() {
+		resp.Redis.OpenedAt = &openedAt
+	}
+	if resp.Redis.Breaker == redispkg.BreakerOpen {
+		resp.Status = StatusDegraded
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), pingTimeout)
+	defer cancel()
+	if err := h.db.Ping(ctx); err != nil {
+		h.logger.Warnw("Health check failed: database unreachable", "error", err)
+		resp.Status = StatusUnavailable
+		resp.Database = StatusUnavailable
+	}
+
+	status := http.StatusOK
+	if resp.Status == StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Errorw("HealthHandler failed: failed to encode response", "error", err)
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+)
+
+// pingerFunc adapts a function to Pinger
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) Ping(ctx context.Context) error { return f(ctx) }
+
+// newBreaker returns a breaker on a miniredis server, opened when redisDown
+func newBreaker(t *testing.T, redisDown bool) *redispkg.Breaker {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	breaker := redispkg.NewBreaker(client, 1, time.Second, zap.NewNop().Sugar())
+	if redisDown {
+		mr.Close()
+		require.Error(t, breaker.Probe(context.Background()))
+	}
+	return breaker
+}
+
+func TestHealthHandler(t *testing.T) {
+	dbUp := pingerFunc(func(ctx context.Context) error { return nil })
+	dbDown := pingerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+
+	tests := []struct {
+		name       string
+		db         Pinger
+		redisDown  bool
+		wantCode   int
+		wantStatus string
+		wantDB     string
+	}{
+		{name: "all dependencies available", db: dbUp, wantCode: http.StatusOK, wantStatus: StatusOK, wantDB: StatusOK},
+		{name: "Redis unavailable - degraded", db: dbUp, redisDown: true, wantCode: http.StatusOK, wantStatus: StatusDegraded, wantDB: StatusOK},
+		{name: "database unavailable", db: dbDown, wantCode: http.StatusServiceUnavailable, wantStatus: StatusUnavailable, wantDB: StatusUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(tt.db, newBreaker(t, tt.redisDown), zap.NewNop().Sugar())
+
+			rec := httptest.NewRecorder()
+			h.HealthHandler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var resp struct {
+				Status   string `json:"status"`
+				Database string `json:"database"`
+				Redis    struct {
+					Breaker  string     `json:"breaker"`
+					OpenedAt *time.Time `json:"opened_at"`
+				} `json:"redis"`
+			}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.wantDB, resp.Database)
+			if tt.redisDown {
+				assert.Equal(t, "open", resp.Redis.Breaker)
+				assert.NotNil(t, resp.Redis.OpenedAt)
+			} else {
+				assert.Equal(t, "closed", resp.Redis.Breaker)
+				assert.Nil(t, resp.Redis.OpenedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const (
+	callerIDKey        = contextKey("callerID")
+	authenticatedIDKey = contextKey("authenticatedID") // Set only by Identity
+)
+
+// Identity headers, set by the gateway in front of the API once it has authenticated the caller.
+// The signature is the hex HMAC-SHA256, keyed with the secret shared with the gateway, of
+// "<user ID>:<timestamp>", where the timestamp is in Unix seconds.
+const (
+	UserIDHeader            = "X-User-ID"
+	IdentityTimestampHeader = "X-Identity-Timestamp"
+	IdentitySignatureHeader = "X-Identity-Signature"
+)
+
+// MaxIdentityAge bounds how far an identity's timestamp may be from now, limiting replays.
+const MaxIdentityAge = 5 * time.Minute
+
+// Identity stores the caller's user ID in the request context when the identity headers carry a valid
+// signature made with secret. Requests without a verified identity pass through anonymously;
+// handlers decide whether a caller is required. The identity headers are removed either way,
+// so only the context is trusted downstream. An empty secret verifies nothing.
+func Identity(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userID, ok := verifyIdentity(r.Header, secret, time.Now()); ok {
+				ctx := context.WithValue(WithUserID(r.Context(), userID), authenticatedIDKey, userID)
+				r = r.WithContext(ctx)
+			}
+			r.Header.Del(UserIDHeader)
+			r.Header.Del(IdentityTimestampHeader)
+			r.Header.Del(IdentitySignatureHeader)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetIdentity signs userID with secret and sets the identity headers, as the gateway does.
+func SetIdentity(header http.Header, secret []byte, userID uuid.UUID) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	header.Set(UserIDHeader, userID.String())
+	header.Set(IdentityTimestampHeader, timestamp)
+	header.Set(IdentitySignatureHeader, hex.EncodeToString(signIdentity(secret, userID.String(), timestamp)))
+}
+
+// WithUserID returns a copy of ctx carrying the caller's user ID, as the actor of the changes made with it.
+// It does not authenticate the caller: RequireRole only trusts identities verified by Identity.
+func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, callerIDKey, userID)
+}
+
+// UserIDFromContext returns the caller's user ID stored by Identity or WithUserID.
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(callerIDKey).(uuid.UUID)
+	return userID, ok && userID != uuid.Nil
+}
+
+// authenticatedUserID returns the caller's user ID if Identity verified it and it was not replaced since
+func authenticatedUserID(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := UserIDFromContext(ctx)
+	verified, _ := ctx.Value(authenticatedIDKey).(uuid.UUID)
+	return userID, ok && userID == verified
+}
+
+// verifyIdentity returns the user ID of header's identity if its signature is valid and recent
+func verifyIdentity(header http.Header, secret []byte, now time.Time) (uuid.UUID, bool) {
+	if len(secret) == 0 {
+		return uuid.Nil, false
+	}
+	id, timestamp := header.Get(UserIDHeader), header.Get(IdentityTimestampHeader)
+	signature, err := hex.DecodeString(header.Get(IdentitySignatureHeader))
+	if err != nil || !hmac.Equal(signature, signIdentity(secret, id, timestamp)) {
+		return uuid.Nil, false
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > MaxIdentityAge || age < -MaxIdentityAge {
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(id)
+	if err != nil || userID == uuid.Nil {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// signIdentity returns the HMAC-SHA256 of an identity
+func signIdentity(secret []byte, id, timestamp string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id + ":" + timestamp))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentity(t *testing.T) {
+	secret := []byte("identity-secret")
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		secret   []byte
+		setup    func(header http.Header)
+		wantUser bool
+	}{
+		{
+			name:     "signed identity",
+			secret:   secret,
+			setup:    func(header http.Header) { SetIdentity(header, secret, userID) },
+			wantUser: true,
+		},
+		{
+			name:   "unsigned user ID header",
+			secret: secret,
+			setup:  func(header http.Header) { header.Set(UserIDHeader, userID.String()) },
+		},
+		{
+			name:   "signed with another secret",
+			secret: secret,
+			setup:  func(header http.Header) { SetIdentity(header, []byte("other-secret"), userID) },
+		},
+		{
+			name:   "user ID replaced after signing",
+			secret: secret,
+			setup: func(header http.Header) {
+				SetIdentity(header, secret, userID)
+				header.Set(UserIDHeader, uuid.NewString())
+			},
+		},
+		{
+			name:   "expired signature",
+			secret: secret,
+			setup: func(header http.Header) {
+				timestamp := strconv.FormatInt(time.Now().Add(-MaxIdentityAge-time.Minute).Unix(), 10)
+				header.Set(UserIDHeader, userID.String())
+				header.Set(IdentityTimestampHeader, timestamp)
+				header.Set(IdentitySignatureHeader, hex.EncodeToString(signIdentity(secret, userID.String(), timestamp)))
+			},
+		},
+		{
+			name:   "no secret configured",
+			secret: nil,
+			setup:  func(header http.Header) { SetIdentity(header, nil, userID) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser bool
+			var gotHeader string
+			handler := Identity(tt.secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var id uuid.UUID
+				id, gotUser = UserIDFromContext(r.Context())
+				if gotUser {
+					assert.Equal(t, userID, id)
+				}
+				gotHeader = r.Header.Get(UserIDHeader)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.setup(req.Header)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.wantUser, gotUser)
+			assert.Empty(t, gotHeader, "identity headers are not passed on")
+		})
+	}
+}
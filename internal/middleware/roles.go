@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	authdomain "github.com/henryhall897/golang-todo-app/internal/auth/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+
+	"github.com/google/uuid"
+)
+
+// RoleLookup resolves the auth identity, and therefore the role, of a user.
+type RoleLookup interface {
+	GetAuthIdentityByUserID(ctx context.Context, userID uuid.UUID) (authdomain.AuthIdentity, error)
+}
+
+// RequireRole only lets through callers whose auth identity has the given role.
+// It must run after Identity: callers whose identity Identity did not verify are unauthorized.
+func RequireRole(lookup RoleLookup, role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := logging.GetLogger(r.Context())
+
+			callerID, ok := authenticatedUserID(r.Context())
+			if !ok {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			identity, err := lookup.GetAuthIdentityByUserID(r.Context(), callerID)
+			if err != nil {
+				if errors.Is(err, common.ErrNotFound) {
+					logger.Warnw("RequireRole failed: caller has no auth identity", "user_id", callerID)
+					http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+					return
+				}
+				logger.Errorw("RequireRole failed: could not look up auth identity", "user_id", callerID, "error", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			if identity.Role != role {
+				logger.Warnw("RequireRole failed: insufficient role", "user_id", callerID, "role", identity.Role, "required", role)
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authdomain "github.com/henryhall897/golang-todo-app/internal/auth/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// adminLookup reports every user as an admin
+type adminLookup struct{}
+
+func (adminLookup) GetAuthIdentityByUserID(ctx context.Context, userID uuid.UUID) (authdomain.AuthIdentity, error) {
+	return authdomain.AuthIdentity{UserID: userID, Role: authdomain.RoleAdmin}, nil
+}
+
+func TestRequireRole(t *testing.T) {
+	secret := []byte("identity-secret")
+	adminID := uuid.New()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	t.Run("verified identity is authorized", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		SetIdentity(req.Header, secret, adminID)
+		rr := httptest.NewRecorder()
+		Identity(secret)(RequireRole(adminLookup{}, authdomain.RoleAdmin)(ok)).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("unverified identities are unauthorized", func(t *testing.T) {
+		// An unsigned header
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.Header.Set(UserIDHeader, adminID.String())
+		rr := httptest.NewRecorder()
+		Identity(secret)(RequireRole(adminLookup{}, authdomain.RoleAdmin)(ok)).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		// A user ID stored without Identity
+		req = httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req = req.WithContext(WithUserID(req.Context(), adminID))
+		rr = httptest.NewRecorder()
+		RequireRole(adminLookup{}, authdomain.RoleAdmin)(ok).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,33 @@
+package testutils
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/uuid"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+)
+
+// IdentitySecret signs caller identities in tests, as the secret shared with the gateway does
+var IdentitySecret = []byte("test-identity-secret")
+
+// Identity wraps next in the identity middleware, verifying the identities signed by SignAs
+func Identity(next http.Handler) http.Handler {
+	return middleware.Identity(IdentitySecret)(next)
+}
+
+// SignAs sets identity headers signed for callerID, as the gateway does.
+// Nothing is set for uuid.Nil, leaving the request anonymous.
+func SignAs(header http.Header, callerID uuid.UUID) {
+	if callerID != uuid.Nil {
+		middleware.SetIdentity(header, IdentitySecret, callerID)
+	}
+}
+
+// NewRequest returns an incoming test request signed for callerID; uuid.Nil leaves it anonymous
+func NewRequest(method, target string, body io.Reader, callerID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	SignAs(req.Header, callerID)
+	return req
+}
@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LogNotifier writes messages to the application log instead of delivering them.
+// It is the default for local development.
+type LogNotifier struct {
+	logger *zap.SugaredLogger
+}
+
+// NewLogNotifier initializes a LogNotifier.
+func NewLogNotifier(logger *zap.SugaredLogger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify logs the message.
+func (n *LogNotifier) Notify(ctx context.Context, msg Message) error {
+	n.logger.Infow("Notification", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogNotifier(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	notifier := NewLogNotifier(zap.New(core).Sugar())
+
+	err := notifier.Notify(context.Background(), Message{To: "user@example.com", Subject: "Hello", Body: "World"})
+	require.NoError(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "user@example.com", fields["to"])
+	assert.Equal(t, "Hello", fields["subject"])
+	assert.Equal(t, "World", fields["body"])
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+var errInvalidRecipient = errors.New("invalid recipient address")
+
+// validateRecipient rejects empty addresses and addresses that would inject headers.
+func validateRecipient(to string) error {
+	if to == "" || strings.ContainsAny(to, "\r\n") {
+		return fmt.Errorf("%w: %q", errInvalidRecipient, to)
+	}
+	return nil
+}
+
+// formatMail renders the message as an RFC 5322 email with CRLF line endings.
+// Messages with an HTML body are sent as multipart/alternative with the plain-text part first.
+func formatMail(from string, msg Message, date time.Time) []byte {
+	var buf bytes.Buffer
+
+	writeHeader := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	writeHeader("From", from)
+	writeHeader("To", msg.To)
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader("Date", date.Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+
+	if msg.HTMLBody == "" {
+		writeHeader("Content-Type", `text/plain; charset="utf-8"`)
+		writeHeader("Content-Transfer-Encoding", "8bit")
+		buf.WriteString("\r\n")
+		buf.WriteString(crlf(msg.Body))
+		buf.WriteString("\r\n")
+		return buf.Bytes()
+	}
+
+	var parts bytes.Buffer
+	mw := multipart.NewWriter(&parts)
+	writeHeader("Content-Type", `multipart/alternative; boundary="`+mw.Boundary()+`"`)
+	buf.WriteString("\r\n")
+
+	// Writes to a bytes.Buffer cannot fail
+	for _, part := range []struct{ contentType, body string }{
+		{`text/plain; charset="utf-8"`, msg.Body},
+		{`text/html; charset="utf-8"`, msg.HTMLBody},
+	} {
+		w, _ := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		w.Write([]byte(crlf(part.body) + "\r\n"))
+	}
+	mw.Close()
+
+	buf.Write(parts.Bytes())
+	return buf.Bytes()
+}
+
+// crlf normalizes line endings to CRLF.
+func crlf(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+}
@@ -0,0 +1,24 @@
+package notify
+
+import "context"
+
+// Message is a notification addressed to a single recipient.
+type Message struct {
+	To       string `json:"to"` // Recipient email address
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`                // Plain-text body
+	HTMLBody string `json:"html_body,omitempty"` // Optional HTML alternative to Body
+}
+
+// Notifier delivers messages to users.
+//
+//go:generate moq -out=../../gen/mocks/notifymock/notifier_mock.go -pkg=notifymock . Notifier
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+var (
+	_ Notifier = (*LogNotifier)(nil)
+	_ Notifier = (*SMTPNotifier)(nil)
+	_ Notifier = (*FileOutbox)(nil)
+)
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// FileOutbox delivers messages by writing each one as an .eml file to a directory.
+// It is meant for tests and local development, where no mail relay is available.
+type FileOutbox struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+// NewFileOutbox initializes a FileOutbox, creating its directory when missing.
+func NewFileOutbox(dir, from string) (*FileOutbox, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory %s: %w", dir, err)
+	}
+	return &FileOutbox{dir: dir, from: from}, nil
+}
+
+// Dir returns the directory messages are written to.
+func (o *FileOutbox) Dir() string {
+	return o.dir
+}
+
+// Notify writes the message to the outbox. Files are written under a temporary
+// name and renamed, so readers never see a partial message.
+func (o *FileOutbox) Notify(ctx context.Context, msg Message) error {
+	if err := validateRecipient(msg.To); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%d-%06d.eml", now.UnixNano(), o.seq.Add(1))
+
+	tmp, err := os.CreateTemp(o.dir, ".outbox-*")
+	if err != nil {
+		return fmt.Errorf("failed to create outbox file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(formatMail(o.from, msg, now)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(o.dir, name)); err != nil {
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+	return nil
+}
+
+// Files lists the delivered message files in the order they were written.
+func (o *FileOutbox) Files() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(o.dir, "*.eml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox: %w", err)
+	}
+	// Glob sorts by name, and names start with the write time
+	return files, nil
+}
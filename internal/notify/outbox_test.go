@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readOutboxMail parses a message written by FileOutbox.
+func readOutboxMail(t *testing.T, path string) *mail.Message {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	return msg
+}
+
+func TestFileOutbox(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("writes a plain-text message", func(t *testing.T) {
+		outbox, err := NewFileOutbox(filepath.Join(t.TempDir(), "outbox"), "todo@example.com")
+		require.NoError(t, err)
+
+		err = outbox.Notify(ctx, Message{To: "user@example.com", Subject: "Hello", Body: "First line\nSecond line"})
+		require.NoError(t, err)
+
+		files, err := outbox.Files()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		msg := readOutboxMail(t, files[0])
+		assert.Equal(t, "todo@example.com", msg.Header.Get("From"))
+		assert.Equal(t, "user@example.com", msg.Header.Get("To"))
+		assert.Equal(t, "Hello", msg.Header.Get("Subject"))
+
+		body, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "First line\r\nSecond line\r\n", string(body))
+	})
+
+	t.Run("writes HTML messages as multipart/alternative", func(t *testing.T) {
+		outbox, err := NewFileOutbox(t.TempDir(), "todo@example.com")
+		require.NoError(t, err)
+
+		err = outbox.Notify(ctx, Message{
+			To:       "user@example.com",
+			Subject:  "Digest",
+			Body:     "plain",
+			HTMLBody: "<p>html</p>",
+		})
+		require.NoError(t, err)
+
+		files, err := outbox.Files()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		msg := readOutboxMail(t, files[0])
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+		var parts []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(part)
+			require.NoError(t, err)
+			parts = append(parts, part.Header.Get("Content-Type")+"|"+string(body))
+		}
+		assert.Equal(t, []string{
+			`text/plain; charset="utf-8"|plain` + "\r\n",
+			`text/html; charset="utf-8"|<p>html</p>` + "\r\n",
+		}, parts)
+	})
+
+	t.Run("keeps messages in write order", func(t *testing.T) {
+		outbox, err := NewFileOutbox(t.TempDir(), "todo@example.com")
+		require.NoError(t, err)
+
+		for _, subject := range []string{"one", "two", "three"} {
+			require.NoError(t, outbox.Notify(ctx, Message{To: "user@example.com", Subject: subject, Body: subject}))
+		}
+
+		files, err := outbox.Files()
+		require.NoError(t, err)
+		require.Len(t, files, 3)
+
+		var subjects []string
+		for _, file := range files {
+			subjects = append(subjects, readOutboxMail(t, file).Header.Get("Subject"))
+		}
+		assert.Equal(t, []string{"one", "two", "three"}, subjects)
+	})
+
+	t.Run("rejects a recipient with header injection", func(t *testing.T) {
+		outbox, err := NewFileOutbox(t.TempDir(), "todo@example.com")
+		require.NoError(t, err)
+
+		err = outbox.Notify(ctx, Message{To: "user@example.com\r\nBcc: victim@example.com", Subject: "hi", Body: "hi"})
+		require.ErrorIs(t, err, errInvalidRecipient)
+
+		files, err := outbox.Files()
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// smtpTimeout bounds a whole mail exchange with the relay, unless the context gives up sooner.
+const smtpTimeout = 30 * time.Second
+
+// SMTPConfig holds the settings needed to send mail through an SMTP relay.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // Optional; PLAIN auth is used when set
+	Password string
+	From     string
+}
+
+// SMTPNotifier delivers messages as email, with an HTML alternative when one is set.
+type SMTPNotifier struct {
+	host string
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPNotifier initializes an SMTPNotifier for the given relay.
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SMTPNotifier{
+		host: cfg.Host,
+		addr: net.JoinHostPort(cfg.Host, cfg.Port),
+		auth: auth,
+		from: cfg.From,
+	}
+}
+
+// Notify sends the message to its recipient. The exchange with the relay is abandoned once ctx is
+// done or smtpTimeout has passed.
+func (n *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	if err := validateRecipient(msg.To); err != nil {
+		return err
+	}
+
+	if err := n.send(ctx, msg.To, n.format(msg)); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return fmt.Errorf("failed to send mail to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// send delivers body to a single recipient the way smtp.SendMail does, over a connection bound to ctx.
+func (n *SMTPNotifier) send(ctx context.Context, to string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	// Expire the connection once ctx is done, so a stalled relay cannot block the exchange
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if n.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// format renders the message as an email from the configured sender.
+func (n *SMTPNotifier) format(msg Message) []byte {
+	return formatMail(n.from, msg, time.Now())
+}
@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedMail is a message accepted by fakeSMTPServer.
+type receivedMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// fakeSMTPServer speaks just enough SMTP for SMTPNotifier.
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	mail     []receivedMail
+	rejectTo string // RCPT TO address answered with a 550
+	wg       sync.WaitGroup
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &fakeSMTPServer{listener: listener}
+	srv.wg.Add(1)
+	go srv.serve()
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+		srv.wg.Wait()
+	})
+	return srv
+}
+
+func (s *fakeSMTPServer) hostPort() (string, string) {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host, port
+}
+
+func (s *fakeSMTPServer) received() []receivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedMail(nil), s.mail...)
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+
+	var current receivedMail
+	_ = tp.PrintfLine("220 localhost fake SMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case "MAIL":
+			current = receivedMail{From: addressArg(line)}
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			to := addressArg(line)
+			if to == s.rejectTo {
+				_ = tp.PrintfLine("550 mailbox unavailable")
+				continue
+			}
+			current.To = append(current.To, to)
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = string(data)
+			s.mu.Lock()
+			s.mail = append(s.mail, current)
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+// addressArg extracts the address from `MAIL FROM:<a>` / `RCPT TO:<a>`.
+func addressArg(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delivers a plain-text message", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		host, port := srv.hostPort()
+		notifier := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "todo@example.com"})
+
+		err := notifier.Notify(ctx, Message{
+			To:      "user@example.com",
+			Subject: "Reminder: Buy groceries",
+			Body:    "Buy groceries is due soon.\n.\nSee you!",
+		})
+		require.NoError(t, err)
+
+		mail := srv.received()
+		require.Len(t, mail, 1)
+		assert.Equal(t, "todo@example.com", mail[0].From)
+		assert.Equal(t, []string{"user@example.com"}, mail[0].To)
+
+		headers, body, found := strings.Cut(mail[0].Data, "\n\n")
+		require.True(t, found)
+		assert.Contains(t, headers, "To: user@example.com")
+		assert.Contains(t, headers, "Subject: Reminder: Buy groceries")
+		assert.Contains(t, headers, `Content-Type: text/plain; charset="utf-8"`)
+		assert.Equal(t, "Buy groceries is due soon.\n.\nSee you!\n", body)
+	})
+
+	t.Run("encodes non-ASCII and control characters in the subject", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		host, port := srv.hostPort()
+		notifier := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "todo@example.com"})
+
+		err := notifier.Notify(ctx, Message{To: "user@example.com", Subject: "Café\r\nBcc: victim@example.com", Body: "hi"})
+		require.NoError(t, err)
+
+		mail := srv.received()
+		require.Len(t, mail, 1)
+		assert.NotContains(t, mail[0].Data, "\nBcc:")
+		assert.Contains(t, mail[0].Data, "Subject: =?utf-8?q?")
+	})
+
+	t.Run("rejected recipient returns an error", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		srv.rejectTo = "nobody@example.com"
+		host, port := srv.hostPort()
+		notifier := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "todo@example.com"})
+
+		err := notifier.Notify(ctx, Message{To: "nobody@example.com", Subject: "s", Body: "b"})
+		require.Error(t, err)
+		assert.Empty(t, srv.received())
+	})
+
+	t.Run("stalled server is abandoned once the context is done", func(t *testing.T) {
+		// The server accepts the connection but never greets
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		go func() {
+			conn, err := listener.Accept()
+			if err == nil {
+				defer conn.Close()
+				_, _ = conn.Read(make([]byte, 1))
+			}
+		}()
+		host, port, _ := net.SplitHostPort(listener.Addr().String())
+		notifier := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "todo@example.com"})
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err = notifier.Notify(timeoutCtx, Message{To: "user@example.com", Subject: "s", Body: "b"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("invalid recipient is refused before dialing", func(t *testing.T) {
+		notifier := NewSMTPNotifier(SMTPConfig{Host: "127.0.0.1", Port: "1", From: "todo@example.com"})
+
+		err := notifier.Notify(ctx, Message{To: "user@example.com\r\nRCPT TO:<other@example.com>", Subject: "s", Body: "b"})
+		require.ErrorIs(t, err, errInvalidRecipient)
+	})
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	"github.com/henryhall897/golang-todo-app/internal/realtime"
+	"github.com/henryhall897/golang-todo-app/internal/scheduler"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Purger removes every cache entry derived from a user's data:
+// the cached user, its email pointer and the user pages that may list it, the user's task statistics,
+// their digest markers and the realtime history and presence of their lists.
+// Reminder markers are left to expire; they hold only task IDs.
+type Purger struct {
+	users  usersdomain.Cache
+	stats  redispkg.Cache
+	digest redispkg.Cache
+	client redis.UniversalClient
+}
+
+// NewPurger initializes a Purger over the feature caches and the raw client used for realtime keys.
+func NewPurger(users usersdomain.Cache, stats, digest redispkg.Cache, client redis.UniversalClient) *Purger {
+	return &Purger{
+		users:  users,
+		stats:  stats,
+		digest: digest,
+		client: client,
+	}
+}
+
+// PurgeUser deletes the subject's cache entries. Every entry is attempted; the errors are joined.
+func (p *Purger) PurgeUser(ctx context.Context, subject domain.Subject) error {
+	var errs []error
+
+	if err := p.users.DeleteUserByID(ctx, subject.UserID); err != nil {
+		errs = append(errs, fmt.Errorf("user: %w", err))
+	}
+	if subject.Email != "" {
+		if err := p.users.DeleteUserByEmail(ctx, subject.Email); err != nil {
+			errs = append(errs, fmt.Errorf("user email: %w", err))
+		}
+	}
+	// Move readers off the cached pages, then remove them rather than letting the erased user expire with them
+	if err := p.users.InvalidateUserPages(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("user page generation: %w", err))
+	}
+	if err := p.users.DeleteUserPages(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("user pages: %w", err))
+	}
+	if err := p.stats.Delete(ctx, tasks.StatsCacheKeyByUser(subject.UserID)); err != nil {
+		errs = append(errs, fmt.Errorf("task stats: %w", err))
+	}
+	if _, err := p.digest.DeleteByPrefix(ctx, scheduler.DigestKeyPrefix(subject.UserID)); err != nil {
+		errs = append(errs, fmt.Errorf("digest markers: %w", err))
+	}
+
+	if len(subject.ListIDs) > 0 {
+		keys := make([]string, 0, 2*len(subject.ListIDs))
+		for _, listID := range subject.ListIDs {
+			keys = append(keys, realtime.ListKeys(listID)...)
+		}
+		// One DEL per key, as the keys of different lists need not share a Redis Cluster slot
+		_, err := p.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.Del(ctx, key)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("realtime state: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	"github.com/henryhall897/golang-todo-app/internal/realtime"
+	"github.com/henryhall897/golang-todo-app/internal/scheduler"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	usercache "github.com/henryhall897/golang-todo-app/internal/users/cache"
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPurgeUser(t *testing.T) {
+	srv, err := miniredis.Run()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+	users := usercache.NewRedisUser(redispkg.NewJSONCache(client, usersdomain.RedisPrefix, logger))
+	stats := redispkg.NewJSONCache(client, tasks.StatsRedisPrefix, logger)
+	digest := redispkg.NewJSONCache(client, scheduler.DigestRedisPrefix, logger)
+
+	erased := usersdomain.User{ID: uuid.New(), Name: "Erased", Email: "erased@example.com"}
+	kept := usersdomain.User{ID: uuid.New(), Name: "Kept", Email: "kept@example.com"}
+	erasedList, keptList := uuid.New(), uuid.New()
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	// Seed the caches of both users
+	for _, user := range []usersdomain.User{erased, kept} {
+		require.NoError(t, users.CacheUser(ctx, user))
+		require.NoError(t, stats.Set(ctx, tasks.StatsCacheKeyByUser(user.ID), tasks.TaskStats{}, 0))
+		require.NoError(t, digest.Set(ctx, scheduler.DigestKey(user.ID, day), true, 0))
+	}
+	generation, err := users.PageGeneration(ctx)
+	require.NoError(t, err)
+	require.NoError(t, users.CacheUserByPagination(ctx, []usersdomain.User{erased, kept}, usersdomain.GetUsersParams{Limit: 10}, generation))
+	for _, listID := range []uuid.UUID{erasedList, keptList} {
+		for _, key := range realtime.ListKeys(listID) {
+			require.NoError(t, srv.Set(key, "state"))
+		}
+	}
+
+	purger := NewPurger(users, stats, digest, client)
+	err = purger.PurgeUser(ctx, domain.Subject{UserID: erased.ID, Email: erased.Email, ListIDs: []uuid.UUID{erasedList}})
+	require.NoError(t, err)
+
+	// Every entry derived from the erased user is gone
+	_, err = users.GetUserByID(ctx, erased.ID)
+	assert.Error(t, err)
+	_, err = users.GetUserByEmail(ctx, erased.Email)
+	assert.Error(t, err)
+	_, err = users.GetUserByPagination(ctx, usersdomain.GetUsersParams{Limit: 10}, generation)
+	assert.Error(t, err, "pages of earlier generations are deleted, not left to expire")
+	current, err := users.PageGeneration(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, current, generation)
+	assert.False(t, srv.Exists("taskstats:"+tasks.StatsCacheKeyByUser(erased.ID)))
+	assert.False(t, srv.Exists("digest:"+scheduler.DigestKey(erased.ID, day)))
+	for _, key := range realtime.ListKeys(erasedList) {
+		assert.False(t, srv.Exists(key), key)
+	}
+
+	// Other users' entries are kept
+	got, err := users.GetUserByID(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kept.Email, got.Email)
+	assert.True(t, srv.Exists("taskstats:"+tasks.StatsCacheKeyByUser(kept.ID)))
+	assert.True(t, srv.Exists("digest:"+scheduler.DigestKey(kept.ID, day)))
+	for _, key := range realtime.ListKeys(keptList) {
+		assert.True(t, srv.Exists(key), key)
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Data export statuses. An export is pending until a worker claims it, running while the
+// archive is written, then completed or failed.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Export worker policy. A running export whose lease expired is claimed again,
+// so an archive interrupted by a crash is rebuilt from scratch.
+const (
+	LeaseDuration = 5 * time.Minute
+	BatchSize     = 5
+)
+
+// Files written to every export archive
+const (
+	ArchiveUser        = "user.json"
+	ArchivePreferences = "preferences.json"
+	ArchiveIdentities  = "identities.json"
+	ArchiveLists       = "lists.json"
+	ArchiveTasks       = "tasks.json"
+)
+
+// ArchiveContentType is the media type of a downloaded export.
+const ArchiveContentType = "application/zip"
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the methods required for data export storage and reading a user's data.
+//
+//go:generate moq -out=../../../gen/mocks/privacymock/privacy_repo_mock.go -pkg=privacymock . Repository
+type Repository interface {
+	CreateExport(ctx context.Context, userID uuid.UUID) (DataExport, error)
+	GetExport(ctx context.Context, id, userID uuid.UUID) (DataExport, error)
+	ClaimExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]DataExport, error)
+	CompleteExport(ctx context.Context, params CompleteExportParams) error
+	FailExport(ctx context.Context, id uuid.UUID, reason string) error
+	DeleteExpiredExports(ctx context.Context, now time.Time) ([]string, error)
+	ListExportFiles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	ListLists(ctx context.Context, userID uuid.UUID) ([]List, error)
+	GetUserData(ctx context.Context, userID uuid.UUID) (UserData, error)
+	RedactUser(ctx context.Context, userID uuid.UUID) error
+}
+
+//go:generate moq -out=../../../gen/mocks/privacymock/privacy_service_mock.go -pkg=privacymock . Service
+type Service interface {
+	RequestExport(ctx context.Context, userID uuid.UUID) (DataExport, error)
+	GetExport(ctx context.Context, id, userID uuid.UUID) (DataExport, error)
+	OpenExport(ctx context.Context, id, userID uuid.UUID) (DataExport, io.ReadSeekCloser, error)
+	EraseUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// CachePurger removes every cached entry derived from a user's data.
+//
+//go:generate moq -out=../../../gen/mocks/privacymock/cache_purger_mock.go -pkg=privacymock . CachePurger
+type CachePurger interface {
+	PurgeUser(ctx context.Context, subject Subject) error
+}
+
+// UserStore reads and deletes the user records owned by the users feature.
+type UserStore interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (usersdomain.User, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (usersdomain.Preferences, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) (usersdomain.User, error)
+}
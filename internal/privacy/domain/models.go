@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataExport is a user's request for a copy of their data and the archive produced for it.
+// The archive location is internal; users download it through the export's download route.
+type DataExport struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      string     `json:"status"`
+	FilePath    string     `json:"-"`
+	SizeBytes   *int64     `json:"size_bytes"`
+	Error       *string    `json:"error"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// CompleteExportParams records the archive written for an export.
+type CompleteExportParams struct {
+	ID          uuid.UUID
+	FilePath    string
+	SizeBytes   int64
+	CompletedAt time.Time
+	ExpiresAt   time.Time
+}
+
+// Identity is an auth identity linked to the user, as exported.
+type Identity struct {
+	AuthID    string    `json:"auth_id"`
+	Provider  string    `json:"provider"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// List is a todo list owned by the user, as exported.
+type List struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Task is a task in one of the user's lists, as exported.
+type Task struct {
+	ID          uuid.UUID  `json:"id"`
+	ListID      uuid.UUID  `json:"list_id"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Status      *string    `json:"status"`
+	Priority    *int32     `json:"priority"`
+	DueDate     *time.Time `json:"due_date"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Tags        []string   `json:"tags"`
+}
+
+// UserData is everything stored about a user apart from the user record and preferences,
+// read from a single snapshot.
+type UserData struct {
+	Identities []Identity
+	Lists      []List
+	Tasks      []Task
+}
+
+// Subject identifies the cached data of an erased user.
+type Subject struct {
+	UserID  uuid.UUID
+	Email   string
+	ListIDs []uuid.UUID
+}
+
+// Tombstone is the audit record of an erasure. It holds counts rather than personal data,
+// so the audit trail proves the erasure without retaining what was erased.
+type Tombstone struct {
+	UserID   uuid.UUID `json:"user_id"`
+	ErasedAt time.Time `json:"erased_at"`
+	Lists    int       `json:"lists"`
+	Exports  int       `json:"exports"`
+}
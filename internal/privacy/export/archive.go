@@ -0,0 +1,64 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+)
+
+// Archive is everything written to a data export.
+type Archive struct {
+	User        usersdomain.User
+	Preferences usersdomain.Preferences
+	Data        domain.UserData
+}
+
+// WriteArchive writes the archive to w as a zip of JSON documents, one per kind of record.
+func WriteArchive(w io.Writer, archive Archive, modified time.Time) error {
+	zw := zip.NewWriter(w)
+
+	entries := []struct {
+		name  string
+		value interface{}
+	}{
+		{domain.ArchiveUser, archive.User},
+		{domain.ArchivePreferences, archive.Preferences},
+		{domain.ArchiveIdentities, nonNil(archive.Data.Identities)},
+		{domain.ArchiveLists, nonNil(archive.Data.Lists)},
+		{domain.ArchiveTasks, nonNil(archive.Data.Tasks)},
+	}
+	for _, entry := range entries {
+		file, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     entry.name,
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", entry.name, err)
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entry.value); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// nonNil encodes an empty slice as [] rather than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Store leases queued exports, reads the data they cover and records their outcome.
+type Store interface {
+	ClaimExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.DataExport, error)
+	CompleteExport(ctx context.Context, params domain.CompleteExportParams) error
+	FailExport(ctx context.Context, id uuid.UUID, reason string) error
+	DeleteExpiredExports(ctx context.Context, now time.Time) ([]string, error)
+	GetUserData(ctx context.Context, userID uuid.UUID) (domain.UserData, error)
+}
+
+// Worker writes the archives of queued data exports to a directory and removes them once they expire.
+// Exports are leased before they are written, so several replicas can run workers against the same
+// queue as long as they share the directory.
+type Worker struct {
+	store     Store
+	users     domain.UserStore
+	dir       string
+	retention time.Duration
+	logger    *zap.SugaredLogger
+	now       func() time.Time
+}
+
+// NewWorker initializes a Worker writing archives to dir that are kept for retention.
+func NewWorker(store Store, users domain.UserStore, dir string, retention time.Duration, logger *zap.SugaredLogger) *Worker {
+	return &Worker{
+		store:     store,
+		users:     users,
+		dir:       dir,
+		retention: retention,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Name identifies the job in logs.
+func (w *Worker) Name() string {
+	return "data-exports"
+}
+
+// Run removes expired archives, then writes queued exports until the queue is drained or ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) error {
+	if err := w.prune(ctx); err != nil {
+		return err
+	}
+
+	total := 0
+	for ctx.Err() == nil {
+		now := w.now()
+		batch, err := w.store.ClaimExports(ctx, now, now.Add(domain.LeaseDuration), domain.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim data exports: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, export := range batch {
+			w.export(ctx, export)
+		}
+		total += len(batch)
+	}
+
+	if total > 0 {
+		w.logger.Infow("Data exports written", "count", total)
+	}
+	return ctx.Err()
+}
+
+// prune deletes expired exports and their archives.
+func (w *Worker) prune(ctx context.Context) error {
+	paths, err := w.store.DeleteExpiredExports(ctx, w.now())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired data exports: %w", err)
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			w.logger.Warnw("Failed to remove expired data export archive", "path", path, "error", err)
+		}
+	}
+	return nil
+}
+
+// export writes a single export's archive and records the outcome.
+func (w *Worker) export(ctx context.Context, export domain.DataExport) {
+	path, size, err := w.write(ctx, export)
+	if err != nil {
+		w.logger.Errorw("Data export failed", "export_id", export.ID, "error", err)
+		if err := w.store.FailExport(ctx, export.ID, err.Error()); err != nil {
+			// The lease expires and the export is attempted again
+			w.logger.Errorw("Data export failed: could not record failure", "export_id", export.ID, "error", err)
+		}
+		return
+	}
+
+	completedAt := w.now()
+	if err := w.store.CompleteExport(ctx, domain.CompleteExportParams{
+		ID:          export.ID,
+		FilePath:    path,
+		SizeBytes:   size,
+		CompletedAt: completedAt,
+		ExpiresAt:   completedAt.Add(w.retention),
+	}); errors.Is(err, common.ErrNotFound) {
+		// The export was deleted while its archive was written, as when its user was erased: the archive
+		// must not outlive it
+		w.logger.Warnw("Data export discarded: export no longer exists", "export_id", export.ID)
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			w.logger.Errorw("Failed to remove discarded data export archive", "path", path, "error", err)
+		}
+	} else if err != nil {
+		w.logger.Errorw("Data export failed: could not record completion", "export_id", export.ID, "error", err)
+	}
+}
+
+// write gathers the user's data and writes it to the export's archive, returning its path and size.
+// The archive is written under a temporary name and renamed, so a download never sees a partial file.
+func (w *Worker) write(ctx context.Context, export domain.DataExport) (string, int64, error) {
+	user, err := w.users.GetUserByID(ctx, export.UserID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read user: %w", err)
+	}
+
+	prefs, err := w.users.GetPreferences(ctx, export.UserID)
+	if errors.Is(err, common.ErrNotFound) {
+		// Users who never changed their preferences have the defaults
+		prefs = usersdomain.Preferences{UserID: export.UserID, TimeZone: usersdomain.DefaultTimeZone}
+	} else if err != nil {
+		return "", 0, fmt.Errorf("failed to read preferences: %w", err)
+	}
+
+	data, err := w.store.GetUserData(ctx, export.UserID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read user data: %w", err)
+	}
+
+	if err := os.MkdirAll(w.dir, 0o700); err != nil {
+		return "", 0, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(w.dir, export.ID.String()+"-*.tmp")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // No-op once renamed
+
+	archive := Archive{User: user, Preferences: prefs, Data: data}
+	if err := WriteArchive(tmp, archive, w.now()); err != nil {
+		_ = tmp.Close()
+		return "", 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	info, err := os.Stat(tmp.Name())
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat archive: %w", err)
+	}
+
+	path := filepath.Join(w.dir, export.ID.String()+".zip")
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", 0, fmt.Errorf("failed to move archive into place: %w", err)
+	}
+	return path, info.Size(), nil
+}
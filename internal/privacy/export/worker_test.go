@@ -0,0 +1,225 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/usersmock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryStore is an in-memory export queue that hands out each pending export once
+type memoryStore struct {
+	pending   []domain.DataExport
+	data      domain.UserData
+	dataErr   error
+	expired   []string
+	completed []domain.CompleteExportParams
+	failed    map[uuid.UUID]string
+
+	// erased reports the exports gone when completed, as if their user was erased meanwhile
+	erased bool
+}
+
+func (s *memoryStore) ClaimExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.DataExport, error) {
+	n := min(limit, len(s.pending))
+	batch := s.pending[:n]
+	s.pending = s.pending[n:]
+	return batch, nil
+}
+
+func (s *memoryStore) CompleteExport(ctx context.Context, params domain.CompleteExportParams) error {
+	if s.erased {
+		return common.ErrNotFound
+	}
+	s.completed = append(s.completed, params)
+	return nil
+}
+
+func (s *memoryStore) FailExport(ctx context.Context, id uuid.UUID, reason string) error {
+	if s.failed == nil {
+		s.failed = map[uuid.UUID]string{}
+	}
+	s.failed[id] = reason
+	return nil
+}
+
+func (s *memoryStore) DeleteExpiredExports(ctx context.Context, now time.Time) ([]string, error) {
+	expired := s.expired
+	s.expired = nil
+	return expired, nil
+}
+
+func (s *memoryStore) GetUserData(ctx context.Context, userID uuid.UUID) (domain.UserData, error) {
+	return s.data, s.dataErr
+}
+
+// userStore returns a users repository mock serving one user without stored preferences
+func userStore(user usersdomain.User) *usersmock.RepositoryMock {
+	return &usersmock.RepositoryMock{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (usersdomain.User, error) {
+			if id != user.ID {
+				return usersdomain.User{}, common.ErrNotFound
+			}
+			return user, nil
+		},
+		GetPreferencesFunc: func(ctx context.Context, userID uuid.UUID) (usersdomain.Preferences, error) {
+			return usersdomain.Preferences{}, common.ErrNotFound
+		},
+	}
+}
+
+// readEntry decodes a JSON entry of a zip archive
+func readEntry(t *testing.T, zr *zip.ReadCloser, name string, dest interface{}) {
+	t.Helper()
+	file, err := zr.Open(name)
+	require.NoError(t, err, name)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err, name)
+	require.NoError(t, json.Unmarshal(content, dest), name)
+}
+
+func TestWorkerRun(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	user := usersdomain.User{ID: uuid.New(), Name: "Ada", Email: "ada@example.com"}
+	listID := uuid.New()
+
+	t.Run("success - archive written and completed", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "exports")
+		export := domain.DataExport{ID: uuid.New(), UserID: user.ID, Status: domain.StatusRunning}
+		store := &memoryStore{
+			pending: []domain.DataExport{export},
+			data: domain.UserData{
+				Identities: []domain.Identity{{AuthID: "auth0|ada", Provider: "auth0", Role: "user"}},
+				Lists:      []domain.List{{ID: listID, Title: "Groceries"}},
+				Tasks:      []domain.Task{{ID: uuid.New(), ListID: listID, Title: "Milk", Tags: []string{"dairy"}}},
+			},
+		}
+		worker := NewWorker(store, userStore(user), dir, 24*time.Hour, zap.NewNop().Sugar())
+		worker.now = func() time.Time { return now }
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		require.Len(t, store.completed, 1)
+		completed := store.completed[0]
+		assert.Equal(t, export.ID, completed.ID)
+		assert.Equal(t, filepath.Join(dir, export.ID.String()+".zip"), completed.FilePath)
+		assert.Equal(t, now.Add(24*time.Hour), completed.ExpiresAt)
+
+		info, err := os.Stat(completed.FilePath)
+		require.NoError(t, err)
+		assert.Equal(t, info.Size(), completed.SizeBytes)
+
+		// Only the archive is left behind
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		zr, err := zip.OpenReader(completed.FilePath)
+		require.NoError(t, err)
+		defer zr.Close()
+
+		var gotUser usersdomain.User
+		readEntry(t, zr, domain.ArchiveUser, &gotUser)
+		assert.Equal(t, user.Email, gotUser.Email)
+
+		var prefs usersdomain.Preferences
+		readEntry(t, zr, domain.ArchivePreferences, &prefs)
+		assert.Equal(t, usersdomain.DefaultTimeZone, prefs.TimeZone)
+
+		var identities []domain.Identity
+		readEntry(t, zr, domain.ArchiveIdentities, &identities)
+		assert.Equal(t, store.data.Identities, identities)
+
+		var lists []domain.List
+		readEntry(t, zr, domain.ArchiveLists, &lists)
+		assert.Equal(t, "Groceries", lists[0].Title)
+
+		var tasks []domain.Task
+		readEntry(t, zr, domain.ArchiveTasks, &tasks)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "Milk", tasks[0].Title)
+		assert.Equal(t, []string{"dairy"}, tasks[0].Tags)
+	})
+
+	t.Run("failure - export marked failed", func(t *testing.T) {
+		dir := t.TempDir()
+		export := domain.DataExport{ID: uuid.New(), UserID: user.ID}
+		store := &memoryStore{pending: []domain.DataExport{export}, dataErr: errors.New("db down")}
+		worker := NewWorker(store, userStore(user), dir, time.Hour, zap.NewNop().Sugar())
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		assert.Empty(t, store.completed)
+		assert.Contains(t, store.failed[export.ID], "db down")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("success - archive removed when the user was erased while it was written", func(t *testing.T) {
+		dir := t.TempDir()
+		export := domain.DataExport{ID: uuid.New(), UserID: user.ID}
+		store := &memoryStore{pending: []domain.DataExport{export}, erased: true}
+		worker := NewWorker(store, userStore(user), dir, time.Hour, zap.NewNop().Sugar())
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		assert.Empty(t, store.completed)
+		assert.Empty(t, store.failed)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("success - expired archives removed", func(t *testing.T) {
+		dir := t.TempDir()
+		expired := filepath.Join(dir, "expired.zip")
+		require.NoError(t, os.WriteFile(expired, []byte("zip"), 0o600))
+		store := &memoryStore{expired: []string{expired, filepath.Join(dir, "already-gone.zip")}}
+		worker := NewWorker(store, userStore(user), dir, time.Hour, zap.NewNop().Sugar())
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		assert.NoFileExists(t, expired)
+	})
+}
+
+func TestWriteArchiveEmpty(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "empty.zip")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+
+	require.NoError(t, WriteArchive(file, Archive{}, time.Now()))
+	require.NoError(t, file.Close())
+
+	zr, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer zr.Close()
+
+	// Missing records are written as empty arrays rather than null
+	for _, name := range []string{domain.ArchiveIdentities, domain.ArchiveLists, domain.ArchiveTasks} {
+		var entries []json.RawMessage
+		readEntry(t, zr, name, &entries)
+		assert.NotNil(t, entries, name)
+		assert.Empty(t, entries, name)
+	}
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/services"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type Handler struct {
+	service domain.Service
+	logger  *zap.SugaredLogger
+}
+
+// New initializes a new privacy Handler instance
+func New(service domain.Service, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RequestExportHandler queues an export of the caller's data.
+// The response points at the export, which can be polled until its archive is ready.
+func (h *Handler) RequestExportHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.authorizeUser(w, r, "RequestExportHandler")
+	if !ok {
+		return
+	}
+
+	// Call the service layer
+	export, err := h.service.RequestExport(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return the queued export as JSON
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/users/"+userID.String()+"/exports/"+export.ID.String())
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		h.logger.Errorw("RequestExportHandler failed: failed to encode response", "id", export.ID, "error", err)
+	}
+}
+
+// GetExportHandler reports the status of one of the caller's exports.
+func (h *Handler) GetExportHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.authorizeUser(w, r, "GetExportHandler")
+	if !ok {
+		return
+	}
+
+	// Extract validated export ID from context
+	exportID, ok := r.Context().Value(exportIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw("GetExportHandler failed: export ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Call the service layer
+	export, err := h.service.GetExport(r.Context(), exportID, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		h.logger.Errorw("GetExportHandler failed: failed to encode response", "id", exportID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// DownloadExportHandler serves the zip archive of one of the caller's completed exports.
+func (h *Handler) DownloadExportHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.authorizeUser(w, r, "DownloadExportHandler")
+	if !ok {
+		return
+	}
+
+	// Extract validated export ID from context
+	exportID, ok := r.Context().Value(exportIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw("DownloadExportHandler failed: export ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Call the service layer
+	export, archive, err := h.service.OpenExport(r.Context(), exportID, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrExportNotReady) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer archive.Close()
+
+	w.Header().Set("Content-Type", domain.ArchiveContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "export-" + export.ID.String() + ".zip",
+	}))
+
+	modified := export.UpdatedAt
+	if export.CompletedAt != nil {
+		modified = *export.CompletedAt
+	}
+	http.ServeContent(w, r, "", modified, archive)
+}
+
+// EraseAccountHandler deletes the caller's account and everything stored about them.
+func (h *Handler) EraseAccountHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.authorizeUser(w, r, "EraseAccountHandler")
+	if !ok {
+		return
+	}
+
+	// Call the service layer
+	if err := h.service.EraseUser(r.Context(), userID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeUser returns the user ID from the path once the caller is identified as that user.
+// Otherwise it writes the error response and returns false.
+func (h *Handler) authorizeUser(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	// The caller must be identified to act on their own data
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw(name + " failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	// Extract validated user ID from context
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw(name + " failed: user ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return uuid.Nil, false
+	}
+
+	if userID != callerID {
+		h.logger.Warnw(name+" failed: caller is not the requested user", "caller_id", callerID, "user_id", userID)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/privacymock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware/testutils"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/services"
+
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// HandlerTestSuite holds shared test dependencies
+type HandlerTestSuite struct {
+	mockService *privacymock.ServiceMock
+	router      http.Handler
+	callerID    uuid.UUID
+}
+
+// SetupSuite wires the handlers behind the identity middleware
+func SetupSuite() *HandlerTestSuite {
+	mockService := &privacymock.ServiceMock{}
+	handler := New(mockService, zap.NewNop().Sugar())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/{id}/exports", VerifyUserPath(handler.RequestExportHandler))
+	mux.HandleFunc("GET /users/{id}/exports/{exportID}", VerifyExportPath(handler.GetExportHandler))
+	mux.HandleFunc("GET /users/{id}/exports/{exportID}/download", VerifyExportPath(handler.DownloadExportHandler))
+	mux.HandleFunc("DELETE /users/{id}/account", VerifyUserPath(handler.EraseAccountHandler))
+
+	return &HandlerTestSuite{
+		mockService: mockService,
+		router:      testutils.Identity(mux),
+		callerID:    uuid.New(),
+	}
+}
+
+func (s *HandlerTestSuite) do(method, path string, callerID uuid.UUID) *httptest.ResponseRecorder {
+	req := testutils.NewRequest(method, path, nil, callerID)
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, req)
+	return rr
+}
+
+// archive is an in-memory export archive
+type archive struct {
+	*bytes.Reader
+}
+
+func (archive) Close() error { return nil }
+
+func TestRequestExportHandler(t *testing.T) {
+	t.Run("success - accepted with location", func(t *testing.T) {
+		suite := SetupSuite()
+		exportID := uuid.New()
+		suite.mockService.RequestExportFunc = func(ctx context.Context, userID uuid.UUID) (domain.DataExport, error) {
+			assert.Equal(t, suite.callerID, userID)
+			return domain.DataExport{ID: exportID, UserID: userID, Status: domain.StatusPending, FilePath: "/secret/path.zip"}, nil
+		}
+
+		rr := suite.do(http.MethodPost, "/users/"+suite.callerID.String()+"/exports", suite.callerID)
+
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Equal(t, "/users/"+suite.callerID.String()+"/exports/"+exportID.String(), rr.Header().Get("Location"))
+		assert.NotContains(t, rr.Body.String(), "/secret/path.zip")
+
+		var export domain.DataExport
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &export))
+		assert.Equal(t, domain.StatusPending, export.Status)
+	})
+
+	t.Run("failure - another user's data", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodPost, "/users/"+uuid.NewString()+"/exports", suite.callerID)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Empty(t, suite.mockService.RequestExportCalls())
+	})
+
+	t.Run("failure - anonymous caller", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodPost, "/users/"+uuid.NewString()+"/exports", uuid.Nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("failure - invalid user ID", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodPost, "/users/nope/exports", suite.callerID)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetExportHandler(t *testing.T) {
+	t.Run("success - status returned", func(t *testing.T) {
+		suite := SetupSuite()
+		exportID := uuid.New()
+		suite.mockService.GetExportFunc = func(ctx context.Context, id, userID uuid.UUID) (domain.DataExport, error) {
+			assert.Equal(t, exportID, id)
+			assert.Equal(t, suite.callerID, userID)
+			return domain.DataExport{ID: id, Status: domain.StatusRunning}, nil
+		}
+
+		rr := suite.do(http.MethodGet, "/users/"+suite.callerID.String()+"/exports/"+exportID.String(), suite.callerID)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), domain.StatusRunning)
+	})
+
+	t.Run("failure - not found", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.GetExportFunc = func(ctx context.Context, id, userID uuid.UUID) (domain.DataExport, error) {
+			return domain.DataExport{}, common.ErrNotFound
+		}
+
+		rr := suite.do(http.MethodGet, "/users/"+suite.callerID.String()+"/exports/"+uuid.NewString(), suite.callerID)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("failure - invalid export ID", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodGet, "/users/"+suite.callerID.String()+"/exports/nope", suite.callerID)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestDownloadExportHandler(t *testing.T) {
+	t.Run("success - archive served", func(t *testing.T) {
+		suite := SetupSuite()
+		exportID := uuid.New()
+		suite.mockService.OpenExportFunc = func(ctx context.Context, id, userID uuid.UUID) (domain.DataExport, io.ReadSeekCloser, error) {
+			return domain.DataExport{ID: id, Status: domain.StatusCompleted, CompletedAt: common.Ptr(time.Now())}, archive{bytes.NewReader([]byte("PK zip"))}, nil
+		}
+
+		rr := suite.do(http.MethodGet, "/users/"+suite.callerID.String()+"/exports/"+exportID.String()+"/download", suite.callerID)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, domain.ArchiveContentType, rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=export-`+exportID.String()+`.zip`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "PK zip", rr.Body.String())
+	})
+
+	t.Run("failure - not ready", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.OpenExportFunc = func(ctx context.Context, id, userID uuid.UUID) (domain.DataExport, io.ReadSeekCloser, error) {
+			return domain.DataExport{}, nil, services.ErrExportNotReady
+		}
+
+		rr := suite.do(http.MethodGet, "/users/"+suite.callerID.String()+"/exports/"+uuid.NewString()+"/download", suite.callerID)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("failure - another user's export", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodGet, "/users/"+uuid.NewString()+"/exports/"+uuid.NewString()+"/download", suite.callerID)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Empty(t, suite.mockService.OpenExportCalls())
+	})
+}
+
+func TestEraseAccountHandler(t *testing.T) {
+	t.Run("success - erased", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.EraseUserFunc = func(ctx context.Context, userID uuid.UUID) error {
+			assert.Equal(t, suite.callerID, userID)
+			return nil
+		}
+
+		rr := suite.do(http.MethodDelete, "/users/"+suite.callerID.String()+"/account", suite.callerID)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Len(t, suite.mockService.EraseUserCalls(), 1)
+	})
+
+	t.Run("failure - not found", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.EraseUserFunc = func(ctx context.Context, userID uuid.UUID) error {
+			return common.ErrNotFound
+		}
+
+		rr := suite.do(http.MethodDelete, "/users/"+suite.callerID.String()+"/account", suite.callerID)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("failure - another user's account", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodDelete, "/users/"+uuid.NewString()+"/account", suite.callerID)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Empty(t, suite.mockService.EraseUserCalls())
+	})
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const (
+	userIDKey   = contextKey("userID")
+	exportIDKey = contextKey("exportID")
+)
+
+// VerifyUserPath extracts and validates the user UUID from `/users/{id}/...`
+func VerifyUserPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 2 || segments[0] != "users" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(segments[1])
+		if err != nil || id == uuid.Nil {
+			logger.Warnw("VerifyUserPath failed: invalid user ID", "id", segments[1])
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), userIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// VerifyExportPath extracts and validates the export UUID from `/users/{id}/exports/{exportID}/...`
+func VerifyExportPath(next http.HandlerFunc) http.HandlerFunc {
+	return VerifyUserPath(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 4 || segments[2] != "exports" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(segments[3])
+		if err != nil || id == uuid.Nil {
+			logger.Warnw("VerifyExportPath failed: invalid export ID", "id", segments[3])
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), exportIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,108 @@
+-- Queue a data export for a user
+-- name: CreateDataExport :one
+INSERT INTO data_exports (user_id)
+VALUES ($1)
+RETURNING *;
+
+-- Retrieve a data export, ensuring it belongs to the user
+-- name: GetDataExport :one
+SELECT *
+FROM data_exports
+WHERE id = $1 AND user_id = $2;
+
+-- Lease queued exports to this worker until lease_until.
+-- Rows locked by another worker are skipped; an expired lease makes a running export claimable again.
+-- name: ClaimDataExports :many
+UPDATE data_exports
+SET status = 'running',
+    lease_until = sqlc.arg('lease_until'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT id
+    FROM data_exports
+    WHERE status = 'pending'
+       OR (status = 'running' AND lease_until <= sqlc.arg('now'))
+    ORDER BY created_at
+    LIMIT sqlc.arg('max_exports')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- Record the archive produced for an export
+-- name: CompleteDataExport :execrows
+UPDATE data_exports
+SET status = 'completed',
+    file_path = $2,
+    size_bytes = $3,
+    lease_until = NULL,
+    completed_at = $4,
+    expires_at = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- Record why an export could not be produced
+-- name: FailDataExport :exec
+UPDATE data_exports
+SET status = 'failed',
+    error = $2,
+    lease_until = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- Delete exports whose archives expired, returning the archive locations
+-- name: DeleteExpiredDataExports :many
+DELETE FROM data_exports
+WHERE expires_at <= $1
+RETURNING file_path;
+
+-- List the archive locations of a user's exports
+-- name: ListDataExportFiles :many
+SELECT file_path
+FROM data_exports
+WHERE user_id = $1 AND file_path IS NOT NULL;
+
+-- List the identities linked to a user
+-- name: ListUserAuthIdentities :many
+SELECT *
+FROM auth_identities
+WHERE user_id = $1
+ORDER BY created_at, auth_id;
+
+-- List every todo list owned by a user
+-- name: ListUserTodoLists :many
+SELECT *
+FROM todolists
+WHERE user_id = $1
+ORDER BY created_at, id;
+
+-- List every task in a user's todo lists
+-- name: ListUserTasks :many
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = $1
+ORDER BY tasks.list_id, tasks.created_at, tasks.id;
+
+-- Delete the outbox events holding a user's data: events about the user, their lists and the tasks
+-- in those lists, including lists already deleted
+-- name: DeleteUserOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE (aggregate_type = 'user' AND aggregate_id = sqlc.arg('user_id'))
+   OR (aggregate_type = 'list' AND (payload -> 'list' ->> 'user_id')::uuid = sqlc.arg('user_id'))
+   OR (aggregate_type = 'task' AND (payload -> 'task' ->> 'list_id')::uuid IN (
+        SELECT id FROM todolists WHERE user_id = sqlc.arg('user_id')
+        UNION
+        SELECT aggregate_id FROM outbox_events
+        WHERE aggregate_type = 'list' AND (payload -> 'list' ->> 'user_id')::uuid = sqlc.arg('user_id')
+   ));
+
+-- Clear the recorded states of the audit entries about a user and their auth identities,
+-- keeping who did what and when
+-- name: RedactUserAuditEntries :execrows
+UPDATE audit_log
+SET before = NULL,
+    after = NULL
+WHERE (target_type = 'user' AND target_id = sqlc.arg('user_id'))
+   OR (target_type = 'auth_identity'
+       AND (before ->> 'user_id' = sqlc.arg('user_id') OR after ->> 'user_id' = sqlc.arg('user_id')));
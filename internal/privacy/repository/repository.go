@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/privacystore"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type repository struct {
+	pool  *pgxpool.Pool
+	query *privacystore.Queries
+}
+
+func New(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool:  pool,
+		query: privacystore.New(pool),
+	}
+}
+
+// CreateExport queues a data export for a user.
+func (r *repository) CreateExport(ctx context.Context, userID uuid.UUID) (domain.DataExport, error) {
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	export, err := r.query.CreateDataExport(ctx, pgUserID)
+	if err != nil {
+		// 23503 is PostgreSQL's foreign key violation error code: the user does not exist
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			return domain.DataExport{}, fmt.Errorf("user %s: %w", userID, common.ErrNotFound)
+		}
+		return domain.DataExport{}, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	return pgToDataExport(export)
+}
+
+// GetExport retrieves a data export owned by the user.
+func (r *repository) GetExport(ctx context.Context, id, userID uuid.UUID) (domain.DataExport, error) {
+	pgID, _ := common.ToPgUUID(id)
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	export, err := r.query.GetDataExport(ctx, privacystore.GetDataExportParams{ID: pgID, UserID: pgUserID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.DataExport{}, fmt.Errorf("data export %s: %w", id, common.ErrNotFound)
+	} else if err != nil {
+		return domain.DataExport{}, fmt.Errorf("data export %s: %w", id, common.ErrInternalServerError)
+	}
+
+	return pgToDataExport(export)
+}
+
+// ClaimExports leases up to limit queued exports to the caller until leaseUntil.
+func (r *repository) ClaimExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.DataExport, error) {
+	rows, err := r.query.ClaimDataExports(ctx, privacystore.ClaimDataExportsParams{
+		LeaseUntil: common.ToPgTimestamp(&leaseUntil),
+		Now:        common.ToPgTimestamp(&now),
+		MaxExports: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim data exports: %w", err)
+	}
+
+	results := make([]domain.DataExport, 0, len(rows))
+	for _, row := range rows {
+		result, err := pgToDataExport(row)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// CompleteExport records the archive written for an export. It returns common.ErrNotFound when the
+// export no longer exists, as when its user was erased while the archive was written.
+func (r *repository) CompleteExport(ctx context.Context, params domain.CompleteExportParams) error {
+	pgID, err := common.ToPgUUID(params.ID)
+	if err != nil {
+		return fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	rows, err := r.query.CompleteDataExport(ctx, privacystore.CompleteDataExportParams{
+		ID:          pgID,
+		FilePath:    common.ToPgText(&params.FilePath),
+		SizeBytes:   pgtype.Int8{Int64: params.SizeBytes, Valid: true},
+		CompletedAt: common.ToPgTimestamp(&params.CompletedAt),
+		ExpiresAt:   common.ToPgTimestamp(&params.ExpiresAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete data export: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("data export %s: %w", params.ID, common.ErrNotFound)
+	}
+	return nil
+}
+
+// FailExport records why an export could not be produced.
+func (r *repository) FailExport(ctx context.Context, id uuid.UUID, reason string) error {
+	pgID, err := common.ToPgUUID(id)
+	if err != nil {
+		return fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	if err := r.query.FailDataExport(ctx, privacystore.FailDataExportParams{
+		ID:    pgID,
+		Error: common.ToPgText(&reason),
+	}); err != nil {
+		return fmt.Errorf("failed to fail data export: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredExports deletes the exports that expired by now and returns their archive locations.
+func (r *repository) DeleteExpiredExports(ctx context.Context, now time.Time) ([]string, error) {
+	paths, err := r.query.DeleteExpiredDataExports(ctx, common.ToPgTimestamp(&now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired data exports: %w", err)
+	}
+	return pgToPaths(paths), nil
+}
+
+// ListExportFiles returns the archive locations of a user's exports.
+func (r *repository) ListExportFiles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	paths, err := r.query.ListDataExportFiles(ctx, pgUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data export files: %w", err)
+	}
+	return pgToPaths(paths), nil
+}
+
+// ListLists returns every todo list owned by the user.
+func (r *repository) ListLists(ctx context.Context, userID uuid.UUID) ([]domain.List, error) {
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	lists, err := r.query.ListUserTodoLists(ctx, pgUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todo lists: %w", err)
+	}
+	return pgToLists(lists)
+}
+
+// GetUserData reads the user's identities, lists and tasks from a single snapshot,
+// so an export never holds a task whose list it is missing.
+func (r *repository) GetUserData(ctx context.Context, userID uuid.UUID) (domain.UserData, error) {
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return domain.UserData{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Read-only, nothing to commit
+
+	query := r.query.WithTx(tx)
+
+	identities, err := query.ListUserAuthIdentities(ctx, pgUserID)
+	if err != nil {
+		return domain.UserData{}, fmt.Errorf("failed to list auth identities: %w", err)
+	}
+
+	lists, err := query.ListUserTodoLists(ctx, pgUserID)
+	if err != nil {
+		return domain.UserData{}, fmt.Errorf("failed to list todo lists: %w", err)
+	}
+
+	tasks, err := query.ListUserTasks(ctx, pgUserID)
+	if err != nil {
+		return domain.UserData{}, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	var data domain.UserData
+	data.Identities = pgToIdentities(identities)
+	if data.Lists, err = pgToLists(lists); err != nil {
+		return domain.UserData{}, err
+	}
+	if data.Tasks, err = pgToTasks(tasks); err != nil {
+		return domain.UserData{}, err
+	}
+	return data, nil
+}
+
+// RedactUser removes the user's data from the tables that outlive them in one transaction: the states
+// recorded by their audit entries are cleared and the outbox events about them, their lists and
+// tasks are deleted.
+func (r *repository) RedactUser(ctx context.Context, userID uuid.UUID) error {
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := r.query.WithTx(tx)
+
+	if _, err := query.RedactUserAuditEntries(ctx, userID.String()); err != nil {
+		return fmt.Errorf("failed to redact audit entries: %w", err)
+	}
+
+	if _, err := query.DeleteUserOutboxEvents(ctx, pgUserID); err != nil {
+		return fmt.Errorf("failed to delete outbox events: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
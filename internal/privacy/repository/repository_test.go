@@ -0,0 +1,239 @@
+//go:build unit
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/core/dbpool"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+	userrepo "github.com/henryhall897/golang-todo-app/internal/users/repository"
+	"github.com/henryhall897/golang-todo-app/pkg/dbtest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PrivacyTestSuite struct {
+	suite.Suite
+	pgt        *dbtest.PostgresTest
+	ctx        context.Context
+	repository *repository
+	lists      *todolist.Store
+	tasks      *tasks.Store
+	users      usersdomain.Repository
+	userID     uuid.UUID
+}
+
+func TestPrivacy(t *testing.T) {
+	suite.Run(t, &PrivacyTestSuite{})
+}
+
+func (p *PrivacyTestSuite) SetupSuite() {
+	p.ctx = context.Background()
+
+	var err error
+	p.pgt, err = dbtest.NewPostgresTest(p.ctx, zap.L(), "../../../database/migrations", &dbpool.Config{
+		Logging:      false,
+		Host:         "localhost",
+		Port:         "5432",
+		User:         "testuser",
+		Password:     "1234",
+		DatabaseName: "privacytestdb",
+		MaxConns:     1,
+		MinConns:     1,
+	})
+	p.Require().NoError(err)
+
+	err = p.pgt.MigrateUp()
+	p.Require().NoError(err)
+
+	p.repository = New(p.pgt.DB())
+	p.lists = todolist.New(p.pgt.DB())
+	p.tasks = tasks.New(p.pgt.DB())
+	p.users = userrepo.New(p.pgt.DB())
+}
+
+func (p *PrivacyTestSuite) SetupTest() {
+	err := p.pgt.DB().QueryRow(p.ctx, "INSERT INTO users (id, name, email) VALUES (gen_random_uuid(), $1, $2) RETURNING id", "Data Owner", "owner@example.com").Scan(&p.userID)
+	p.Require().NoError(err)
+}
+
+func (p *PrivacyTestSuite) TearDownSuite() {
+	p.Require().NoError(p.pgt.TearDown())
+}
+
+func (p *PrivacyTestSuite) TearDownTest() {
+	_, err := p.pgt.DB().Exec(p.ctx, "TRUNCATE TABLE users, audit_log, outbox_events CASCADE;")
+	p.Require().NoError(err)
+}
+
+func (p *PrivacyTestSuite) TestExportLifecycle() {
+	created, err := p.repository.CreateExport(p.ctx, p.userID)
+	p.Require().NoError(err)
+	p.Equal(domain.StatusPending, created.Status)
+
+	// Another user's export is invisible
+	_, err = p.repository.GetExport(p.ctx, created.ID, uuid.New())
+	p.ErrorIs(err, common.ErrNotFound)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	claimed, err := p.repository.ClaimExports(p.ctx, now, now.Add(domain.LeaseDuration), domain.BatchSize)
+	p.Require().NoError(err)
+	p.Require().Len(claimed, 1)
+	p.Equal(domain.StatusRunning, claimed[0].Status)
+
+	// A leased export is not handed out again until its lease expires
+	again, err := p.repository.ClaimExports(p.ctx, now, now.Add(domain.LeaseDuration), domain.BatchSize)
+	p.Require().NoError(err)
+	p.Empty(again)
+
+	expired, err := p.repository.ClaimExports(p.ctx, now.Add(domain.LeaseDuration), now.Add(2*domain.LeaseDuration), domain.BatchSize)
+	p.Require().NoError(err)
+	p.Len(expired, 1)
+
+	p.Require().NoError(p.repository.CompleteExport(p.ctx, domain.CompleteExportParams{
+		ID:          created.ID,
+		FilePath:    "/exports/archive.zip",
+		SizeBytes:   42,
+		CompletedAt: now,
+		ExpiresAt:   now.Add(time.Hour),
+	}))
+
+	completed, err := p.repository.GetExport(p.ctx, created.ID, p.userID)
+	p.Require().NoError(err)
+	p.Equal(domain.StatusCompleted, completed.Status)
+	p.Equal("/exports/archive.zip", completed.FilePath)
+	p.Equal(int64(42), *completed.SizeBytes)
+	p.Equal(now.Add(time.Hour), *completed.ExpiresAt)
+
+	files, err := p.repository.ListExportFiles(p.ctx, p.userID)
+	p.Require().NoError(err)
+	p.Equal([]string{"/exports/archive.zip"}, files)
+
+	// Nothing has expired yet
+	paths, err := p.repository.DeleteExpiredExports(p.ctx, now)
+	p.Require().NoError(err)
+	p.Empty(paths)
+
+	paths, err = p.repository.DeleteExpiredExports(p.ctx, now.Add(time.Hour))
+	p.Require().NoError(err)
+	p.Equal([]string{"/exports/archive.zip"}, paths)
+
+	_, err = p.repository.GetExport(p.ctx, created.ID, p.userID)
+	p.ErrorIs(err, common.ErrNotFound)
+
+	// A deleted export cannot be completed
+	err = p.repository.CompleteExport(p.ctx, domain.CompleteExportParams{ID: created.ID, FilePath: "/exports/archive.zip"})
+	p.ErrorIs(err, common.ErrNotFound)
+}
+
+func (p *PrivacyTestSuite) TestFailExport() {
+	created, err := p.repository.CreateExport(p.ctx, p.userID)
+	p.Require().NoError(err)
+
+	p.Require().NoError(p.repository.FailExport(p.ctx, created.ID, "disk full"))
+
+	failed, err := p.repository.GetExport(p.ctx, created.ID, p.userID)
+	p.Require().NoError(err)
+	p.Equal(domain.StatusFailed, failed.Status)
+	p.Equal("disk full", *failed.Error)
+}
+
+func (p *PrivacyTestSuite) TestCreateExportUnknownUser() {
+	_, err := p.repository.CreateExport(p.ctx, uuid.New())
+	p.ErrorIs(err, common.ErrNotFound)
+}
+
+func (p *PrivacyTestSuite) TestGetUserData() {
+	_, err := p.pgt.DB().Exec(p.ctx, "INSERT INTO auth_identities (auth_id, provider, user_id, role) VALUES ($1, $2, $3, $4)", "auth0|owner", "auth0", p.userID, "user")
+	p.Require().NoError(err)
+
+	list, err := p.lists.CreateTodoList(p.ctx, todolist.CreateTodoListParams{UserID: p.userID, Title: "Groceries"})
+	p.Require().NoError(err)
+	_, err = p.tasks.CreateTask(p.ctx, list.ID, "Milk", nil, nil, time.Now().Add(time.Hour), 1)
+	p.Require().NoError(err)
+
+	// Another user's data is not included
+	var otherID uuid.UUID
+	err = p.pgt.DB().QueryRow(p.ctx, "INSERT INTO users (id, name, email) VALUES (gen_random_uuid(), $1, $2) RETURNING id", "Other", "other@example.com").Scan(&otherID)
+	p.Require().NoError(err)
+	_, err = p.lists.CreateTodoList(p.ctx, todolist.CreateTodoListParams{UserID: otherID, Title: "Other"})
+	p.Require().NoError(err)
+
+	data, err := p.repository.GetUserData(p.ctx, p.userID)
+	p.Require().NoError(err)
+	p.Require().Len(data.Identities, 1)
+	p.Equal("auth0|owner", data.Identities[0].AuthID)
+	p.Require().Len(data.Lists, 1)
+	p.Equal(list.ID, data.Lists[0].ID)
+	p.Require().Len(data.Tasks, 1)
+	p.Equal("Milk", data.Tasks[0].Title)
+	p.Equal(list.ID, data.Tasks[0].ListID)
+
+	lists, err := p.repository.ListLists(p.ctx, p.userID)
+	p.Require().NoError(err)
+	p.Len(lists, 1)
+}
+
+func (p *PrivacyTestSuite) TestRedactUser() {
+	// Events and audit entries holding the user's data
+	_, _, err := p.users.UpdateUser(p.ctx, usersdomain.UpdateUserParams{ID: p.userID, Name: "Data Owner", Email: "owner@example.com"})
+	p.Require().NoError(err)
+	list, err := p.lists.CreateTodoList(p.ctx, todolist.CreateTodoListParams{UserID: p.userID, Title: "Groceries"})
+	p.Require().NoError(err)
+	_, err = p.tasks.CreateTask(p.ctx, list.ID, "Milk", nil, nil, time.Now().Add(time.Hour), 1)
+	p.Require().NoError(err)
+	_, err = p.pgt.DB().Exec(p.ctx, `INSERT INTO audit_log (actor_id, action, target_type, target_id, before, after) VALUES
+		($1, 'user.update', 'user', $2, '{"name":"Data Owner","email":"old@example.com"}', '{"name":"Data Owner","email":"owner@example.com"}'),
+		($1, 'auth_identity.create', 'auth_identity', 'auth0|owner', NULL, $3)`,
+		p.userID, p.userID.String(), `{"auth_id":"auth0|owner","user_id":"`+p.userID.String()+`"}`)
+	p.Require().NoError(err)
+
+	// Another user's data is kept
+	var otherID uuid.UUID
+	err = p.pgt.DB().QueryRow(p.ctx, "INSERT INTO users (id, name, email) VALUES (gen_random_uuid(), $1, $2) RETURNING id", "Other", "other@example.com").Scan(&otherID)
+	p.Require().NoError(err)
+	_, err = p.lists.CreateTodoList(p.ctx, todolist.CreateTodoListParams{UserID: otherID, Title: "Other"})
+	p.Require().NoError(err)
+	_, err = p.pgt.DB().Exec(p.ctx, `INSERT INTO audit_log (actor_id, action, target_type, target_id, after) VALUES ($1, 'user.create', 'user', $2, '{"email":"other@example.com"}')`, otherID, otherID.String())
+	p.Require().NoError(err)
+
+	p.Require().NoError(p.repository.RedactUser(p.ctx, p.userID))
+	_, err = p.users.DeleteUser(p.ctx, p.userID)
+	p.Require().NoError(err)
+
+	// No personal data is left in the audit trail or the outbox
+	for _, pii := range []string{"Data Owner", "owner@example.com", "old@example.com", "auth0|owner", "Groceries", "Milk"} {
+		var leaks int
+		err = p.pgt.DB().QueryRow(p.ctx, `SELECT
+			(SELECT count(*) FROM audit_log WHERE before::text LIKE '%' || $1 || '%' OR after::text LIKE '%' || $1 || '%') +
+			(SELECT count(*) FROM outbox_events WHERE payload::text LIKE '%' || $1 || '%')`, pii).Scan(&leaks)
+		p.Require().NoError(err)
+		p.Zero(leaks, pii)
+	}
+
+	// Who did what is kept, and the deletion is still published
+	var entries, deleted int
+	err = p.pgt.DB().QueryRow(p.ctx, "SELECT count(*) FROM audit_log WHERE actor_id = $1", p.userID).Scan(&entries)
+	p.Require().NoError(err)
+	p.Equal(2, entries)
+	err = p.pgt.DB().QueryRow(p.ctx, "SELECT count(*) FROM outbox_events WHERE aggregate_id = $1 AND event_type = 'user.deleted'", p.userID).Scan(&deleted)
+	p.Require().NoError(err)
+	p.Equal(1, deleted)
+
+	var otherAudit, otherEvents int
+	err = p.pgt.DB().QueryRow(p.ctx, "SELECT count(*) FROM audit_log WHERE after::text LIKE '%other@example.com%'").Scan(&otherAudit)
+	p.Require().NoError(err)
+	p.Equal(1, otherAudit)
+	err = p.pgt.DB().QueryRow(p.ctx, "SELECT count(*) FROM outbox_events WHERE payload::text LIKE '%Other%'").Scan(&otherEvents)
+	p.Require().NoError(err)
+	p.Equal(1, otherEvents)
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/privacystore"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pgToDataExport converts a privacystore.DataExport to a domain.DataExport
+func pgToDataExport(pg privacystore.DataExport) (domain.DataExport, error) {
+	id, err := common.FromPgUUID(pg.ID)
+	if err != nil {
+		return domain.DataExport{}, fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	userID, err := common.FromPgUUID(pg.UserID)
+	if err != nil {
+		return domain.DataExport{}, fmt.Errorf("failed to transform user_id uuid: %w", err)
+	}
+
+	var sizeBytes *int64
+	if pg.SizeBytes.Valid {
+		sizeBytes = common.Ptr(pg.SizeBytes.Int64)
+	}
+
+	var filePath string
+	if pg.FilePath.Valid {
+		filePath = pg.FilePath.String
+	}
+
+	return domain.DataExport{
+		ID:          id,
+		UserID:      userID,
+		Status:      pg.Status,
+		FilePath:    filePath,
+		SizeBytes:   sizeBytes,
+		Error:       common.FromPgText(pg.Error),
+		CreatedAt:   common.FromPgTimestamp(pg.CreatedAt),
+		UpdatedAt:   common.FromPgTimestamp(pg.UpdatedAt),
+		CompletedAt: optionalTime(pg.CompletedAt),
+		ExpiresAt:   optionalTime(pg.ExpiresAt),
+	}, nil
+}
+
+// pgToIdentities converts privacystore.AuthIdentity rows to domain.Identity values
+func pgToIdentities(pg []privacystore.AuthIdentity) []domain.Identity {
+	identities := make([]domain.Identity, 0, len(pg))
+	for _, identity := range pg {
+		identities = append(identities, domain.Identity{
+			AuthID:    identity.AuthID,
+			Provider:  identity.Provider,
+			Role:      identity.Role,
+			CreatedAt: common.FromPgTimestamp(identity.CreatedAt),
+			UpdatedAt: common.FromPgTimestamp(identity.UpdatedAt),
+		})
+	}
+	return identities
+}
+
+// pgToLists converts privacystore.Todolist rows to domain.List values
+func pgToLists(pg []privacystore.Todolist) ([]domain.List, error) {
+	lists := make([]domain.List, 0, len(pg))
+	for _, list := range pg {
+		id, err := common.FromPgUUID(list.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform list id uuid: %w", err)
+		}
+
+		lists = append(lists, domain.List{
+			ID:          id,
+			Title:       list.Title,
+			Description: common.FromPgText(list.Description),
+			CreatedAt:   common.FromPgTimestamp(list.CreatedAt),
+			UpdatedAt:   common.FromPgTimestamp(list.UpdatedAt),
+		})
+	}
+	return lists, nil
+}
+
+// pgToTasks converts privacystore.ListUserTasksRow rows to domain.Task values
+func pgToTasks(pg []privacystore.ListUserTasksRow) ([]domain.Task, error) {
+	tasks := make([]domain.Task, 0, len(pg))
+	for _, task := range pg {
+		id, err := common.FromPgUUID(task.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform task id uuid: %w", err)
+		}
+
+		listID, err := common.FromPgUUID(task.ListID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform task list_id uuid: %w", err)
+		}
+
+		tags := task.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+
+		tasks = append(tasks, domain.Task{
+			ID:          id,
+			ListID:      listID,
+			Title:       task.Title,
+			Description: common.FromPgText(task.Description),
+			Status:      common.FromPgText(task.Status),
+			Priority:    common.FromPgInt4(task.Priority),
+			DueDate:     optionalTime(task.DueDate),
+			CompletedAt: optionalTime(task.CompletedAt),
+			CreatedAt:   common.FromPgTimestamp(task.CreatedAt),
+			UpdatedAt:   common.FromPgTimestamp(task.UpdatedAt),
+			Tags:        tags,
+		})
+	}
+	return tasks, nil
+}
+
+// pgToPaths drops the NULL entries of a list of archive locations
+func pgToPaths(pg []pgtype.Text) []string {
+	paths := make([]string, 0, len(pg))
+	for _, path := range pg {
+		if path.Valid {
+			paths = append(paths, path.String)
+		}
+	}
+	return paths
+}
+
+// optionalTime converts a nullable timestamp, keeping NULL as nil
+func optionalTime(pg pgtype.Timestamp) *time.Time {
+	if !pg.Valid {
+		return nil
+	}
+	return common.Ptr(common.FromPgTimestamp(pg))
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/henryhall897/golang-todo-app/internal/privacy/handler"
+)
+
+// RegisterRoutes sets up data export and account erasure routes. Every route acts on the caller's own account;
+// the patterns are more specific than the users feature's `/users/` pattern.
+func RegisterRoutes(router *http.ServeMux, h *handler.Handler) {
+	// Handle `/users/{id}/exports` (Request Export)
+	router.HandleFunc("/users/{id}/exports", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			handler.VerifyUserPath(h.RequestExportHandler).ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+
+	// Handle `/users/{id}/exports/{exportID}` (Get Export)
+	router.HandleFunc("/users/{id}/exports/{exportID}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			handler.VerifyExportPath(h.GetExportHandler).ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+
+	// Handle `/users/{id}/exports/{exportID}/download` (Download Export)
+	router.HandleFunc("/users/{id}/exports/{exportID}/download", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			handler.VerifyExportPath(h.DownloadExportHandler).ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+
+	// Handle `/users/{id}/account` (Erase Account)
+	router.HandleFunc("/users/{id}/account", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			handler.VerifyUserPath(h.EraseAccountHandler).ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+}
@@ -0,0 +1,8 @@
+package services
+
+import "errors"
+
+// Service-level errors (handler should only see these)
+var (
+	ErrExportNotReady = errors.New("data export is not ready")
+)
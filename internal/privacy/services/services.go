@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+
+	"go.uber.org/zap"
+)
+
+type service struct {
+	repo    domain.Repository
+	users   domain.UserStore
+	purger  domain.CachePurger
+	auditor auditdomain.Auditor
+	logger  *zap.SugaredLogger
+	now     func() time.Time
+}
+
+func New(repo domain.Repository, users domain.UserStore, purger domain.CachePurger, auditor auditdomain.Auditor, logger *zap.SugaredLogger) domain.Service {
+	return &service{
+		repo:    repo,
+		users:   users,
+		purger:  purger,
+		auditor: auditor,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// RequestExport queues an export of the user's data. The archive is written by the export worker.
+func (s *service) RequestExport(ctx context.Context, userID uuid.UUID) (domain.DataExport, error) {
+	export, err := s.repo.CreateExport(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return domain.DataExport{}, common.ErrNotFound
+		}
+
+		s.logger.Errorw("RequestExport failed: internal server error", "user_id", userID, "error", err)
+		return domain.DataExport{}, common.ErrInternalServerError
+	}
+
+	s.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionUserExport,
+		TargetType: auditdomain.TargetUser,
+		TargetID:   userID.String(),
+		After:      export,
+	})
+	return export, nil
+}
+
+// GetExport retrieves one of the user's exports.
+func (s *service) GetExport(ctx context.Context, id, userID uuid.UUID) (domain.DataExport, error) {
+	export, err := s.repo.GetExport(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return domain.DataExport{}, common.ErrNotFound
+		}
+
+		s.logger.Errorw("GetExport failed: internal server error", "export_id", id, "error", err)
+		return domain.DataExport{}, common.ErrInternalServerError
+	}
+	return export, nil
+}
+
+// OpenExport opens the archive of a completed export. The caller closes it.
+// An export that is still queued or failed returns ErrExportNotReady; one whose archive expired is not found.
+func (s *service) OpenExport(ctx context.Context, id, userID uuid.UUID) (domain.DataExport, io.ReadSeekCloser, error) {
+	export, err := s.GetExport(ctx, id, userID)
+	if err != nil {
+		return domain.DataExport{}, nil, err
+	}
+
+	if export.Status != domain.StatusCompleted || export.FilePath == "" {
+		return domain.DataExport{}, nil, ErrExportNotReady
+	}
+	if export.ExpiresAt != nil && !s.now().Before(*export.ExpiresAt) {
+		return domain.DataExport{}, nil, common.ErrNotFound
+	}
+
+	file, err := os.Open(export.FilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return domain.DataExport{}, nil, common.ErrNotFound
+		}
+
+		s.logger.Errorw("OpenExport failed: could not open archive", "export_id", id, "error", err)
+		return domain.DataExport{}, nil, common.ErrInternalServerError
+	}
+	return export, file, nil
+}
+
+// EraseUser deletes the user with everything stored about them: database rows cascade from the user,
+// the states recorded by their audit entries are cleared and the outbox events holding their data are
+// deleted, export archives are removed from disk and every cache entry derived from their data is purged.
+// The audit trail keeps who did what and when, and a tombstone recording the erasure without the erased data.
+func (s *service) EraseUser(ctx context.Context, userID uuid.UUID) error {
+	// The email and list IDs name cache entries, so read them before the rows are gone
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return common.ErrNotFound
+		}
+
+		s.logger.Errorw("EraseUser failed: could not read user", "user_id", userID, "error", err)
+		return common.ErrInternalServerError
+	}
+
+	lists, err := s.repo.ListLists(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("EraseUser failed: could not list todo lists", "user_id", userID, "error", err)
+		return common.ErrInternalServerError
+	}
+
+	files, err := s.repo.ListExportFiles(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("EraseUser failed: could not list export files", "user_id", userID, "error", err)
+		return common.ErrInternalServerError
+	}
+
+	// Redact first: once the user is deleted a failed request can no longer be retried
+	if err := s.repo.RedactUser(ctx, userID); err != nil {
+		s.logger.Errorw("EraseUser failed: could not redact user data", "user_id", userID, "error", err)
+		return common.ErrInternalServerError
+	}
+
+	if _, err := s.users.DeleteUser(ctx, userID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return common.ErrNotFound
+		}
+
+		s.logger.Errorw("EraseUser failed: could not delete user", "user_id", userID, "error", err)
+		return common.ErrInternalServerError
+	}
+
+	// The rows are gone; failing to clean up after them is logged rather than returned,
+	// since retrying the request would find no user to erase
+	for _, path := range files {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warnw("Failed to remove data export archive", "user_id", userID, "error", err)
+		}
+	}
+
+	subject := domain.Subject{UserID: userID, Email: user.Email}
+	for _, list := range lists {
+		subject.ListIDs = append(subject.ListIDs, list.ID)
+	}
+	if err := s.purger.PurgeUser(ctx, subject); err != nil {
+		s.logger.Warnw("Failed to purge cached user data", "user_id", userID, "error", err)
+	}
+
+	s.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionUserErase,
+		TargetType: auditdomain.TargetUser,
+		TargetID:   userID.String(),
+		After: domain.Tombstone{
+			UserID:   userID,
+			ErasedAt: s.now().UTC(),
+			Lists:    len(lists),
+			Exports:  len(files),
+		},
+	})
+	return nil
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/auditmock"
+	"github.com/henryhall897/golang-todo-app/gen/mocks/privacymock"
+	"github.com/henryhall897/golang-todo-app/gen/mocks/usersmock"
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/privacy/domain"
+	usersdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newService wires a service over the given mocks, with a no-op auditor when none is given
+func newService(repo *privacymock.RepositoryMock, users *usersmock.RepositoryMock, purger *privacymock.CachePurgerMock, auditor *auditmock.AuditorMock) *service {
+	if auditor == nil {
+		auditor = &auditmock.AuditorMock{RecordFunc: func(ctx context.Context, params auditdomain.RecordParams) {}}
+	}
+	return New(repo, users, purger, auditor, zap.NewNop().Sugar()).(*service)
+}
+
+func TestRequestExport(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success - queued and audited", func(t *testing.T) {
+		mockRepo := &privacymock.RepositoryMock{
+			CreateExportFunc: func(ctx context.Context, id uuid.UUID) (domain.DataExport, error) {
+				return domain.DataExport{ID: uuid.New(), UserID: id, Status: domain.StatusPending}, nil
+			},
+		}
+		auditor := &auditmock.AuditorMock{RecordFunc: func(ctx context.Context, params auditdomain.RecordParams) {}}
+		svc := newService(mockRepo, nil, nil, auditor)
+
+		export, err := svc.RequestExport(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, export.Status)
+		require.Len(t, auditor.RecordCalls(), 1)
+		assert.Equal(t, auditdomain.ActionUserExport, auditor.RecordCalls()[0].Params.Action)
+		assert.Equal(t, userID.String(), auditor.RecordCalls()[0].Params.TargetID)
+	})
+
+	t.Run("failure - user not found", func(t *testing.T) {
+		mockRepo := &privacymock.RepositoryMock{
+			CreateExportFunc: func(ctx context.Context, id uuid.UUID) (domain.DataExport, error) {
+				return domain.DataExport{}, common.ErrNotFound
+			},
+		}
+		svc := newService(mockRepo, nil, nil, nil)
+
+		_, err := svc.RequestExport(context.Background(), userID)
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+}
+
+func TestOpenExport(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	archive := filepath.Join(t.TempDir(), "export.zip")
+	require.NoError(t, os.WriteFile(archive, []byte("zip"), 0o600))
+
+	repoReturning := func(export domain.DataExport, err error) *privacymock.RepositoryMock {
+		return &privacymock.RepositoryMock{
+			GetExportFunc: func(ctx context.Context, id, owner uuid.UUID) (domain.DataExport, error) {
+				return export, err
+			},
+		}
+	}
+
+	t.Run("success - completed archive opened", func(t *testing.T) {
+		svc := newService(repoReturning(domain.DataExport{
+			Status:    domain.StatusCompleted,
+			FilePath:  archive,
+			ExpiresAt: common.Ptr(now.Add(time.Hour)),
+		}, nil), nil, nil, nil)
+		svc.now = func() time.Time { return now }
+
+		_, file, err := svc.OpenExport(context.Background(), uuid.New(), userID)
+		require.NoError(t, err)
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "zip", string(content))
+	})
+
+	t.Run("failure - not ready", func(t *testing.T) {
+		for _, status := range []string{domain.StatusPending, domain.StatusRunning, domain.StatusFailed} {
+			svc := newService(repoReturning(domain.DataExport{Status: status}, nil), nil, nil, nil)
+
+			_, _, err := svc.OpenExport(context.Background(), uuid.New(), userID)
+
+			assert.ErrorIs(t, err, ErrExportNotReady, status)
+		}
+	})
+
+	t.Run("failure - archive expired", func(t *testing.T) {
+		svc := newService(repoReturning(domain.DataExport{
+			Status:    domain.StatusCompleted,
+			FilePath:  archive,
+			ExpiresAt: common.Ptr(now),
+		}, nil), nil, nil, nil)
+		svc.now = func() time.Time { return now }
+
+		_, _, err := svc.OpenExport(context.Background(), uuid.New(), userID)
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+
+	t.Run("failure - archive missing", func(t *testing.T) {
+		svc := newService(repoReturning(domain.DataExport{
+			Status:   domain.StatusCompleted,
+			FilePath: filepath.Join(t.TempDir(), "missing.zip"),
+		}, nil), nil, nil, nil)
+
+		_, _, err := svc.OpenExport(context.Background(), uuid.New(), userID)
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+
+	t.Run("failure - export not found", func(t *testing.T) {
+		svc := newService(repoReturning(domain.DataExport{}, common.ErrNotFound), nil, nil, nil)
+
+		_, _, err := svc.OpenExport(context.Background(), uuid.New(), userID)
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+}
+
+func TestEraseUser(t *testing.T) {
+	userID := uuid.New()
+	listIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	// setup returns mocks for a user with two lists and one export archive on disk
+	setup := func(t *testing.T) (*privacymock.RepositoryMock, *usersmock.RepositoryMock, string) {
+		archive := filepath.Join(t.TempDir(), "export.zip")
+		require.NoError(t, os.WriteFile(archive, []byte("zip"), 0o600))
+
+		repo := &privacymock.RepositoryMock{
+			ListListsFunc: func(ctx context.Context, id uuid.UUID) ([]domain.List, error) {
+				return []domain.List{{ID: listIDs[0]}, {ID: listIDs[1]}}, nil
+			},
+			ListExportFilesFunc: func(ctx context.Context, id uuid.UUID) ([]string, error) {
+				return []string{archive}, nil
+			},
+			RedactUserFunc: func(ctx context.Context, id uuid.UUID) error {
+				return nil
+			},
+		}
+		users := &usersmock.RepositoryMock{
+			GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (usersdomain.User, error) {
+				return usersdomain.User{ID: id, Name: "Erased", Email: "erased@example.com"}, nil
+			},
+			DeleteUserFunc: func(ctx context.Context, id uuid.UUID) (usersdomain.User, error) {
+				return usersdomain.User{ID: id, Name: "Erased", Email: "erased@example.com"}, nil
+			},
+		}
+		return repo, users, archive
+	}
+
+	t.Run("success - rows, archives and cache removed with a tombstone", func(t *testing.T) {
+		repo, users, archive := setup(t)
+		purger := &privacymock.CachePurgerMock{
+			PurgeUserFunc: func(ctx context.Context, subject domain.Subject) error { return nil },
+		}
+		auditor := &auditmock.AuditorMock{RecordFunc: func(ctx context.Context, params auditdomain.RecordParams) {}}
+		svc := newService(repo, users, purger, auditor)
+
+		err := svc.EraseUser(context.Background(), userID)
+		require.NoError(t, err)
+
+		require.Len(t, repo.RedactUserCalls(), 1)
+		assert.Equal(t, userID, repo.RedactUserCalls()[0].UserID)
+		require.Len(t, users.DeleteUserCalls(), 1)
+		assert.NoFileExists(t, archive)
+
+		require.Len(t, purger.PurgeUserCalls(), 1)
+		assert.Equal(t, domain.Subject{UserID: userID, Email: "erased@example.com", ListIDs: listIDs}, purger.PurgeUserCalls()[0].Subject)
+
+		require.Len(t, auditor.RecordCalls(), 1)
+		params := auditor.RecordCalls()[0].Params
+		assert.Equal(t, auditdomain.ActionUserErase, params.Action)
+		assert.Nil(t, params.Before)
+		tombstone, ok := params.After.(domain.Tombstone)
+		require.True(t, ok)
+		assert.Equal(t, userID, tombstone.UserID)
+		assert.Equal(t, 2, tombstone.Lists)
+		assert.Equal(t, 1, tombstone.Exports)
+	})
+
+	t.Run("success - purge failure is not returned", func(t *testing.T) {
+		repo, users, _ := setup(t)
+		purger := &privacymock.CachePurgerMock{
+			PurgeUserFunc: func(ctx context.Context, subject domain.Subject) error { return errors.New("redis down") },
+		}
+		auditor := &auditmock.AuditorMock{RecordFunc: func(ctx context.Context, params auditdomain.RecordParams) {}}
+		svc := newService(repo, users, purger, auditor)
+
+		err := svc.EraseUser(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.Len(t, auditor.RecordCalls(), 1)
+	})
+
+	t.Run("failure - user not found", func(t *testing.T) {
+		repo, users, archive := setup(t)
+		users.GetUserByIDFunc = func(ctx context.Context, id uuid.UUID) (usersdomain.User, error) {
+			return usersdomain.User{}, common.ErrNotFound
+		}
+		svc := newService(repo, users, &privacymock.CachePurgerMock{}, nil)
+
+		err := svc.EraseUser(context.Background(), userID)
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.Empty(t, users.DeleteUserCalls())
+		assert.FileExists(t, archive)
+	})
+
+	t.Run("failure - redaction fails, user kept", func(t *testing.T) {
+		repo, users, archive := setup(t)
+		repo.RedactUserFunc = func(ctx context.Context, id uuid.UUID) error {
+			return errors.New("db down")
+		}
+		purger := &privacymock.CachePurgerMock{}
+		svc := newService(repo, users, purger, nil)
+
+		err := svc.EraseUser(context.Background(), userID)
+
+		assert.ErrorIs(t, err, common.ErrInternalServerError)
+		assert.Empty(t, users.DeleteUserCalls())
+		assert.Empty(t, purger.PurgeUserCalls())
+		assert.FileExists(t, archive)
+	})
+
+	t.Run("failure - delete fails, nothing purged", func(t *testing.T) {
+		repo, users, archive := setup(t)
+		users.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) (usersdomain.User, error) {
+			return usersdomain.User{}, errors.New("db down")
+		}
+		purger := &privacymock.CachePurgerMock{}
+		svc := newService(repo, users, purger, nil)
+
+		err := svc.EraseUser(context.Background(), userID)
+
+		assert.ErrorIs(t, err, common.ErrInternalServerError)
+		assert.Empty(t, purger.PurgeUserCalls())
+		assert.FileExists(t, archive)
+	})
+}
@@ -0,0 +1,79 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+)
+
+// Broadcaster publishes task events to the subscribers of their list.
+type Broadcaster struct {
+	client redis.UniversalClient
+	logger *zap.SugaredLogger
+}
+
+// NewBroadcaster initializes a Broadcaster.
+func NewBroadcaster(client redis.UniversalClient, logger *zap.SugaredLogger) *Broadcaster {
+	return &Broadcaster{
+		client: client,
+		logger: logger,
+	}
+}
+
+// taskEventData is the part of a task event's data the broadcaster routes on
+type taskEventData struct {
+	Task struct {
+		ListID uuid.UUID `json:"list_id"`
+	} `json:"task"`
+}
+
+// Handle is an events.Handler. Task events are appended to their list's history and published on its
+// channel; other events are ignored. The bus delivers at least once, so a message may repeat.
+func (b *Broadcaster) Handle(ctx context.Context, event events.Event) error {
+	if event.AggregateType != events.AggregateTask {
+		return nil
+	}
+
+	var data taskEventData
+	if err := json.Unmarshal(event.Data, &data); err != nil || data.Task.ListID == uuid.Nil {
+		b.logger.Warnw("Dropping task event without a list", "id", event.ID, "type", event.Type, "error", err)
+		return nil
+	}
+	listID := data.Task.ListID
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+	}
+
+	// Append to the history first; its entry ID orders the event and lets clients resume after it
+	key := historyKey(listID)
+	cursor, err := b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: HistoryLength,
+		Values: map[string]interface{}{"event": payload},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to append event %s to list history: %w", event.ID, err)
+	}
+	if err := b.client.Expire(ctx, key, HistoryTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set list history expiry: %w", err)
+	}
+
+	message, err := json.Marshal(Message{Cursor: cursor, ListID: listID, Event: event})
+	if err != nil {
+		return fmt.Errorf("failed to encode message for event %s: %w", event.ID, err)
+	}
+	if err := b.client.Publish(ctx, channel(listID), message).Err(); err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+	}
+
+	b.logger.Debugw("Broadcast task event", "id", event.ID, "type", event.Type, "listID", listID, "cursor", cursor)
+	return nil
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+	"github.com/henryhall897/golang-todo-app/internal/realtime"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// LastEventIDHeader is sent by reconnecting EventSource clients with the ID of the last event they received.
+const LastEventIDHeader = "Last-Event-ID"
+
+type Handler struct {
+	hub       *realtime.Hub
+	presence  *realtime.Presence
+	lists     realtime.ListReader
+	store     tasks.Repository
+	upgrader  websocket.Upgrader
+	logger    *zap.SugaredLogger
+	heartbeat time.Duration
+}
+
+// New initializes a new realtime Handler instance.
+// WebSocket connections are accepted from the API's own origin and from allowedOrigin ("*" allows any).
+func New(hub *realtime.Hub, presence *realtime.Presence, lists realtime.ListReader, store tasks.Repository, allowedOrigin string, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		hub:      hub,
+		presence: presence,
+		lists:    lists,
+		store:    store,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			CheckOrigin:     checkOrigin(allowedOrigin),
+		},
+		logger:    logger,
+		heartbeat: realtime.HeartbeatInterval,
+	}
+}
+
+// authorizeList returns the caller and the list in the request context.
+// It writes an error response and returns false unless the caller owns the list.
+func (h *Handler) authorizeList(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, uuid.UUID, bool) {
+	// The caller must be identified to watch a list
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw(name + " failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	// Extract validated list ID from context
+	listID, ok := r.Context().Value(listIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Errorw(name + " failed: list ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	// Lists belonging to other users are reported as missing
+	if _, err := h.lists.GetTodoListByID(r.Context(), todolist.GetTodoListByIDParams{ID: listID, UserID: callerID}); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			h.logger.Warnw(name+" failed: list not found", "listID", listID, "callerID", callerID)
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return uuid.Nil, uuid.Nil, false
+		}
+		h.logger.Errorw(name+" failed: internal server error", "listID", listID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return callerID, listID, true
+}
+
+// ListEventsHandler streams a list's task changes as Server-Sent Events.
+// Each event's ID is its cursor in the list history; a client reconnecting with Last-Event-ID first
+// receives the events it missed. A `reset` event tells the client that events were lost and the
+// list should be reloaded. Comment lines are sent as heartbeats while the list is idle.
+func (h *Handler) ListEventsHandler(w http.ResponseWriter, r *http.Request) {
+	_, listID, ok := h.authorizeList(w, r, "ListEventsHandler")
+	if !ok {
+		return
+	}
+
+	// Subscribe before reading the history, so no event falls between the two
+	sub := h.hub.Subscribe(listID)
+	defer sub.Close()
+
+	var backlog []realtime.Message
+	var reset bool
+	lastCursor := r.Header.Get(LastEventIDHeader)
+	if lastCursor != "" {
+		if err := realtime.ParseCursor(lastCursor); err != nil {
+			h.logger.Warnw("ListEventsHandler failed: invalid Last-Event-ID header", "lastEventID", lastCursor)
+			http.Error(w, "Invalid Last-Event-ID header", http.StatusBadRequest)
+			return
+		}
+
+		var err error
+		backlog, reset, err = h.hub.Replay(r.Context(), listID, lastCursor)
+		if err != nil {
+			h.logger.Errorw("ListEventsHandler failed: internal server error", "listID", listID, "error", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable response buffering in reverse proxies
+	w.WriteHeader(http.StatusOK)
+
+	stream := newEventStream(w)
+	if err := stream.send(fmt.Sprintf("retry: %d\n\n", realtime.RetryInterval.Milliseconds())); err != nil {
+		return
+	}
+	if reset {
+		if err := stream.send("event: reset\ndata: {}\n\n"); err != nil {
+			return
+		}
+	}
+	for _, message := range backlog {
+		if err := stream.message(message); err != nil {
+			return
+		}
+		lastCursor = message.Cursor
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := stream.send(": heartbeat\n\n"); err != nil {
+				return
+			}
+		case message, ok := <-sub.C:
+			if !ok {
+				// The hub stopped or dropped this subscriber; the client reconnects and resumes
+				return
+			}
+			// Presence and other ephemeral messages are only sent to WebSocket clients
+			if message.Cursor == "" {
+				continue
+			}
+			// Skip messages already sent from the history
+			if lastCursor != "" && !realtime.After(message.Cursor, lastCursor) {
+				continue
+			}
+			if err := stream.message(message); err != nil {
+				h.logger.Debugw("ListEventsHandler: client stream closed", "listID", listID, "error", err)
+				return
+			}
+			lastCursor = message.Cursor
+		}
+	}
+}
+
+// eventStream writes Server-Sent Events, flushing each one to the client
+type eventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func newEventStream(w http.ResponseWriter) *eventStream {
+	return &eventStream{w: w, rc: http.NewResponseController(w)}
+}
+
+// message writes a realtime message as an event named after the domain event type
+func (s *eventStream) message(message realtime.Message) error {
+	data, err := json.Marshal(message.Event)
+	if err != nil {
+		return err
+	}
+	return s.send(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", message.Cursor, message.Event.Type, data))
+}
+
+// send writes a raw chunk within the write timeout and flushes it
+func (s *eventStream) send(chunk string) error {
+	if err := s.rc.SetWriteDeadline(time.Now().Add(realtime.WriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := s.w.Write([]byte(chunk)); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/realtimemock"
+	"github.com/henryhall897/golang-todo-app/gen/mocks/tasksmock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/events"
+	"github.com/henryhall897/golang-todo-app/internal/middleware/testutils"
+	"github.com/henryhall897/golang-todo-app/internal/realtime"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+)
+
+// HandlerTestSuite holds shared test dependencies
+type HandlerTestSuite struct {
+	mockLists      *realtimemock.ListReaderMock
+	mockStore      *tasksmock.RepositoryMock
+	broadcaster    *realtime.Broadcaster
+	server         *httptest.Server
+	callerID       uuid.UUID
+	collaboratorID uuid.UUID // A second user allowed to open the list
+	listID         uuid.UUID
+}
+
+// SetupSuite runs a hub on miniredis and serves the handler behind the identity middleware
+func SetupSuite(t *testing.T) *HandlerTestSuite {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	logger := zap.NewNop().Sugar()
+
+	hub := realtime.NewHub(client, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { _ = hub.Run(ctx) }()
+	require.Eventually(t, func() bool {
+		return client.PubSubNumPat(context.Background()).Val() == 1
+	}, time.Second, 5*time.Millisecond)
+
+	s := &HandlerTestSuite{
+		mockLists:      &realtimemock.ListReaderMock{},
+		mockStore:      &tasksmock.RepositoryMock{},
+		broadcaster:    realtime.NewBroadcaster(client, logger),
+		callerID:       uuid.New(),
+		collaboratorID: uuid.New(),
+		listID:         uuid.New(),
+	}
+	s.mockLists.GetTodoListByIDFunc = func(ctx context.Context, params todolist.GetTodoListByIDParams) (todolist.TodoList, error) {
+		if params.ID == s.listID && (params.UserID == s.callerID || params.UserID == s.collaboratorID) {
+			return todolist.TodoList{ID: params.ID, UserID: params.UserID}, nil
+		}
+		return todolist.TodoList{}, common.ErrNotFound
+	}
+
+	handler := New(hub, realtime.NewPresence(client, logger), s.mockLists, s.mockStore, "https://app.example.com", logger)
+	handler.heartbeat = 50 * time.Millisecond
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /lists/{id}/events", VerifyListPath(handler.ListEventsHandler))
+	mux.HandleFunc("GET /lists/{id}/ws", VerifyListPath(handler.ListSocketHandler))
+	s.server = httptest.NewServer(testutils.Identity(mux))
+
+	t.Cleanup(func() {
+		cancel()
+		s.server.Close()
+	})
+	return s
+}
+
+// open starts a stream request; the caller must close the response body
+func (s *HandlerTestSuite) open(t *testing.T, listID uuid.UUID, callerID uuid.UUID, lastEventID string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, s.server.URL+"/lists/"+listID.String()+"/events", nil)
+	require.NoError(t, err)
+	testutils.SignAs(req.Header, callerID)
+	if lastEventID != "" {
+		req.Header.Set(LastEventIDHeader, lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// publish broadcasts a task event on the suite's list
+func (s *HandlerTestSuite) publish(t *testing.T, eventType string) events.Event {
+	t.Helper()
+	event, err := events.New(eventType, events.AggregateTask, uuid.New(), map[string]interface{}{
+		"task": map[string]interface{}{"list_id": s.listID},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.broadcaster.Handle(context.Background(), event))
+	return event
+}
+
+// sseEvent is a parsed Server-Sent Event; comment lines are collected separately
+type sseEvent struct {
+	id, name, data string
+	comments       []string
+}
+
+// readEvent reads the next dispatched event or heartbeat comment block from the stream
+func readEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return event
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "":
+			event.comments = append(event.comments, value)
+		case "id":
+			event.id = value
+		case "event":
+			event.name = value
+		case "data":
+			event.data = value
+		}
+	}
+}
+
+// nextEvent skips heartbeats until a named event arrives
+func nextEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+	for {
+		if event := readEvent(t, reader); event.name != "" {
+			return event
+		}
+	}
+}
+
+func TestListEventsHandler(t *testing.T) {
+	s := SetupSuite(t)
+
+	t.Run("success - streams live events with heartbeats", func(t *testing.T) {
+		resp := s.open(t, s.listID, s.callerID, "")
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+		reader := bufio.NewReader(resp.Body)
+		// The stream opens with the retry hint, sent once the subscription is in place
+		readEvent(t, reader)
+
+		published := s.publish(t, events.TaskCreated)
+		event := nextEvent(t, reader)
+		assert.Equal(t, events.TaskCreated, event.name)
+		assert.NoError(t, realtime.ParseCursor(event.id))
+
+		var received events.Event
+		require.NoError(t, json.Unmarshal([]byte(event.data), &received))
+		assert.Equal(t, published.ID, received.ID)
+
+		assert.Equal(t, []string{"heartbeat"}, readEvent(t, reader).comments)
+	})
+
+	t.Run("success - resumes after Last-Event-ID", func(t *testing.T) {
+		resp := s.open(t, s.listID, s.callerID, "")
+		reader := bufio.NewReader(resp.Body)
+		readEvent(t, reader)
+		s.publish(t, events.TaskCreated)
+		cursor := nextEvent(t, reader).id
+		resp.Body.Close()
+
+		// Events published while the client was away
+		missed := []events.Event{s.publish(t, events.TaskUpdated), s.publish(t, events.TaskDeleted)}
+
+		resp = s.open(t, s.listID, s.callerID, cursor)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		reader = bufio.NewReader(resp.Body)
+
+		for _, want := range missed {
+			event := nextEvent(t, reader)
+			assert.Equal(t, want.Type, event.name)
+			assert.True(t, realtime.After(event.id, cursor))
+			cursor = event.id
+		}
+
+		live := s.publish(t, events.TaskCompleted)
+		event := nextEvent(t, reader)
+		assert.Equal(t, live.Type, event.name)
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		resp := s.open(t, s.listID, uuid.Nil, "")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("failure - list of another user", func(t *testing.T) {
+		resp := s.open(t, s.listID, uuid.New(), "")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("failure - invalid Last-Event-ID", func(t *testing.T) {
+		resp := s.open(t, s.listID, s.callerID, "yesterday")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("failure - invalid list ID", func(t *testing.T) {
+		resp, err := http.Get(s.server.URL + "/lists/not-a-uuid/events")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const listIDKey = contextKey("listID")
+
+// VerifyListPath extracts and validates the list UUID from `/lists/{id}/...`
+func VerifyListPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 2 || segments[0] != "lists" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(segments[1])
+		if err != nil || id == uuid.Nil {
+			logger.Warnw("VerifyListPath failed: invalid list ID", "id", segments[1])
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), listIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
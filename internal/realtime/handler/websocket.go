@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/realtime"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// checkOrigin accepts requests without an Origin header, from the API's own host, and from allowedOrigin
+func checkOrigin(allowedOrigin string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || allowedOrigin == "*" || strings.EqualFold(origin, allowedOrigin) {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// ListSocketHandler opens a WebSocket for collaborative editing of a list.
+// Clients send task mutations (realtime.ClientMessage) and receive an ack or error for each, the list's task
+// events from every editor, and the list's viewers whenever someone joins or leaves.
+func (h *Handler) ListSocketHandler(w http.ResponseWriter, r *http.Request) {
+	callerID, listID, ok := h.authorizeList(w, r, "ListSocketHandler")
+	if !ok {
+		return
+	}
+
+	// Upgrade writes its own error response on failure
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Warnw("ListSocketHandler failed: upgrade rejected", "listID", listID, "error", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// Subscribe before joining, so the client receives its own arrival
+	sub := h.hub.Subscribe(listID)
+	defer sub.Close()
+
+	session, err := h.presence.Join(ctx, listID, callerID)
+	if err != nil {
+		h.logger.Errorw("ListSocketHandler failed: could not join presence", "listID", listID, "error", err)
+		closeSocket(conn, websocket.CloseInternalServerErr, "")
+		return
+	}
+	defer func() {
+		// Leave even when the request context is gone
+		leaveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), realtime.WriteTimeout)
+		defer cancel()
+		if err := session.Leave(leaveCtx); err != nil {
+			h.logger.Warnw("ListSocketHandler: failed to leave presence", "listID", listID, "error", err)
+		}
+	}()
+
+	replies := make(chan realtime.ServerMessage)
+	go h.readSocket(ctx, cancel, conn, callerID, listID, replies)
+
+	ping := time.NewTicker(realtime.SocketPingInterval)
+	defer ping.Stop()
+	refresh := time.NewTicker(realtime.PresenceRefreshInterval)
+	defer refresh.Stop()
+
+	// All writes happen on this goroutine
+	for {
+		var reply realtime.ServerMessage
+		select {
+		case <-ctx.Done():
+			closeSocket(conn, websocket.CloseNormalClosure, "")
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(realtime.WriteTimeout)); err != nil {
+				return
+			}
+			continue
+		case <-refresh.C:
+			if err := session.Refresh(ctx); err != nil {
+				h.logger.Warnw("ListSocketHandler: failed to refresh presence", "listID", listID, "error", err)
+			}
+			continue
+		case message, ok := <-sub.C:
+			if !ok {
+				// The hub stopped or dropped this subscriber; the client should reconnect and reload
+				closeSocket(conn, websocket.CloseTryAgainLater, "subscription ended")
+				return
+			}
+			var err error
+			if reply, err = socketMessage(message); err != nil {
+				h.logger.Warnw("ListSocketHandler: skipping invalid message", "listID", listID, "error", err)
+				continue
+			}
+		case reply = <-replies:
+		}
+
+		if err := conn.SetWriteDeadline(time.Now().Add(realtime.WriteTimeout)); err != nil {
+			return
+		}
+		if err := conn.WriteJSON(reply); err != nil {
+			h.logger.Debugw("ListSocketHandler: client connection closed", "listID", listID, "error", err)
+			return
+		}
+	}
+}
+
+// readSocket applies the client's mutations and queues a reply for each, until the connection fails
+func (h *Handler) readSocket(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, callerID, listID uuid.UUID, replies chan<- realtime.ServerMessage) {
+	defer cancel()
+
+	conn.SetReadLimit(realtime.SocketMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(realtime.SocketPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(realtime.SocketPongTimeout))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				h.logger.Debugw("ListSocketHandler: read failed", "listID", listID, "error", err)
+			}
+			return
+		}
+
+		var reply realtime.ServerMessage
+		var message realtime.ClientMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			reply = realtime.ServerMessage{Type: realtime.ReplyError, Error: "invalid message"}
+		} else {
+			reply = h.applyMutation(ctx, callerID, listID, message)
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// applyMutation performs a client's task mutation with the tasks store and builds its reply
+func (h *Handler) applyMutation(ctx context.Context, callerID, listID uuid.UUID, message realtime.ClientMessage) realtime.ServerMessage {
+	fail := func(reason string) realtime.ServerMessage {
+		return realtime.ServerMessage{Type: realtime.ReplyError, RequestID: message.RequestID, Error: reason}
+	}
+
+	// Validate the message before touching the store
+	switch message.Type {
+	case realtime.ActionCreateTask:
+		if message.Title == nil || strings.TrimSpace(*message.Title) == "" {
+			return fail("title is required")
+		}
+		if message.DueDate == nil {
+			return fail("due_date is required")
+		}
+	case realtime.ActionUpdateTask, realtime.ActionCompleteTask, realtime.ActionDeleteTask:
+		if message.TaskID == uuid.Nil {
+			return fail("task_id is required")
+		}
+	default:
+		return fail("unknown message type")
+	}
+
+	var task tasks.FullTask
+	var err error
+	switch message.Type {
+	case realtime.ActionCreateTask:
+		var priority int32
+		if message.Priority != nil {
+			priority = *message.Priority
+		}
+		task, err = h.store.CreateTask(ctx, listID, *message.Title, message.Description, message.Status, *message.DueDate, priority)
+
+	case realtime.ActionUpdateTask:
+		task, err = h.store.UpdateTask(ctx, tasks.UpdateTaskParams{
+			ID:          message.TaskID,
+			ListID:      listID,
+			UserID:      callerID,
+			Title:       message.Title,
+			Description: message.Description,
+			Status:      message.Status,
+			DueDate:     message.DueDate,
+			Priority:    message.Priority,
+			Tags:        message.Tags,
+		})
+
+	case realtime.ActionCompleteTask:
+		task, err = h.store.MarkTaskCompleted(ctx, tasks.UpdateTaskParams{ID: message.TaskID, ListID: listID, UserID: callerID})
+
+	case realtime.ActionDeleteTask:
+		var deleted []tasks.FullTask
+		deleted, err = h.store.DeleteTasks(ctx, tasks.DeleteTasksParams{IDs: []uuid.UUID{message.TaskID}, ListID: listID, UserID: callerID})
+		if err == nil && len(deleted) == 0 {
+			err = common.ErrNotFound
+		}
+		if err == nil {
+			task = deleted[0]
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return fail("task not found")
+		}
+		h.logger.Errorw("ListSocketHandler: mutation failed", "type", message.Type, "listID", listID, "taskID", message.TaskID, "error", err)
+		return fail("mutation failed")
+	}
+	return realtime.ServerMessage{Type: realtime.ReplyAck, RequestID: message.RequestID, Task: &task}
+}
+
+// socketMessage converts a hub message to the message sent to WebSocket clients
+func socketMessage(message realtime.Message) (realtime.ServerMessage, error) {
+	if message.Event.Type == realtime.EventPresence {
+		var data realtime.PresenceData
+		if err := json.Unmarshal(message.Event.Data, &data); err != nil {
+			return realtime.ServerMessage{}, err
+		}
+		return realtime.ServerMessage{Type: realtime.ReplyPresence, Viewers: data.Viewers}, nil
+	}
+
+	event := message.Event
+	return realtime.ServerMessage{Type: realtime.ReplyEvent, Cursor: message.Cursor, Event: &event}, nil
+}
+
+// closeSocket sends a close frame; the connection itself is closed by the caller
+func closeSocket(conn *websocket.Conn, code int, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(realtime.WriteTimeout))
+}
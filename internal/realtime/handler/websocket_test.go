@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+	"github.com/henryhall897/golang-todo-app/internal/middleware/testutils"
+	"github.com/henryhall897/golang-todo-app/internal/realtime"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+)
+
+// dial opens an in-process WebSocket client on the suite's list
+func (s *HandlerTestSuite) dial(t *testing.T, callerID uuid.UUID, header http.Header) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	if header == nil {
+		header = http.Header{}
+	}
+	testutils.SignAs(header, callerID)
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http") + "/lists/" + s.listID.String() + "/ws"
+	return websocket.DefaultDialer.Dial(url, header)
+}
+
+func (s *HandlerTestSuite) connect(t *testing.T, callerID uuid.UUID) *websocket.Conn {
+	t.Helper()
+	conn, resp, err := s.dial(t, callerID, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readReply(t *testing.T, conn *websocket.Conn) realtime.ServerMessage {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var reply realtime.ServerMessage
+	require.NoError(t, conn.ReadJSON(&reply))
+	return reply
+}
+
+// readUntil skips replies of other types, such as presence updates racing with the expected reply
+func readUntil(t *testing.T, conn *websocket.Conn, replyType string) realtime.ServerMessage {
+	t.Helper()
+	for {
+		if reply := readReply(t, conn); reply.Type == replyType {
+			return reply
+		}
+	}
+}
+
+// readPresence reads presence updates until one lists the expected number of viewers
+func readPresence(t *testing.T, conn *websocket.Conn, viewers int) []realtime.Viewer {
+	t.Helper()
+	for {
+		if reply := readUntil(t, conn, realtime.ReplyPresence); len(reply.Viewers) == viewers {
+			return reply.Viewers
+		}
+	}
+}
+
+func TestListSocketHandler(t *testing.T) {
+	s := SetupSuite(t)
+	title := "Buy milk"
+	due := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+
+	t.Run("success - presence of each viewer", func(t *testing.T) {
+		owner := s.connect(t, s.callerID)
+		viewers := readPresence(t, owner, 1)
+		assert.Equal(t, s.callerID, viewers[0].UserID)
+
+		collaborator := s.connect(t, s.collaboratorID)
+		viewers = readPresence(t, collaborator, 2)
+		assert.ElementsMatch(t, []uuid.UUID{s.callerID, s.collaboratorID}, []uuid.UUID{viewers[0].UserID, viewers[1].UserID})
+		readPresence(t, owner, 2)
+
+		// A second tab of the same user is one viewer with two connections
+		s.connect(t, s.callerID)
+		viewers = readPresence(t, owner, 2)
+		for _, viewer := range viewers {
+			if viewer.UserID == s.callerID {
+				assert.Equal(t, 2, viewer.Connections)
+			}
+		}
+
+		// Leaving is announced to the remaining viewers
+		require.NoError(t, collaborator.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+		viewers = readPresence(t, owner, 1)
+		assert.Equal(t, s.callerID, viewers[0].UserID)
+	})
+
+	t.Run("success - create task is acknowledged and broadcast to other editors", func(t *testing.T) {
+		created := tasks.FullTask{ID: uuid.New(), ListID: s.listID, Title: &title, DueDate: &due}
+		s.mockStore.CreateTaskFunc = func(ctx context.Context, lid uuid.UUID, gotTitle string, description, status *string, gotDue time.Time, prio int32) (tasks.FullTask, error) {
+			assert.Equal(t, s.listID, lid)
+			assert.Equal(t, title, gotTitle)
+			assert.True(t, due.Equal(gotDue))
+			return created, nil
+		}
+
+		editor := s.connect(t, s.callerID)
+		other := s.connect(t, s.collaboratorID)
+		readPresence(t, other, 2)
+
+		require.NoError(t, editor.WriteJSON(realtime.ClientMessage{Type: realtime.ActionCreateTask, RequestID: "req-1", Title: &title, DueDate: &due}))
+		ack := readUntil(t, editor, realtime.ReplyAck)
+		assert.Equal(t, "req-1", ack.RequestID)
+		require.NotNil(t, ack.Task)
+		assert.Equal(t, created.ID, ack.Task.ID)
+
+		// The store's domain event reaches every editor once the relay broadcasts it
+		event, err := events.New(events.TaskCreated, events.AggregateTask, created.ID, map[string]interface{}{"task": created})
+		require.NoError(t, err)
+		require.NoError(t, s.broadcaster.Handle(context.Background(), event))
+
+		for _, conn := range []*websocket.Conn{editor, other} {
+			reply := readUntil(t, conn, realtime.ReplyEvent)
+			require.NotNil(t, reply.Event)
+			assert.Equal(t, event.ID, reply.Event.ID)
+			assert.NoError(t, realtime.ParseCursor(reply.Cursor))
+		}
+	})
+
+	t.Run("success - update, complete and delete use the caller and list", func(t *testing.T) {
+		taskID := uuid.New()
+		stored := tasks.FullTask{ID: taskID, ListID: s.listID, Title: &title}
+		s.mockStore.UpdateTaskFunc = func(ctx context.Context, params tasks.UpdateTaskParams) (tasks.FullTask, error) {
+			assert.Equal(t, tasks.UpdateTaskParams{ID: taskID, ListID: s.listID, UserID: s.callerID, Title: &title}, params)
+			return stored, nil
+		}
+		s.mockStore.MarkTaskCompletedFunc = func(ctx context.Context, params tasks.UpdateTaskParams) (tasks.FullTask, error) {
+			assert.Equal(t, tasks.UpdateTaskParams{ID: taskID, ListID: s.listID, UserID: s.callerID}, params)
+			return stored, nil
+		}
+		s.mockStore.DeleteTasksFunc = func(ctx context.Context, params tasks.DeleteTasksParams) ([]tasks.FullTask, error) {
+			assert.Equal(t, tasks.DeleteTasksParams{IDs: []uuid.UUID{taskID}, ListID: s.listID, UserID: s.callerID}, params)
+			return []tasks.FullTask{stored}, nil
+		}
+
+		conn := s.connect(t, s.callerID)
+		for _, action := range []string{realtime.ActionUpdateTask, realtime.ActionCompleteTask, realtime.ActionDeleteTask} {
+			require.NoError(t, conn.WriteJSON(realtime.ClientMessage{Type: action, RequestID: action, TaskID: taskID, Title: titleFor(action, &title)}))
+			ack := readUntil(t, conn, realtime.ReplyAck)
+			assert.Equal(t, action, ack.RequestID)
+			assert.Equal(t, taskID, ack.Task.ID)
+		}
+	})
+
+	t.Run("failure - rejected messages", func(t *testing.T) {
+		s.mockStore.DeleteTasksFunc = func(ctx context.Context, params tasks.DeleteTasksParams) ([]tasks.FullTask, error) {
+			return nil, nil
+		}
+		conn := s.connect(t, s.callerID)
+
+		tests := []struct {
+			name    string
+			message interface{}
+			want    string
+		}{
+			{name: "unknown type", message: realtime.ClientMessage{Type: "task.archive"}, want: "unknown message type"},
+			{name: "missing title", message: realtime.ClientMessage{Type: realtime.ActionCreateTask, DueDate: &due}, want: "title is required"},
+			{name: "missing due date", message: realtime.ClientMessage{Type: realtime.ActionCreateTask, Title: &title}, want: "due_date is required"},
+			{name: "missing task ID", message: realtime.ClientMessage{Type: realtime.ActionUpdateTask}, want: "task_id is required"},
+			{name: "unknown task", message: realtime.ClientMessage{Type: realtime.ActionDeleteTask, TaskID: uuid.New()}, want: "task not found"},
+			{name: "invalid JSON", message: "not json", want: "invalid message"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if text, ok := tt.message.(string); ok {
+					require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(text)))
+				} else {
+					require.NoError(t, conn.WriteJSON(tt.message))
+				}
+				reply := readUntil(t, conn, realtime.ReplyError)
+				assert.Equal(t, tt.want, reply.Error)
+			})
+		}
+	})
+
+	t.Run("failure - list of another user", func(t *testing.T) {
+		_, resp, err := s.dial(t, uuid.New(), nil)
+		require.ErrorIs(t, err, websocket.ErrBadHandshake)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("failure - foreign origin", func(t *testing.T) {
+		_, resp, err := s.dial(t, s.callerID, http.Header{"Origin": {"https://evil.example.com"}})
+		require.ErrorIs(t, err, websocket.ErrBadHandshake)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("success - allowed origin", func(t *testing.T) {
+		conn, resp, err := s.dial(t, s.callerID, http.Header{"Origin": {"https://app.example.com"}})
+		require.NoError(t, err)
+		resp.Body.Close()
+		conn.Close()
+	})
+}
+
+// titleFor only sends a title with updates
+func titleFor(action string, title *string) *string {
+	if action == realtime.ActionUpdateTask {
+		return title
+	}
+	return nil
+}
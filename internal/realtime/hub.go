@@ -0,0 +1,196 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Hub delivers the messages published for each list to this replica's subscribers.
+type Hub struct {
+	client      redis.UniversalClient
+	logger      *zap.SugaredLogger
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription receives the messages of one list until it is closed.
+// C is closed when the subscription ends, either by Close, by the hub stopping,
+// or because the subscriber fell too far behind; the client should then resume from its last cursor.
+type Subscription struct {
+	ListID uuid.UUID
+	C      <-chan Message
+	ch     chan Message
+	hub    *Hub
+}
+
+// NewHub initializes a Hub. Messages are only delivered while Run is running.
+func NewHub(client redis.UniversalClient, logger *zap.SugaredLogger) *Hub {
+	return &Hub{
+		client:      client,
+		logger:      logger,
+		subscribers: make(map[uuid.UUID]map[*Subscription]struct{}),
+	}
+}
+
+// Run receives published messages until ctx is done, then ends every subscription.
+// A single pattern subscription serves all lists, so it is in place before any client subscribes.
+func (h *Hub) Run(ctx context.Context) error {
+	pubsub := h.client.PSubscribe(ctx, ChannelPrefix+"*")
+	defer func() { _ = pubsub.Close() }()
+	defer h.closeAll()
+
+	// Wait for the subscription to be confirmed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to list channels: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			h.dispatch(msg)
+		}
+	}
+}
+
+// dispatch decodes a pub/sub message and queues it for the list's subscribers
+func (h *Hub) dispatch(msg *redis.Message) {
+	listID, err := listIDFromChannel(msg.Channel)
+	if err != nil {
+		h.logger.Warnw("Ignoring message on invalid channel", "channel", msg.Channel, "error", err)
+		return
+	}
+
+	var message Message
+	if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+		h.logger.Warnw("Ignoring invalid message", "channel", msg.Channel, "error", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers[listID] {
+		select {
+		case sub.ch <- message:
+		default:
+			// The subscriber is not keeping up; end it rather than block every other subscriber
+			h.logger.Warnw("Dropping slow subscriber", "listID", listID)
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe registers a subscriber for a list's messages. The subscription must be closed when done.
+func (h *Hub) Subscribe(listID uuid.UUID) *Subscription {
+	ch := make(chan Message, SubscriberBuffer)
+	sub := &Subscription{ListID: listID, C: ch, ch: ch, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return sub
+	}
+	if h.subscribers[listID] == nil {
+		h.subscribers[listID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[listID][sub] = struct{}{}
+	return sub
+}
+
+// Close ends the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// remove unregisters a subscription and closes its channel; h.mu must be held
+func (h *Hub) remove(sub *Subscription) {
+	subs, ok := h.subscribers[sub.ListID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.ListID)
+	}
+	close(sub.ch)
+}
+
+// closeAll ends every subscription and refuses new ones
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			h.remove(sub)
+		}
+	}
+	h.closed = true
+}
+
+// Replay returns the retained messages of a list that come after the cursor, oldest first.
+// Reset is true when older messages the client has not seen were already trimmed from the history,
+// so the client should reload the list instead of relying on the replay alone.
+func (h *Hub) Replay(ctx context.Context, listID uuid.UUID, after string) (messages []Message, reset bool, err error) {
+	if err := ParseCursor(after); err != nil {
+		return nil, false, err
+	}
+
+	key := historyKey(listID)
+	entries, err := h.client.XRange(ctx, key, after, "+").Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read list history: %w", err)
+	}
+
+	// A full history whose oldest entry is newer than the cursor has lost events in between
+	if len(entries) > 0 && After(entries[0].ID, after) {
+		length, err := h.client.XLen(ctx, key).Result()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read list history length: %w", err)
+		}
+		reset = length >= HistoryLength
+	}
+
+	for _, entry := range entries {
+		if !After(entry.ID, after) {
+			continue // XRANGE is inclusive of the cursor itself
+		}
+		message, err := historyMessage(listID, entry)
+		if err != nil {
+			h.logger.Warnw("Skipping invalid history entry", "listID", listID, "cursor", entry.ID, "error", err)
+			continue
+		}
+		messages = append(messages, message)
+	}
+	return messages, reset, nil
+}
+
+// historyMessage converts a history stream entry to a Message
+func historyMessage(listID uuid.UUID, entry redis.XMessage) (Message, error) {
+	raw, ok := entry.Values["event"].(string)
+	if !ok {
+		return Message{}, fmt.Errorf("entry %s has no event", entry.ID)
+	}
+	message := Message{Cursor: entry.ID, ListID: listID}
+	if err := json.Unmarshal([]byte(raw), &message.Event); err != nil {
+		return Message{}, fmt.Errorf("failed to decode entry %s: %w", entry.ID, err)
+	}
+	return message, nil
+}
@@ -0,0 +1,217 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+)
+
+type hubTest struct {
+	client      *redis.Client
+	hub         *Hub
+	broadcaster *Broadcaster
+	stop        context.CancelFunc
+	done        chan error
+}
+
+// newHubTest starts a hub on a fresh miniredis and waits until it is subscribed
+func newHubTest(t *testing.T) *hubTest {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	logger := zap.NewNop().Sugar()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ht := &hubTest{
+		client:      client,
+		hub:         NewHub(client, logger),
+		broadcaster: NewBroadcaster(client, logger),
+		stop:        cancel,
+		done:        make(chan error, 1),
+	}
+	go func() { ht.done <- ht.hub.Run(ctx) }()
+	t.Cleanup(cancel)
+
+	require.Eventually(t, func() bool {
+		return client.PubSubNumPat(context.Background()).Val() == 1
+	}, time.Second, 5*time.Millisecond)
+	return ht
+}
+
+func taskEvent(t *testing.T, eventType string, listID uuid.UUID) events.Event {
+	t.Helper()
+	event, err := events.New(eventType, events.AggregateTask, uuid.New(), map[string]interface{}{
+		"task": map[string]interface{}{"list_id": listID, "title": "Write tests"},
+	})
+	require.NoError(t, err)
+	return event
+}
+
+func receive(t *testing.T, sub *Subscription) Message {
+	t.Helper()
+	select {
+	case message, ok := <-sub.C:
+		require.True(t, ok, "subscription closed")
+		return message
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+		return Message{}
+	}
+}
+
+func TestHubDelivery(t *testing.T) {
+	ht := newHubTest(t)
+	ctx := context.Background()
+	listID, otherListID := uuid.New(), uuid.New()
+
+	sub := ht.hub.Subscribe(listID)
+	defer sub.Close()
+	other := ht.hub.Subscribe(otherListID)
+	defer other.Close()
+
+	t.Run("success - task event reaches the list's subscribers", func(t *testing.T) {
+		event := taskEvent(t, events.TaskCreated, listID)
+		require.NoError(t, ht.broadcaster.Handle(ctx, event))
+
+		message := receive(t, sub)
+		assert.Equal(t, listID, message.ListID)
+		assert.Equal(t, event.ID, message.Event.ID)
+		assert.Equal(t, events.TaskCreated, message.Event.Type)
+		assert.NoError(t, ParseCursor(message.Cursor))
+		assert.Empty(t, other.C)
+	})
+
+	t.Run("success - other events are ignored", func(t *testing.T) {
+		event, err := events.New(events.ListUpdated, events.AggregateList, listID, nil)
+		require.NoError(t, err)
+		require.NoError(t, ht.broadcaster.Handle(ctx, event))
+
+		exists, err := ht.client.Exists(ctx, historyKey(listID)).Result()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), exists)
+		length, err := ht.client.XLen(ctx, historyKey(listID)).Result()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), length)
+	})
+
+	t.Run("success - closed subscription stops receiving", func(t *testing.T) {
+		closed := ht.hub.Subscribe(listID)
+		closed.Close()
+		closed.Close()
+
+		_, ok := <-closed.C
+		assert.False(t, ok)
+	})
+}
+
+func TestHubReplay(t *testing.T) {
+	ht := newHubTest(t)
+	ctx := context.Background()
+	listID := uuid.New()
+
+	sub := ht.hub.Subscribe(listID)
+	defer sub.Close()
+
+	var cursors []string
+	for i := 0; i < 3; i++ {
+		require.NoError(t, ht.broadcaster.Handle(ctx, taskEvent(t, events.TaskUpdated, listID)))
+		cursors = append(cursors, receive(t, sub).Cursor)
+	}
+
+	t.Run("success - events after the cursor", func(t *testing.T) {
+		messages, reset, err := ht.hub.Replay(ctx, listID, cursors[0])
+		require.NoError(t, err)
+		assert.False(t, reset)
+		require.Len(t, messages, 2)
+		assert.Equal(t, cursors[1], messages[0].Cursor)
+		assert.Equal(t, cursors[2], messages[1].Cursor)
+	})
+
+	t.Run("success - nothing after the latest cursor", func(t *testing.T) {
+		messages, reset, err := ht.hub.Replay(ctx, listID, cursors[2])
+		require.NoError(t, err)
+		assert.False(t, reset)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("success - reset when the history was trimmed", func(t *testing.T) {
+		trimmedID := uuid.New()
+		var first string
+		for i := 0; i < HistoryLength+1; i++ {
+			id, err := ht.client.XAdd(ctx, &redis.XAddArgs{
+				Stream: historyKey(trimmedID),
+				MaxLen: HistoryLength,
+				Values: map[string]interface{}{"event": "{}"},
+			}).Result()
+			require.NoError(t, err)
+			if i == 0 {
+				first = id
+			}
+		}
+
+		messages, reset, err := ht.hub.Replay(ctx, trimmedID, first)
+		require.NoError(t, err)
+		assert.True(t, reset)
+		assert.Len(t, messages, HistoryLength)
+	})
+
+	t.Run("failure - invalid cursor", func(t *testing.T) {
+		_, _, err := ht.hub.Replay(ctx, listID, "not-a-cursor")
+		assert.Error(t, err)
+	})
+}
+
+func TestHubShutdown(t *testing.T) {
+	ht := newHubTest(t)
+	listID := uuid.New()
+	sub := ht.hub.Subscribe(listID)
+
+	ht.stop()
+
+	select {
+	case err := <-ht.done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("hub did not stop")
+	}
+	_, ok := <-sub.C
+	assert.False(t, ok, "subscriptions end when the hub stops")
+
+	late := ht.hub.Subscribe(listID)
+	_, ok = <-late.C
+	assert.False(t, ok, "no subscriptions after the hub stopped")
+	late.Close()
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	ht := newHubTest(t)
+	ctx := context.Background()
+	listID := uuid.New()
+	slow := ht.hub.Subscribe(listID)
+	defer slow.Close()
+
+	for i := 0; i < SubscriberBuffer+1; i++ {
+		require.NoError(t, ht.broadcaster.Handle(ctx, taskEvent(t, events.TaskUpdated, listID)))
+	}
+
+	require.Eventually(t, func() bool {
+		ht.hub.mu.Lock()
+		defer ht.hub.mu.Unlock()
+		return len(ht.hub.subscribers[listID]) == 0
+	}, 2*time.Second, 5*time.Millisecond)
+
+	received := 0
+	for range slow.C {
+		received++
+	}
+	assert.Equal(t, SubscriberBuffer, received)
+}
@@ -0,0 +1,16 @@
+package realtime
+
+import (
+	"context"
+
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+)
+
+// ListReader looks up a list owned by a user; subscribers may only watch their own lists.
+//
+//go:generate moq -out=../../gen/mocks/realtimemock/list_reader_mock.go -pkg=realtimemock . ListReader
+type ListReader interface {
+	GetTodoListByID(ctx context.Context, params todolist.GetTodoListByIDParams) (todolist.TodoList, error)
+}
+
+var _ ListReader = (*todolist.Store)(nil)
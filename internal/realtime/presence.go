@@ -0,0 +1,193 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+)
+
+const (
+	// PresencePrefix prefixes the hash of each list's connected viewers
+	PresencePrefix = "realtime:presence:"
+	// PresenceRefreshInterval is how often a connection confirms it is still viewing
+	PresenceRefreshInterval = 30 * time.Second
+	// PresenceTTL drops connections that stopped refreshing, such as those of a crashed replica
+	PresenceTTL = 90 * time.Second
+	// EventPresence is the ephemeral event published when a list's viewers change
+	EventPresence = "presence.updated"
+)
+
+// Viewer is a user viewing a list, over one or more connections.
+type Viewer struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Connections int       `json:"connections"`
+	Since       time.Time `json:"since"` // When the user's earliest connection joined
+}
+
+// PresenceData is the data of a presence event.
+type PresenceData struct {
+	Viewers []Viewer `json:"viewers"`
+}
+
+// presenceEntry is a connection's record in a list's presence hash
+type presenceEntry struct {
+	UserID   uuid.UUID `json:"user_id"`
+	JoinedAt time.Time `json:"joined_at"`
+	SeenAt   time.Time `json:"seen_at"`
+}
+
+// Presence tracks who is viewing each list across all replicas and announces changes on the list's channel.
+type Presence struct {
+	client redis.UniversalClient
+	logger *zap.SugaredLogger
+	now    func() time.Time
+}
+
+// PresenceSession is one connection's presence in a list. It must be refreshed periodically and left when done.
+type PresenceSession struct {
+	ID       string
+	ListID   uuid.UUID
+	UserID   uuid.UUID
+	JoinedAt time.Time
+	presence *Presence
+}
+
+// NewPresence initializes a Presence tracker.
+func NewPresence(client redis.UniversalClient, logger *zap.SugaredLogger) *Presence {
+	return &Presence{
+		client: client,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// presenceKey returns the presence hash key of a list
+func presenceKey(listID uuid.UUID) string {
+	return PresencePrefix + listID.String()
+}
+
+// Join records a new connection of the user to the list and announces the list's viewers.
+func (p *Presence) Join(ctx context.Context, listID, userID uuid.UUID) (*PresenceSession, error) {
+	session := &PresenceSession{
+		ID:       uuid.NewString(),
+		ListID:   listID,
+		UserID:   userID,
+		JoinedAt: p.now(),
+		presence: p,
+	}
+	if err := session.store(ctx); err != nil {
+		return nil, err
+	}
+	if err := p.announce(ctx, listID); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Refresh confirms the connection is still viewing the list.
+func (s *PresenceSession) Refresh(ctx context.Context) error {
+	return s.store(ctx)
+}
+
+// Leave removes the connection from the list and announces the list's viewers.
+func (s *PresenceSession) Leave(ctx context.Context) error {
+	if err := s.presence.client.HDel(ctx, presenceKey(s.ListID), s.ID).Err(); err != nil {
+		return fmt.Errorf("failed to remove presence: %w", err)
+	}
+	return s.presence.announce(ctx, s.ListID)
+}
+
+// store writes the session's entry and extends the list's presence expiry
+func (s *PresenceSession) store(ctx context.Context) error {
+	entry, err := json.Marshal(presenceEntry{UserID: s.UserID, JoinedAt: s.JoinedAt, SeenAt: s.presence.now()})
+	if err != nil {
+		return fmt.Errorf("failed to encode presence: %w", err)
+	}
+
+	key := presenceKey(s.ListID)
+	_, err = s.presence.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, s.ID, entry)
+		pipe.Expire(ctx, key, PresenceTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store presence: %w", err)
+	}
+	return nil
+}
+
+// Viewers returns the users viewing a list, earliest first. Expired connections are removed.
+func (p *Presence) Viewers(ctx context.Context, listID uuid.UUID) ([]Viewer, error) {
+	key := presenceKey(listID)
+	entries, err := p.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read presence: %w", err)
+	}
+
+	cutoff := p.now().Add(-PresenceTTL)
+	byUser := make(map[uuid.UUID]*Viewer)
+	var expired []string
+	for id, raw := range entries {
+		var entry presenceEntry
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil || entry.SeenAt.Before(cutoff) {
+			expired = append(expired, id)
+			continue
+		}
+		viewer, ok := byUser[entry.UserID]
+		if !ok {
+			viewer = &Viewer{UserID: entry.UserID, Since: entry.JoinedAt}
+			byUser[entry.UserID] = viewer
+		}
+		viewer.Connections++
+		if entry.JoinedAt.Before(viewer.Since) {
+			viewer.Since = entry.JoinedAt
+		}
+	}
+
+	if len(expired) > 0 {
+		if err := p.client.HDel(ctx, key, expired...).Err(); err != nil {
+			p.logger.Warnw("Failed to remove expired presence", "listID", listID, "error", err)
+		}
+	}
+
+	viewers := make([]Viewer, 0, len(byUser))
+	for _, viewer := range byUser {
+		viewers = append(viewers, *viewer)
+	}
+	sort.Slice(viewers, func(i, j int) bool {
+		if !viewers[i].Since.Equal(viewers[j].Since) {
+			return viewers[i].Since.Before(viewers[j].Since)
+		}
+		return viewers[i].UserID.String() < viewers[j].UserID.String()
+	})
+	return viewers, nil
+}
+
+// announce publishes the list's current viewers to its subscribers
+func (p *Presence) announce(ctx context.Context, listID uuid.UUID) error {
+	viewers, err := p.Viewers(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	event, err := events.New(EventPresence, events.AggregateList, listID, PresenceData{Viewers: viewers})
+	if err != nil {
+		return err
+	}
+	message, err := json.Marshal(Message{ListID: listID, Event: event})
+	if err != nil {
+		return fmt.Errorf("failed to encode presence message: %w", err)
+	}
+	if err := p.client.Publish(ctx, channel(listID), message).Err(); err != nil {
+		return fmt.Errorf("failed to publish presence: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package realtime
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+)
+
+// WebSocket message types sent by clients.
+const (
+	ActionCreateTask   = "task.create"
+	ActionUpdateTask   = "task.update"
+	ActionCompleteTask = "task.complete"
+	ActionDeleteTask   = "task.delete"
+)
+
+// WebSocket message types sent by the server.
+const (
+	ReplyAck      = "ack"      // A client mutation succeeded
+	ReplyError    = "error"    // A client message was rejected
+	ReplyEvent    = "event"    // A task in the list changed
+	ReplyPresence = "presence" // The list's viewers changed
+)
+
+// ClientMessage is a task mutation sent over a list's WebSocket.
+// Only the fields relevant to the action are read; nil fields are left unchanged by updates.
+type ClientMessage struct {
+	Type        string     `json:"type"`
+	RequestID   string     `json:"request_id,omitempty"` // Echoed in the reply to correlate it
+	TaskID      uuid.UUID  `json:"task_id,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Status      *string    `json:"status,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Priority    *int32     `json:"priority,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+// ServerMessage is a reply or broadcast sent over a list's WebSocket.
+type ServerMessage struct {
+	Type      string          `json:"type"`
+	RequestID string          `json:"request_id,omitempty"`
+	Task      *tasks.FullTask `json:"task,omitempty"`   // Ack: the task as stored
+	Error     string          `json:"error,omitempty"`  // Error: why the message was rejected
+	Cursor    string          `json:"cursor,omitempty"` // Event: position in the list history
+	Event     *events.Event   `json:"event,omitempty"`  // Event: the task change
+	Viewers   []Viewer        `json:"viewers,omitempty"`
+}
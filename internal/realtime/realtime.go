@@ -0,0 +1,115 @@
+// Package realtime fans task changes out to clients watching a list, across every replica.
+//
+// The Broadcaster consumes domain events from the event bus, appends each task event to its list's
+// history stream in Redis and publishes it on the list's pub/sub channel. Every replica runs a Hub
+// that receives those messages and hands them to its local subscribers. The history stream lets a
+// reconnecting client resume from the last event it saw. Presence tracks who is viewing each list and
+// announces changes on the same channel, without keeping them in the history.
+package realtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+)
+
+const (
+	// ChannelPrefix prefixes the pub/sub channel of each list
+	ChannelPrefix = "realtime:list:"
+	// HistoryPrefix prefixes the history stream of each list
+	HistoryPrefix = "realtime:history:"
+	// HistoryLength is the number of recent events kept per list for resuming
+	HistoryLength = 1000
+	// HistoryTTL expires the history of lists without recent changes
+	HistoryTTL = 24 * time.Hour
+	// SubscriberBuffer is the number of messages queued per subscriber before it is dropped as too slow
+	SubscriberBuffer = 64
+	// HeartbeatInterval is how often an idle stream sends a keep-alive
+	HeartbeatInterval = 15 * time.Second
+	// RetryInterval is the reconnection delay suggested to clients
+	RetryInterval = 3 * time.Second
+	// WriteTimeout bounds each write to a client, so a stalled client releases its stream
+	WriteTimeout = 10 * time.Second
+	// SocketPingInterval is how often WebSocket clients are pinged
+	SocketPingInterval = 30 * time.Second
+	// SocketPongTimeout closes WebSocket connections that stop answering pings
+	SocketPongTimeout = 60 * time.Second
+	// SocketMaxMessageSize bounds a single client message
+	SocketMaxMessageSize = 64 << 10
+)
+
+// Message is an event delivered to a list's subscribers. Task events carry the cursor of their
+// history entry; ephemeral events, such as presence updates, are not kept in the history and have none.
+type Message struct {
+	Cursor string       `json:"cursor,omitempty"` // ID of the event in the list's history stream
+	ListID uuid.UUID    `json:"list_id"`
+	Event  events.Event `json:"event"`
+}
+
+// channel returns the pub/sub channel of a list
+func channel(listID uuid.UUID) string {
+	return ChannelPrefix + listID.String()
+}
+
+// historyKey returns the history stream key of a list
+func historyKey(listID uuid.UUID) string {
+	return HistoryPrefix + listID.String()
+}
+
+// ListKeys returns the Redis keys holding a list's realtime state: its history stream and presence hash.
+func ListKeys(listID uuid.UUID) []string {
+	return []string{historyKey(listID), presenceKey(listID)}
+}
+
+// listIDFromChannel extracts the list ID from a pub/sub channel name
+func listIDFromChannel(name string) (uuid.UUID, error) {
+	return uuid.Parse(strings.TrimPrefix(name, ChannelPrefix))
+}
+
+// cursor is a parsed Redis stream entry ID ("<milliseconds>-<sequence>").
+type cursor struct {
+	ms, seq uint64
+}
+
+// ParseCursor validates a cursor, such as a client's Last-Event-ID.
+func ParseCursor(value string) error {
+	_, err := parseCursor(value)
+	return err
+}
+
+func parseCursor(value string) (cursor, error) {
+	msPart, seqPart, ok := strings.Cut(value, "-")
+	if !ok {
+		return cursor{}, fmt.Errorf("invalid cursor %q", value)
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor %q: %w", value, err)
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor %q: %w", value, err)
+	}
+	return cursor{ms: ms, seq: seq}, nil
+}
+
+// After reports whether cursor a comes after cursor b. Invalid cursors come before every valid one.
+func After(a, b string) bool {
+	ca, errA := parseCursor(a)
+	cb, errB := parseCursor(b)
+	switch {
+	case errA != nil:
+		return false
+	case errB != nil:
+		return true
+	case ca.ms != cb.ms:
+		return ca.ms > cb.ms
+	default:
+		return ca.seq > cb.seq
+	}
+}
@@ -0,0 +1,42 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid cursor", value: "1760000000000-0"},
+		{name: "missing sequence", value: "1760000000000", wantErr: true},
+		{name: "non-numeric", value: "abc-1", wantErr: true},
+		{name: "negative sequence", value: "1-(-1)", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParseCursor(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	assert.True(t, After("2-0", "1-5"))
+	assert.True(t, After("1-6", "1-5"))
+	assert.False(t, After("1-5", "1-5"))
+	assert.False(t, After("1-4", "1-5"))
+	assert.True(t, After("10-0", "9-0"), "cursors compare numerically")
+	assert.True(t, After("1-0", "invalid"))
+	assert.False(t, After("invalid", "1-0"))
+}
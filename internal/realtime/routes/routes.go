@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/henryhall897/golang-todo-app/internal/realtime/handler"
+)
+
+// RegisterRoutes sets up realtime routes
+func RegisterRoutes(router *http.ServeMux, h *handler.Handler) {
+	// Handle `/lists/{id}/events`; more specific than the tasks feature's `/lists/` pattern
+	router.HandleFunc("/lists/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			handler.VerifyListPath(h.ListEventsHandler).ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+
+	// Handle `/lists/{id}/ws`
+	router.HandleFunc("/lists/{id}/ws", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			handler.VerifyListPath(h.ListSocketHandler).ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+}
@@ -2,9 +2,7 @@ package router
 
 import (
 	"net/http"
-
-	"github.com/henryhall897/golang-todo-app/internal/users/handler"
 )
 
-// RouteRegisterFunc.
-type RouteRegisterFunc func(router *http.ServeMux, h *handler.Handler)
+// RouteRegisterFunc registers a feature's routes on the mux. Each feature binds its own handler.
+type RouteRegisterFunc func(router *http.ServeMux)
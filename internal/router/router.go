@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/henryhall897/golang-todo-app/internal/middleware"
-	"github.com/henryhall897/golang-todo-app/internal/users/handler"
 )
 
 // router manages the routes for the application.
@@ -13,13 +12,13 @@ type Router struct {
 	LimitedHandler http.Handler
 }
 
-// NewRouter initializes application routes using the provided handlers.
-func NewRouter(routeFuncs []RouteRegisterFunc, handlers []handler.Handler) *Router {
+// NewRouter initializes application routes using the provided route modules.
+func NewRouter(routeFuncs []RouteRegisterFunc) *Router {
 	mux := http.NewServeMux()
 
 	// Register each route module dynamically
-	for i, registerFunc := range routeFuncs {
-		registerFunc(mux, &handlers[i])
+	for _, registerFunc := range routeFuncs {
+		registerFunc(mux)
 	}
 
 	// Apply middleware to limit request body size (1MB limit)
@@ -0,0 +1,256 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/notify"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	userdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DigestRedisPrefix namespaces digest de-duplication keys.
+	DigestRedisPrefix = "digest"
+	// DigestCompletedWindow is how far back completed tasks are listed.
+	DigestCompletedWindow = 24 * time.Hour
+	// digestClaimTTL keeps a day's marker until that day is over in every time zone.
+	digestClaimTTL = 48 * time.Hour
+)
+
+//go:embed templates/digest.txt.tmpl templates/digest.html.tmpl
+var digestTemplateFS embed.FS
+
+var (
+	digestTextTemplate = texttemplate.Must(texttemplate.ParseFS(digestTemplateFS, "templates/digest.txt.tmpl"))
+	digestHTMLTemplate = htmltemplate.Must(htmltemplate.ParseFS(digestTemplateFS, "templates/digest.html.tmpl"))
+)
+
+// DigestUserStore lists the users who opted in to the daily digest.
+type DigestUserStore interface {
+	ListDigestSubscribers(ctx context.Context) ([]userdomain.DigestSubscriber, error)
+}
+
+// DigestTaskStore lists the tasks that go into a user's digest.
+type DigestTaskStore interface {
+	ListDigestTasks(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]tasks.FullTask, error)
+}
+
+var (
+	_ DigestUserStore = (userdomain.Repository)(nil)
+	_ DigestTaskStore = (*tasks.Store)(nil)
+)
+
+// DigestJob emails each opted-in user a daily summary of their overdue tasks, the tasks due today
+// and the tasks they completed recently. Days are taken in the user's time zone, and the digest is
+// sent on the first run after sendHour local time. Like reminders, a per-day marker is claimed in
+// Redis before sending so a user gets at most one digest per local day.
+type DigestJob struct {
+	users    DigestUserStore
+	tasks    DigestTaskStore
+	ledger   redispkg.Cache
+	notifier notify.Notifier
+	sendHour int
+	logger   *zap.SugaredLogger
+	now      func() time.Time
+}
+
+// NewDigestJob initializes a DigestJob that sends digests from sendHour (0-23) local time.
+func NewDigestJob(users DigestUserStore, taskStore DigestTaskStore, ledger redispkg.Cache, notifier notify.Notifier, sendHour int, logger *zap.SugaredLogger) *DigestJob {
+	return &DigestJob{
+		users:    users,
+		tasks:    taskStore,
+		ledger:   ledger,
+		notifier: notifier,
+		sendHour: sendHour,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// DigestKey generates the de-duplication key for a user's digest on a local calendar day.
+func DigestKey(userID uuid.UUID, day time.Time) string {
+	return fmt.Sprintf("%s:%s", userID, day.Format(time.DateOnly))
+}
+
+// DigestKeyPrefix returns the prefix shared by every digest de-duplication key of a user.
+func DigestKeyPrefix(userID uuid.UUID) string {
+	return userID.String() + ":"
+}
+
+// Name identifies the job in logs.
+func (j *DigestJob) Name() string {
+	return "daily-digest"
+}
+
+// Run sends the digest to every subscriber whose local send time has passed today.
+// Failures for individual users are logged and retried on the next run.
+func (j *DigestJob) Run(ctx context.Context) error {
+	now := j.now()
+
+	subscribers, err := j.users.ListDigestSubscribers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list digest subscribers: %w", err)
+	}
+
+	sent := 0
+	for _, subscriber := range subscribers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if j.send(ctx, now, subscriber) {
+			sent++
+		}
+	}
+
+	j.logger.Debugw("Digest run completed", "subscribers", len(subscribers), "sent", sent)
+	return nil
+}
+
+// send claims the subscriber's marker for their local day, then builds and delivers the digest.
+// The marker is released when loading or delivery fails. It is kept for empty digests, which are not sent.
+func (j *DigestJob) send(ctx context.Context, now time.Time, subscriber userdomain.DigestSubscriber) bool {
+	loc, err := time.LoadLocation(subscriber.TimeZone)
+	if err != nil {
+		j.logger.Warnw("Invalid digest time zone; using UTC", "user_id", subscriber.ID, "timezone", subscriber.TimeZone)
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	if local.Hour() < j.sendHour {
+		return false
+	}
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	key := DigestKey(subscriber.ID, dayStart)
+	claimed, err := j.ledger.SetIfNotExists(ctx, key, now, digestClaimTTL)
+	if err != nil {
+		j.logger.Warnw("Failed to claim digest; skipping", "user_id", subscriber.ID, "error", err)
+		return false
+	}
+	if !claimed {
+		return false
+	}
+
+	release := func() {
+		if err := j.ledger.Delete(ctx, key); err != nil {
+			j.logger.Warnw("Failed to release digest claim", "user_id", subscriber.ID, "error", err)
+		}
+	}
+
+	userTasks, err := j.tasks.ListDigestTasks(ctx, subscriber.ID, dayEnd, now.Add(-DigestCompletedWindow))
+	if err != nil {
+		j.logger.Errorw("Failed to load digest tasks", "user_id", subscriber.ID, "error", err)
+		release()
+		return false
+	}
+
+	digest := buildDigest(subscriber, loc, dayStart, dayEnd, now, userTasks)
+	if digest.empty() {
+		j.logger.Debugw("Nothing to report; skipping digest", "user_id", subscriber.ID)
+		return false
+	}
+
+	msg, err := digestMessage(subscriber.Email, digest)
+	if err != nil {
+		j.logger.Errorw("Failed to render digest", "user_id", subscriber.ID, "error", err)
+		release()
+		return false
+	}
+
+	if err := j.notifier.Notify(ctx, msg); err != nil {
+		j.logger.Errorw("Failed to send digest", "user_id", subscriber.ID, "error", err)
+		release()
+		return false
+	}
+	return true
+}
+
+// digest is the template data for a user's daily digest.
+type digest struct {
+	Name      string
+	Date      string
+	Overdue   []digestItem
+	DueToday  []digestItem
+	Completed []digestItem
+}
+
+// digestItem is a task as shown in the digest, with times in the user's time zone.
+type digestItem struct {
+	Title       string
+	Description string
+	Priority    int32
+	When        string
+}
+
+func (d digest) empty() bool {
+	return len(d.Overdue) == 0 && len(d.DueToday) == 0 && len(d.Completed) == 0
+}
+
+// buildDigest sorts a user's tasks into the digest sections. Open tasks due before the local day
+// are overdue, open tasks due during it are due today, and tasks completed since the window began
+// are recently completed.
+func buildDigest(subscriber userdomain.DigestSubscriber, loc *time.Location, dayStart, dayEnd, now time.Time, userTasks []tasks.FullTask) digest {
+	d := digest{
+		Name: subscriber.Name,
+		Date: dayStart.Format("Monday, January 2"),
+	}
+	completedSince := now.Add(-DigestCompletedWindow)
+
+	for _, task := range userTasks {
+		item := digestItem{Title: "Untitled task"}
+		if task.Title != nil && *task.Title != "" {
+			item.Title = *task.Title
+		}
+		if task.Description != nil {
+			item.Description = *task.Description
+		}
+		if task.Priority != nil {
+			item.Priority = *task.Priority
+		}
+
+		completed := task.Status != nil && *task.Status == tasks.StatusCompleted
+		switch {
+		case completed && task.CompletedAt != nil && !task.CompletedAt.Before(completedSince):
+			item.When = task.CompletedAt.In(loc).Format("Jan 2 15:04")
+			d.Completed = append(d.Completed, item)
+		case completed || task.DueDate == nil:
+			continue
+		case task.DueDate.Before(dayStart):
+			item.When = task.DueDate.In(loc).Format("Jan 2 15:04")
+			d.Overdue = append(d.Overdue, item)
+		case task.DueDate.Before(dayEnd):
+			item.When = task.DueDate.In(loc).Format("15:04")
+			d.DueToday = append(d.DueToday, item)
+		}
+	}
+	return d
+}
+
+// digestMessage renders the digest as a message with plain-text and HTML bodies.
+func digestMessage(to string, d digest) (notify.Message, error) {
+	var text, html bytes.Buffer
+	if err := digestTextTemplate.Execute(&text, d); err != nil {
+		return notify.Message{}, fmt.Errorf("failed to render text digest: %w", err)
+	}
+	if err := digestHTMLTemplate.Execute(&html, d); err != nil {
+		return notify.Message{}, fmt.Errorf("failed to render HTML digest: %w", err)
+	}
+
+	return notify.Message{
+		To:       to,
+		Subject:  "Your tasks for " + d.Date,
+		Body:     text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
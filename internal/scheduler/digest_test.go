@@ -0,0 +1,277 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/notifymock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/notify"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	userdomain "github.com/henryhall897/golang-todo-app/internal/users/domain"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// digestStore is a DigestUserStore and DigestTaskStore stub.
+type digestStore struct {
+	ListDigestSubscribersFunc func(ctx context.Context) ([]userdomain.DigestSubscriber, error)
+	ListDigestTasksFunc       func(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]tasks.FullTask, error)
+}
+
+func (s *digestStore) ListDigestSubscribers(ctx context.Context) ([]userdomain.DigestSubscriber, error) {
+	return s.ListDigestSubscribersFunc(ctx)
+}
+
+func (s *digestStore) ListDigestTasks(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]tasks.FullTask, error) {
+	return s.ListDigestTasksFunc(ctx, userID, dueBefore, completedSince)
+}
+
+type DigestTestSuite struct {
+	server    *miniredis.Miniredis
+	ledger    redispkg.Cache
+	mockStore *digestStore
+	outbox    *notify.FileOutbox
+	job       *DigestJob
+	now       time.Time
+}
+
+func setupDigestSuite(t *testing.T) *DigestTestSuite {
+	srv, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(srv.Close)
+
+	outbox, err := notify.NewFileOutbox(t.TempDir(), "todo@example.com")
+	require.NoError(t, err)
+
+	logger := zap.NewNop().Sugar()
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+
+	suite := &DigestTestSuite{
+		server:    srv,
+		ledger:    redispkg.NewJSONCache(client, DigestRedisPrefix, logger),
+		mockStore: &digestStore{},
+		outbox:    outbox,
+		now:       time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+	}
+	suite.job = NewDigestJob(suite.mockStore, suite.mockStore, suite.ledger, outbox, 7, logger)
+	suite.job.now = func() time.Time { return suite.now }
+	return suite
+}
+
+func digestSubscriber(timezone string) userdomain.DigestSubscriber {
+	return userdomain.DigestSubscriber{
+		ID:       uuid.New(),
+		Name:     "Test User",
+		Email:    "user@example.com",
+		TimeZone: timezone,
+	}
+}
+
+func digestTask(title, status string, due, completed *time.Time) tasks.FullTask {
+	return tasks.FullTask{
+		ID:          uuid.New(),
+		ListID:      uuid.New(),
+		Title:       common.Ptr(title),
+		Status:      common.Ptr(status),
+		Priority:    common.Ptr(int32(2)),
+		DueDate:     due,
+		CompletedAt: completed,
+	}
+}
+
+// readDigest parses a digest from the outbox into its plain-text and HTML parts.
+func readDigest(t *testing.T, path string) (subject, text, html string) {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	parts := make([]string, 0, 2)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts = append(parts, string(body))
+	}
+	require.Len(t, parts, 2)
+	return msg.Header.Get("Subject"), parts[0], parts[1]
+}
+
+func TestDigestJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends one digest per local day", func(t *testing.T) {
+		suite := setupDigestSuite(t)
+		subscriber := digestSubscriber("Europe/Berlin") // 11:00 local
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		suite.mockStore.ListDigestSubscribersFunc = func(ctx context.Context) ([]userdomain.DigestSubscriber, error) {
+			return []userdomain.DigestSubscriber{subscriber}, nil
+		}
+		suite.mockStore.ListDigestTasksFunc = func(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]tasks.FullTask, error) {
+			assert.Equal(t, subscriber.ID, userID)
+			assert.True(t, time.Date(2026, 10, 19, 0, 0, 0, 0, berlin).Equal(dueBefore))
+			assert.Equal(t, suite.now.Add(-DigestCompletedWindow), completedSince)
+			return []tasks.FullTask{
+				digestTask("Pay <rent>", "pending", common.Ptr(suite.now.Add(-48*time.Hour)), nil),
+				digestTask("Buy groceries", "pending", common.Ptr(suite.now.Add(6*time.Hour)), nil),
+				digestTask("Call plumber", tasks.StatusCompleted, nil, common.Ptr(suite.now.Add(-2*time.Hour))),
+			}, nil
+		}
+
+		require.NoError(t, suite.job.Run(ctx))
+		require.NoError(t, suite.job.Run(ctx))
+
+		files, err := suite.outbox.Files()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		subject, text, html := readDigest(t, files[0])
+		assert.Equal(t, "Your tasks for Sunday, October 18", subject)
+		assert.Contains(t, text, "Hi Test User,")
+		assert.Contains(t, text, "Overdue (1)\r\n  - Pay <rent> (was due Oct 16 11:00) [priority 2]")
+		assert.Contains(t, text, "Due today (1)\r\n  - Buy groceries (due 17:00) [priority 2]")
+		assert.Contains(t, text, "Recently completed (1)\r\n  - Call plumber (completed Oct 18 09:00)")
+
+		// The HTML part escapes task content
+		assert.Contains(t, html, "<strong>Pay &lt;rent&gt;</strong>")
+		assert.NotContains(t, html, "<rent>")
+
+		key := DigestRedisPrefix + ":" + DigestKey(subscriber.ID, time.Date(2026, 10, 18, 0, 0, 0, 0, berlin))
+		assert.True(t, suite.server.Exists(key))
+	})
+
+	t.Run("waits for the send hour in the user's time zone", func(t *testing.T) {
+		suite := setupDigestSuite(t)
+		early := digestSubscriber("America/Los_Angeles") // 02:00 local
+		late := digestSubscriber("Asia/Tokyo")           // 18:00 local
+		suite.mockStore.ListDigestSubscribersFunc = func(ctx context.Context) ([]userdomain.DigestSubscriber, error) {
+			return []userdomain.DigestSubscriber{early, late}, nil
+		}
+		var loaded []uuid.UUID
+		suite.mockStore.ListDigestTasksFunc = func(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]tasks.FullTask, error) {
+			loaded = append(loaded, userID)
+			return []tasks.FullTask{digestTask("Buy groceries", "pending", common.Ptr(suite.now.Add(time.Hour)), nil)}, nil
+		}
+
+		require.NoError(t, suite.job.Run(ctx))
+		assert.Equal(t, []uuid.UUID{late.ID}, loaded)
+
+		// Later the same UTC day it is morning in Los Angeles
+		suite.now = suite.now.Add(6 * time.Hour)
+		require.NoError(t, suite.job.Run(ctx))
+		assert.Equal(t, []uuid.UUID{late.ID, early.ID}, loaded)
+
+		files, err := suite.outbox.Files()
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+
+	t.Run("empty digest is not sent", func(t *testing.T) {
+		suite := setupDigestSuite(t)
+		suite.mockStore.ListDigestSubscribersFunc = func(ctx context.Context) ([]userdomain.DigestSubscriber, error) {
+			return []userdomain.DigestSubscriber{digestSubscriber("UTC")}, nil
+		}
+		suite.mockStore.ListDigestTasksFunc = func(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]tasks.FullTask, error) {
+			return nil, nil
+		}
+
+		require.NoError(t, suite.job.Run(ctx))
+
+		files, err := suite.outbox.Files()
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("failed delivery is retried on the next run", func(t *testing.T) {
+		suite := setupDigestSuite(t)
+		notifier := &notifymock.NotifierMock{}
+		attempts := 0
+		notifier.NotifyFunc = func(ctx context.Context, msg notify.Message) error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("smtp unavailable")
+			}
+			return nil
+		}
+		suite.job.notifier = notifier
+
+		subscriber := digestSubscriber("UTC")
+		suite.mockStore.ListDigestSubscribersFunc = func(ctx context.Context) ([]userdomain.DigestSubscriber, error) {
+			return []userdomain.DigestSubscriber{subscriber}, nil
+		}
+		suite.mockStore.ListDigestTasksFunc = func(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]tasks.FullTask, error) {
+			return []tasks.FullTask{digestTask("Buy groceries", "pending", common.Ptr(suite.now.Add(time.Hour)), nil)}, nil
+		}
+
+		require.NoError(t, suite.job.Run(ctx))
+		require.NoError(t, suite.job.Run(ctx))
+		require.NoError(t, suite.job.Run(ctx))
+
+		assert.Equal(t, 2, attempts)
+		assert.NotEmpty(t, notifier.NotifyCalls()[1].Msg.HTMLBody)
+	})
+
+	t.Run("store error is returned", func(t *testing.T) {
+		suite := setupDigestSuite(t)
+		suite.mockStore.ListDigestSubscribersFunc = func(ctx context.Context) ([]userdomain.DigestSubscriber, error) {
+			return nil, errors.New("database error")
+		}
+
+		require.Error(t, suite.job.Run(ctx))
+	})
+}
+
+func TestBuildDigest(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, loc)
+	dayStart := time.Date(2026, 10, 18, 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	d := buildDigest(digestSubscriber("UTC"), loc, dayStart, dayEnd, now, []tasks.FullTask{
+		digestTask("Overdue", "pending", common.Ptr(dayStart.Add(-time.Minute)), nil),
+		digestTask("Due tonight", "in_progress", common.Ptr(dayEnd.Add(-time.Minute)), nil),
+		digestTask("Due tomorrow", "pending", common.Ptr(dayEnd), nil),
+		digestTask("No due date", "pending", nil, nil),
+		digestTask("Done", tasks.StatusCompleted, common.Ptr(dayStart), common.Ptr(now.Add(-time.Hour))),
+		digestTask("Done long ago", tasks.StatusCompleted, common.Ptr(dayStart), common.Ptr(now.Add(-48*time.Hour))),
+	})
+
+	titles := func(items []digestItem) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Title)
+		}
+		return out
+	}
+	assert.Equal(t, "Sunday, October 18", d.Date)
+	assert.Equal(t, []string{"Overdue"}, titles(d.Overdue))
+	assert.Equal(t, []string{"Due tonight"}, titles(d.DueToday))
+	assert.Equal(t, []string{"Done"}, titles(d.Completed))
+	assert.False(t, d.empty())
+}
@@ -0,0 +1,133 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/notify"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ReminderRedisPrefix namespaces reminder de-duplication keys.
+const ReminderRedisPrefix = "reminder"
+
+// ReminderStore lists the open tasks falling due within a time range.
+type ReminderStore interface {
+	ListTasksDueBetween(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error)
+}
+
+var _ ReminderStore = (*tasks.Store)(nil)
+
+// ReminderJob notifies task owners shortly before their tasks fall due.
+// Each (task, due date) pair is reminded at most once: a marker is claimed in Redis before sending,
+// so reminders are not repeated across scans or across replicas, and a rescheduled task is reminded again.
+type ReminderJob struct {
+	store    ReminderStore
+	ledger   redispkg.Cache
+	notifier notify.Notifier
+	leadTime time.Duration
+	logger   *zap.SugaredLogger
+	now      func() time.Time
+}
+
+// NewReminderJob initializes a ReminderJob that reminds users leadTime before a task is due.
+func NewReminderJob(store ReminderStore, ledger redispkg.Cache, notifier notify.Notifier, leadTime time.Duration, logger *zap.SugaredLogger) *ReminderJob {
+	return &ReminderJob{
+		store:    store,
+		ledger:   ledger,
+		notifier: notifier,
+		leadTime: leadTime,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// ReminderKey generates the de-duplication key for a task's reminder at a given due date.
+func ReminderKey(taskID uuid.UUID, due time.Time) string {
+	return fmt.Sprintf("%s:%d", taskID, due.Unix())
+}
+
+// Name identifies the job in logs.
+func (j *ReminderJob) Name() string {
+	return "due-date-reminders"
+}
+
+// Run sends a reminder for every open task due within the lead time that has not been reminded yet.
+// Failures for individual tasks are logged and the scan continues; they are retried on the next run.
+func (j *ReminderJob) Run(ctx context.Context) error {
+	now := j.now()
+
+	dueTasks, err := j.store.ListTasksDueBetween(ctx, now, now.Add(j.leadTime))
+	if err != nil {
+		return fmt.Errorf("failed to list tasks due soon: %w", err)
+	}
+
+	sent := 0
+	for _, dueTask := range dueTasks {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if j.remind(ctx, now, dueTask) {
+			sent++
+		}
+	}
+
+	j.logger.Debugw("Reminder scan completed", "due", len(dueTasks), "sent", sent)
+	return nil
+}
+
+// remind claims the reminder marker and sends the notification, releasing the marker if delivery fails.
+func (j *ReminderJob) remind(ctx context.Context, now time.Time, dueTask tasks.DueTask) bool {
+	task := dueTask.Task
+	if task.DueDate == nil {
+		return false
+	}
+	key := ReminderKey(task.ID, *task.DueDate)
+
+	// Keep the marker until the task is past due so later scans skip it
+	ttl := task.DueDate.Sub(now) + j.leadTime
+	claimed, err := j.ledger.SetIfNotExists(ctx, key, now, ttl)
+	if err != nil {
+		j.logger.Warnw("Failed to claim reminder; skipping", "task_id", task.ID, "error", err)
+		return false
+	}
+	if !claimed {
+		return false
+	}
+
+	if err := j.notifier.Notify(ctx, reminderMessage(dueTask)); err != nil {
+		j.logger.Errorw("Failed to send reminder", "task_id", task.ID, "user_id", dueTask.UserID, "error", err)
+		if err := j.ledger.Delete(ctx, key); err != nil {
+			j.logger.Warnw("Failed to release reminder claim", "task_id", task.ID, "error", err)
+		}
+		return false
+	}
+	return true
+}
+
+// reminderMessage renders the reminder for a task.
+func reminderMessage(dueTask tasks.DueTask) notify.Message {
+	title := "Untitled task"
+	if dueTask.Task.Title != nil && *dueTask.Task.Title != "" {
+		title = *dueTask.Task.Title
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", dueTask.UserName)
+	fmt.Fprintf(&body, "Your task %q is due at %s.\n", title, dueTask.Task.DueDate.UTC().Format(time.RFC1123))
+	if dueTask.Task.Description != nil && *dueTask.Task.Description != "" {
+		fmt.Fprintf(&body, "\n%s\n", *dueTask.Task.Description)
+	}
+
+	return notify.Message{
+		To:      dueTask.UserEmail,
+		Subject: "Reminder: " + title,
+		Body:    body.String(),
+	}
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/notifymock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/notify"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// reminderStore is a ReminderStore stub.
+type reminderStore struct {
+	ListTasksDueBetweenFunc func(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error)
+}
+
+func (s *reminderStore) ListTasksDueBetween(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error) {
+	return s.ListTasksDueBetweenFunc(ctx, from, to)
+}
+
+type ReminderTestSuite struct {
+	server    *miniredis.Miniredis
+	mockStore *reminderStore
+	notifier  *notifymock.NotifierMock
+	job       *ReminderJob
+	now       time.Time
+}
+
+func setupReminderSuite(t *testing.T) *ReminderTestSuite {
+	srv, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(srv.Close)
+
+	logger := zap.NewNop().Sugar()
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+
+	suite := &ReminderTestSuite{
+		server:    srv,
+		mockStore: &reminderStore{},
+		notifier: &notifymock.NotifierMock{
+			NotifyFunc: func(ctx context.Context, msg notify.Message) error { return nil },
+		},
+		now: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+	}
+	suite.job = NewReminderJob(suite.mockStore, redispkg.NewJSONCache(client, ReminderRedisPrefix, logger), suite.notifier, time.Hour, logger)
+	suite.job.now = func() time.Time { return suite.now }
+	return suite
+}
+
+func dueTask(title string, due time.Time) tasks.DueTask {
+	return tasks.DueTask{
+		Task: tasks.FullTask{
+			ID:          uuid.New(),
+			ListID:      uuid.New(),
+			Title:       common.Ptr(title),
+			Description: common.Ptr("Details for " + title),
+			DueDate:     &due,
+		},
+		UserID:    uuid.New(),
+		UserEmail: "user@example.com",
+		UserName:  "Test User",
+	}
+}
+
+func TestReminderJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reminds each due task once", func(t *testing.T) {
+		suite := setupReminderSuite(t)
+		first := dueTask("Buy groceries", suite.now.Add(30*time.Minute))
+		second := dueTask("Call plumber", suite.now.Add(45*time.Minute))
+		suite.mockStore.ListTasksDueBetweenFunc = func(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error) {
+			assert.Equal(t, suite.now, from)
+			assert.Equal(t, suite.now.Add(time.Hour), to)
+			return []tasks.DueTask{first, second}, nil
+		}
+
+		require.NoError(t, suite.job.Run(ctx))
+		require.NoError(t, suite.job.Run(ctx))
+
+		calls := suite.notifier.NotifyCalls()
+		require.Len(t, calls, 2)
+		assert.Equal(t, "user@example.com", calls[0].Msg.To)
+		assert.Equal(t, "Reminder: Buy groceries", calls[0].Msg.Subject)
+		assert.Contains(t, calls[0].Msg.Body, "Hi Test User")
+		assert.Contains(t, calls[0].Msg.Body, "Details for Buy groceries")
+		assert.Equal(t, "Reminder: Call plumber", calls[1].Msg.Subject)
+
+		// The marker outlives the due date by the lead time
+		key := ReminderRedisPrefix + ":" + ReminderKey(first.Task.ID, *first.Task.DueDate)
+		require.True(t, suite.server.Exists(key))
+		assert.Equal(t, 90*time.Minute, suite.server.TTL(key))
+	})
+
+	t.Run("rescheduled task is reminded again", func(t *testing.T) {
+		suite := setupReminderSuite(t)
+		task := dueTask("Buy groceries", suite.now.Add(30*time.Minute))
+		suite.mockStore.ListTasksDueBetweenFunc = func(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error) {
+			return []tasks.DueTask{task}, nil
+		}
+
+		require.NoError(t, suite.job.Run(ctx))
+		task.Task.DueDate = common.Ptr(suite.now.Add(50 * time.Minute))
+		require.NoError(t, suite.job.Run(ctx))
+
+		assert.Len(t, suite.notifier.NotifyCalls(), 2)
+	})
+
+	t.Run("failed delivery is retried on the next run", func(t *testing.T) {
+		suite := setupReminderSuite(t)
+		task := dueTask("Buy groceries", suite.now.Add(30*time.Minute))
+		suite.mockStore.ListTasksDueBetweenFunc = func(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error) {
+			return []tasks.DueTask{task}, nil
+		}
+		attempts := 0
+		suite.notifier.NotifyFunc = func(ctx context.Context, msg notify.Message) error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("smtp unavailable")
+			}
+			return nil
+		}
+
+		require.NoError(t, suite.job.Run(ctx))
+		require.NoError(t, suite.job.Run(ctx))
+		require.NoError(t, suite.job.Run(ctx))
+
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("unavailable ledger skips sending", func(t *testing.T) {
+		suite := setupReminderSuite(t)
+		suite.mockStore.ListTasksDueBetweenFunc = func(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error) {
+			return []tasks.DueTask{dueTask("Buy groceries", suite.now.Add(30*time.Minute))}, nil
+		}
+		suite.server.Close()
+
+		require.NoError(t, suite.job.Run(ctx))
+		assert.Empty(t, suite.notifier.NotifyCalls())
+	})
+
+	t.Run("store error is returned", func(t *testing.T) {
+		suite := setupReminderSuite(t)
+		suite.mockStore.ListTasksDueBetweenFunc = func(ctx context.Context, from, to time.Time) ([]tasks.DueTask, error) {
+			return nil, errors.New("database error")
+		}
+
+		require.Error(t, suite.job.Run(ctx))
+		assert.Empty(t, suite.notifier.NotifyCalls())
+	})
+}
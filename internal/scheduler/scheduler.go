@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job is a unit of background work run periodically by the Scheduler.
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+type entry struct {
+	job      Job
+	interval time.Duration
+}
+
+// Scheduler runs registered jobs on fixed intervals until its context is cancelled.
+// Each job runs in its own goroutine, so a slow job never delays the others,
+// and a job is never run concurrently with itself.
+type Scheduler struct {
+	entries []entry
+	logger  *zap.SugaredLogger
+}
+
+// New initializes an empty Scheduler.
+func New(logger *zap.SugaredLogger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Add registers a job to run once at startup and then every interval.
+func (s *Scheduler) Add(job Job, interval time.Duration) {
+	s.entries = append(s.entries, entry{job: job, interval: interval})
+}
+
+// Run starts every registered job and blocks until ctx is cancelled and all jobs have returned.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range s.entries {
+		wg.Add(1)
+		go func(e entry) {
+			defer wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	s.logger.Infow("Scheduled job started", "job", e.job.Name(), "interval", e.interval)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, e.job)
+
+		select {
+		case <-ctx.Done():
+			s.logger.Infow("Scheduled job stopped", "job", e.job.Name())
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs a job, logging failures and recovering panics so the schedule keeps going.
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Errorw("Scheduled job panicked", "job", job.Name(), "panic", r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.logger.Errorw("Scheduled job failed", "job", job.Name(), "duration", time.Since(start), "error", err)
+		return
+	}
+	s.logger.Debugw("Scheduled job completed", "job", job.Name(), "duration", time.Since(start))
+}
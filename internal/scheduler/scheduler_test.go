@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// funcJob adapts a function to the Job interface.
+type funcJob struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func (j funcJob) Name() string                  { return j.name }
+func (j funcJob) Run(ctx context.Context) error { return j.run(ctx) }
+
+func TestScheduler(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	t.Run("runs jobs immediately and on every tick until cancelled", func(t *testing.T) {
+		var fast, slow atomic.Int32
+		s := New(logger)
+		s.Add(funcJob{name: "fast", run: func(ctx context.Context) error { fast.Add(1); return nil }}, 10*time.Millisecond)
+		s.Add(funcJob{name: "slow", run: func(ctx context.Context) error { slow.Add(1); return nil }}, time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			s.Run(ctx)
+			close(done)
+		}()
+
+		require.Eventually(t, func() bool { return fast.Load() >= 3 }, time.Second, 5*time.Millisecond)
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not stop after cancellation")
+		}
+		assert.Equal(t, int32(1), slow.Load(), "slow job should only have run at startup")
+	})
+
+	t.Run("failing and panicking jobs keep their schedule", func(t *testing.T) {
+		var failing, panicking atomic.Int32
+		s := New(logger)
+		s.Add(funcJob{name: "failing", run: func(ctx context.Context) error {
+			failing.Add(1)
+			return errors.New("boom")
+		}}, 5*time.Millisecond)
+		s.Add(funcJob{name: "panicking", run: func(ctx context.Context) error {
+			panicking.Add(1)
+			panic("boom")
+		}}, 5*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Run(ctx)
+
+		require.Eventually(t, func() bool { return failing.Load() >= 2 && panicking.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	})
+}
@@ -13,6 +13,17 @@ import (
 	"golang.org/x/net/http2/h2c"
 )
 
+// Connection timeouts. There is deliberately no write timeout: it would cut off long-lived
+// responses such as event streams, which bound each of their own writes instead.
+const (
+	readHeaderTimeout = 10 * time.Second
+	idleTimeout       = 2 * time.Minute
+	// pingInterval is how long an HTTP/2 connection may go without frames before it is health checked
+	pingInterval = 30 * time.Second
+	// pingTimeout closes connections whose health check is not answered in time
+	pingTimeout = 15 * time.Second
+)
+
 // HTTPServer is a server that listens for HTTP/1.x and also supports HTTP/2 cleartext (h2c).
 type HTTPServer struct {
 	cfg *config.ServerConfig
@@ -27,9 +38,13 @@ func NewHTTPServer(config *config.ServerConfig) *HTTPServer {
 func (s *HTTPServer) Serve(ctx context.Context, handler http.Handler) error {
 	s.cfg.Logger.Infof("Starting server on %s:%s", s.cfg.BindAddress, s.cfg.Port)
 
-	// Create an HTTP/2 server
+	// Create an HTTP/2 server. Idle streams are kept alive by pings, which also detect dead peers
+	// whose streams would otherwise stay open.
 	h2s := &http2.Server{
 		MaxConcurrentStreams: 250,
+		IdleTimeout:          idleTimeout,
+		ReadIdleTimeout:      pingInterval,
+		PingTimeout:          pingTimeout,
 	}
 
 	// Wrap the handler to support h2c (HTTP/2 without TLS)
@@ -37,8 +52,10 @@ func (s *HTTPServer) Serve(ctx context.Context, handler http.Handler) error {
 
 	// Initialize HTTP server
 	srv := &http.Server{
-		Addr:    fmt.Sprintf("%s:%s", s.cfg.BindAddress, s.cfg.Port),
-		Handler: wrappedHandler,
+		Addr:              fmt.Sprintf("%s:%s", s.cfg.BindAddress, s.cfg.Port),
+		Handler:           wrappedHandler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		IdleTimeout:       idleTimeout,
 	}
 
 	// Channel to capture errors
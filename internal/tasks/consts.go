@@ -0,0 +1,90 @@
+package tasks
+
+import "time"
+
+// Task event types recorded in the task history.
+const (
+	TaskEventUpdated         = "updated"
+	TaskEventCompleted       = "completed"
+	TaskEventPriorityChanged = "priority_changed"
+	TaskEventDeleted         = "deleted"
+)
+
+// StatusCompleted is the status of a finished task; setting it also records completed_at.
+const StatusCompleted = "completed"
+
+const (
+	DefaultEventLimit  = 20
+	DefaultEventOffset = 0
+	MaxEventLimit      = 100
+)
+
+const (
+	DefaultSearchLimit  = 20
+	DefaultSearchOffset = 0
+	MaxSearchLimit      = 100
+)
+
+// Sort fields accepted by QueryTasks.
+const (
+	SortFieldTitle       = "title"
+	SortFieldStatus      = "status"
+	SortFieldPriority    = "priority"
+	SortFieldDueDate     = "due_date"
+	SortFieldCompletedAt = "completed_at"
+	SortFieldCreatedAt   = "created_at"
+	SortFieldUpdatedAt   = "updated_at"
+)
+
+const (
+	DefaultListLimit  = 50
+	DefaultListOffset = 0
+	MaxListLimit      = 200
+)
+
+// StatsWindows are the trailing windows, in days, reported in TaskStats.
+var StatsWindows = []int{7, 30, 90}
+
+const (
+	StatsRedisPrefix = "taskstats"
+	StatsRedisTTL    = 5 * time.Minute
+)
+
+// Import formats accepted by ParseImport.
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+)
+
+// Task fields that imported rows can set. CSV columns are mapped onto them; JSON objects use them as keys.
+const (
+	ImportFieldTitle       = "title"
+	ImportFieldDescription = "description"
+	ImportFieldStatus      = "status"
+	ImportFieldPriority    = "priority"
+	ImportFieldDueDate     = "due_date"
+	ImportFieldCompletedAt = "completed_at"
+	ImportFieldTags        = "tags"
+)
+
+// ImportFields lists every importable field.
+var ImportFields = []string{
+	ImportFieldTitle, ImportFieldDescription, ImportFieldStatus, ImportFieldPriority,
+	ImportFieldDueDate, ImportFieldCompletedAt, ImportFieldTags,
+}
+
+const (
+	MaxImportRows   = 5000
+	ImportBatchSize = 500 // Rows copied per COPY statement
+	// DefaultImportStatus is stored for rows without a status; imports copy every column, bypassing defaults
+	DefaultImportStatus = "pending"
+	maxTitleLength      = 255
+	maxStatusLength     = 50
+)
+
+// Export formats accepted by NewExporter.
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+	ExportFormatICS  = "ics"
+)
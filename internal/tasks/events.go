@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/tasks/gen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// getTaskForUpdate loads and locks a task row so its current values can be recorded in the task history.
+func getTaskForUpdate(ctx context.Context, query *gen.Queries, taskID, userID uuid.UUID) (FullTask, error) {
+	dbParams, err := toDBGetTaskForUpdateParams(taskID, userID)
+	if err != nil {
+		return FullTask{}, fmt.Errorf("failed to transform get task params: %w", err)
+	}
+
+	dbTask, err := query.GetTaskForUpdate(ctx, dbParams)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return FullTask{}, fmt.Errorf("no task found to update with the provided parameters: %w", common.ErrNotFound)
+		}
+		return FullTask{}, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return toFullTask(dbTask)
+}
+
+// recordTaskEvent writes a task history entry using the given (transaction-bound) queries
+// and publishes the matching domain event. Nothing is recorded when the event carries no changes.
+func recordTaskEvent(ctx context.Context, query *gen.Queries, eventType string, actorID uuid.UUID, task FullTask, changes map[string]FieldChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	dbParams, err := toDBCreateTaskEventParams(TaskEvent{
+		TaskID:    task.ID,
+		ListID:    task.ListID,
+		ActorID:   actorID,
+		EventType: eventType,
+		Changes:   changes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to transform task event: %w", err)
+	}
+
+	if _, err := query.CreateTaskEvent(ctx, dbParams); err != nil {
+		return fmt.Errorf("failed to record task event: %w", err)
+	}
+
+	return publishTaskEvent(ctx, query, domainEventTypes[eventType], task, changes)
+}
+
+// diffTasks returns the fields that differ between two versions of a task, keyed by their JSON name.
+func diffTasks(before, after FullTask) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+
+	addChange := func(field string, old, new interface{}, equal bool) {
+		if !equal {
+			changes[field] = FieldChange{Old: old, New: new}
+		}
+	}
+
+	addChange("title", before.Title, after.Title, equalPtr(before.Title, after.Title))
+	addChange("description", before.Description, after.Description, equalPtr(before.Description, after.Description))
+	addChange("status", before.Status, after.Status, equalPtr(before.Status, after.Status))
+	addChange("priority", before.Priority, after.Priority, equalPtr(before.Priority, after.Priority))
+	addChange("tags", before.Tags, after.Tags, slices.Equal(before.Tags, after.Tags))
+
+	beforeDue, afterDue := timeOrNil(before.DueDate), timeOrNil(after.DueDate)
+	addChange("due_date", beforeDue, afterDue, equalTime(beforeDue, afterDue))
+
+	beforeCompleted, afterCompleted := timeOrNil(before.CompletedAt), timeOrNil(after.CompletedAt)
+	addChange("completed_at", beforeCompleted, afterCompleted, equalTime(beforeCompleted, afterCompleted))
+
+	return changes
+}
+
+// deletedTaskChanges records every field of a deleted task as its old value.
+func deletedTaskChanges(task FullTask) map[string]FieldChange {
+	return diffTasks(task, FullTask{})
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// timeOrNil treats a zero timestamp the same as an unset one.
+func timeOrNil(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,65 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTasks(t *testing.T) {
+	due := time.Now().Truncate(time.Second)
+	before := FullTask{
+		ID:          uuid.New(),
+		Title:       common.Ptr("Title"),
+		Description: common.Ptr("Description"),
+		Status:      common.Ptr("pending"),
+		DueDate:     &due,
+		Priority:    common.Ptr(int32(1)),
+		CompletedAt: &time.Time{},
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		after := before
+		after.DueDate = common.Ptr(due.UTC())
+		require.Empty(t, diffTasks(before, after))
+	})
+
+	t.Run("changed fields only", func(t *testing.T) {
+		completedAt := time.Now()
+		after := before
+		after.Status = common.Ptr("completed")
+		after.Priority = nil
+		after.CompletedAt = &completedAt
+
+		changes := diffTasks(before, after)
+
+		require.Len(t, changes, 3)
+		require.Equal(t, FieldChange{Old: before.Status, New: after.Status}, changes["status"])
+		require.Equal(t, FieldChange{Old: before.Priority, New: after.Priority}, changes["priority"])
+		require.Nil(t, changes["completed_at"].Old)
+		require.Equal(t, &completedAt, changes["completed_at"].New)
+	})
+
+	t.Run("deleted task records old values", func(t *testing.T) {
+		changes := deletedTaskChanges(before)
+
+		require.Len(t, changes, 5)
+		require.Equal(t, before.Title, changes["title"].Old)
+		require.Nil(t, changes["title"].New)
+		require.NotContains(t, changes, "completed_at")
+	})
+
+	t.Run("tag changes", func(t *testing.T) {
+		after := before
+		after.Tags = []string{"home"}
+
+		changes := diffTasks(before, after)
+
+		require.Len(t, changes, 1)
+		require.Equal(t, FieldChange{Old: before.Tags, New: after.Tags}, changes["tags"])
+	})
+}
@@ -0,0 +1,333 @@
+package tasks
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Exporter writes a list and its tasks one at a time, so exports never hold the whole list in memory.
+// Nothing is written before the first task or Close; Close must be called to complete the document.
+type Exporter interface {
+	ContentType() string
+	FileExtension() string
+	WriteTask(task FullTask) error
+	Close() error
+}
+
+// exportEncoder renders the parts of one export format
+type exportEncoder interface {
+	header(list ExportList) error
+	task(task FullTask) error
+	footer() error
+}
+
+// exporter writes the header lazily and the footer on Close
+type exporter struct {
+	encoder     exportEncoder
+	list        ExportList
+	contentType string
+	extension   string
+	started     bool
+}
+
+// NewExporter returns an Exporter writing the list to w in the given format.
+func NewExporter(format string, w io.Writer, list ExportList) (Exporter, error) {
+	e := &exporter{list: list, extension: format}
+	switch format {
+	case ExportFormatCSV:
+		e.encoder = &csvEncoder{w: csv.NewWriter(w)}
+		e.contentType = "text/csv; charset=utf-8"
+	case ExportFormatJSON:
+		e.encoder = &jsonEncoder{w: bufio.NewWriter(w)}
+		e.contentType = "application/json"
+	case ExportFormatICS:
+		e.encoder = &icsEncoder{w: bufio.NewWriter(w), stamp: list.ExportedAt}
+		e.contentType = "text/calendar; charset=utf-8"
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	return e, nil
+}
+
+func (e *exporter) ContentType() string   { return e.contentType }
+func (e *exporter) FileExtension() string { return e.extension }
+
+func (e *exporter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	return e.encoder.header(e.list)
+}
+
+func (e *exporter) WriteTask(task FullTask) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.encoder.task(task)
+}
+
+func (e *exporter) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.encoder.footer()
+}
+
+// exportColumns are the CSV export columns; they match the import field names so exports re-import as-is.
+var exportColumns = []string{
+	"id", ImportFieldTitle, ImportFieldDescription, ImportFieldStatus, ImportFieldPriority,
+	ImportFieldDueDate, ImportFieldCompletedAt, "created_at", "updated_at", ImportFieldTags,
+}
+
+// csvEncoder writes one row per task under a header row
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (c *csvEncoder) header(ExportList) error {
+	return c.w.Write(exportColumns)
+}
+
+func (c *csvEncoder) task(task FullTask) error {
+	return c.w.Write([]string{
+		task.ID.String(),
+		csvText(derefString(task.Title)),
+		csvText(derefString(task.Description)),
+		csvText(derefString(task.Status)),
+		formatOptional(task.Priority, func(p int32) string { return strconv.FormatInt(int64(p), 10) }),
+		formatOptional(setTime(task.DueDate), formatExportTime),
+		formatOptional(setTime(task.CompletedAt), formatExportTime),
+		formatExportTime(task.CreatedAt),
+		formatExportTime(task.UpdatedAt),
+		csvText(strings.Join(task.Tags, ",")),
+	})
+}
+
+// csvFormulaPrefixes start the cells spreadsheets evaluate as formulas
+const csvFormulaPrefixes = "=+-@"
+
+// csvText neutralizes a text cell a spreadsheet would evaluate as a formula by prefixing it with a
+// quote. Cells already starting with a quote are prefixed too, so fromCSVText can undo it on import.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes+"'", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// fromCSVText removes the quote csvText prefixed a cell with
+func fromCSVText(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes+"'", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func (c *csvEncoder) footer() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonEncoder writes {"list": {...}, "tasks": [...]}, one array element per task
+type jsonEncoder struct {
+	w     *bufio.Writer
+	count int
+}
+
+func (j *jsonEncoder) header(list ExportList) error {
+	encoded, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to encode list: %w", err)
+	}
+	_, err = fmt.Fprintf(j.w, "{\"list\":%s,\"tasks\":[", encoded)
+	return err
+}
+
+func (j *jsonEncoder) task(task FullTask) error {
+	encoded, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+	if j.count > 0 {
+		if err := j.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	j.count++
+	if _, err := j.w.Write(encoded); err != nil {
+		return err
+	}
+	// Hand completed batches to the underlying writer instead of growing the buffer
+	if j.w.Buffered() >= j.w.Size()/2 {
+		return j.w.Flush()
+	}
+	return nil
+}
+
+func (j *jsonEncoder) footer() error {
+	if _, err := j.w.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
+
+// icsStatuses maps task statuses to iCalendar VTODO statuses; anything else still needs action
+var icsStatuses = map[string]string{
+	StatusCompleted: "COMPLETED",
+	"in_progress":   "IN-PROCESS",
+	"cancelled":     "CANCELLED",
+}
+
+// icsMaxLineOctets is the content line length after which lines are folded (RFC 5545 section 3.1)
+const icsMaxLineOctets = 75
+
+// icsEncoder writes an iCalendar with one VTODO per task
+type icsEncoder struct {
+	w     *bufio.Writer
+	stamp time.Time
+}
+
+func (c *icsEncoder) header(list ExportList) error {
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//golang-todo-app//Task Export//EN")
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("METHOD", "PUBLISH")
+	c.line("X-WR-CALNAME", escapeICSText(list.Title))
+	if list.Description != "" {
+		c.line("X-WR-CALDESC", escapeICSText(list.Description))
+	}
+	return c.w.Flush()
+}
+
+func (c *icsEncoder) task(task FullTask) error {
+	c.line("BEGIN", "VTODO")
+	c.line("UID", task.ID.String())
+	c.line("DTSTAMP", formatICSTime(c.stamp))
+	c.line("CREATED", formatICSTime(task.CreatedAt))
+	c.line("LAST-MODIFIED", formatICSTime(task.UpdatedAt))
+	c.line("SUMMARY", escapeICSText(derefString(task.Title)))
+	if task.Description != nil && *task.Description != "" {
+		c.line("DESCRIPTION", escapeICSText(*task.Description))
+	}
+	if due := setTime(task.DueDate); due != nil {
+		c.line("DUE", formatICSTime(*due))
+	}
+
+	status := CalendarStatus(task)
+	c.line("STATUS", status)
+	if completedAt := setTime(task.CompletedAt); status == "COMPLETED" && completedAt != nil {
+		c.line("COMPLETED", formatICSTime(*completedAt))
+	}
+
+	// iCalendar priorities run from 1 (highest) to 9 (lowest), like task priorities
+	if task.Priority != nil && *task.Priority >= 1 && *task.Priority <= 9 {
+		c.line("PRIORITY", strconv.Itoa(int(*task.Priority)))
+	}
+	if len(task.Tags) > 0 {
+		categories := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			categories[i] = escapeICSText(tag)
+		}
+		c.line("CATEGORIES", strings.Join(categories, ","))
+	}
+	c.line("END", "VTODO")
+
+	if c.w.Buffered() >= c.w.Size()/2 {
+		return c.w.Flush()
+	}
+	return nil
+}
+
+func (c *icsEncoder) footer() error {
+	c.line("END", "VCALENDAR")
+	return c.w.Flush()
+}
+
+// CalendarStatus returns the iCalendar VTODO status of a task.
+func CalendarStatus(task FullTask) string {
+	if task.Status != nil {
+		if mapped, ok := icsStatuses[*task.Status]; ok {
+			return mapped
+		}
+	}
+	return "NEEDS-ACTION"
+}
+
+// WriteCalendarObject writes a task as a calendar object resource: an iCalendar holding its VTODO alone.
+// Unlike the ICS export it carries no METHOD, as CalDAV requires (RFC 4791 section 4.1).
+func WriteCalendarObject(w io.Writer, task FullTask, stamp time.Time) error {
+	c := &icsEncoder{w: bufio.NewWriter(w), stamp: stamp}
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//golang-todo-app//CalDAV//EN")
+	if err := c.task(task); err != nil {
+		return err
+	}
+	return c.footer()
+}
+
+// line writes a CRLF-terminated content line, folding it at icsMaxLineOctets without splitting characters.
+// Write errors are sticky in the bufio.Writer and reported by the next Flush.
+func (c *icsEncoder) line(name, value string) {
+	content := name + ":" + value
+	limit := icsMaxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		_, _ = c.w.WriteString(content[:cut])
+		_, _ = c.w.WriteString("\r\n ")
+		content = content[cut:]
+		limit = icsMaxLineOctets - 1 // Continuation lines start with a space
+	}
+	_, _ = c.w.WriteString(content)
+	_, _ = c.w.WriteString("\r\n")
+}
+
+// icsTextEscaper escapes TEXT values (RFC 5545 section 3.3.11)
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeICSText(value string) string {
+	return icsTextEscaper.Replace(value)
+}
+
+// formatICSTime formats a UTC DATE-TIME value
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatOptional[T any](value *T, format func(T) string) string {
+	if value == nil {
+		return ""
+	}
+	return format(*value)
+}
+
+// setTime treats the zero time, which unset timestamps are read as, like a missing value
+func setTime(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,232 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportFixtures() (ExportList, []FullTask) {
+	listID := uuid.MustParse("7b0f1c2e-3d4a-4b5c-8d6e-9f0a1b2c3d4e")
+	created := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 11, 1, 17, 30, 0, 0, time.UTC)
+	completed := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	var unset time.Time // Unset timestamps are read as the zero time
+
+	list := ExportList{ID: listID, Title: "Groceries", Description: "Weekly shop", ExportedAt: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)}
+	return list, []FullTask{
+		{
+			ID:          uuid.MustParse("11111111-1111-4111-8111-111111111111"),
+			ListID:      listID,
+			Title:       common.Ptr("Buy milk, eggs; bread"),
+			Description: common.Ptr("Line one\nLine two"),
+			Status:      common.Ptr("pending"),
+			Priority:    common.Ptr(int32(2)),
+			DueDate:     &due,
+			CompletedAt: &unset,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+			Tags:        []string{"home", "errands"},
+		},
+		{
+			ID:          uuid.MustParse("22222222-2222-4222-8222-222222222222"),
+			ListID:      listID,
+			Title:       common.Ptr("Return bottles"),
+			Status:      common.Ptr(StatusCompleted),
+			Priority:    common.Ptr(int32(0)),
+			DueDate:     &unset,
+			CompletedAt: &completed,
+			CreatedAt:   created,
+			UpdatedAt:   completed,
+			Tags:        []string{},
+		},
+	}
+}
+
+func export(t *testing.T, format string) (Exporter, string) {
+	list, tasks := exportFixtures()
+	var buf bytes.Buffer
+	exporter, err := NewExporter(format, &buf, list)
+	require.NoError(t, err)
+	for _, task := range tasks {
+		require.NoError(t, exporter.WriteTask(task))
+	}
+	require.NoError(t, exporter.Close())
+	return exporter, buf.String()
+}
+
+func TestExportCSV(t *testing.T) {
+	exporter, output := export(t, ExportFormatCSV)
+	assert.Equal(t, "text/csv; charset=utf-8", exporter.ContentType())
+	assert.Equal(t, "csv", exporter.FileExtension())
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, exportColumns, records[0])
+	assert.Equal(t, []string{
+		"11111111-1111-4111-8111-111111111111", "Buy milk, eggs; bread", "Line one\nLine two", "pending", "2",
+		"2026-11-01T17:30:00Z", "", "2026-10-01T08:00:00Z", "2026-10-01T08:00:00Z", "home,errands",
+	}, records[1])
+	assert.Equal(t, "", records[2][5], "unset due date is empty")
+	assert.Equal(t, "2026-10-15T09:00:00Z", records[2][6])
+
+	// Exports re-import with the default mapping
+	imported, rowErrors, err := ParseImport(ImportFormatCSV, strings.NewReader(output), nil, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, rowErrors)
+	require.Len(t, imported, 2)
+	assert.Equal(t, "Buy milk, eggs; bread", imported[0].Title)
+	assert.Equal(t, []string{"home", "errands"}, imported[0].Tags)
+}
+
+func TestExportCSVNeutralizesFormulas(t *testing.T) {
+	list, _ := exportFixtures()
+	var buf bytes.Buffer
+	exporter, err := NewExporter(ExportFormatCSV, &buf, list)
+	require.NoError(t, err)
+
+	titles := []string{`=HYPERLINK("http://evil.example","x")`, "+1+1", "-2+3", "@SUM(A1)", "'quoted", "plain"}
+	for _, title := range titles {
+		require.NoError(t, exporter.WriteTask(FullTask{
+			ID: uuid.New(), Title: common.Ptr(title), Description: common.Ptr("=1+1"), Tags: []string{"@home"},
+		}))
+	}
+	require.NoError(t, exporter.Close())
+
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(titles)+1)
+	assert.Equal(t, `'=HYPERLINK("http://evil.example","x")`, records[1][1])
+	assert.Equal(t, "'+1+1", records[2][1])
+	assert.Equal(t, "'-2+3", records[3][1])
+	assert.Equal(t, "'@SUM(A1)", records[4][1])
+	assert.Equal(t, "''quoted", records[5][1])
+	assert.Equal(t, "plain", records[6][1])
+	assert.Equal(t, "'=1+1", records[1][2])
+	assert.Equal(t, "'@home", records[1][9])
+
+	// The quotes are removed again on import
+	imported, rowErrors, err := ParseImport(ImportFormatCSV, strings.NewReader(buf.String()), nil, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, rowErrors)
+	require.Len(t, imported, len(titles))
+	for i, title := range titles {
+		assert.Equal(t, title, imported[i].Title)
+	}
+	require.NotNil(t, imported[0].Description)
+	assert.Equal(t, "=1+1", *imported[0].Description)
+	assert.Equal(t, []string{"@home"}, imported[0].Tags)
+}
+
+func TestExportJSON(t *testing.T) {
+	exporter, output := export(t, ExportFormatJSON)
+	assert.Equal(t, "application/json", exporter.ContentType())
+
+	var document struct {
+		List  ExportList `json:"list"`
+		Tasks []FullTask `json:"tasks"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &document))
+	assert.Equal(t, "Groceries", document.List.Title)
+	require.Len(t, document.Tasks, 2)
+	assert.Equal(t, "Return bottles", *document.Tasks[1].Title)
+}
+
+func TestExportJSONEmptyList(t *testing.T) {
+	list, _ := exportFixtures()
+	var buf bytes.Buffer
+	exporter, err := NewExporter(ExportFormatJSON, &buf, list)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Close())
+
+	var document map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	assert.JSONEq(t, "[]", string(document["tasks"]))
+}
+
+func TestExportICS(t *testing.T) {
+	exporter, output := export(t, ExportFormatICS)
+	assert.Equal(t, "text/calendar; charset=utf-8", exporter.ContentType())
+	assert.Equal(t, "ics", exporter.FileExtension())
+
+	require.True(t, strings.HasSuffix(output, "END:VCALENDAR\r\n"))
+	lines := strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n")
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), icsMaxLineOctets)
+	}
+
+	assert.Equal(t, []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//golang-todo-app//Task Export//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Groceries",
+		"X-WR-CALDESC:Weekly shop",
+		"BEGIN:VTODO",
+		"UID:11111111-1111-4111-8111-111111111111",
+		"DTSTAMP:20261018T120000Z",
+		"CREATED:20261001T080000Z",
+		"LAST-MODIFIED:20261001T080000Z",
+		`SUMMARY:Buy milk\, eggs\; bread`,
+		`DESCRIPTION:Line one\nLine two`,
+		"DUE:20261101T173000Z",
+		"STATUS:NEEDS-ACTION",
+		"PRIORITY:2",
+		"CATEGORIES:home,errands",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:22222222-2222-4222-8222-222222222222",
+		"DTSTAMP:20261018T120000Z",
+		"CREATED:20261001T080000Z",
+		"LAST-MODIFIED:20261015T090000Z",
+		"SUMMARY:Return bottles",
+		"STATUS:COMPLETED",
+		"COMPLETED:20261015T090000Z",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, lines)
+}
+
+func TestExportICSFoldsLongLines(t *testing.T) {
+	list, tasks := exportFixtures()
+	task := tasks[1]
+	task.Title = common.Ptr(strings.Repeat("ü", 100)) // Two octets per character
+
+	var buf bytes.Buffer
+	exporter, err := NewExporter(ExportFormatICS, &buf, list)
+	require.NoError(t, err)
+	require.NoError(t, exporter.WriteTask(task))
+	require.NoError(t, exporter.Close())
+
+	var summary strings.Builder
+	inSummary := false
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		assert.LessOrEqual(t, len(line), icsMaxLineOctets)
+		switch {
+		case strings.HasPrefix(line, "SUMMARY:"):
+			inSummary = true
+			summary.WriteString(strings.TrimPrefix(line, "SUMMARY:"))
+		case inSummary && strings.HasPrefix(line, " "):
+			summary.WriteString(line[1:])
+		default:
+			inSummary = false
+		}
+	}
+	assert.Equal(t, *task.Title, summary.String())
+}
+
+func TestNewExporterUnsupportedFormat(t *testing.T) {
+	_, err := NewExporter("xml", &bytes.Buffer{}, ExportList{})
+	assert.Error(t, err)
+}
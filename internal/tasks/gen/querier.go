@@ -6,17 +6,38 @@ package gen
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
-	DeleteTasks(ctx context.Context, arg DeleteTasksParams) ([]Task, error)
-	ListOverdueTasks(ctx context.Context, arg ListOverdueTasksParams) ([]Task, error)
-	ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, error)
-	ListTasksByStatus(ctx context.Context, arg ListTasksByStatusParams) ([]Task, error)
+	CountTasksByStatus(ctx context.Context, arg CountTasksByStatusParams) (int64, error)
+	CountTasksGroupedByStatus(ctx context.Context, arg CountTasksGroupedByStatusParams) ([]CountTasksGroupedByStatusRow, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
+	CreateTask(ctx context.Context, arg CreateTaskParams) (CreateTaskRow, error)
+	CreateTaskEvent(ctx context.Context, arg CreateTaskEventParams) (TaskEvent, error)
+	CreateTaskWithID(ctx context.Context, arg CreateTaskWithIDParams) (CreateTaskWithIDRow, error)
+	DeleteTasks(ctx context.Context, arg DeleteTasksParams) ([]DeleteTasksRow, error)
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error)
+	FullTextSearchTasks(ctx context.Context, arg FullTextSearchTasksParams) ([]FullTextSearchTasksRow, error)
+	GetTask(ctx context.Context, arg GetTaskParams) (GetTaskRow, error)
+	GetTaskCompletionWindow(ctx context.Context, arg GetTaskCompletionWindowParams) (GetTaskCompletionWindowRow, error)
+	GetTaskForUpdate(ctx context.Context, arg GetTaskForUpdateParams) (GetTaskForUpdateRow, error)
+	GetTaskStatsSummary(ctx context.Context, arg GetTaskStatsSummaryParams) (GetTaskStatsSummaryRow, error)
+	ImportOutboxEvents(ctx context.Context, arg []ImportOutboxEventsParams) (int64, error)
+	ImportTasks(ctx context.Context, arg []ImportTasksParams) (int64, error)
+	ImportWebhookDeliveries(ctx context.Context, arg []ImportWebhookDeliveriesParams) (int64, error)
+	ListDigestTasks(ctx context.Context, arg ListDigestTasksParams) ([]ListDigestTasksRow, error)
+	ListListWebhookSubscriptions(ctx context.Context, id pgtype.UUID) ([]ListListWebhookSubscriptionsRow, error)
+	ListOverdueTasks(ctx context.Context, arg ListOverdueTasksParams) ([]ListOverdueTasksRow, error)
+	ListTaskEvents(ctx context.Context, arg ListTaskEventsParams) ([]TaskEvent, error)
+	ListTasks(ctx context.Context, arg ListTasksParams) ([]ListTasksRow, error)
+	ListTasksByStatus(ctx context.Context, arg ListTasksByStatusParams) ([]ListTasksByStatusRow, error)
+	ListTasksDueBetween(ctx context.Context, arg ListTasksDueBetweenParams) ([]ListTasksDueBetweenRow, error)
+	LockTodoListForImport(ctx context.Context, arg LockTodoListForImportParams) (pgtype.UUID, error)
 	MarkTaskCompleted(ctx context.Context, arg MarkTaskCompletedParams) error
-	SearchTasks(ctx context.Context, arg SearchTasksParams) ([]Task, error)
-	UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error)
+	SearchTasks(ctx context.Context, arg SearchTasksParams) ([]SearchTasksRow, error)
+	UpdateTask(ctx context.Context, arg UpdateTaskParams) (UpdateTaskRow, error)
 	UpdateTaskPriority(ctx context.Context, arg UpdateTaskPriorityParams) error
 }
 
@@ -11,10 +11,103 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTasksByStatus = `-- name: CountTasksByStatus :one
+SELECT COUNT(*)
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE tasks.list_id = $1
+  AND todolists.user_id = $2
+  AND tasks.status = $3
+`
+
+type CountTasksByStatusParams struct {
+	ListID pgtype.UUID `json:"list_id"`
+	UserID pgtype.UUID `json:"user_id"`
+	Status pgtype.Text `json:"status"`
+}
+
+func (q *Queries) CountTasksByStatus(ctx context.Context, arg CountTasksByStatusParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTasksByStatus, arg.ListID, arg.UserID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTasksGroupedByStatus = `-- name: CountTasksGroupedByStatus :many
+SELECT COALESCE(tasks.status, 'pending')::text AS status,
+       COUNT(*) AS count
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = $1
+  AND ($2::uuid IS NULL OR tasks.list_id = $2)
+GROUP BY 1
+ORDER BY 1
+`
+
+type CountTasksGroupedByStatusParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	ListID pgtype.UUID `json:"list_id"`
+}
+
+type CountTasksGroupedByStatusRow struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+func (q *Queries) CountTasksGroupedByStatus(ctx context.Context, arg CountTasksGroupedByStatusParams) ([]CountTasksGroupedByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countTasksGroupedByStatus, arg.UserID, arg.ListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountTasksGroupedByStatusRow
+	for rows.Next() {
+		var i CountTasksGroupedByStatusRow
+		if err := rows.Scan(
+			&i.Status,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateOutboxEventParams struct {
+	ID            pgtype.UUID      `json:"id"`
+	AggregateType string           `json:"aggregate_type"`
+	AggregateID   pgtype.UUID      `json:"aggregate_id"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, createOutboxEvent,
+		arg.ID,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Payload,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createTask = `-- name: CreateTask :one
 INSERT INTO tasks (list_id, title, description, status, due_date, priority)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, list_id, title, description, status, priority, due_date, completed_at, created_at, updated_at
+RETURNING id, list_id, title, description, status, priority,
+          due_date, completed_at, created_at, updated_at, tags
 `
 
 type CreateTaskParams struct {
@@ -26,7 +119,21 @@ type CreateTaskParams struct {
 	Priority    pgtype.Int4      `json:"priority"`
 }
 
-func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
+type CreateTaskRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (CreateTaskRow, error) {
 	row := q.db.QueryRow(ctx, createTask,
 		arg.ListID,
 		arg.Title,
@@ -35,7 +142,7 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		arg.DueDate,
 		arg.Priority,
 	)
-	var i Task
+	var i CreateTaskRow
 	err := row.Scan(
 		&i.ID,
 		&i.ListID,
@@ -47,6 +154,104 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.CompletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tags,
+	)
+	return i, err
+}
+
+const createTaskEvent = `-- name: CreateTaskEvent :one
+INSERT INTO task_events (task_id, list_id, actor_id, event_type, changes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, task_id, list_id, actor_id, event_type, changes, created_at
+`
+
+type CreateTaskEventParams struct {
+	TaskID    pgtype.UUID `json:"task_id"`
+	ListID    pgtype.UUID `json:"list_id"`
+	ActorID   pgtype.UUID `json:"actor_id"`
+	EventType string      `json:"event_type"`
+	Changes   []byte      `json:"changes"`
+}
+
+func (q *Queries) CreateTaskEvent(ctx context.Context, arg CreateTaskEventParams) (TaskEvent, error) {
+	row := q.db.QueryRow(ctx, createTaskEvent,
+		arg.TaskID,
+		arg.ListID,
+		arg.ActorID,
+		arg.EventType,
+		arg.Changes,
+	)
+	var i TaskEvent
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.ListID,
+		&i.ActorID,
+		&i.EventType,
+		&i.Changes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTaskWithID = `-- name: CreateTaskWithID :one
+INSERT INTO tasks (id, list_id, title, description, status, due_date, priority, completed_at, tags)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, list_id, title, description, status, priority,
+          due_date, completed_at, created_at, updated_at, tags
+`
+
+type CreateTaskWithIDParams struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	Priority    pgtype.Int4      `json:"priority"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	Tags        []string         `json:"tags"`
+}
+
+type CreateTaskWithIDRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) CreateTaskWithID(ctx context.Context, arg CreateTaskWithIDParams) (CreateTaskWithIDRow, error) {
+	row := q.db.QueryRow(ctx, createTaskWithID,
+		arg.ID,
+		arg.ListID,
+		arg.Title,
+		arg.Description,
+		arg.Status,
+		arg.DueDate,
+		arg.Priority,
+		arg.CompletedAt,
+		arg.Tags,
+	)
+	var i CreateTaskWithIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -57,7 +262,8 @@ USING todolists
 WHERE tasks.id = ANY($1::uuid[])
   AND tasks.list_id = todolists.id
   AND todolists.user_id = $2
-RETURNING tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority, tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at
+RETURNING tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+          tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 `
 
 type DeleteTasksParams struct {
@@ -65,15 +271,151 @@ type DeleteTasksParams struct {
 	UserID  pgtype.UUID   `json:"user_id"`
 }
 
-func (q *Queries) DeleteTasks(ctx context.Context, arg DeleteTasksParams) ([]Task, error) {
+type DeleteTasksRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) DeleteTasks(ctx context.Context, arg DeleteTasksParams) ([]DeleteTasksRow, error) {
 	rows, err := q.db.Query(ctx, deleteTasks, arg.Column1, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []DeleteTasksRow
+	for rows.Next() {
+		var i DeleteTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const enqueueWebhookDeliveries = `-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT webhook_subscriptions.id, $1, $2, $3
+FROM webhook_subscriptions
+JOIN todolists ON todolists.user_id = webhook_subscriptions.user_id
+WHERE todolists.id = $4
+  AND webhook_subscriptions.active
+  AND $2::text = ANY(webhook_subscriptions.event_types)
+`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID   pgtype.UUID `json:"event_id"`
+	EventType string      `json:"event_type"`
+	Payload   []byte      `json:"payload"`
+	ListID    pgtype.UUID `json:"list_id"`
+}
+
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enqueueWebhookDeliveries,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.ListID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const fullTextSearchTasks = `-- name: FullTextSearchTasks :many
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags,
+       ts_rank(tasks.search_vector, websearch_to_tsquery('english', $1))::real AS rank,
+       ts_headline('english', coalesce(tasks.title, ''), websearch_to_tsquery('english', $1),
+                   'StartSel=' || chr(57344) || ', StopSel=' || chr(57345) || ', HighlightAll=true')::text AS title_snippet,
+       ts_headline('english', coalesce(tasks.description, ''), websearch_to_tsquery('english', $1),
+                   'StartSel=' || chr(57344) || ', StopSel=' || chr(57345) || ', MaxFragments=2, MaxWords=20, MinWords=5')::text AS description_snippet
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = $2
+  AND tasks.search_vector @@ websearch_to_tsquery('english', $1)
+  AND ($3::text IS NULL OR tasks.status = $3)
+  AND ($4::int IS NULL OR tasks.priority >= $4)
+  AND ($5::int IS NULL OR tasks.priority <= $5)
+  AND ($6::timestamp IS NULL OR tasks.due_date >= $6)
+  AND ($7::timestamp IS NULL OR tasks.due_date < $7)
+ORDER BY rank DESC, tasks.due_date ASC NULLS LAST, tasks.id
+LIMIT $8 OFFSET $9
+`
+
+type FullTextSearchTasksParams struct {
+	Query       string           `json:"query"`
+	UserID      pgtype.UUID      `json:"user_id"`
+	Status      pgtype.Text      `json:"status"`
+	MinPriority pgtype.Int4      `json:"min_priority"`
+	MaxPriority pgtype.Int4      `json:"max_priority"`
+	DueAfter    pgtype.Timestamp `json:"due_after"`
+	DueBefore   pgtype.Timestamp `json:"due_before"`
+	Limit       int32            `json:"limit"`
+	Offset      int32            `json:"offset"`
+}
+
+type FullTextSearchTasksRow struct {
+	ID                 pgtype.UUID      `json:"id"`
+	ListID             pgtype.UUID      `json:"list_id"`
+	Title              pgtype.Text      `json:"title"`
+	Description        pgtype.Text      `json:"description"`
+	Status             pgtype.Text      `json:"status"`
+	Priority           pgtype.Int4      `json:"priority"`
+	DueDate            pgtype.Timestamp `json:"due_date"`
+	CompletedAt        pgtype.Timestamp `json:"completed_at"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+	UpdatedAt          pgtype.Timestamp `json:"updated_at"`
+	Tags               []string         `json:"tags"`
+	Rank               float32          `json:"rank"`
+	TitleSnippet       string           `json:"title_snippet"`
+	DescriptionSnippet string           `json:"description_snippet"`
+}
+
+func (q *Queries) FullTextSearchTasks(ctx context.Context, arg FullTextSearchTasksParams) ([]FullTextSearchTasksRow, error) {
+	rows, err := q.db.Query(ctx, fullTextSearchTasks,
+		arg.Query,
+		arg.UserID,
+		arg.Status,
+		arg.MinPriority,
+		arg.MaxPriority,
+		arg.DueAfter,
+		arg.DueBefore,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FullTextSearchTasksRow
 	for rows.Next() {
-		var i Task
+		var i FullTextSearchTasksRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ListID,
@@ -85,6 +427,303 @@ func (q *Queries) DeleteTasks(ctx context.Context, arg DeleteTasksParams) ([]Tas
 			&i.CompletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
+			&i.Rank,
+			&i.TitleSnippet,
+			&i.DescriptionSnippet,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTask = `-- name: GetTask :one
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE tasks.id = $1
+  AND tasks.list_id = $2
+  AND todolists.user_id = $3
+`
+
+type GetTaskParams struct {
+	ID     pgtype.UUID `json:"id"`
+	ListID pgtype.UUID `json:"list_id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetTaskRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) GetTask(ctx context.Context, arg GetTaskParams) (GetTaskRow, error) {
+	row := q.db.QueryRow(ctx, getTask, arg.ID, arg.ListID, arg.UserID)
+	var i GetTaskRow
+	err := row.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
+	)
+	return i, err
+}
+
+const getTaskCompletionWindow = `-- name: GetTaskCompletionWindow :one
+SELECT COUNT(*) AS created,
+       COUNT(*) FILTER (WHERE tasks.completed_at IS NOT NULL) AS completed
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = $1
+  AND ($2::uuid IS NULL OR tasks.list_id = $2)
+  AND tasks.created_at >= $3
+`
+
+type GetTaskCompletionWindowParams struct {
+	UserID pgtype.UUID      `json:"user_id"`
+	ListID pgtype.UUID      `json:"list_id"`
+	Since  pgtype.Timestamp `json:"since"`
+}
+
+type GetTaskCompletionWindowRow struct {
+	Created   int64 `json:"created"`
+	Completed int64 `json:"completed"`
+}
+
+func (q *Queries) GetTaskCompletionWindow(ctx context.Context, arg GetTaskCompletionWindowParams) (GetTaskCompletionWindowRow, error) {
+	row := q.db.QueryRow(ctx, getTaskCompletionWindow, arg.UserID, arg.ListID, arg.Since)
+	var i GetTaskCompletionWindowRow
+	err := row.Scan(
+		&i.Created,
+		&i.Completed,
+	)
+	return i, err
+}
+
+const getTaskForUpdate = `-- name: GetTaskForUpdate :one
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE tasks.id = $1
+  AND todolists.user_id = $2
+FOR UPDATE OF tasks
+`
+
+type GetTaskForUpdateParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetTaskForUpdateRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) GetTaskForUpdate(ctx context.Context, arg GetTaskForUpdateParams) (GetTaskForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getTaskForUpdate, arg.ID, arg.UserID)
+	var i GetTaskForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
+	)
+	return i, err
+}
+
+const getTaskStatsSummary = `-- name: GetTaskStatsSummary :one
+SELECT COUNT(*) AS total,
+       COUNT(*) FILTER (WHERE tasks.due_date < CURRENT_TIMESTAMP AND tasks.status != 'completed') AS overdue,
+       COUNT(*) FILTER (WHERE tasks.completed_at IS NOT NULL) AS completed,
+       COALESCE(AVG(EXTRACT(EPOCH FROM tasks.completed_at - tasks.created_at))
+                FILTER (WHERE tasks.completed_at IS NOT NULL), 0)::float8 AS avg_completion_seconds
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = $1
+  AND ($2::uuid IS NULL OR tasks.list_id = $2)
+`
+
+type GetTaskStatsSummaryParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	ListID pgtype.UUID `json:"list_id"`
+}
+
+type GetTaskStatsSummaryRow struct {
+	Total                int64   `json:"total"`
+	Overdue              int64   `json:"overdue"`
+	Completed            int64   `json:"completed"`
+	AvgCompletionSeconds float64 `json:"avg_completion_seconds"`
+}
+
+func (q *Queries) GetTaskStatsSummary(ctx context.Context, arg GetTaskStatsSummaryParams) (GetTaskStatsSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getTaskStatsSummary, arg.UserID, arg.ListID)
+	var i GetTaskStatsSummaryRow
+	err := row.Scan(
+		&i.Total,
+		&i.Overdue,
+		&i.Completed,
+		&i.AvgCompletionSeconds,
+	)
+	return i, err
+}
+
+type ImportOutboxEventsParams struct {
+	ID            pgtype.UUID      `json:"id"`
+	AggregateType string           `json:"aggregate_type"`
+	AggregateID   pgtype.UUID      `json:"aggregate_id"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+type ImportTasksParams struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+type ImportWebhookDeliveriesParams struct {
+	SubscriptionID pgtype.UUID `json:"subscription_id"`
+	EventID        pgtype.UUID `json:"event_id"`
+	EventType      string      `json:"event_type"`
+	Payload        []byte      `json:"payload"`
+}
+
+const listDigestTasks = `-- name: ListDigestTasks :many
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = $1
+  AND ((tasks.status != 'completed' AND tasks.due_date < $2)
+    OR tasks.completed_at >= $3)
+ORDER BY tasks.due_date ASC NULLS LAST, tasks.id
+`
+
+type ListDigestTasksParams struct {
+	UserID         pgtype.UUID      `json:"user_id"`
+	DueBefore      pgtype.Timestamp `json:"due_before"`
+	CompletedSince pgtype.Timestamp `json:"completed_since"`
+}
+
+type ListDigestTasksRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) ListDigestTasks(ctx context.Context, arg ListDigestTasksParams) ([]ListDigestTasksRow, error) {
+	rows, err := q.db.Query(ctx, listDigestTasks, arg.UserID, arg.DueBefore, arg.CompletedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDigestTasksRow
+	for rows.Next() {
+		var i ListDigestTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listListWebhookSubscriptions = `-- name: ListListWebhookSubscriptions :many
+SELECT webhook_subscriptions.id, webhook_subscriptions.event_types
+FROM webhook_subscriptions
+JOIN todolists ON todolists.user_id = webhook_subscriptions.user_id
+WHERE todolists.id = $1
+  AND webhook_subscriptions.active
+`
+
+type ListListWebhookSubscriptionsRow struct {
+	ID         pgtype.UUID `json:"id"`
+	EventTypes []string    `json:"event_types"`
+}
+
+func (q *Queries) ListListWebhookSubscriptions(ctx context.Context, id pgtype.UUID) ([]ListListWebhookSubscriptionsRow, error) {
+	rows, err := q.db.Query(ctx, listListWebhookSubscriptions, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListListWebhookSubscriptionsRow
+	for rows.Next() {
+		var i ListListWebhookSubscriptionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventTypes,
 		); err != nil {
 			return nil, err
 		}
@@ -97,7 +736,8 @@ func (q *Queries) DeleteTasks(ctx context.Context, arg DeleteTasksParams) ([]Tas
 }
 
 const listOverdueTasks = `-- name: ListOverdueTasks :many
-SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority, tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE tasks.list_id = $1
@@ -112,15 +752,29 @@ type ListOverdueTasksParams struct {
 	UserID pgtype.UUID `json:"user_id"`
 }
 
-func (q *Queries) ListOverdueTasks(ctx context.Context, arg ListOverdueTasksParams) ([]Task, error) {
+type ListOverdueTasksRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) ListOverdueTasks(ctx context.Context, arg ListOverdueTasksParams) ([]ListOverdueTasksRow, error) {
 	rows, err := q.db.Query(ctx, listOverdueTasks, arg.ListID, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []ListOverdueTasksRow
 	for rows.Next() {
-		var i Task
+		var i ListOverdueTasksRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ListID,
@@ -132,6 +786,60 @@ func (q *Queries) ListOverdueTasks(ctx context.Context, arg ListOverdueTasksPara
 			&i.CompletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskEvents = `-- name: ListTaskEvents :many
+SELECT task_events.id, task_events.task_id, task_events.list_id, task_events.actor_id, task_events.event_type, task_events.changes, task_events.created_at
+FROM task_events
+JOIN todolists ON task_events.list_id = todolists.id
+WHERE task_events.task_id = $1
+  AND task_events.list_id = $2
+  AND todolists.user_id = $3
+ORDER BY task_events.created_at DESC, task_events.id DESC
+LIMIT $4 OFFSET $5
+`
+
+type ListTaskEventsParams struct {
+	TaskID pgtype.UUID `json:"task_id"`
+	ListID pgtype.UUID `json:"list_id"`
+	UserID pgtype.UUID `json:"user_id"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+}
+
+func (q *Queries) ListTaskEvents(ctx context.Context, arg ListTaskEventsParams) ([]TaskEvent, error) {
+	rows, err := q.db.Query(ctx, listTaskEvents,
+		arg.TaskID,
+		arg.ListID,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskEvent
+	for rows.Next() {
+		var i TaskEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.ListID,
+			&i.ActorID,
+			&i.EventType,
+			&i.Changes,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -144,7 +852,8 @@ func (q *Queries) ListOverdueTasks(ctx context.Context, arg ListOverdueTasksPara
 }
 
 const listTasks = `-- name: ListTasks :many
-SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority, tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE todolists.id = $1
@@ -157,15 +866,29 @@ type ListTasksParams struct {
 	UserID pgtype.UUID `json:"user_id"`
 }
 
-func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, error) {
+type ListTasksRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]ListTasksRow, error) {
 	rows, err := q.db.Query(ctx, listTasks, arg.ID, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []ListTasksRow
 	for rows.Next() {
-		var i Task
+		var i ListTasksRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ListID,
@@ -177,6 +900,7 @@ func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, e
 			&i.CompletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -189,7 +913,8 @@ func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, e
 }
 
 const listTasksByStatus = `-- name: ListTasksByStatus :many
-SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority, tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE tasks.list_id = $1
@@ -203,15 +928,29 @@ type ListTasksByStatusParams struct {
 	Status pgtype.Text `json:"status"`
 }
 
-func (q *Queries) ListTasksByStatus(ctx context.Context, arg ListTasksByStatusParams) ([]Task, error) {
+type ListTasksByStatusRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) ListTasksByStatus(ctx context.Context, arg ListTasksByStatusParams) ([]ListTasksByStatusRow, error) {
 	rows, err := q.db.Query(ctx, listTasksByStatus, arg.ListID, arg.UserID, arg.Status)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []ListTasksByStatusRow
 	for rows.Next() {
-		var i Task
+		var i ListTasksByStatusRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ListID,
@@ -223,6 +962,7 @@ func (q *Queries) ListTasksByStatus(ctx context.Context, arg ListTasksByStatusPa
 			&i.CompletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -234,6 +974,98 @@ func (q *Queries) ListTasksByStatus(ctx context.Context, arg ListTasksByStatusPa
 	return items, nil
 }
 
+const listTasksDueBetween = `-- name: ListTasksDueBetween :many
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags,
+       users.id AS user_id,
+       users.email AS user_email,
+       users.name AS user_name
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+JOIN users ON todolists.user_id = users.id
+WHERE tasks.due_date >= $1
+  AND tasks.due_date < $2
+  AND tasks.status != 'completed'
+ORDER BY tasks.due_date ASC, tasks.id
+`
+
+type ListTasksDueBetweenParams struct {
+	DueFrom pgtype.Timestamp `json:"due_from"`
+	DueTo   pgtype.Timestamp `json:"due_to"`
+}
+
+type ListTasksDueBetweenRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+	UserID      pgtype.UUID      `json:"user_id"`
+	UserEmail   string           `json:"user_email"`
+	UserName    string           `json:"user_name"`
+}
+
+func (q *Queries) ListTasksDueBetween(ctx context.Context, arg ListTasksDueBetweenParams) ([]ListTasksDueBetweenRow, error) {
+	rows, err := q.db.Query(ctx, listTasksDueBetween, arg.DueFrom, arg.DueTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTasksDueBetweenRow
+	for rows.Next() {
+		var i ListTasksDueBetweenRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Tags,
+			&i.UserID,
+			&i.UserEmail,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTodoListForImport = `-- name: LockTodoListForImport :one
+SELECT todolists.id
+FROM todolists
+WHERE todolists.id = $1
+  AND todolists.user_id = $2
+FOR SHARE
+`
+
+type LockTodoListForImportParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) LockTodoListForImport(ctx context.Context, arg LockTodoListForImportParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, lockTodoListForImport, arg.ID, arg.UserID)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const markTaskCompleted = `-- name: MarkTaskCompleted :exec
 UPDATE tasks
 SET 
@@ -259,7 +1091,8 @@ func (q *Queries) MarkTaskCompleted(ctx context.Context, arg MarkTaskCompletedPa
 }
 
 const searchTasks = `-- name: SearchTasks :many
-SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority, tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE tasks.list_id = $1
@@ -274,15 +1107,29 @@ type SearchTasksParams struct {
 	Column3 pgtype.Text `json:"column_3"`
 }
 
-func (q *Queries) SearchTasks(ctx context.Context, arg SearchTasksParams) ([]Task, error) {
+type SearchTasksRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
+}
+
+func (q *Queries) SearchTasks(ctx context.Context, arg SearchTasksParams) ([]SearchTasksRow, error) {
 	rows, err := q.db.Query(ctx, searchTasks, arg.ListID, arg.UserID, arg.Column3)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []SearchTasksRow
 	for rows.Next() {
-		var i Task
+		var i SearchTasksRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ListID,
@@ -294,6 +1141,7 @@ func (q *Queries) SearchTasks(ctx context.Context, arg SearchTasksParams) ([]Tas
 			&i.CompletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -313,12 +1161,14 @@ SET title = COALESCE($3, tasks.title),
     due_date = COALESCE($6, due_date),
     priority = COALESCE($7, priority),
     updated_at = CURRENT_TIMESTAMP,
-    completed_at = COALESCE($8, completed_at)
+    completed_at = COALESCE($8, completed_at),
+    tags = COALESCE($9::text[], tags)
 FROM todolists
 WHERE tasks.id = $1
   AND tasks.list_id = todolists.id
   AND todolists.user_id = $2
-RETURNING tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority, tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at
+RETURNING tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+          tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 `
 
 type UpdateTaskParams struct {
@@ -330,9 +1180,24 @@ type UpdateTaskParams struct {
 	DueDate     pgtype.Timestamp `json:"due_date"`
 	Priority    pgtype.Int4      `json:"priority"`
 	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	Tags        []string         `json:"tags"`
+}
+
+type UpdateTaskRow struct {
+	ID          pgtype.UUID      `json:"id"`
+	ListID      pgtype.UUID      `json:"list_id"`
+	Title       pgtype.Text      `json:"title"`
+	Description pgtype.Text      `json:"description"`
+	Status      pgtype.Text      `json:"status"`
+	Priority    pgtype.Int4      `json:"priority"`
+	DueDate     pgtype.Timestamp `json:"due_date"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Tags        []string         `json:"tags"`
 }
 
-func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error) {
+func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (UpdateTaskRow, error) {
 	row := q.db.QueryRow(ctx, updateTask,
 		arg.ID,
 		arg.UserID,
@@ -342,8 +1207,9 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		arg.DueDate,
 		arg.Priority,
 		arg.CompletedAt,
+		arg.Tags,
 	)
-	var i Task
+	var i UpdateTaskRow
 	err := row.Scan(
 		&i.ID,
 		&i.ListID,
@@ -355,6 +1221,7 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		&i.CompletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tags,
 	)
 	return i, err
 }
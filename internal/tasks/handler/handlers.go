@@ -0,0 +1,633 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	store  tasks.Repository
+	stats  tasks.StatsProvider
+	lists  tasks.ListReader
+	logger *zap.SugaredLogger
+}
+
+// New initializes a new task Handler instance
+func New(store tasks.Repository, stats tasks.StatsProvider, lists tasks.ListReader, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		store:  store,
+		stats:  stats,
+		lists:  lists,
+		logger: logger,
+	}
+}
+
+// ListTaskEventsHandler handles retrieving a page of a task's change history
+func (h *Handler) ListTaskEventsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+
+	// The caller must be identified to read a task's history
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("ListTaskEventsHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated IDs from context
+	listID, listOK := r.Context().Value(listIDKey).(uuid.UUID)
+	taskID, taskOK := r.Context().Value(taskIDKey).(uuid.UUID)
+	if !listOK || !taskOK {
+		logger.Errorw("ListTaskEventsHandler failed: list or task ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Extract query parameters
+	limit := tasks.DefaultEventLimit
+	offset := tasks.DefaultEventOffset
+
+	// Parse limit parameter if provided
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > tasks.MaxEventLimit {
+			logger.Warnw("ListTaskEventsHandler failed: invalid limit parameter", "limit", limitStr)
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	// Parse offset parameter if provided
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.ParseInt(offsetStr, 10, 32)
+		if err != nil || parsedOffset < 0 {
+			logger.Warnw("ListTaskEventsHandler failed: invalid offset parameter", "offset", offsetStr)
+			http.Error(w, "Invalid offset parameter", http.StatusBadRequest)
+			return
+		}
+		offset = int(parsedOffset)
+	}
+
+	params := tasks.ListTaskEventsParams{
+		TaskID: taskID,
+		ListID: listID,
+		UserID: callerID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	// Call the store
+	events, err := h.store.ListTaskEvents(r.Context(), params)
+	if err != nil {
+		logger.Errorw("ListTaskEventsHandler failed: internal server error", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Ensure an empty array instead of nil
+	if len(events) == 0 {
+		events = []tasks.TaskEvent{}
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		logger.Errorw("ListTaskEventsHandler failed: failed to encode response", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// SearchTasksHandler handles ranked full-text search across all of the caller's lists.
+// Supported parameters: q (required), status, min_priority, max_priority, due_after, due_before (RFC 3339), limit, offset.
+func (h *Handler) SearchTasksHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+	query := r.URL.Query()
+
+	// The caller must be identified to search their tasks
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("SearchTasksHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// A search term is required
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		logger.Warnw("SearchTasksHandler failed: missing q parameter")
+		http.Error(w, "Missing q parameter", http.StatusBadRequest)
+		return
+	}
+
+	params := tasks.FullTextSearchParams{
+		UserID: callerID,
+		Query:  q,
+		Limit:  tasks.DefaultSearchLimit,
+		Offset: tasks.DefaultSearchOffset,
+	}
+
+	// Parse status parameter if provided
+	if status := query.Get("status"); status != "" {
+		params.Status = &status
+	}
+
+	// Parse priority range parameters if provided
+	for name, dest := range map[string]**int32{"min_priority": &params.MinPriority, "max_priority": &params.MaxPriority} {
+		if value := query.Get(name); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				logger.Warnw("SearchTasksHandler failed: invalid priority parameter", name, value)
+				http.Error(w, "Invalid "+name+" parameter", http.StatusBadRequest)
+				return
+			}
+			priority := int32(parsed)
+			*dest = &priority
+		}
+	}
+
+	// Parse due date range parameters if provided
+	for name, dest := range map[string]**time.Time{"due_after": &params.DueAfter, "due_before": &params.DueBefore} {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				logger.Warnw("SearchTasksHandler failed: invalid time parameter", name, value)
+				http.Error(w, "Invalid "+name+" parameter", http.StatusBadRequest)
+				return
+			}
+			*dest = &parsed
+		}
+	}
+
+	// Parse limit parameter if provided
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > tasks.MaxSearchLimit {
+			logger.Warnw("SearchTasksHandler failed: invalid limit parameter", "limit", limitStr)
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		params.Limit = int32(parsedLimit)
+	}
+
+	// Parse offset parameter if provided
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.ParseInt(offsetStr, 10, 32)
+		if err != nil || parsedOffset < 0 {
+			logger.Warnw("SearchTasksHandler failed: invalid offset parameter", "offset", offsetStr)
+			http.Error(w, "Invalid offset parameter", http.StatusBadRequest)
+			return
+		}
+		params.Offset = int32(parsedOffset)
+	}
+
+	// Call the store
+	results, err := h.store.SearchAllTasks(r.Context(), params)
+	if err != nil {
+		logger.Errorw("SearchTasksHandler failed: internal server error", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Ensure an empty array instead of nil
+	if len(results) == 0 {
+		results = []tasks.TaskSearchResult{}
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		logger.Errorw("SearchTasksHandler failed: failed to encode response", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// ListTasksHandler handles filtered, sorted listing of the tasks in one of the caller's lists.
+// Supported parameters: status and tags (comma-separated), min_priority, max_priority,
+// due_after, due_before, completed_after, completed_before (RFC 3339), q, sort, limit, offset.
+// sort is a comma-separated list of fields, each optionally prefixed with "-" for descending order.
+func (h *Handler) ListTasksHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+	query := r.URL.Query()
+
+	// The caller must be identified to list tasks
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("ListTasksHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated list ID from context
+	listID, ok := r.Context().Value(listIDKey).(uuid.UUID)
+	if !ok {
+		logger.Errorw("ListTasksHandler failed: list ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	params := tasks.TaskQuery{
+		ListID:   listID,
+		UserID:   callerID,
+		Statuses: splitList(query["status"]),
+		Tags:     splitList(query["tags"]),
+		Limit:    tasks.DefaultListLimit,
+		Offset:   tasks.DefaultListOffset,
+	}
+
+	// Parse text parameter if provided
+	if text := strings.TrimSpace(query.Get("q")); text != "" {
+		params.Text = &text
+	}
+
+	// Parse priority range parameters if provided
+	for name, dest := range map[string]**int32{"min_priority": &params.MinPriority, "max_priority": &params.MaxPriority} {
+		if value := query.Get(name); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				logger.Warnw("ListTasksHandler failed: invalid priority parameter", name, value)
+				http.Error(w, "Invalid "+name+" parameter", http.StatusBadRequest)
+				return
+			}
+			priority := int32(parsed)
+			*dest = &priority
+		}
+	}
+
+	// Parse time range parameters if provided
+	timeParams := map[string]**time.Time{
+		"due_after":        &params.DueAfter,
+		"due_before":       &params.DueBefore,
+		"completed_after":  &params.CompletedAfter,
+		"completed_before": &params.CompletedBefore,
+	}
+	for name, dest := range timeParams {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				logger.Warnw("ListTasksHandler failed: invalid time parameter", name, value)
+				http.Error(w, "Invalid "+name+" parameter", http.StatusBadRequest)
+				return
+			}
+			*dest = &parsed
+		}
+	}
+
+	// Parse sort parameter if provided
+	if sortStr := query.Get("sort"); sortStr != "" {
+		sortKeys, err := tasks.ParseSort(sortStr)
+		if err != nil {
+			logger.Warnw("ListTasksHandler failed: invalid sort parameter", "sort", sortStr, "error", err)
+			http.Error(w, "Invalid sort parameter", http.StatusBadRequest)
+			return
+		}
+		params.Sort = sortKeys
+	}
+
+	// Parse limit parameter if provided
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > tasks.MaxListLimit {
+			logger.Warnw("ListTasksHandler failed: invalid limit parameter", "limit", limitStr)
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		params.Limit = int32(parsedLimit)
+	}
+
+	// Parse offset parameter if provided
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.ParseInt(offsetStr, 10, 32)
+		if err != nil || parsedOffset < 0 {
+			logger.Warnw("ListTasksHandler failed: invalid offset parameter", "offset", offsetStr)
+			http.Error(w, "Invalid offset parameter", http.StatusBadRequest)
+			return
+		}
+		params.Offset = int32(parsedOffset)
+	}
+
+	// Call the store
+	result, err := h.store.QueryTasks(r.Context(), params)
+	if err != nil {
+		logger.Errorw("ListTasksHandler failed: internal server error", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Ensure an empty array instead of nil
+	if len(result) == 0 {
+		result = []tasks.FullTask{}
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.Errorw("ListTasksHandler failed: failed to encode response", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// splitList flattens repeated and comma-separated query values, dropping empty entries.
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// ListStatsHandler handles retrieving task statistics for one of the caller's lists
+func (h *Handler) ListStatsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+
+	// The caller must be identified to read list statistics
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("ListStatsHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated list ID from context
+	listID, ok := r.Context().Value(listIDKey).(uuid.UUID)
+	if !ok {
+		logger.Errorw("ListStatsHandler failed: list ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	params := tasks.TaskStatsParams{
+		UserID: callerID,
+		ListID: &listID,
+	}
+
+	// Call the store
+	stats, err := h.store.GetTaskStats(r.Context(), params)
+	if err != nil {
+		logger.Errorw("ListStatsHandler failed: internal server error", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		logger.Errorw("ListStatsHandler failed: failed to encode response", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// UserStatsHandler handles retrieving task statistics across all of a user's lists.
+// Callers may only read their own statistics.
+func (h *Handler) UserStatsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+
+	// The caller must be identified to read user statistics
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("UserStatsHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated user ID from context
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		logger.Errorw("UserStatsHandler failed: user ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if userID != callerID {
+		logger.Warnw("UserStatsHandler failed: caller does not own the requested stats", "caller_id", callerID, "user_id", userID)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	// Fetch the (possibly cached) stats
+	stats, err := h.stats.UserStats(r.Context(), userID)
+	if err != nil {
+		logger.Errorw("UserStatsHandler failed: internal server error", "user_id", userID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		logger.Errorw("UserStatsHandler failed: failed to encode response", "user_id", userID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// ImportTasksHandler handles importing tasks into a list from a CSV or JSON file sent as the request body.
+// The format comes from the `format` query parameter or the Content-Type header; CSV columns can be
+// renamed with repeated `map=field=Column` parameters. Valid rows are imported and invalid rows reported;
+// with `dry_run=true` nothing is kept.
+func (h *Handler) ImportTasksHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+
+	// The caller must be identified to import tasks
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("ImportTasksHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated list ID from context
+	listID, ok := r.Context().Value(listIDKey).(uuid.UUID)
+	if !ok {
+		logger.Errorw("ImportTasksHandler failed: list ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	format, ok := importFormat(r)
+	if !ok {
+		logger.Warnw("ImportTasksHandler failed: invalid format", "format", r.URL.Query().Get("format"))
+		http.Error(w, "Invalid format parameter", http.StatusBadRequest)
+		return
+	}
+
+	mapping, err := tasks.ParseImportMapping(r.URL.Query()["map"])
+	if err != nil {
+		logger.Warnw("ImportTasksHandler failed: invalid mapping", "error", err)
+		http.Error(w, "Invalid map parameter", http.StatusBadRequest)
+		return
+	}
+
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			logger.Warnw("ImportTasksHandler failed: invalid dry_run", "dry_run", dryRunStr)
+			http.Error(w, "Invalid dry_run parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Parse and validate every row before touching the database
+	importTasks, rowErrors, err := tasks.ParseImport(format, r.Body, mapping, time.Now().UTC())
+	if err != nil {
+		logger.Warnw("ImportTasksHandler failed: invalid import file", "list_id", listID, "error", err)
+		http.Error(w, "Invalid import file", http.StatusBadRequest)
+		return
+	}
+
+	result := tasks.ImportResult{
+		Failed: len(rowErrors),
+		DryRun: dryRun,
+		Errors: rowErrors,
+	}
+	if result.Errors == nil {
+		result.Errors = []tasks.ImportRowError{}
+	}
+
+	if len(importTasks) > 0 {
+		params := tasks.ImportTasksParams{
+			ListID: listID,
+			UserID: callerID,
+			Tasks:  importTasks,
+			DryRun: dryRun,
+		}
+
+		// Call the store
+		result.Imported, err = h.store.ImportTasks(r.Context(), params)
+		if err != nil {
+			if errors.Is(err, common.ErrNotFound) {
+				logger.Warnw("ImportTasksHandler failed: list not found", "list_id", listID, "user_id", callerID)
+				http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+				return
+			}
+			logger.Errorw("ImportTasksHandler failed: internal server error", "list_id", listID, "error", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.Errorw("ImportTasksHandler failed: failed to encode response", "list_id", listID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// importFormat resolves the import format from the `format` query parameter, falling back to the Content-Type
+func importFormat(r *http.Request) (string, bool) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		format = strings.ToLower(format)
+		return format, format == tasks.ImportFormatCSV || format == tasks.ImportFormatJSON
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false
+	}
+	switch mediaType {
+	case "text/csv":
+		return tasks.ImportFormatCSV, true
+	case "application/json":
+		return tasks.ImportFormatJSON, true
+	}
+	return "", false
+}
+
+// ExportListHandler handles exporting a list and its tasks as CSV, JSON or iCalendar (`format=ics`),
+// streaming tasks to the client as they are read. The format defaults to JSON.
+func (h *Handler) ExportListHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+
+	// The caller must be identified to export a list
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("ExportListHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated list ID from context
+	listID, ok := r.Context().Value(listIDKey).(uuid.UUID)
+	if !ok {
+		logger.Errorw("ExportListHandler failed: list ID missing in request context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = tasks.ExportFormatJSON
+	}
+
+	// Only the owner may export a list
+	list, err := h.lists.GetTodoListByID(r.Context(), todolist.GetTodoListByIDParams{ID: listID, UserID: callerID})
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			logger.Warnw("ExportListHandler failed: list not found", "list_id", listID, "user_id", callerID)
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		logger.Errorw("ExportListHandler failed: internal server error", "list_id", listID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	exporter, err := tasks.NewExporter(format, w, tasks.ExportList{
+		ID:          list.ID,
+		Title:       list.Title,
+		Description: list.Description,
+		ExportedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		logger.Warnw("ExportListHandler failed: invalid format", "format", format)
+		http.Error(w, "Invalid format parameter", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", exporter.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": exportFileName(list.Title, listID) + "." + exporter.FileExtension(),
+	}))
+
+	// Stream the tasks; once the body has started, failures can only truncate the response
+	err = h.store.StreamTasks(r.Context(), tasks.TaskListParams{ListID: listID, UserID: callerID}, exporter.WriteTask)
+	if err == nil {
+		err = exporter.Close()
+	}
+	if err != nil {
+		logger.Errorw("ExportListHandler failed: export interrupted", "list_id", listID, "format", format, "error", err)
+	}
+}
+
+// exportFileName derives a download file name from the list title, falling back to the list ID
+func exportFileName(title string, listID uuid.UUID) string {
+	name := strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, title))
+	if name == "" {
+		return listID.String()
+	}
+	return name
+}
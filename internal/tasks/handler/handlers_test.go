@@ -0,0 +1,759 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/tasksmock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware/testutils"
+	"github.com/henryhall897/golang-todo-app/internal/tasks"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// HandlerTestSuite holds shared test dependencies
+type HandlerTestSuite struct {
+	mockStore *tasksmock.RepositoryMock
+	mockStats *tasksmock.StatsProviderMock
+	mockLists *tasksmock.ListReaderMock
+	handler   *Handler
+	router    http.Handler
+}
+
+// SetupSuite initializes common dependencies and routes
+func SetupSuite() *HandlerTestSuite {
+	logger := zap.NewNop().Sugar() // No-op logger for tests
+
+	mockStore := &tasksmock.RepositoryMock{}
+	mockStats := &tasksmock.StatsProviderMock{}
+	mockLists := &tasksmock.ListReaderMock{}
+
+	handler := &Handler{
+		store:  mockStore,
+		stats:  mockStats,
+		lists:  mockLists,
+		logger: logger,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/lists/", VerifyTaskPath(handler.ListTaskEventsHandler))
+	mux.HandleFunc("/tasks/search", handler.SearchTasksHandler)
+	mux.Handle("POST /lists/{id}/tasks/import", VerifyListPath(handler.ImportTasksHandler))
+	mux.Handle("GET /lists/{id}/export", VerifyListPath(handler.ExportListHandler))
+
+	return &HandlerTestSuite{
+		mockStore: mockStore,
+		mockStats: mockStats,
+		mockLists: mockLists,
+		handler:   handler,
+		router:    testutils.Identity(mux),
+	}
+}
+
+func TestListTaskEventsHandler(t *testing.T) {
+	callerID := uuid.New()
+	listID := uuid.New()
+	taskID := uuid.New()
+	path := fmt.Sprintf("/lists/%s/tasks/%s/events", listID, taskID)
+
+	sampleEvents := []tasks.TaskEvent{
+		{
+			ID:        uuid.New(),
+			TaskID:    taskID,
+			ListID:    listID,
+			ActorID:   callerID,
+			EventType: tasks.TaskEventUpdated,
+			Changes: map[string]tasks.FieldChange{
+				"title": {Old: "Old Title", New: "New Title"},
+			},
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+
+	t.Run("success - events returned", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.ListTaskEventsFunc = func(ctx context.Context, params tasks.ListTaskEventsParams) ([]tasks.TaskEvent, error) {
+			assert.Equal(t, taskID, params.TaskID)
+			assert.Equal(t, listID, params.ListID)
+			assert.Equal(t, callerID, params.UserID)
+			assert.Equal(t, int32(5), params.Limit)
+			assert.Equal(t, int32(10), params.Offset)
+			return sampleEvents, nil
+		}
+
+		req := testutils.NewRequest(http.MethodGet, path+"?limit=5&offset=10", nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var events []tasks.TaskEvent
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &events))
+		require.Len(t, events, 1)
+		assert.Equal(t, sampleEvents[0].ID, events[0].ID)
+		assert.Equal(t, "New Title", events[0].Changes["title"].New)
+	})
+
+	t.Run("success - empty history returns empty array", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.ListTaskEventsFunc = func(ctx context.Context, params tasks.ListTaskEventsParams) ([]tasks.TaskEvent, error) {
+			assert.Equal(t, int32(tasks.DefaultEventLimit), params.Limit)
+			assert.Equal(t, int32(tasks.DefaultEventOffset), params.Offset)
+			return nil, nil
+		}
+
+		req := testutils.NewRequest(http.MethodGet, path, nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, "[]", rr.Body.String())
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite := SetupSuite()
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Empty(t, suite.mockStore.ListTaskEventsCalls())
+	})
+
+	t.Run("failure - invalid task ID", func(t *testing.T) {
+		suite := SetupSuite()
+
+		req := testutils.NewRequest(http.MethodGet, fmt.Sprintf("/lists/%s/tasks/not-a-uuid/events", listID), nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - invalid limit", func(t *testing.T) {
+		suite := SetupSuite()
+
+		req := testutils.NewRequest(http.MethodGet, path+"?limit=1000", nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - store error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.ListTaskEventsFunc = func(ctx context.Context, params tasks.ListTaskEventsParams) ([]tasks.TaskEvent, error) {
+			return nil, fmt.Errorf("database error")
+		}
+
+		req := testutils.NewRequest(http.MethodGet, path, nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestSearchTasksHandler(t *testing.T) {
+	callerID := uuid.New()
+	title := "Buy groceries"
+
+	sampleResults := []tasks.TaskSearchResult{
+		{
+			Task:               tasks.FullTask{ID: uuid.New(), ListID: uuid.New(), Title: &title},
+			Rank:               0.6,
+			TitleSnippet:       "Buy <mark>groceries</mark>",
+			DescriptionSnippet: "",
+		},
+	}
+
+	t.Run("success - results returned with filters", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.SearchAllTasksFunc = func(ctx context.Context, params tasks.FullTextSearchParams) ([]tasks.TaskSearchResult, error) {
+			assert.Equal(t, callerID, params.UserID)
+			assert.Equal(t, "groceries -milk", params.Query)
+			require.NotNil(t, params.Status)
+			assert.Equal(t, "pending", *params.Status)
+			require.NotNil(t, params.MinPriority)
+			assert.Equal(t, int32(2), *params.MinPriority)
+			assert.Nil(t, params.MaxPriority)
+			require.NotNil(t, params.DueBefore)
+			assert.True(t, params.DueBefore.Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)))
+			assert.Nil(t, params.DueAfter)
+			assert.Equal(t, int32(5), params.Limit)
+			assert.Equal(t, int32(10), params.Offset)
+			return sampleResults, nil
+		}
+
+		req := testutils.NewRequest(http.MethodGet, "/tasks/search?q=groceries+-milk&status=pending&min_priority=2&due_before=2026-11-01T00:00:00Z&limit=5&offset=10", nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var results []tasks.TaskSearchResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &results))
+		require.Len(t, results, 1)
+		assert.Equal(t, sampleResults[0].Task.ID, results[0].Task.ID)
+		assert.Equal(t, "Buy <mark>groceries</mark>", results[0].TitleSnippet)
+	})
+
+	t.Run("success - no matches returns empty array", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.SearchAllTasksFunc = func(ctx context.Context, params tasks.FullTextSearchParams) ([]tasks.TaskSearchResult, error) {
+			assert.Equal(t, int32(tasks.DefaultSearchLimit), params.Limit)
+			assert.Equal(t, int32(tasks.DefaultSearchOffset), params.Offset)
+			return nil, nil
+		}
+
+		req := testutils.NewRequest(http.MethodGet, "/tasks/search?q=nothing", nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, "[]", rr.Body.String())
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite := SetupSuite()
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=groceries", nil)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Empty(t, suite.mockStore.SearchAllTasksCalls())
+	})
+
+	t.Run("failure - invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"", "q=+", "q=a&min_priority=high", "q=a&max_priority=1.5", "q=a&due_after=tomorrow", "q=a&limit=0", "q=a&limit=1000", "q=a&offset=-1"} {
+			suite := SetupSuite()
+
+			req := testutils.NewRequest(http.MethodGet, "/tasks/search?"+query, nil, callerID)
+			rr := httptest.NewRecorder()
+			suite.router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+			assert.Empty(t, suite.mockStore.SearchAllTasksCalls(), query)
+		}
+	})
+
+	t.Run("failure - store error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.SearchAllTasksFunc = func(ctx context.Context, params tasks.FullTextSearchParams) ([]tasks.TaskSearchResult, error) {
+			return nil, fmt.Errorf("database error")
+		}
+
+		req := testutils.NewRequest(http.MethodGet, "/tasks/search?q=groceries", nil, callerID)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestListTasksHandler(t *testing.T) {
+	callerID := uuid.New()
+	listID := uuid.New()
+	path := fmt.Sprintf("/lists/%s/tasks", listID)
+
+	sampleTasks := []tasks.FullTask{
+		{ID: uuid.New(), ListID: listID, Tags: []string{"home"}},
+	}
+
+	serve := func(suite *HandlerTestSuite, target string, withCaller bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if withCaller {
+			testutils.SignAs(req.Header, callerID)
+		}
+		rr := httptest.NewRecorder()
+		testutils.Identity(VerifyListPath(suite.handler.ListTasksHandler)).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("success - filters and sort passed to store", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.QueryTasksFunc = func(ctx context.Context, q tasks.TaskQuery) ([]tasks.FullTask, error) {
+			assert.Equal(t, listID, q.ListID)
+			assert.Equal(t, callerID, q.UserID)
+			assert.Equal(t, []string{"pending", "in_progress"}, q.Statuses)
+			assert.Equal(t, []string{"home", "urgent"}, q.Tags)
+			require.NotNil(t, q.MinPriority)
+			assert.Equal(t, int32(1), *q.MinPriority)
+			require.NotNil(t, q.MaxPriority)
+			assert.Equal(t, int32(3), *q.MaxPriority)
+			require.NotNil(t, q.DueAfter)
+			assert.Nil(t, q.DueBefore)
+			require.NotNil(t, q.CompletedBefore)
+			assert.Nil(t, q.CompletedAfter)
+			require.NotNil(t, q.Text)
+			assert.Equal(t, "plumber", *q.Text)
+			assert.Equal(t, []tasks.SortKey{{Field: tasks.SortFieldDueDate, Desc: true}, {Field: tasks.SortFieldTitle}}, q.Sort)
+			assert.Equal(t, int32(5), q.Limit)
+			assert.Equal(t, int32(10), q.Offset)
+			return sampleTasks, nil
+		}
+
+		rr := serve(suite, path+"?status=pending,in_progress&tags=home&tags=urgent&min_priority=1&max_priority=3"+
+			"&due_after=2026-10-01T00:00:00Z&completed_before=2026-10-18T00:00:00Z&q=plumber&sort=-due_date,title&limit=5&offset=10", true)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result []tasks.FullTask
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result, 1)
+		assert.Equal(t, sampleTasks[0].ID, result[0].ID)
+		assert.Equal(t, []string{"home"}, result[0].Tags)
+	})
+
+	t.Run("success - defaults and empty array", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.QueryTasksFunc = func(ctx context.Context, q tasks.TaskQuery) ([]tasks.FullTask, error) {
+			assert.Empty(t, q.Statuses)
+			assert.Empty(t, q.Tags)
+			assert.Nil(t, q.Text)
+			assert.Empty(t, q.Sort)
+			assert.Equal(t, int32(tasks.DefaultListLimit), q.Limit)
+			assert.Equal(t, int32(tasks.DefaultListOffset), q.Offset)
+			return nil, nil
+		}
+
+		rr := serve(suite, path, true)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, "[]", rr.Body.String())
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := serve(suite, path, false)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Empty(t, suite.mockStore.QueryTasksCalls())
+	})
+
+	t.Run("failure - invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"min_priority=high", "max_priority=1.5", "due_before=tomorrow", "completed_after=2026-13-01", "sort=-id", "sort=priority%3Bdrop", "limit=0", "limit=1000", "offset=-1"} {
+			suite := SetupSuite()
+
+			rr := serve(suite, path+"?"+query, true)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+			assert.Empty(t, suite.mockStore.QueryTasksCalls(), query)
+		}
+	})
+
+	t.Run("failure - invalid list ID", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := serve(suite, "/lists/not-a-uuid/tasks", true)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - store error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.QueryTasksFunc = func(ctx context.Context, q tasks.TaskQuery) ([]tasks.FullTask, error) {
+			return nil, fmt.Errorf("database error")
+		}
+
+		rr := serve(suite, path, true)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestListStatsHandler(t *testing.T) {
+	callerID := uuid.New()
+	listID := uuid.New()
+	path := fmt.Sprintf("/lists/%s/stats", listID)
+
+	serve := func(suite *HandlerTestSuite, target string, withCaller bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if withCaller {
+			testutils.SignAs(req.Header, callerID)
+		}
+		rr := httptest.NewRecorder()
+		testutils.Identity(VerifyListPath(suite.handler.ListStatsHandler)).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("success - stats for the list", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.GetTaskStatsFunc = func(ctx context.Context, params tasks.TaskStatsParams) (tasks.TaskStats, error) {
+			assert.Equal(t, callerID, params.UserID)
+			require.NotNil(t, params.ListID)
+			assert.Equal(t, listID, *params.ListID)
+			return tasks.TaskStats{Total: 3, ByStatus: map[string]int64{"pending": 2, "completed": 1}, Completed: 1}, nil
+		}
+
+		rr := serve(suite, path, true)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var stats tasks.TaskStats
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stats))
+		assert.Equal(t, int64(3), stats.Total)
+		assert.Equal(t, int64(2), stats.ByStatus["pending"])
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := serve(suite, path, false)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Empty(t, suite.mockStore.GetTaskStatsCalls())
+	})
+
+	t.Run("failure - store error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.GetTaskStatsFunc = func(ctx context.Context, params tasks.TaskStatsParams) (tasks.TaskStats, error) {
+			return tasks.TaskStats{}, fmt.Errorf("database error")
+		}
+
+		rr := serve(suite, path, true)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestUserStatsHandler(t *testing.T) {
+	callerID := uuid.New()
+	path := fmt.Sprintf("/users/%s/stats", callerID)
+
+	serve := func(suite *HandlerTestSuite, target string, withCaller bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if withCaller {
+			testutils.SignAs(req.Header, callerID)
+		}
+		rr := httptest.NewRecorder()
+		testutils.Identity(VerifyUserPath(suite.handler.UserStatsHandler)).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("success - caller's own stats", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStats.UserStatsFunc = func(ctx context.Context, userID uuid.UUID) (tasks.TaskStats, error) {
+			assert.Equal(t, callerID, userID)
+			return tasks.TaskStats{
+				Total:             4,
+				Overdue:           1,
+				CompletionWindows: []tasks.CompletionWindow{{Days: 7, Created: 4, Completed: 2, CompletionRate: 0.5}},
+			}, nil
+		}
+
+		rr := serve(suite, path, true)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var stats tasks.TaskStats
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stats))
+		assert.Equal(t, int64(4), stats.Total)
+		require.Len(t, stats.CompletionWindows, 1)
+		assert.Equal(t, 0.5, stats.CompletionWindows[0].CompletionRate)
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := serve(suite, path, false)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Empty(t, suite.mockStats.UserStatsCalls())
+	})
+
+	t.Run("failure - another user's stats", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := serve(suite, fmt.Sprintf("/users/%s/stats", uuid.New()), true)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Empty(t, suite.mockStats.UserStatsCalls())
+	})
+
+	t.Run("failure - invalid user ID", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := serve(suite, "/users/not-a-uuid/stats", true)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - provider error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStats.UserStatsFunc = func(ctx context.Context, userID uuid.UUID) (tasks.TaskStats, error) {
+			return tasks.TaskStats{}, fmt.Errorf("database error")
+		}
+
+		rr := serve(suite, path, true)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestImportTasksHandler(t *testing.T) {
+	callerID := uuid.New()
+	listID := uuid.New()
+	path := fmt.Sprintf("/lists/%s/tasks/import", listID)
+
+	newRequest := func(query, contentType, body string) *http.Request {
+		req := testutils.NewRequest(http.MethodPost, path+query, strings.NewReader(body), callerID)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		return req
+	}
+
+	t.Run("success - CSV with mapping imports valid rows and reports invalid ones", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.ImportTasksFunc = func(ctx context.Context, params tasks.ImportTasksParams) (int, error) {
+			assert.Equal(t, listID, params.ListID)
+			assert.Equal(t, callerID, params.UserID)
+			assert.False(t, params.DryRun)
+			require.Len(t, params.Tasks, 1)
+			assert.Equal(t, "Buy milk", params.Tasks[0].Title)
+			assert.Equal(t, int32(2), params.Tasks[0].Priority)
+			return len(params.Tasks), nil
+		}
+
+		body := "Name,Priority\nBuy milk,2\n,1\n"
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?map=title=Name", "text/csv; charset=utf-8", body))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var result tasks.ImportResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, []tasks.ImportRowError{{Row: 3, Field: tasks.ImportFieldTitle, Message: "is required"}}, result.Errors)
+	})
+
+	t.Run("success - JSON dry run", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.ImportTasksFunc = func(ctx context.Context, params tasks.ImportTasksParams) (int, error) {
+			assert.True(t, params.DryRun)
+			return len(params.Tasks), nil
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=json&dry_run=true", "", `[{"title": "Buy milk"}]`))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"imported": 1, "failed": 0, "dry_run": true, "errors": []}`, rr.Body.String())
+	})
+
+	t.Run("success - no valid rows skips the store", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("", "application/json", `[{"description": "untitled"}]`))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, suite.mockStore.ImportTasksCalls())
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite := SetupSuite()
+
+		req := httptest.NewRequest(http.MethodPost, path+"?format=json", strings.NewReader("[]"))
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("failure - unknown format", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("", "text/plain", "title\nBuy milk\n"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - invalid mapping", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=csv&map=owner=Owner", "", "title\nBuy milk\n"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - malformed file", func(t *testing.T) {
+		suite := SetupSuite()
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=json", "", `{"title": "Buy milk"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, suite.mockStore.ImportTasksCalls())
+	})
+
+	t.Run("failure - list not found", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.ImportTasksFunc = func(ctx context.Context, params tasks.ImportTasksParams) (int, error) {
+			return 0, common.ErrNotFound
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=csv", "", "title\nBuy milk\n"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("failure - store error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockStore.ImportTasksFunc = func(ctx context.Context, params tasks.ImportTasksParams) (int, error) {
+			return 0, fmt.Errorf("database error")
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=csv", "", "title\nBuy milk\n"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestExportListHandler(t *testing.T) {
+	callerID := uuid.New()
+	listID := uuid.New()
+	path := fmt.Sprintf("/lists/%s/export", listID)
+	title := "Buy milk"
+	sampleTasks := []tasks.FullTask{
+		{ID: uuid.New(), ListID: listID, Title: &title, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()},
+	}
+
+	setupOwnedList := func(suite *HandlerTestSuite) {
+		suite.mockLists.GetTodoListByIDFunc = func(ctx context.Context, params todolist.GetTodoListByIDParams) (todolist.TodoList, error) {
+			assert.Equal(t, listID, params.ID)
+			assert.Equal(t, callerID, params.UserID)
+			return todolist.TodoList{ID: listID, UserID: callerID, Title: "Groceries"}, nil
+		}
+		suite.mockStore.StreamTasksFunc = func(ctx context.Context, params tasks.TaskListParams, fn func(tasks.FullTask) error) error {
+			assert.Equal(t, tasks.TaskListParams{ListID: listID, UserID: callerID}, params)
+			for _, task := range sampleTasks {
+				if err := fn(task); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	newRequest := func(query string) *http.Request {
+		req := testutils.NewRequest(http.MethodGet, path+query, nil, callerID)
+		return req
+	}
+
+	t.Run("success - JSON by default", func(t *testing.T) {
+		suite := SetupSuite()
+		setupOwnedList(suite)
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest(""))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=Groceries.json`, rr.Header().Get("Content-Disposition"))
+
+		var document struct {
+			List  tasks.ExportList `json:"list"`
+			Tasks []tasks.FullTask `json:"tasks"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &document))
+		assert.Equal(t, listID, document.List.ID)
+		require.Len(t, document.Tasks, 1)
+		assert.Equal(t, sampleTasks[0].ID, document.Tasks[0].ID)
+	})
+
+	t.Run("success - CSV", func(t *testing.T) {
+		suite := SetupSuite()
+		setupOwnedList(suite)
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=csv"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[1], sampleTasks[0].ID.String()+",Buy milk,"))
+	})
+
+	t.Run("success - iCalendar", func(t *testing.T) {
+		suite := SetupSuite()
+		setupOwnedList(suite)
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=ics"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=Groceries.ics`, rr.Header().Get("Content-Disposition"))
+		assert.Contains(t, rr.Body.String(), "BEGIN:VTODO\r\nUID:"+sampleTasks[0].ID.String()+"\r\n")
+		assert.True(t, strings.HasSuffix(rr.Body.String(), "END:VCALENDAR\r\n"))
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite := SetupSuite()
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("failure - invalid format", func(t *testing.T) {
+		suite := SetupSuite()
+		setupOwnedList(suite)
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=xml"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, suite.mockStore.StreamTasksCalls())
+	})
+
+	t.Run("failure - list not found", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockLists.GetTodoListByIDFunc = func(ctx context.Context, params todolist.GetTodoListByIDParams) (todolist.TodoList, error) {
+			return todolist.TodoList{}, common.ErrNotFound
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=csv"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("failure - stream error truncates the document", func(t *testing.T) {
+		suite := SetupSuite()
+		setupOwnedList(suite)
+		suite.mockStore.StreamTasksFunc = func(ctx context.Context, params tasks.TaskListParams, fn func(tasks.FullTask) error) error {
+			require.NoError(t, fn(sampleTasks[0]))
+			return fmt.Errorf("connection reset")
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, newRequest("?format=ics"))
+
+		assert.NotContains(t, rr.Body.String(), "END:VCALENDAR")
+	})
+}
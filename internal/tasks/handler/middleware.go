@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+)
+
+type contextKey string
+
+var errNilID = errors.New("nil UUID provided")
+
+const (
+	listIDKey = contextKey("listID")
+	taskIDKey = contextKey("taskID")
+	userIDKey = contextKey("userID")
+)
+
+// VerifyUserPath extracts and validates the user UUID from `/users/{userID}/...`
+func VerifyUserPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 2 || segments[0] != "users" {
+			http.NotFound(w, r)
+			return
+		}
+
+		userID, err := parseID(segments[1])
+		if err != nil {
+			logger.Warnw("VerifyUserPath failed: invalid user ID", "user_id", segments[1], "error", err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), userIDKey, userID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// VerifyListPath extracts and validates the list UUID from `/lists/{listID}/...`
+func VerifyListPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 2 || segments[0] != "lists" {
+			http.NotFound(w, r)
+			return
+		}
+
+		listID, err := parseID(segments[1])
+		if err != nil {
+			logger.Warnw("VerifyListPath failed: invalid list ID", "list_id", segments[1], "error", err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), listIDKey, listID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// VerifyTaskPath extracts and validates the list and task UUIDs from `/lists/{listID}/tasks/{taskID}/...`
+func VerifyTaskPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract IDs from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 4 || segments[0] != "lists" || segments[2] != "tasks" {
+			http.NotFound(w, r)
+			return
+		}
+
+		listID, err := parseID(segments[1])
+		if err != nil {
+			logger.Warnw("VerifyTaskPath failed: invalid list ID", "list_id", segments[1], "error", err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		taskID, err := parseID(segments[3])
+		if err != nil {
+			logger.Warnw("VerifyTaskPath failed: invalid task ID", "task_id", segments[3], "error", err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUIDs in request context and proceed
+		ctx := context.WithValue(r.Context(), listIDKey, listID)
+		ctx = context.WithValue(ctx, taskIDKey, taskID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// parseID parses a non-nil UUID path segment.
+func parseID(segment string) (uuid.UUID, error) {
+	id, err := uuid.Parse(segment)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if id == uuid.Nil {
+		return uuid.Nil, errNilID
+	}
+	return id, nil
+}
@@ -0,0 +1,247 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrCalendarObject is returned for an iCalendar document that does not hold a usable VTODO.
+var ErrCalendarObject = errors.New("invalid calendar object")
+
+// icsTaskStatuses maps iCalendar VTODO statuses back to task statuses
+var icsTaskStatuses = map[string]string{
+	"NEEDS-ACTION": DefaultImportStatus,
+	"IN-PROCESS":   "in_progress",
+	"COMPLETED":    StatusCompleted,
+	"CANCELLED":    "cancelled",
+}
+
+// CalendarTask is the task described by a VTODO.
+type CalendarTask struct {
+	UID         string
+	Title       string
+	Description *string
+	Status      string
+	DueDate     *time.Time
+	CompletedAt *time.Time
+	Priority    int32
+	Tags        []string
+}
+
+// ParseCalendarObject reads the VTODO of a calendar object resource (RFC 4791 section 4.1).
+// Only the properties tasks store are read; alarms, recurrence and unknown properties are ignored.
+// A completed VTODO without a COMPLETED time is completed at now.
+func ParseCalendarObject(r io.Reader, now time.Time) (CalendarTask, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return CalendarTask{}, fmt.Errorf("failed to read calendar object: %w", err)
+	}
+
+	var (
+		task       CalendarTask
+		components []string // Open components, outermost first
+		found      bool
+		status     = "NEEDS-ACTION"
+	)
+	for _, line := range unfoldICSLines(string(raw)) {
+		name, params, value, err := parseICSLine(line)
+		if err != nil {
+			return CalendarTask{}, err
+		}
+
+		switch name {
+		case "BEGIN":
+			if len(components) == 0 && !strings.EqualFold(value, "VCALENDAR") {
+				return CalendarTask{}, fmt.Errorf("%w: expected VCALENDAR, found %s", ErrCalendarObject, value)
+			}
+			components = append(components, strings.ToUpper(value))
+			continue
+		case "END":
+			if len(components) == 0 || components[len(components)-1] != strings.ToUpper(value) {
+				return CalendarTask{}, fmt.Errorf("%w: unexpected END:%s", ErrCalendarObject, value)
+			}
+			if components[len(components)-1] == "VTODO" && len(components) == 2 {
+				found = true
+			}
+			components = components[:len(components)-1]
+			continue
+		}
+
+		// Only the properties of the first VTODO are read; later ones are overridden instances
+		if found || len(components) != 2 || components[1] != "VTODO" {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			task.UID = value
+		case "SUMMARY":
+			task.Title = unescapeICSText(value)
+		case "DESCRIPTION":
+			description := unescapeICSText(value)
+			task.Description = &description
+		case "STATUS":
+			status = strings.ToUpper(value)
+		case "DUE":
+			if task.DueDate, err = parseICSTime(value, params); err != nil {
+				return CalendarTask{}, fmt.Errorf("%w: DUE %v", ErrCalendarObject, err)
+			}
+		case "COMPLETED":
+			if task.CompletedAt, err = parseICSTime(value, params); err != nil {
+				return CalendarTask{}, fmt.Errorf("%w: COMPLETED %v", ErrCalendarObject, err)
+			}
+		case "PRIORITY":
+			priority, err := strconv.Atoi(value)
+			if err != nil || priority < 0 || priority > 9 {
+				return CalendarTask{}, fmt.Errorf("%w: PRIORITY must be an integer from 0 to 9", ErrCalendarObject)
+			}
+			task.Priority = int32(priority)
+		case "CATEGORIES":
+			for _, tag := range splitICSList(value) {
+				if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(task.Tags, tag) {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+		}
+	}
+
+	switch {
+	case len(components) != 0:
+		return CalendarTask{}, fmt.Errorf("%w: unterminated %s", ErrCalendarObject, components[len(components)-1])
+	case !found:
+		return CalendarTask{}, fmt.Errorf("%w: no VTODO component", ErrCalendarObject)
+	case task.UID == "":
+		return CalendarTask{}, fmt.Errorf("%w: VTODO has no UID", ErrCalendarObject)
+	case utf8.RuneCountInString(task.Title) > maxTitleLength:
+		return CalendarTask{}, fmt.Errorf("%w: SUMMARY must be at most %d characters", ErrCalendarObject, maxTitleLength)
+	}
+
+	var ok bool
+	if task.Status, ok = icsTaskStatuses[status]; !ok {
+		task.Status = DefaultImportStatus
+	}
+	// Completed tasks always carry a completion time
+	if task.Status == StatusCompleted && task.CompletedAt == nil {
+		task.CompletedAt = &now
+	}
+
+	return task, nil
+}
+
+// unfoldICSLines splits a document into content lines, joining folded continuation lines (RFC 5545 section 3.1)
+func unfoldICSLines(raw string) []string {
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+	raw = strings.ReplaceAll(raw, "\n ", "")
+	raw = strings.ReplaceAll(raw, "\n\t", "")
+	raw = strings.TrimPrefix(raw, "\ufeff")
+
+	var lines []string
+	for _, line := range strings.Split(raw, "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseICSLine splits a content line into its upper-cased name, its parameters and its value.
+// Parameter values may be quoted, and quoted values may contain ':' and ';'.
+func parseICSLine(line string) (string, map[string]string, string, error) {
+	nameEnd := strings.IndexAny(line, ";:")
+	if nameEnd <= 0 {
+		return "", nil, "", fmt.Errorf("%w: malformed line %q", ErrCalendarObject, line)
+	}
+	name := strings.ToUpper(line[:nameEnd])
+
+	params := map[string]string{}
+	rest := line[nameEnd:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return "", nil, "", fmt.Errorf("%w: malformed parameter in %q", ErrCalendarObject, line)
+		}
+		key := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return "", nil, "", fmt.Errorf("%w: unterminated quote in %q", ErrCalendarObject, line)
+			}
+			value, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return "", nil, "", fmt.Errorf("%w: missing value in %q", ErrCalendarObject, line)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		params[key] = value
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return "", nil, "", fmt.Errorf("%w: missing value in %q", ErrCalendarObject, line)
+	}
+	return name, params, rest[1:], nil
+}
+
+// parseICSTime reads a DATE or DATE-TIME value. Times in a TZID are converted to UTC;
+// floating times and dates are taken as UTC.
+func parseICSTime(value string, params map[string]string) (*time.Time, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		parsed, err := time.Parse("20060102", value)
+		if err != nil {
+			return nil, errors.New("must be a DATE or DATE-TIME")
+		}
+		return &parsed, nil
+	}
+
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+
+	layout := "20060102T150405"
+	if strings.HasSuffix(value, "Z") {
+		layout, location = "20060102T150405Z", time.UTC
+	}
+	parsed, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		return nil, errors.New("must be a DATE or DATE-TIME")
+	}
+	parsed = parsed.UTC()
+	return &parsed, nil
+}
+
+// icsTextUnescaper reverses escapeICSText
+var icsTextUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeICSText(value string) string {
+	return icsTextUnescaper.Replace(value)
+}
+
+// splitICSList splits a comma-separated TEXT list, leaving escaped commas in place, and unescapes each item
+func splitICSList(value string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++ // Skip the escaped character
+		case ',':
+			items = append(items, unescapeICSText(value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(items, unescapeICSText(value[start:]))
+}
@@ -0,0 +1,106 @@
+package tasks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCalendarObjectRoundTrip(t *testing.T) {
+	_, tasks := exportFixtures()
+	stamp := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCalendarObject(&buf, tasks[0], stamp))
+	assert.NotContains(t, buf.String(), "METHOD:")
+
+	parsed, err := ParseCalendarObject(&buf, now)
+	require.NoError(t, err)
+	assert.Equal(t, CalendarTask{
+		UID:         tasks[0].ID.String(),
+		Title:       "Buy milk, eggs; bread",
+		Description: tasks[0].Description,
+		Status:      "pending",
+		DueDate:     tasks[0].DueDate,
+		Priority:    2,
+		Tags:        []string{"home", "errands"},
+	}, parsed)
+
+	buf.Reset()
+	require.NoError(t, WriteCalendarObject(&buf, tasks[1], stamp))
+	parsed, err = ParseCalendarObject(&buf, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, parsed.Status)
+	assert.Equal(t, tasks[1].CompletedAt, parsed.CompletedAt)
+	assert.Nil(t, parsed.DueDate)
+}
+
+func TestParseCalendarObject(t *testing.T) {
+	now := time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)
+	object := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Berlin",
+		"END:VTIMEZONE",
+		"BEGIN:VTODO",
+		"UID:client-generated@example.com",
+		"SUMMARY:A long summary folded",
+		"  across lines",
+		`CATEGORIES;LANGUAGE="en:GB":work,a\, b`,
+		"CATEGORIES:work,later",
+		`DUE;TZID="Europe/Berlin":20261101T090000`,
+		"STATUS:COMPLETED",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"SUMMARY:Alarm text is ignored",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:client-generated@example.com",
+		"SUMMARY:Overridden instance",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	parsed, err := ParseCalendarObject(strings.NewReader(object), now)
+	require.NoError(t, err)
+	assert.Equal(t, "client-generated@example.com", parsed.UID)
+	assert.Equal(t, "A long summary folded across lines", parsed.Title)
+	assert.Equal(t, []string{"work", "a, b", "later"}, parsed.Tags)
+	assert.Equal(t, time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC), *parsed.DueDate)
+	assert.Equal(t, StatusCompleted, parsed.Status)
+	assert.Equal(t, now, *parsed.CompletedAt, "completed without COMPLETED is completed now")
+
+	dated, err := ParseCalendarObject(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:x\nDUE;VALUE=DATE:20261224\nSTATUS:X-UNKNOWN\nEND:VTODO\nEND:VCALENDAR\n"), now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), *dated.DueDate)
+	assert.Equal(t, "pending", dated.Status)
+	assert.Nil(t, dated.CompletedAt)
+}
+
+func TestParseCalendarObjectErrors(t *testing.T) {
+	tests := map[string]string{
+		"not a calendar": "BEGIN:VCARD\nEND:VCARD\n",
+		"no vtodo":       "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nEND:VEVENT\nEND:VCALENDAR\n",
+		"no uid":         "BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY:x\nEND:VTODO\nEND:VCALENDAR\n",
+		"unterminated":   "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:x\nEND:VTODO\n",
+		"mismatched end": "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:x\nEND:VCALENDAR\n",
+		"bad priority":   "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:x\nPRIORITY:10\nEND:VTODO\nEND:VCALENDAR\n",
+		"bad due":        "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:x\nDUE:tomorrow\nEND:VTODO\nEND:VCALENDAR\n",
+		"malformed line": "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:x\nno colon\nEND:VTODO\nEND:VCALENDAR\n",
+		"long summary":   "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:x\nSUMMARY:" + strings.Repeat("a", maxTitleLength+1) + "\nEND:VTODO\nEND:VCALENDAR\n",
+	}
+	for name, object := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseCalendarObject(strings.NewReader(object), time.Now())
+			assert.ErrorIs(t, err, ErrCalendarObject)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrImportFile reports an import file that cannot be read at all, as opposed to invalid rows.
+var ErrImportFile = errors.New("invalid import file")
+
+// importRecord holds the raw values of one row; nil means the field was absent or empty
+type importRecord struct {
+	row         int
+	title       *string
+	description *string
+	status      *string
+	priority    *string
+	dueDate     *string
+	completedAt *string
+	tags        []string
+}
+
+// ParseImportMapping parses CSV column mappings of the form "field=Column Header".
+// Fields without a mapping are read from the column named after the field.
+func ParseImportMapping(values []string) (map[string]string, error) {
+	mapping := make(map[string]string, len(values))
+	for _, value := range values {
+		field, column, ok := strings.Cut(value, "=")
+		field = strings.TrimSpace(field)
+		column = strings.TrimSpace(column)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q: expected field=column", value)
+		}
+		if !slices.Contains(ImportFields, field) {
+			return nil, fmt.Errorf("invalid mapping %q: unknown field %q", value, field)
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+// ParseImport reads and validates the rows of a CSV or JSON import file.
+// Valid rows are returned as tasks and invalid rows as errors; an error is only returned
+// when the file itself cannot be read, wrapping ErrImportFile for malformed input.
+func ParseImport(format string, r io.Reader, mapping map[string]string, now time.Time) ([]ImportTask, []ImportRowError, error) {
+	var records []importRecord
+	var rowErrors []ImportRowError
+	var err error
+
+	switch format {
+	case ImportFormatCSV:
+		records, err = readCSV(r, mapping)
+	case ImportFormatJSON:
+		records, rowErrors, err = readJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported format %q", ErrImportFile, format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result []ImportTask
+	for _, record := range records {
+		task, errs := validateImportRecord(record, now)
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		result = append(result, task)
+	}
+
+	slices.SortStableFunc(rowErrors, func(a, b ImportRowError) int { return a.Row - b.Row })
+	return result, rowErrors, nil
+}
+
+// readCSV reads rows keyed by the header, applying the column mapping
+func readCSV(r io.Reader, mapping map[string]string) ([]importRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Short rows leave their trailing fields empty
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: missing header row", ErrImportFile)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+
+	// Resolve each field to its column index
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")) // Spreadsheet exports may start with a BOM
+		for _, field := range ImportFields {
+			column, ok := mapping[field]
+			if !ok {
+				column = field
+			}
+			if strings.EqualFold(name, column) {
+				columns[field] = i
+			}
+		}
+	}
+	for field, column := range mapping {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("%w: mapped column %q for %s not found in header", ErrImportFile, column, field)
+		}
+	}
+	if _, ok := columns[ImportFieldTitle]; !ok {
+		return nil, fmt.Errorf("%w: no column for %s", ErrImportFile, ImportFieldTitle)
+	}
+
+	var records []importRecord
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrImportFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(records) == MaxImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrImportFile, MaxImportRows)
+		}
+
+		value := func(field string) *string {
+			i, ok := columns[field]
+			if !ok || i >= len(fields) || strings.TrimSpace(fields[i]) == "" {
+				return nil
+			}
+			v := fromCSVText(strings.TrimSpace(fields[i]))
+			return &v
+		}
+		record := importRecord{
+			row:         line,
+			title:       value(ImportFieldTitle),
+			description: value(ImportFieldDescription),
+			status:      value(ImportFieldStatus),
+			priority:    value(ImportFieldPriority),
+			dueDate:     value(ImportFieldDueDate),
+			completedAt: value(ImportFieldCompletedAt),
+		}
+		if tags := value(ImportFieldTags); tags != nil {
+			record.tags = strings.Split(*tags, ",")
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// jsonImportRow is one element of a JSON import array
+type jsonImportRow struct {
+	Title       *string      `json:"title"`
+	Description *string      `json:"description"`
+	Status      *string      `json:"status"`
+	Priority    *json.Number `json:"priority"`
+	DueDate     *string      `json:"due_date"`
+	CompletedAt *string      `json:"completed_at"`
+	Tags        []string     `json:"tags"`
+}
+
+// readJSON reads an array of task objects. Elements that do not decode are reported as row errors.
+func readJSON(r io.Reader) ([]importRecord, []ImportRowError, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil || token != json.Delim('[') {
+		return nil, nil, fmt.Errorf("%w: expected a JSON array", ErrImportFile)
+	}
+
+	var records []importRecord
+	var rowErrors []ImportRowError
+	for row := 1; decoder.More(); row++ {
+		if row > MaxImportRows {
+			return nil, nil, fmt.Errorf("%w: more than %d rows", ErrImportFile, MaxImportRows)
+		}
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrImportFile, err)
+		}
+
+		var element jsonImportRow
+		elementDecoder := json.NewDecoder(bytes.NewReader(raw))
+		elementDecoder.UseNumber()
+		if err := elementDecoder.Decode(&element); err != nil {
+			rowError := ImportRowError{Row: row, Message: "row must be an object of task fields"}
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				rowError = ImportRowError{Row: row, Field: typeErr.Field, Message: "must be a " + typeErr.Type.String()}
+			}
+			rowErrors = append(rowErrors, rowError)
+			continue
+		}
+
+		record := importRecord{
+			row:         row,
+			title:       nonEmpty(element.Title),
+			description: nonEmpty(element.Description),
+			status:      nonEmpty(element.Status),
+			dueDate:     nonEmpty(element.DueDate),
+			completedAt: nonEmpty(element.CompletedAt),
+			tags:        element.Tags,
+		}
+		if element.Priority != nil {
+			priority := element.Priority.String()
+			record.priority = &priority
+		}
+		records = append(records, record)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrImportFile, err)
+	}
+	return records, rowErrors, nil
+}
+
+// nonEmpty trims a value, treating blank strings as absent
+func nonEmpty(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// validateImportRecord converts a raw row into a task, reporting every invalid field
+func validateImportRecord(record importRecord, now time.Time) (ImportTask, []ImportRowError) {
+	var errs []ImportRowError
+	fail := func(field, message string) {
+		errs = append(errs, ImportRowError{Row: record.row, Field: field, Message: message})
+	}
+
+	task := ImportTask{
+		Row:         record.row,
+		Description: record.description,
+		Status:      DefaultImportStatus,
+		Tags:        []string{},
+	}
+
+	switch {
+	case record.title == nil:
+		fail(ImportFieldTitle, "is required")
+	case utf8.RuneCountInString(*record.title) > maxTitleLength:
+		fail(ImportFieldTitle, fmt.Sprintf("must be at most %d characters", maxTitleLength))
+	default:
+		task.Title = *record.title
+	}
+
+	if record.status != nil {
+		if utf8.RuneCountInString(*record.status) > maxStatusLength {
+			fail(ImportFieldStatus, fmt.Sprintf("must be at most %d characters", maxStatusLength))
+		} else {
+			task.Status = *record.status
+		}
+	}
+
+	if record.priority != nil {
+		priority, err := strconv.ParseInt(*record.priority, 10, 32)
+		if err != nil {
+			fail(ImportFieldPriority, "must be an integer")
+		}
+		task.Priority = int32(priority)
+	}
+
+	var err error
+	if task.DueDate, err = parseImportTime(record.dueDate); err != nil {
+		fail(ImportFieldDueDate, err.Error())
+	}
+	if task.CompletedAt, err = parseImportTime(record.completedAt); err != nil {
+		fail(ImportFieldCompletedAt, err.Error())
+	}
+	// Completed tasks always carry a completion time
+	if task.Status == StatusCompleted && task.CompletedAt == nil {
+		task.CompletedAt = &now
+	}
+
+	for _, tag := range record.tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(task.Tags, tag) {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	return task, errs
+}
+
+// parseImportTime accepts RFC 3339 timestamps and plain dates (midnight UTC)
+func parseImportTime(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, *value); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, errors.New("must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+}
@@ -0,0 +1,154 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportMapping(t *testing.T) {
+	t.Run("success - fields mapped to columns", func(t *testing.T) {
+		mapping, err := ParseImportMapping([]string{"title=Task Name", " due_date = Deadline "})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{ImportFieldTitle: "Task Name", ImportFieldDueDate: "Deadline"}, mapping)
+	})
+
+	t.Run("failure - unknown field", func(t *testing.T) {
+		_, err := ParseImportMapping([]string{"owner=Owner"})
+		assert.Error(t, err)
+	})
+
+	t.Run("failure - missing column", func(t *testing.T) {
+		_, err := ParseImportMapping([]string{"title"})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseImportCSV(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success - default header mapping", func(t *testing.T) {
+		input := "Title,Description,Status,Priority,Due_Date,Tags\n" +
+			"Buy milk,2 litres,,3,2026-11-01,\"home, errands\"\n" +
+			"Ship release,,completed,,2026-11-02T09:30:00Z,\n"
+
+		result, rowErrors, err := ParseImport(ImportFormatCSV, strings.NewReader(input), nil, now)
+		require.NoError(t, err)
+		require.Empty(t, rowErrors)
+		require.Len(t, result, 2)
+
+		assert.Equal(t, 2, result[0].Row)
+		assert.Equal(t, "Buy milk", result[0].Title)
+		require.NotNil(t, result[0].Description)
+		assert.Equal(t, "2 litres", *result[0].Description)
+		assert.Equal(t, DefaultImportStatus, result[0].Status)
+		assert.Equal(t, int32(3), result[0].Priority)
+		require.NotNil(t, result[0].DueDate)
+		assert.True(t, result[0].DueDate.Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, []string{"home", "errands"}, result[0].Tags)
+		assert.Nil(t, result[0].CompletedAt)
+
+		assert.Equal(t, 3, result[1].Row)
+		assert.Equal(t, StatusCompleted, result[1].Status)
+		require.NotNil(t, result[1].CompletedAt)
+		assert.True(t, result[1].CompletedAt.Equal(now))
+		assert.Equal(t, []string{}, result[1].Tags)
+	})
+
+	t.Run("success - custom mapping", func(t *testing.T) {
+		input := "Task Name,Deadline\nWrite report,2026-12-01\n"
+		mapping := map[string]string{ImportFieldTitle: "Task Name", ImportFieldDueDate: "Deadline"}
+
+		result, rowErrors, err := ParseImport(ImportFormatCSV, strings.NewReader(input), mapping, now)
+		require.NoError(t, err)
+		require.Empty(t, rowErrors)
+		require.Len(t, result, 1)
+		assert.Equal(t, "Write report", result[0].Title)
+		require.NotNil(t, result[0].DueDate)
+	})
+
+	t.Run("success - invalid rows reported by line", func(t *testing.T) {
+		input := "title,priority,due_date\n" +
+			"Valid,1,\n" +
+			",high,tomorrow\n" +
+			"Also valid\n"
+
+		result, rowErrors, err := ParseImport(ImportFormatCSV, strings.NewReader(input), nil, now)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, []ImportRowError{
+			{Row: 3, Field: ImportFieldTitle, Message: "is required"},
+			{Row: 3, Field: ImportFieldPriority, Message: "must be an integer"},
+			{Row: 3, Field: ImportFieldDueDate, Message: "must be an RFC 3339 timestamp or a YYYY-MM-DD date"},
+		}, rowErrors)
+	})
+
+	t.Run("failure - no title column", func(t *testing.T) {
+		_, _, err := ParseImport(ImportFormatCSV, strings.NewReader("name\nBuy milk\n"), nil, now)
+		assert.ErrorIs(t, err, ErrImportFile)
+	})
+
+	t.Run("failure - mapped column missing", func(t *testing.T) {
+		mapping := map[string]string{ImportFieldDueDate: "Deadline"}
+		_, _, err := ParseImport(ImportFormatCSV, strings.NewReader("title\nBuy milk\n"), mapping, now)
+		assert.ErrorIs(t, err, ErrImportFile)
+	})
+
+	t.Run("failure - empty file", func(t *testing.T) {
+		_, _, err := ParseImport(ImportFormatCSV, strings.NewReader(""), nil, now)
+		assert.ErrorIs(t, err, ErrImportFile)
+	})
+
+	t.Run("failure - too many rows", func(t *testing.T) {
+		input := "title\n" + strings.Repeat("Task\n", MaxImportRows+1)
+		_, _, err := ParseImport(ImportFormatCSV, strings.NewReader(input), nil, now)
+		assert.ErrorIs(t, err, ErrImportFile)
+	})
+}
+
+func TestParseImportJSON(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success - rows parsed and validated", func(t *testing.T) {
+		input := `[
+			{"title": "Buy milk", "priority": 2, "tags": ["home", "home", " errands "], "owner": "ignored"},
+			{"title": "", "status": "completed"},
+			{"title": 42},
+			"not an object",
+			{"title": "Plan trip", "due_date": "2026-11-01T09:00:00+02:00", "priority": 1.5}
+		]`
+
+		result, rowErrors, err := ParseImport(ImportFormatJSON, strings.NewReader(input), nil, now)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, 1, result[0].Row)
+		assert.Equal(t, "Buy milk", result[0].Title)
+		assert.Equal(t, int32(2), result[0].Priority)
+		assert.Equal(t, []string{"home", "errands"}, result[0].Tags)
+
+		assert.Equal(t, []ImportRowError{
+			{Row: 2, Field: ImportFieldTitle, Message: "is required"},
+			{Row: 3, Field: ImportFieldTitle, Message: "must be a string"},
+			{Row: 4, Message: "row must be an object of task fields"},
+			{Row: 5, Field: ImportFieldPriority, Message: "must be an integer"},
+		}, rowErrors)
+	})
+
+	t.Run("failure - not an array", func(t *testing.T) {
+		_, _, err := ParseImport(ImportFormatJSON, strings.NewReader(`{"title": "Buy milk"}`), nil, now)
+		assert.ErrorIs(t, err, ErrImportFile)
+	})
+
+	t.Run("failure - truncated array", func(t *testing.T) {
+		_, _, err := ParseImport(ImportFormatJSON, strings.NewReader(`[{"title": "Buy milk"}`), nil, now)
+		assert.ErrorIs(t, err, ErrImportFile)
+	})
+
+	t.Run("failure - unsupported format", func(t *testing.T) {
+		_, _, err := ParseImport("xml", strings.NewReader(""), nil, now)
+		assert.ErrorIs(t, err, ErrImportFile)
+	})
+}
@@ -0,0 +1,56 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the methods required for task operations.
+//
+//go:generate moq -out=../../gen/mocks/tasksmock/task_repo_mock.go -pkg=tasksmock . Repository
+type Repository interface {
+	CreateTask(ctx context.Context, lid uuid.UUID, title string, description, status *string, due time.Time, prio int32) (FullTask, error)
+	CreateTaskWithID(ctx context.Context, id uuid.UUID, params CreateTaskParams) (FullTask, error)
+	UpdateTask(ctx context.Context, params UpdateTaskParams) (FullTask, error)
+	DeleteTasks(ctx context.Context, params DeleteTasksParams) ([]FullTask, error)
+	GetTask(ctx context.Context, params GetTaskParams) (FullTask, error)
+	ListTasks(ctx context.Context, params TaskListParams) ([]FullTask, error)
+	ListOverdueTasks(ctx context.Context, params TaskListParams) ([]FullTask, error)
+	MarkTaskCompleted(ctx context.Context, params UpdateTaskParams) (FullTask, error)
+	ListTasksByStatus(ctx context.Context, params CountTasksByStatusParams) ([]FullTask, error)
+	SearchTasks(ctx context.Context, params SearchTasksParams) ([]FullTask, error)
+	UpdateTaskPriority(ctx context.Context, params UpdateTaskParams) (FullTask, error)
+	ListTaskEvents(ctx context.Context, params ListTaskEventsParams) ([]TaskEvent, error)
+	SearchAllTasks(ctx context.Context, params FullTextSearchParams) ([]TaskSearchResult, error)
+	QueryTasks(ctx context.Context, q TaskQuery) ([]FullTask, error)
+	CountTasksByStatus(ctx context.Context, params CountTasksByStatusParams) (int64, error)
+	GetTaskStats(ctx context.Context, params TaskStatsParams) (TaskStats, error)
+	ListTasksDueBetween(ctx context.Context, from, to time.Time) ([]DueTask, error)
+	ListDigestTasks(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]FullTask, error)
+	ImportTasks(ctx context.Context, params ImportTasksParams) (int, error)
+	StreamTasks(ctx context.Context, params TaskListParams, fn func(FullTask) error) error
+}
+
+var _ Repository = (*Store)(nil)
+
+// StatsProvider returns a user's task statistics.
+//
+//go:generate moq -out=../../gen/mocks/tasksmock/stats_provider_mock.go -pkg=tasksmock . StatsProvider
+type StatsProvider interface {
+	UserStats(ctx context.Context, userID uuid.UUID) (TaskStats, error)
+}
+
+var _ StatsProvider = (*CachedStats)(nil)
+
+// ListReader looks up a list owned by a user; exports are headed by the list's details.
+//
+//go:generate moq -out=../../gen/mocks/tasksmock/list_reader_mock.go -pkg=tasksmock . ListReader
+type ListReader interface {
+	GetTodoListByID(ctx context.Context, params todolist.GetTodoListByIDParams) (todolist.TodoList, error)
+}
+
+var _ ListReader = (*todolist.Store)(nil)
@@ -18,6 +18,7 @@ type FullTask struct {
 	UpdatedAt   time.Time  `json:"updated_at"`
 	Priority    *int32     `json:"priority"`
 	CompletedAt *time.Time `json:"completed_at"`
+	Tags        []string   `json:"tags"`
 }
 
 // CreateTaskParams holds the parameters needed to create a task.
@@ -28,6 +29,8 @@ type CreateTaskParams struct {
 	Status      *string    `json:"status"`
 	DueDate     *time.Time `json:"due_date"`
 	Priority    int32      `json:"priority"`
+	CompletedAt *time.Time `json:"completed_at"` // Only stored by CreateTaskWithID
+	Tags        []string   `json:"tags"`         // Only stored by CreateTaskWithID
 }
 
 // UpdateTaskParams holds the parameters needed to update a task.
@@ -41,6 +44,7 @@ type UpdateTaskParams struct {
 	DueDate     *time.Time `json:"due_date"`
 	Priority    *int32     `json:"priority"`
 	CompletedAt *time.Time `json:"completed_at"`
+	Tags        []string   `json:"tags"` // Replaces the task's tags when non-nil
 }
 
 // MarkTaskCompletedParams holds the parameters needed to mark a task as completed.
@@ -57,6 +61,13 @@ type DeleteTasksParams struct {
 	UserID uuid.UUID   `json:"user_id"` // User ID
 }
 
+// GetTaskParams holds the parameters needed to look up a task in a specific user's todo list.
+type GetTaskParams struct {
+	ID     uuid.UUID `json:"id"`      // Task ID
+	ListID uuid.UUID `json:"list_id"` // Todo List ID
+	UserID uuid.UUID `json:"user_id"` // User ID
+}
+
 // TaskListParams holds the parameters needed to list tasks for a specific user and todo list.
 type TaskListParams struct {
 	ListID uuid.UUID `json:"list_id"` // Todo List ID
@@ -76,3 +87,126 @@ type SearchTasksParams struct {
 	UserID  uuid.UUID `json:"user_id"` // The ID of the user performing the search
 	Keyword *string   `json:"keyword"` // The search term to match in task titles or descriptions
 }
+
+// TaskEvent represents an entry in a task's change history.
+type TaskEvent struct {
+	ID        uuid.UUID              `json:"id"`
+	TaskID    uuid.UUID              `json:"task_id"`
+	ListID    uuid.UUID              `json:"list_id"`
+	ActorID   uuid.UUID              `json:"actor_id"`   // User who made the change
+	EventType string                 `json:"event_type"` // e.g., "updated", "completed", "priority_changed", "deleted"
+	Changes   map[string]FieldChange `json:"changes"`    // Changed fields keyed by their JSON name
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// FieldChange holds the previous and new value of a single task field.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ListTaskEventsParams holds the parameters needed to page through a task's history.
+type ListTaskEventsParams struct {
+	TaskID uuid.UUID `json:"task_id"` // Task ID
+	ListID uuid.UUID `json:"list_id"` // Todo List ID
+	UserID uuid.UUID `json:"user_id"` // User ID
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+// FullTextSearchParams holds the parameters needed to run a ranked search across all of a user's lists.
+type FullTextSearchParams struct {
+	UserID      uuid.UUID  `json:"user_id"`      // User ID
+	Query       string     `json:"query"`        // Web-search style query (quoted phrases, OR, -exclusions)
+	Status      *string    `json:"status"`       // Optional status filter
+	MinPriority *int32     `json:"min_priority"` // Optional inclusive lower priority bound
+	MaxPriority *int32     `json:"max_priority"` // Optional inclusive upper priority bound
+	DueAfter    *time.Time `json:"due_after"`    // Optional inclusive lower due date bound
+	DueBefore   *time.Time `json:"due_before"`   // Optional exclusive upper due date bound
+	Limit       int32      `json:"limit"`
+	Offset      int32      `json:"offset"`
+}
+
+// TaskSearchResult is a single ranked match returned by a full-text search.
+type TaskSearchResult struct {
+	Task               FullTask `json:"task"`
+	Rank               float32  `json:"rank"`
+	TitleSnippet       string   `json:"title_snippet"`       // HTML-escaped title with matches wrapped in <mark> tags
+	DescriptionSnippet string   `json:"description_snippet"` // HTML-escaped best-matching description fragments with <mark> tags
+}
+
+// TaskStatsParams selects the tasks summarised by GetTaskStats.
+type TaskStatsParams struct {
+	UserID uuid.UUID  `json:"user_id"` // User ID
+	ListID *uuid.UUID `json:"list_id"` // Restricts the stats to one list; nil covers all of the user's lists
+}
+
+// TaskStats summarises a user's tasks, or the tasks in one of their lists.
+type TaskStats struct {
+	Total             int64              `json:"total"`
+	ByStatus          map[string]int64   `json:"by_status"`
+	Overdue           int64              `json:"overdue"`
+	Completed         int64              `json:"completed"`
+	AvgTimeToComplete float64            `json:"avg_time_to_complete_seconds"` // Mean of completed_at - created_at
+	CompletionWindows []CompletionWindow `json:"completion_windows"`
+	GeneratedAt       time.Time          `json:"generated_at"`
+}
+
+// CompletionWindow reports how many of the tasks created within a trailing window have since been completed.
+type CompletionWindow struct {
+	Days           int     `json:"days"`
+	Created        int64   `json:"created"`
+	Completed      int64   `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"` // Completed / Created, or 0 when nothing was created
+}
+
+// DueTask is an open task due within a time range, together with the owner to remind.
+type DueTask struct {
+	Task      FullTask  `json:"task"`
+	UserID    uuid.UUID `json:"user_id"`
+	UserEmail string    `json:"user_email"`
+	UserName  string    `json:"user_name"`
+}
+
+// ImportTask is a validated task row ready to be imported.
+type ImportTask struct {
+	Row         int        `json:"row"` // Position in the source file, for error reports
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Status      string     `json:"status"`
+	Priority    int32      `json:"priority"`
+	DueDate     *time.Time `json:"due_date"`
+	CompletedAt *time.Time `json:"completed_at"`
+	Tags        []string   `json:"tags"`
+}
+
+// ImportTasksParams holds the parameters needed to import tasks into a list.
+type ImportTasksParams struct {
+	ListID uuid.UUID    `json:"list_id"` // Todo List ID
+	UserID uuid.UUID    `json:"user_id"` // User ID; must own the list
+	Tasks  []ImportTask `json:"tasks"`
+	DryRun bool         `json:"dry_run"` // Validate against the database without keeping the tasks
+}
+
+// ImportRowError reports why a row of an import file was rejected.
+type ImportRowError struct {
+	Row     int    `json:"row"` // CSV line number, or 1-based position in the JSON array
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"` // Rows rejected by validation
+	DryRun   bool             `json:"dry_run"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ExportList describes the list heading an export.
+type ExportList struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ExportedAt  time.Time `json:"exported_at"`
+}
@@ -0,0 +1,91 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/events"
+	"github.com/henryhall897/golang-todo-app/internal/tasks/gen"
+	webhooks "github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/google/uuid"
+)
+
+// domainEventTypes maps task history event types to the domain events they publish.
+var domainEventTypes = map[string]string{
+	TaskEventUpdated:         events.TaskUpdated,
+	TaskEventPriorityChanged: events.TaskUpdated,
+	TaskEventCompleted:       events.TaskCompleted,
+	TaskEventDeleted:         events.TaskDeleted,
+}
+
+// taskEventData is the data of a task domain event.
+type taskEventData struct {
+	Task    FullTask               `json:"task"`
+	Changes map[string]FieldChange `json:"changes,omitempty"`
+}
+
+// publishTaskEvent records a domain event in the outbox and queues its webhook deliveries using the
+// given (transaction-bound) queries, so both happen if and only if the change is committed.
+func publishTaskEvent(ctx context.Context, query *gen.Queries, eventType string, task FullTask, changes map[string]FieldChange) error {
+	event, err := events.New(eventType, events.AggregateTask, task.ID, taskEventData{Task: task, Changes: changes})
+	if err != nil {
+		return err
+	}
+
+	if err := recordOutboxEvent(ctx, query, event); err != nil {
+		return err
+	}
+	return enqueueWebhookDeliveries(ctx, query, event, task.ListID)
+}
+
+// recordOutboxEvent writes an event to the outbox for the relay to publish.
+func recordOutboxEvent(ctx context.Context, query *gen.Queries, event events.Event) error {
+	row, err := events.ToOutboxRow(event)
+	if err != nil {
+		return err
+	}
+
+	if err := query.CreateOutboxEvent(ctx, gen.CreateOutboxEventParams(row)); err != nil {
+		return fmt.Errorf("failed to record outbox event: %w", err)
+	}
+	return nil
+}
+
+// webhookPayload encodes the body delivered to webhook subscribers for an event
+func webhookPayload(event events.Event) ([]byte, error) {
+	payload, err := json.Marshal(webhooks.NewEvent(event))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+	return payload, nil
+}
+
+// enqueueWebhookDeliveries queues an event for each of the list owner's webhook subscriptions.
+func enqueueWebhookDeliveries(ctx context.Context, query *gen.Queries, event events.Event, listID uuid.UUID) error {
+	payload, err := webhookPayload(event)
+	if err != nil {
+		return err
+	}
+
+	eventID, err := common.ToPgUUID(event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to transform webhook event id: %w", err)
+	}
+	pgListID, err := common.ToPgUUID(listID)
+	if err != nil {
+		return fmt.Errorf("failed to transform webhook list id: %w", err)
+	}
+
+	if _, err := query.EnqueueWebhookDeliveries(ctx, gen.EnqueueWebhookDeliveriesParams{
+		EventID:   eventID,
+		EventType: event.Type,
+		Payload:   payload,
+		ListID:    pgListID,
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
@@ -1,7 +1,14 @@
 -- name: CreateTask :one
 INSERT INTO tasks (list_id, title, description, status, due_date, priority)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING *;
+RETURNING id, list_id, title, description, status, priority,
+          due_date, completed_at, created_at, updated_at, tags;
+
+-- name: CreateTaskWithID :one
+INSERT INTO tasks (id, list_id, title, description, status, due_date, priority, completed_at, tags)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, list_id, title, description, status, priority,
+          due_date, completed_at, created_at, updated_at, tags;
 
 -- name: UpdateTask :one
 UPDATE tasks
@@ -11,12 +18,14 @@ SET title = COALESCE($3, tasks.title),
     due_date = COALESCE($6, due_date),
     priority = COALESCE($7, priority),
     updated_at = CURRENT_TIMESTAMP,
-    completed_at = COALESCE($8, completed_at)
+    completed_at = COALESCE($8, completed_at),
+    tags = COALESCE(sqlc.narg('tags')::text[], tags)
 FROM todolists
 WHERE tasks.id = $1
   AND tasks.list_id = todolists.id
   AND todolists.user_id = $2
-RETURNING tasks.*;
+RETURNING tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+          tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags;
 
 -- name: DeleteTasks :many
 DELETE FROM tasks
@@ -24,10 +33,12 @@ USING todolists
 WHERE tasks.id = ANY($1::uuid[])
   AND tasks.list_id = todolists.id
   AND todolists.user_id = $2
-RETURNING tasks.*;
+RETURNING tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+          tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags;
 
 -- name: ListTasks :many
-SELECT tasks.*
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE todolists.id = $1
@@ -48,7 +59,8 @@ WHERE
     AND todolists.user_id = $2;         
 
 -- name: ListOverdueTasks :many
-SELECT tasks.*
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE tasks.list_id = $1
@@ -58,7 +70,8 @@ WHERE tasks.list_id = $1
 ORDER BY tasks.due_date ASC;
 
 -- name: ListTasksByStatus :many
-SELECT tasks.*
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE tasks.list_id = $1
@@ -66,7 +79,8 @@ WHERE tasks.list_id = $1
   AND tasks.status = $3;
 
 -- name: SearchTasks :many
-SELECT tasks.*
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
 FROM tasks
 JOIN todolists ON tasks.list_id = todolists.id
 WHERE tasks.list_id = $1
@@ -91,3 +105,157 @@ SET
     updated_at = CURRENT_TIMESTAMP
 FROM RankedTasks
 WHERE tasks.id = RankedTasks.id;
+
+-- name: GetTask :one
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE tasks.id = $1
+  AND tasks.list_id = $2
+  AND todolists.user_id = $3;
+
+-- name: GetTaskForUpdate :one
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE tasks.id = $1
+  AND todolists.user_id = $2
+FOR UPDATE OF tasks;
+
+-- name: CreateTaskEvent :one
+INSERT INTO task_events (task_id, list_id, actor_id, event_type, changes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListTaskEvents :many
+SELECT task_events.*
+FROM task_events
+JOIN todolists ON task_events.list_id = todolists.id
+WHERE task_events.task_id = $1
+  AND task_events.list_id = $2
+  AND todolists.user_id = $3
+ORDER BY task_events.created_at DESC, task_events.id DESC
+LIMIT $4 OFFSET $5;
+
+-- name: FullTextSearchTasks :many
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags,
+       ts_rank(tasks.search_vector, websearch_to_tsquery('english', sqlc.arg('query')))::real AS rank,
+       ts_headline('english', coalesce(tasks.title, ''), websearch_to_tsquery('english', sqlc.arg('query')),
+                   'StartSel=' || chr(57344) || ', StopSel=' || chr(57345) || ', HighlightAll=true')::text AS title_snippet,
+       ts_headline('english', coalesce(tasks.description, ''), websearch_to_tsquery('english', sqlc.arg('query')),
+                   'StartSel=' || chr(57344) || ', StopSel=' || chr(57345) || ', MaxFragments=2, MaxWords=20, MinWords=5')::text AS description_snippet
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = sqlc.arg('user_id')
+  AND tasks.search_vector @@ websearch_to_tsquery('english', sqlc.arg('query'))
+  AND (sqlc.narg('status')::text IS NULL OR tasks.status = sqlc.narg('status'))
+  AND (sqlc.narg('min_priority')::int IS NULL OR tasks.priority >= sqlc.narg('min_priority'))
+  AND (sqlc.narg('max_priority')::int IS NULL OR tasks.priority <= sqlc.narg('max_priority'))
+  AND (sqlc.narg('due_after')::timestamp IS NULL OR tasks.due_date >= sqlc.narg('due_after'))
+  AND (sqlc.narg('due_before')::timestamp IS NULL OR tasks.due_date < sqlc.narg('due_before'))
+ORDER BY rank DESC, tasks.due_date ASC NULLS LAST, tasks.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountTasksByStatus :one
+SELECT COUNT(*)
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE tasks.list_id = $1
+  AND todolists.user_id = $2
+  AND tasks.status = $3;
+
+-- name: CountTasksGroupedByStatus :many
+SELECT COALESCE(tasks.status, 'pending')::text AS status,
+       COUNT(*) AS count
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('list_id')::uuid IS NULL OR tasks.list_id = sqlc.narg('list_id'))
+GROUP BY 1
+ORDER BY 1;
+
+-- name: GetTaskStatsSummary :one
+SELECT COUNT(*) AS total,
+       COUNT(*) FILTER (WHERE tasks.due_date < CURRENT_TIMESTAMP AND tasks.status != 'completed') AS overdue,
+       COUNT(*) FILTER (WHERE tasks.completed_at IS NOT NULL) AS completed,
+       COALESCE(AVG(EXTRACT(EPOCH FROM tasks.completed_at - tasks.created_at))
+                FILTER (WHERE tasks.completed_at IS NOT NULL), 0)::float8 AS avg_completion_seconds
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('list_id')::uuid IS NULL OR tasks.list_id = sqlc.narg('list_id'));
+
+-- name: GetTaskCompletionWindow :one
+SELECT COUNT(*) AS created,
+       COUNT(*) FILTER (WHERE tasks.completed_at IS NOT NULL) AS completed
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('list_id')::uuid IS NULL OR tasks.list_id = sqlc.narg('list_id'))
+  AND tasks.created_at >= sqlc.arg('since');
+
+-- name: ListTasksDueBetween :many
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags,
+       users.id AS user_id,
+       users.email AS user_email,
+       users.name AS user_name
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+JOIN users ON todolists.user_id = users.id
+WHERE tasks.due_date >= sqlc.arg('due_from')
+  AND tasks.due_date < sqlc.arg('due_to')
+  AND tasks.status != 'completed'
+ORDER BY tasks.due_date ASC, tasks.id;
+
+-- name: ListDigestTasks :many
+SELECT tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority,
+       tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags
+FROM tasks
+JOIN todolists ON tasks.list_id = todolists.id
+WHERE todolists.user_id = sqlc.arg('user_id')
+  AND ((tasks.status != 'completed' AND tasks.due_date < sqlc.arg('due_before'))
+    OR tasks.completed_at >= sqlc.arg('completed_since'))
+ORDER BY tasks.due_date ASC NULLS LAST, tasks.id;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT webhook_subscriptions.id, $1, $2, $3
+FROM webhook_subscriptions
+JOIN todolists ON todolists.user_id = webhook_subscriptions.user_id
+WHERE todolists.id = $4
+  AND webhook_subscriptions.active
+  AND $2::text = ANY(webhook_subscriptions.event_types);
+
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: LockTodoListForImport :one
+SELECT todolists.id
+FROM todolists
+WHERE todolists.id = $1
+  AND todolists.user_id = $2
+FOR SHARE;
+
+-- name: ImportTasks :copyfrom
+INSERT INTO tasks (id, list_id, title, description, status, priority, due_date, completed_at, created_at, updated_at, tags)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: ImportOutboxEvents :copyfrom
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListListWebhookSubscriptions :many
+SELECT webhook_subscriptions.id, webhook_subscriptions.event_types
+FROM webhook_subscriptions
+JOIN todolists ON todolists.user_id = webhook_subscriptions.user_id
+WHERE todolists.id = $1
+  AND webhook_subscriptions.active;
+
+-- name: ImportWebhookDeliveries :copyfrom
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4);
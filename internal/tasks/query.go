@@ -0,0 +1,257 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// sortColumns maps the sort fields accepted by QueryTasks to the columns they order by.
+// Only these identifiers are ever interpolated into the query text; every value is a bind parameter.
+var sortColumns = map[string]string{
+	SortFieldTitle:       "tasks.title",
+	SortFieldStatus:      "tasks.status",
+	SortFieldPriority:    "tasks.priority",
+	SortFieldDueDate:     "tasks.due_date",
+	SortFieldCompletedAt: "tasks.completed_at",
+	SortFieldCreatedAt:   "tasks.created_at",
+	SortFieldUpdatedAt:   "tasks.updated_at",
+}
+
+// defaultSort matches the ordering of ListTasks.
+var defaultSort = []SortKey{
+	{Field: SortFieldPriority},
+	{Field: SortFieldDueDate},
+}
+
+// taskQueryColumns lists the columns selected by QueryTasks, in the order they are scanned.
+const taskQueryColumns = "tasks.id, tasks.list_id, tasks.title, tasks.description, tasks.status, tasks.priority, " +
+	"tasks.due_date, tasks.completed_at, tasks.created_at, tasks.updated_at, tasks.tags"
+
+// SortKey orders query results by a single field.
+type SortKey struct {
+	Field string `json:"field"` // One of the SortField constants
+	Desc  bool   `json:"desc"`
+}
+
+// TaskQuery holds the filters, sort order and page for a composable task listing.
+// Unset filters are ignored; all set filters must match.
+type TaskQuery struct {
+	ListID          uuid.UUID  `json:"list_id"`          // Todo List ID
+	UserID          uuid.UUID  `json:"user_id"`          // User ID
+	Statuses        []string   `json:"statuses"`         // Task status is any of these
+	MinPriority     *int32     `json:"min_priority"`     // Inclusive lower priority bound
+	MaxPriority     *int32     `json:"max_priority"`     // Inclusive upper priority bound
+	DueAfter        *time.Time `json:"due_after"`        // Inclusive lower due date bound
+	DueBefore       *time.Time `json:"due_before"`       // Exclusive upper due date bound
+	Tags            []string   `json:"tags"`             // Task has all of these tags
+	CompletedAfter  *time.Time `json:"completed_after"`  // Inclusive lower completion bound
+	CompletedBefore *time.Time `json:"completed_before"` // Exclusive upper completion bound
+	Text            *string    `json:"text"`             // Web-search style full-text query
+	Sort            []SortKey  `json:"sort"`             // Defaults to priority then due date, ascending
+	Limit           int32      `json:"limit"`            // Zero selects every matching task
+	Offset          int32      `json:"offset"`
+}
+
+// ParseSort parses a comma-separated sort expression such as "-priority,due_date".
+// A leading "-" sorts that field in descending order.
+func ParseSort(expr string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key := SortKey{Field: part}
+		if strings.HasPrefix(part, "-") {
+			key = SortKey{Field: part[1:], Desc: true}
+		}
+
+		if _, ok := sortColumns[key.Field]; !ok {
+			return nil, fmt.Errorf("unknown sort field %q: %w", key.Field, common.ErrValidation)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// QueryTasks lists the tasks in a user's todo list matching the given filters, in the requested order.
+func (s *Store) QueryTasks(ctx context.Context, q TaskQuery) ([]FullTask, error) {
+	sql, args, err := buildTaskQuery(q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build task query: %w", err)
+	}
+
+	// Execute the query
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var dbTasks []taskRow
+	for rows.Next() {
+		i, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		dbTasks = append(dbTasks, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+
+	// Convert the results to FullTask
+	return toFullTaskList(dbTasks)
+}
+
+// StreamTasks calls fn for each task in a user's todo list, in the order of ListTasks, without
+// loading the whole list into memory. Iteration stops at the first error returned by fn.
+func (s *Store) StreamTasks(ctx context.Context, params TaskListParams, fn func(FullTask) error) error {
+	sql, args, err := buildTaskQuery(TaskQuery{ListID: params.ListID, UserID: params.UserID})
+	if err != nil {
+		return fmt.Errorf("failed to build task query: %w", err)
+	}
+
+	// Execute the query
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		dbTask, err := scanTask(rows)
+		if err != nil {
+			return err
+		}
+		task, err := toFullTask(dbTask)
+		if err != nil {
+			return fmt.Errorf("failed to transform task from database: %w", err)
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query tasks: %w", err)
+	}
+	return nil
+}
+
+// scanTask scans a row selected with taskQueryColumns.
+func scanTask(rows pgx.Rows) (taskRow, error) {
+	var i taskRow
+	if err := rows.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tags,
+	); err != nil {
+		return taskRow{}, fmt.Errorf("failed to scan task: %w", err)
+	}
+	return i, nil
+}
+
+// queryArgs collects bind parameters and hands out their placeholders.
+type queryArgs []interface{}
+
+func (a *queryArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// buildTaskQuery renders a TaskQuery as parameterized SQL.
+func buildTaskQuery(q TaskQuery) (string, []interface{}, error) {
+	dbListID, err := common.ToPgUUID(q.ListID)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid list_id: %w", err)
+	}
+
+	dbUserID, err := common.ToPgUUID(q.UserID)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	var args queryArgs
+	where := []string{
+		"tasks.list_id = " + args.add(dbListID),
+		"todolists.user_id = " + args.add(dbUserID),
+	}
+
+	if len(q.Statuses) > 0 {
+		where = append(where, "tasks.status = ANY("+args.add(q.Statuses)+"::text[])")
+	}
+	if q.MinPriority != nil {
+		where = append(where, "tasks.priority >= "+args.add(*q.MinPriority))
+	}
+	if q.MaxPriority != nil {
+		where = append(where, "tasks.priority <= "+args.add(*q.MaxPriority))
+	}
+	if q.DueAfter != nil {
+		where = append(where, "tasks.due_date >= "+args.add(common.ToPgTimestamp(q.DueAfter)))
+	}
+	if q.DueBefore != nil {
+		where = append(where, "tasks.due_date < "+args.add(common.ToPgTimestamp(q.DueBefore)))
+	}
+	if len(q.Tags) > 0 {
+		where = append(where, "tasks.tags @> "+args.add(q.Tags)+"::text[]")
+	}
+	if q.CompletedAfter != nil {
+		where = append(where, "tasks.completed_at >= "+args.add(common.ToPgTimestamp(q.CompletedAfter)))
+	}
+	if q.CompletedBefore != nil {
+		where = append(where, "tasks.completed_at < "+args.add(common.ToPgTimestamp(q.CompletedBefore)))
+	}
+	if q.Text != nil && strings.TrimSpace(*q.Text) != "" {
+		where = append(where, "tasks.search_vector @@ websearch_to_tsquery('english', "+args.add(*q.Text)+")")
+	}
+
+	sortKeys := q.Sort
+	if len(sortKeys) == 0 {
+		sortKeys = defaultSort
+	}
+
+	orderBy := make([]string, 0, len(sortKeys)+1)
+	for _, key := range sortKeys {
+		column, ok := sortColumns[key.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown sort field %q: %w", key.Field, common.ErrValidation)
+		}
+
+		direction := "ASC"
+		if key.Desc {
+			direction = "DESC"
+		}
+		orderBy = append(orderBy, column+" "+direction+" NULLS LAST")
+	}
+	// Break ties on the primary key so pages are stable
+	orderBy = append(orderBy, "tasks.id ASC")
+
+	var sql strings.Builder
+	sql.WriteString("SELECT " + taskQueryColumns + "\n")
+	sql.WriteString("FROM tasks\n")
+	sql.WriteString("JOIN todolists ON tasks.list_id = todolists.id\n")
+	sql.WriteString("WHERE " + strings.Join(where, "\n  AND ") + "\n")
+	sql.WriteString("ORDER BY " + strings.Join(orderBy, ", "))
+	if q.Limit > 0 {
+		sql.WriteString("\nLIMIT " + args.add(q.Limit) + " OFFSET " + args.add(q.Offset))
+	}
+
+	return sql.String(), args, nil
+}
@@ -0,0 +1,115 @@
+package tasks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	t.Run("fields and directions", func(t *testing.T) {
+		keys, err := ParseSort("-priority, due_date,,title")
+
+		require.NoError(t, err)
+		require.Equal(t, []SortKey{
+			{Field: SortFieldPriority, Desc: true},
+			{Field: SortFieldDueDate},
+			{Field: SortFieldTitle},
+		}, keys)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := ParseSort("priority,-id; DROP TABLE tasks")
+
+		require.Error(t, err)
+		require.True(t, errors.Is(err, common.ErrValidation))
+	})
+}
+
+func TestBuildTaskQuery(t *testing.T) {
+	listID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("defaults", func(t *testing.T) {
+		sql, args, err := buildTaskQuery(TaskQuery{ListID: listID, UserID: userID, Limit: 10})
+
+		require.NoError(t, err)
+		require.Contains(t, sql, "WHERE tasks.list_id = $1\n  AND todolists.user_id = $2\n")
+		require.Contains(t, sql, "ORDER BY tasks.priority ASC NULLS LAST, tasks.due_date ASC NULLS LAST, tasks.id ASC")
+		require.True(t, strings.HasSuffix(sql, "LIMIT $3 OFFSET $4"))
+		require.Len(t, args, 4)
+		require.Equal(t, int32(10), args[2])
+	})
+
+	t.Run("zero limit selects every task", func(t *testing.T) {
+		sql, args, err := buildTaskQuery(TaskQuery{ListID: listID, UserID: userID})
+
+		require.NoError(t, err)
+		require.True(t, strings.HasSuffix(sql, "tasks.id ASC"))
+		require.NotContains(t, sql, "LIMIT")
+		require.Len(t, args, 2)
+	})
+
+	t.Run("all filters are bound in order", func(t *testing.T) {
+		now := time.Now()
+		sql, args, err := buildTaskQuery(TaskQuery{
+			ListID:          listID,
+			UserID:          userID,
+			Statuses:        []string{"pending", "in_progress"},
+			MinPriority:     common.Ptr(int32(1)),
+			MaxPriority:     common.Ptr(int32(3)),
+			DueAfter:        &now,
+			DueBefore:       &now,
+			Tags:            []string{"home"},
+			CompletedAfter:  &now,
+			CompletedBefore: &now,
+			Text:            common.Ptr("'; DROP TABLE tasks; --"),
+			Sort:            []SortKey{{Field: SortFieldUpdatedAt, Desc: true}},
+			Limit:           5,
+			Offset:          10,
+		})
+
+		require.NoError(t, err)
+		for _, clause := range []string{
+			"tasks.status = ANY($3::text[])",
+			"tasks.priority >= $4",
+			"tasks.priority <= $5",
+			"tasks.due_date >= $6",
+			"tasks.due_date < $7",
+			"tasks.tags @> $8::text[]",
+			"tasks.completed_at >= $9",
+			"tasks.completed_at < $10",
+			"websearch_to_tsquery('english', $11)",
+			"ORDER BY tasks.updated_at DESC NULLS LAST, tasks.id ASC",
+			"LIMIT $12 OFFSET $13",
+		} {
+			require.Contains(t, sql, clause)
+		}
+		require.NotContains(t, sql, "DROP TABLE")
+		require.Len(t, args, 13)
+		require.Equal(t, []string{"pending", "in_progress"}, args[2])
+		require.Equal(t, "'; DROP TABLE tasks; --", args[10])
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		_, _, err := buildTaskQuery(TaskQuery{
+			ListID: listID,
+			UserID: userID,
+			Sort:   []SortKey{{Field: "tasks.id; DROP TABLE tasks"}},
+		})
+
+		require.ErrorIs(t, err, common.ErrValidation)
+	})
+
+	t.Run("nil user", func(t *testing.T) {
+		_, _, err := buildTaskQuery(TaskQuery{ListID: listID})
+
+		require.Error(t, err)
+	})
+}
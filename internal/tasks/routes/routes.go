@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/tasks/handler"
+)
+
+// RegisterRoutes sets up task routes
+func RegisterRoutes(router *http.ServeMux, h *handler.Handler) {
+	// Handle `/users/{userID}/stats`; more specific than the users feature's `/users/` pattern
+	router.HandleFunc("/users/{id}/stats", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			handler.VerifyUserPath(h.UserStatsHandler).ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+
+	// Handle `/tasks/search`
+	router.HandleFunc("/tasks/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			h.SearchTasksHandler(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+
+	router.Handle("/lists/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Extract path segments
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		// Handle `/lists/{listID}/tasks`
+		if len(segments) == 3 && segments[2] == "tasks" {
+			if r.Method == http.MethodGet {
+				handler.VerifyListPath(h.ListTasksHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Handle `/lists/{listID}/stats`
+		if len(segments) == 3 && segments[2] == "stats" {
+			if r.Method == http.MethodGet {
+				handler.VerifyListPath(h.ListStatsHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Handle `/lists/{listID}/export`
+		if len(segments) == 3 && segments[2] == "export" {
+			if r.Method == http.MethodGet {
+				handler.VerifyListPath(h.ExportListHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Handle `/lists/{listID}/tasks/import`
+		if len(segments) == 4 && segments[2] == "tasks" && segments[3] == "import" {
+			if r.Method == http.MethodPost {
+				handler.VerifyListPath(h.ImportTasksHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Handle `/lists/{listID}/tasks/{taskID}/events`
+		if len(segments) == 5 && segments[2] == "tasks" && segments[4] == "events" {
+			if r.Method == http.MethodGet {
+				handler.VerifyTaskPath(h.ListTaskEventsHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Return 404 for invalid paths
+		http.NotFound(w, r)
+	}))
+}
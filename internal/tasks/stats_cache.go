@@ -0,0 +1,60 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// CachedStats serves per-user task statistics from Redis, computing them from the store on a miss.
+// Entries expire after StatsRedisTTL, so figures may lag task changes by up to that long.
+type CachedStats struct {
+	store  Repository
+	cache  redispkg.Cache
+	logger *zap.SugaredLogger
+}
+
+// NewCachedStats initializes a CachedStats backed by the given store and cache.
+func NewCachedStats(store Repository, cache redispkg.Cache, logger *zap.SugaredLogger) *CachedStats {
+	return &CachedStats{
+		store:  store,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// StatsCacheKeyByUser generates a cache key for a user's task statistics.
+func StatsCacheKeyByUser(userID uuid.UUID) string {
+	return fmt.Sprintf("user:%s", userID)
+}
+
+// UserStats returns the task statistics across all of a user's lists.
+// Cache failures are logged and fall back to the store.
+func (c *CachedStats) UserStats(ctx context.Context, userID uuid.UUID) (TaskStats, error) {
+	key := StatsCacheKeyByUser(userID)
+
+	var stats TaskStats
+	err := c.cache.Get(ctx, key, &stats)
+	if err == nil {
+		return stats, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		c.logger.Warnw("Failed to read task stats from cache", "user_id", userID, "error", err)
+	}
+
+	stats, err = c.store.GetTaskStats(ctx, TaskStatsParams{UserID: userID})
+	if err != nil {
+		return TaskStats{}, err
+	}
+
+	if err := c.cache.Set(ctx, key, stats, StatsRedisTTL); err != nil {
+		c.logger.Warnw("Failed to cache task stats", "user_id", userID, "error", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// statsStore is a Repository stub that only serves GetTaskStats.
+type statsStore struct {
+	Repository
+	stats TaskStats
+	err   error
+	calls int
+}
+
+func (s *statsStore) GetTaskStats(ctx context.Context, params TaskStatsParams) (TaskStats, error) {
+	s.calls++
+	return s.stats, s.err
+}
+
+func TestCachedStats(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+
+	srv, err := miniredis.Run()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	cache := redispkg.NewJSONCache(client, StatsRedisPrefix, logger)
+
+	t.Run("miss computes and caches", func(t *testing.T) {
+		srv.FlushAll()
+		userID := uuid.New()
+		store := &statsStore{stats: TaskStats{Total: 2, ByStatus: map[string]int64{"pending": 2}}}
+		stats := NewCachedStats(store, cache, logger)
+
+		first, err := stats.UserStats(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), first.Total)
+
+		second, err := stats.UserStats(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, first.ByStatus, second.ByStatus)
+		require.Equal(t, 1, store.calls)
+
+		require.True(t, srv.Exists(StatsRedisPrefix+":"+StatsCacheKeyByUser(userID)))
+		require.Equal(t, StatsRedisTTL, srv.TTL(StatsRedisPrefix+":"+StatsCacheKeyByUser(userID)))
+	})
+
+	t.Run("store error is returned and not cached", func(t *testing.T) {
+		srv.FlushAll()
+		userID := uuid.New()
+		store := &statsStore{err: errors.New("database error")}
+
+		_, err := NewCachedStats(store, cache, logger).UserStats(ctx, userID)
+
+		require.Error(t, err)
+		require.False(t, srv.Exists(StatsRedisPrefix+":"+StatsCacheKeyByUser(userID)))
+	})
+
+	t.Run("unreachable cache falls back to the store", func(t *testing.T) {
+		deadClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+		store := &statsStore{stats: TaskStats{Total: 5}}
+
+		result, err := NewCachedStats(store, redispkg.NewJSONCache(deadClient, StatsRedisPrefix, logger), logger).UserStats(ctx, uuid.New())
+
+		require.NoError(t, err)
+		require.Equal(t, int64(5), result.Total)
+		require.Equal(t, 1, store.calls)
+	})
+}
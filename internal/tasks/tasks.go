@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/events"
 	"github.com/henryhall897/golang-todo-app/internal/tasks/gen"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -24,9 +27,8 @@ func New(pool *pgxpool.Pool) *Store {
 }
 
 // CreateTask inserts a new task into the database and returns the created Task.
+// Its domain event and webhook deliveries are written within the same transaction.
 func (s *Store) CreateTask(ctx context.Context, lid uuid.UUID, title string, description, status *string, due time.Time, prio int32) (FullTask, error) {
-	query := gen.New(s.pool)
-
 	params := CreateTaskParams{
 		ListID:      lid,
 		Title:       &title,
@@ -41,6 +43,15 @@ func (s *Store) CreateTask(ctx context.Context, lid uuid.UUID, title string, des
 		return FullTask{}, fmt.Errorf("failed to transform task: %w", err)
 	}
 
+	// Start a transaction so the task and its events are written together
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return FullTask{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := gen.New(tx)
+
 	// Execute the query
 	createdTask, err := query.CreateTask(ctx, dbTask)
 	if err != nil {
@@ -52,13 +63,72 @@ func (s *Store) CreateTask(ctx context.Context, lid uuid.UUID, title string, des
 	if err != nil {
 		return result, fmt.Errorf("failed to transform task from database: %w", err)
 	}
+
+	if err := publishTaskEvent(ctx, query, events.TaskCreated, result, nil); err != nil {
+		return FullTask{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return FullTask{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// ErrTaskExists is returned when a task is created under an ID that is already in use.
+var ErrTaskExists = errors.New("task id already in use")
+
+// CreateTaskWithID inserts a task under a caller-chosen ID, with its completion time and tags,
+// as CalDAV clients name the resources they create. The list's ownership must be checked by the caller.
+// Its domain event and webhook deliveries are written within the same transaction.
+func (s *Store) CreateTaskWithID(ctx context.Context, id uuid.UUID, params CreateTaskParams) (FullTask, error) {
+	// Transform the Go struct to a database-compatible struct
+	dbTask, err := toDBCreateTaskWithID(id, params)
+	if err != nil {
+		return FullTask{}, fmt.Errorf("failed to transform task: %w", err)
+	}
+
+	// Start a transaction so the task and its events are written together
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return FullTask{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := gen.New(tx)
+
+	// Execute the query
+	createdTask, err := query.CreateTaskWithID(ctx, dbTask)
+	if err != nil {
+		// 23505 is PostgreSQL's unique violation error code: the ID belongs to another task
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return FullTask{}, fmt.Errorf("task %s: %w", id, ErrTaskExists)
+		}
+		return FullTask{}, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	// Convert the database result back to the application-compatible struct
+	result, err := toFullTask(createdTask)
+	if err != nil {
+		return result, fmt.Errorf("failed to transform task from database: %w", err)
+	}
+
+	if err := publishTaskEvent(ctx, query, events.TaskCreated, result, nil); err != nil {
+		return FullTask{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return FullTask{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return result, nil
 }
 
 // UpdateTask updates an existing task in the database and returns the updated Task.
+// The change is recorded in the task history within the same transaction.
 func (s *Store) UpdateTask(ctx context.Context, params UpdateTaskParams) (FullTask, error) {
-	query := gen.New(s.pool)
-
 	// Check if priority was updated
 	if params.Priority != nil && *params.Priority != 0 {
 		// Use the dedicated priority update function instead of general update
@@ -74,11 +144,26 @@ func (s *Store) UpdateTask(ctx context.Context, params UpdateTaskParams) (FullTa
 		return FullTask{}, fmt.Errorf("failed to transform update task params: %w", err)
 	}
 
+	// Start a transaction so the update and its history entry are written together
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return FullTask{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := gen.New(tx)
+
+	// Capture the current state of the task for the history entry
+	before, err := getTaskForUpdate(ctx, query, params.ID, params.UserID)
+	if err != nil {
+		return FullTask{}, err
+	}
+
 	// Execute the query
 	updatedTask, err := query.UpdateTask(ctx, dbParams)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return FullTask{}, fmt.Errorf("no task found to update with the provided parameters: %w", err)
+			return FullTask{}, fmt.Errorf("no task found to update with the provided parameters: %w", common.ErrNotFound)
 		}
 		return FullTask{}, fmt.Errorf("failed to update task: %w", err)
 	}
@@ -89,6 +174,15 @@ func (s *Store) UpdateTask(ctx context.Context, params UpdateTaskParams) (FullTa
 		return FullTask{}, fmt.Errorf("failed to transform task from database: %w", err)
 	}
 
+	if err := recordTaskEvent(ctx, query, TaskEventUpdated, params.UserID, result, diffTasks(before, result)); err != nil {
+		return FullTask{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return FullTask{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return result, nil
 }
 
@@ -114,13 +208,16 @@ func (s *Store) DeleteTasks(ctx context.Context, params DeleteTasksParams) ([]Fu
 		return nil, fmt.Errorf("failed to delete tasks: %w", err)
 	}
 
-	// Convert each deleted task to FullTask
+	// Convert each deleted task to FullTask and record its removal in the task history
 	var results []FullTask
 	for _, dbTask := range deletedTasks {
 		task, err := toFullTask(dbTask)
 		if err != nil {
 			return nil, fmt.Errorf("failed to transform deleted task: %w", err)
 		}
+		if err := recordTaskEvent(ctx, query, TaskEventDeleted, params.UserID, task, deletedTaskChanges(task)); err != nil {
+			return nil, err
+		}
 		results = append(results, task)
 	}
 
@@ -132,6 +229,30 @@ func (s *Store) DeleteTasks(ctx context.Context, params DeleteTasksParams) ([]Fu
 	return results, nil
 }
 
+// GetTask retrieves a task from a todo list owned by the user.
+// It returns common.ErrNotFound when the list holds no such task or belongs to someone else.
+func (s *Store) GetTask(ctx context.Context, params GetTaskParams) (FullTask, error) {
+	query := gen.New(s.pool)
+
+	// Transform params to DB params
+	dbParams, err := toDBGetTaskParams(params)
+	if err != nil {
+		return FullTask{}, err
+	}
+
+	// Execute the query
+	dbTask, err := query.GetTask(ctx, dbParams)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return FullTask{}, fmt.Errorf("task %s: %w", params.ID, common.ErrNotFound)
+		}
+		return FullTask{}, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	// Convert the result to FullTask
+	return toFullTask(dbTask)
+}
+
 func (s *Store) ListTasks(ctx context.Context, params TaskListParams) ([]FullTask, error) {
 	query := gen.New(s.pool)
 
@@ -197,6 +318,12 @@ func (s *Store) MarkTaskCompleted(ctx context.Context, params UpdateTaskParams)
 
 	query := gen.New(tx)
 
+	// Capture the current state of the task for the history entry
+	before, err := getTaskForUpdate(ctx, query, params.ID, params.UserID)
+	if err != nil {
+		return FullTask{}, err
+	}
+
 	// Step 1: Update task status to 'completed' and set priority and completed_at
 	dbParams, err := toDBUpdateTaskParams(params)
 	if err != nil {
@@ -226,7 +353,7 @@ func (s *Store) MarkTaskCompleted(ctx context.Context, params UpdateTaskParams)
 	genTask, err := query.UpdateTask(ctx, genUpdateParams)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return FullTask{}, fmt.Errorf("no task found to update with the provided parameters: %w", err)
+			return FullTask{}, fmt.Errorf("no task found to update with the provided parameters: %w", common.ErrNotFound)
 		}
 		return FullTask{}, fmt.Errorf("failed to update task: %w", err)
 	}
@@ -237,6 +364,10 @@ func (s *Store) MarkTaskCompleted(ctx context.Context, params UpdateTaskParams)
 		return FullTask{}, fmt.Errorf("failed to transform task from database: %w", err)
 	}
 
+	if err = recordTaskEvent(ctx, query, TaskEventCompleted, params.UserID, result, diffTasks(before, result)); err != nil {
+		return FullTask{}, err
+	}
+
 	// Step 3: Commit the transaction
 	if err = tx.Commit(ctx); err != nil {
 		return FullTask{}, fmt.Errorf("failed to commit transaction: %w", err)
@@ -324,6 +455,12 @@ func (s *Store) UpdateTaskPriority(ctx context.Context, params UpdateTaskParams)
 
 	query := gen.New(tx)
 
+	// Capture the current state of the task for the history entry
+	before, err := getTaskForUpdate(ctx, query, params.ID, params.UserID)
+	if err != nil {
+		return FullTask{}, err
+	}
+
 	// Step 1: Update the task priority
 	updatePrioParams, err := toDBUpdatePriorityParams(params)
 	if err != nil {
@@ -348,21 +485,286 @@ func (s *Store) UpdateTaskPriority(ctx context.Context, params UpdateTaskParams)
 	updatedTaskGeneral, err := query.UpdateTask(ctx, noPrioParams)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return FullTask{}, fmt.Errorf("no task found to update with the provided parameters: %w", err)
+			return FullTask{}, fmt.Errorf("no task found to update with the provided parameters: %w", common.ErrNotFound)
 		}
 		return FullTask{}, fmt.Errorf("failed to update task: %w", err)
 	}
 
-	// Step 3: Commit the transaction
+	// Step 3: Convert the updated task to the application-compatible format
+	result, err := toFullTask(updatedTaskGeneral)
+	if err != nil {
+		return FullTask{}, fmt.Errorf("failed to transform task from database: %w", err)
+	}
+
+	if err = recordTaskEvent(ctx, query, TaskEventPriorityChanged, params.UserID, result, diffTasks(before, result)); err != nil {
+		return FullTask{}, err
+	}
+
+	// Step 4: Commit the transaction
 	if err = tx.Commit(ctx); err != nil {
 		return FullTask{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// Step 4: Convert the updated task to the application-compatible format
-	result, err := toFullTask(updatedTaskGeneral)
+	return result, nil
+}
+
+// ListTaskEvents retrieves a page of a task's change history, newest first.
+func (s *Store) ListTaskEvents(ctx context.Context, params ListTaskEventsParams) ([]TaskEvent, error) {
+	query := gen.New(s.pool)
+
+	// Transform params to DB params
+	dbParams, err := toDBListTaskEventsParams(params)
 	if err != nil {
-		return FullTask{}, fmt.Errorf("failed to transform task from database: %w", err)
+		return nil, fmt.Errorf("failed to transform list task events params: %w", err)
 	}
 
-	return result, nil
+	// Execute the query
+	dbEvents, err := query.ListTaskEvents(ctx, dbParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task events: %w", err)
+	}
+
+	// Convert the results to TaskEvent
+	return toTaskEventList(dbEvents)
+}
+
+// SearchAllTasks runs a ranked full-text search over the titles and descriptions of all of a user's tasks.
+func (s *Store) SearchAllTasks(ctx context.Context, params FullTextSearchParams) ([]TaskSearchResult, error) {
+	query := gen.New(s.pool)
+
+	// Transform params to DB params
+	dbParams, err := toDBFullTextSearchTasksParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform full-text search params: %w", err)
+	}
+
+	// Execute the query
+	rows, err := query.FullTextSearchTasks(ctx, dbParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	// Convert the results to TaskSearchResult
+	return toTaskSearchResultList(rows)
+}
+
+// CountTasksByStatus counts the tasks in a user's todo list with the given status.
+func (s *Store) CountTasksByStatus(ctx context.Context, params CountTasksByStatusParams) (int64, error) {
+	query := gen.New(s.pool)
+
+	// Transform params to DB params
+	dbParams, err := toDBCountTasksByStatusParams(params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to transform count tasks params: %w", err)
+	}
+
+	// Execute the query
+	count, err := query.CountTasksByStatus(ctx, dbParams)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	return count, nil
+}
+
+// GetTaskStats aggregates status counts, overdue and completion figures for a user's tasks,
+// optionally restricted to a single list.
+func (s *Store) GetTaskStats(ctx context.Context, params TaskStatsParams) (TaskStats, error) {
+	query := gen.New(s.pool)
+
+	dbUserID, dbListID, err := toDBStatsScope(params)
+	if err != nil {
+		return TaskStats{}, fmt.Errorf("failed to transform task stats params: %w", err)
+	}
+
+	summary, err := query.GetTaskStatsSummary(ctx, gen.GetTaskStatsSummaryParams{UserID: dbUserID, ListID: dbListID})
+	if err != nil {
+		return TaskStats{}, fmt.Errorf("failed to get task stats summary: %w", err)
+	}
+
+	statusCounts, err := query.CountTasksGroupedByStatus(ctx, gen.CountTasksGroupedByStatusParams{UserID: dbUserID, ListID: dbListID})
+	if err != nil {
+		return TaskStats{}, fmt.Errorf("failed to count tasks by status: %w", err)
+	}
+
+	stats := TaskStats{
+		Total:             summary.Total,
+		ByStatus:          make(map[string]int64, len(statusCounts)),
+		Overdue:           summary.Overdue,
+		Completed:         summary.Completed,
+		AvgTimeToComplete: summary.AvgCompletionSeconds,
+		CompletionWindows: make([]CompletionWindow, 0, len(StatsWindows)),
+		GeneratedAt:       time.Now().UTC(),
+	}
+	for _, row := range statusCounts {
+		stats.ByStatus[row.Status] = row.Count
+	}
+
+	for _, days := range StatsWindows {
+		since := stats.GeneratedAt.AddDate(0, 0, -days)
+		row, err := query.GetTaskCompletionWindow(ctx, gen.GetTaskCompletionWindowParams{
+			UserID: dbUserID,
+			ListID: dbListID,
+			Since:  common.ToPgTimestamp(&since),
+		})
+		if err != nil {
+			return TaskStats{}, fmt.Errorf("failed to get %d day completion window: %w", days, err)
+		}
+		stats.CompletionWindows = append(stats.CompletionWindows, toCompletionWindow(days, row))
+	}
+
+	return stats, nil
+}
+
+// ListTasksDueBetween retrieves the open tasks of every user that fall due in [from, to), earliest first.
+func (s *Store) ListTasksDueBetween(ctx context.Context, from, to time.Time) ([]DueTask, error) {
+	query := gen.New(s.pool)
+
+	// Execute the query
+	rows, err := query.ListTasksDueBetween(ctx, gen.ListTasksDueBetweenParams{
+		DueFrom: common.ToPgTimestamp(&from),
+		DueTo:   common.ToPgTimestamp(&to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks due between %s and %s: %w", from, to, err)
+	}
+
+	// Convert the results to DueTask
+	return toDueTaskList(rows)
+}
+
+// ListDigestTasks retrieves a user's open tasks due before dueBefore together with the tasks
+// they completed since completedSince, earliest due date first.
+func (s *Store) ListDigestTasks(ctx context.Context, userID uuid.UUID, dueBefore, completedSince time.Time) ([]FullTask, error) {
+	query := gen.New(s.pool)
+
+	dbUserID, err := common.ToPgUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	// Execute the query
+	dbTasks, err := query.ListDigestTasks(ctx, gen.ListDigestTasksParams{
+		UserID:         dbUserID,
+		DueBefore:      common.ToPgTimestamp(&dueBefore),
+		CompletedSince: common.ToPgTimestamp(&completedSince),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest tasks: %w", err)
+	}
+
+	// Convert the results to FullTask
+	return toFullTaskList(dbTasks)
+}
+
+// ImportTasks copies validated tasks into a list owned by the user, in batches within one transaction,
+// and returns how many were imported. Each task publishes a created event and queues webhook deliveries
+// like CreateTask. A dry run performs the full import and rolls it back.
+func (s *Store) ImportTasks(ctx context.Context, params ImportTasksParams) (int, error) {
+	dbListID, err := common.ToPgUUID(params.ListID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid list_id: %w", err)
+	}
+	dbUserID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure, and discards dry runs
+
+	query := gen.New(tx)
+
+	// Lock the list so it cannot be deleted mid-import; lists of other users are reported as missing
+	if _, err := query.LockTodoListForImport(ctx, gen.LockTodoListForImportParams{ID: dbListID, UserID: dbUserID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, common.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to lock todo list: %w", err)
+	}
+
+	subscriptions, err := query.ListListWebhookSubscriptions(ctx, dbListID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	imported := 0
+	for batch := range slices.Chunk(params.Tasks, ImportBatchSize) {
+		dbTasks := make([]gen.ImportTasksParams, 0, len(batch))
+		outboxEvents := make([]gen.ImportOutboxEventsParams, 0, len(batch))
+		var deliveries []gen.ImportWebhookDeliveriesParams
+
+		for _, task := range batch {
+			dbTask, err := toDBImportTask(dbListID, task, uuid.New(), now)
+			if err != nil {
+				return 0, fmt.Errorf("failed to transform task on row %d: %w", task.Row, err)
+			}
+			dbTasks = append(dbTasks, dbTask)
+
+			created, err := toFullTask(taskRow{
+				ID: dbTask.ID, ListID: dbTask.ListID, Title: dbTask.Title, Description: dbTask.Description,
+				Status: dbTask.Status, Priority: dbTask.Priority, DueDate: dbTask.DueDate,
+				CompletedAt: dbTask.CompletedAt, CreatedAt: dbTask.CreatedAt, UpdatedAt: dbTask.UpdatedAt, Tags: dbTask.Tags,
+			})
+			if err != nil {
+				return 0, fmt.Errorf("failed to transform task on row %d: %w", task.Row, err)
+			}
+
+			event, err := events.New(events.TaskCreated, events.AggregateTask, created.ID, taskEventData{Task: created})
+			if err != nil {
+				return 0, err
+			}
+			outboxEvent, err := events.ToOutboxRow(event)
+			if err != nil {
+				return 0, err
+			}
+			outboxEvents = append(outboxEvents, gen.ImportOutboxEventsParams(outboxEvent))
+
+			if len(subscriptions) == 0 {
+				continue
+			}
+			payload, err := webhookPayload(event)
+			if err != nil {
+				return 0, err
+			}
+			for _, subscription := range subscriptions {
+				if slices.Contains(subscription.EventTypes, event.Type) {
+					deliveries = append(deliveries, gen.ImportWebhookDeliveriesParams{
+						SubscriptionID: subscription.ID,
+						EventID:        outboxEvent.ID,
+						EventType:      event.Type,
+						Payload:        payload,
+					})
+				}
+			}
+		}
+
+		copied, err := query.ImportTasks(ctx, dbTasks)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import tasks: %w", err)
+		}
+		if _, err := query.ImportOutboxEvents(ctx, outboxEvents); err != nil {
+			return 0, fmt.Errorf("failed to record outbox events: %w", err)
+		}
+		if len(deliveries) > 0 {
+			if _, err := query.ImportWebhookDeliveries(ctx, deliveries); err != nil {
+				return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+			}
+		}
+		imported += int(copied)
+	}
+
+	if params.DryRun {
+		return imported, nil
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return imported, nil
 }
@@ -4,12 +4,14 @@ package tasks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
 	"github.com/henryhall897/golang-todo-app/internal/core/dbpool"
+	"github.com/henryhall897/golang-todo-app/internal/events"
 	"github.com/henryhall897/golang-todo-app/pkg/dbtest"
 
 	"github.com/google/uuid"
@@ -321,6 +323,30 @@ func (t *TaskTestSuite) TestDeleteTasks() {
 	}
 }
 
+func (t *TaskTestSuite) TestGetTask() {
+	// Arrange: Create a task and a second list of the same user
+	tasks, err := t.createMultipleSampleTasks(1)
+	t.Require().NoError(err)
+	otherListID, err := t.createTodoListDirect(t.userID, "Other list", "Holds no tasks")
+	t.Require().NoError(err)
+
+	// Act: Look the task up in its list
+	task, err := t.store.GetTask(t.ctx, GetTaskParams{ID: tasks[0].ID, ListID: t.todoListID, UserID: t.userID})
+
+	// Assert
+	t.Require().NoError(err)
+	t.Equal(tasks[0].ID, task.ID)
+	t.Equal(*tasks[0].Title, *task.Title)
+
+	// The task is not found through another list, another user or an unknown ID
+	_, err = t.store.GetTask(t.ctx, GetTaskParams{ID: tasks[0].ID, ListID: otherListID, UserID: t.userID})
+	t.ErrorIs(err, common.ErrNotFound)
+	_, err = t.store.GetTask(t.ctx, GetTaskParams{ID: tasks[0].ID, ListID: t.todoListID, UserID: uuid.New()})
+	t.ErrorIs(err, common.ErrNotFound)
+	_, err = t.store.GetTask(t.ctx, GetTaskParams{ID: uuid.New(), ListID: t.todoListID, UserID: t.userID})
+	t.ErrorIs(err, common.ErrNotFound)
+}
+
 func (t *TaskTestSuite) TestListTasks() {
 	// Arrange: Create multiple tasks using the helper
 	tasks, err := t.createMultipleSampleTasks(5)
@@ -558,3 +584,492 @@ func (t *TaskTestSuite) TestUpdateMultipleTaskPriorities() {
 	t.Equal(taskToUpdate.Status, updatedTask.Status)
 	t.Equal(taskToUpdate.DueDate, updatedTask.DueDate)
 }
+
+func (t *TaskTestSuite) TestTaskEvents() {
+	// Arrange: Create a task and apply an update, a completion and a delete
+	tasks, err := t.createMultipleSampleTasks(1)
+	t.Require().NoError(err)
+	createdTask := tasks[0]
+
+	_, err = t.store.UpdateTask(t.ctx, UpdateTaskParams{
+		ID:     createdTask.ID,
+		ListID: t.todoListID,
+		UserID: t.userID,
+		Title:  common.Ptr("Renamed Task"),
+	})
+	t.Require().NoError(err)
+
+	_, err = t.store.UpdateTask(t.ctx, UpdateTaskParams{
+		ID:     createdTask.ID,
+		ListID: t.todoListID,
+		UserID: t.userID,
+		Status: common.Ptr("completed"),
+	})
+	t.Require().NoError(err)
+
+	_, err = t.store.DeleteTasks(t.ctx, DeleteTasksParams{
+		IDs:    []uuid.UUID{createdTask.ID},
+		ListID: t.todoListID,
+		UserID: t.userID,
+	})
+	t.Require().NoError(err)
+
+	// Act: Page through the history, newest first
+	events, err := t.store.ListTaskEvents(t.ctx, ListTaskEventsParams{
+		TaskID: createdTask.ID,
+		ListID: t.todoListID,
+		UserID: t.userID,
+		Limit:  DefaultEventLimit,
+		Offset: DefaultEventOffset,
+	})
+
+	// Assert: Each mutation was recorded with its actor and changed fields
+	t.Require().NoError(err)
+	t.Require().Len(events, 3)
+
+	t.Equal(TaskEventDeleted, events[0].EventType)
+	t.Equal(TaskEventCompleted, events[1].EventType)
+	t.Equal(TaskEventUpdated, events[2].EventType)
+
+	for _, event := range events {
+		t.Equal(createdTask.ID, event.TaskID)
+		t.Equal(t.userID, event.ActorID)
+		t.WithinDuration(time.Now(), event.CreatedAt, 5*time.Second)
+	}
+
+	t.Require().Contains(events[2].Changes, "title")
+	t.Equal(*createdTask.Title, events[2].Changes["title"].Old)
+	t.Equal("Renamed Task", events[2].Changes["title"].New)
+	t.NotContains(events[2].Changes, "description")
+
+	t.Require().Contains(events[1].Changes, "status")
+	t.Equal("completed", events[1].Changes["status"].New)
+
+	// Paging skips the newest entries
+	page, err := t.store.ListTaskEvents(t.ctx, ListTaskEventsParams{
+		TaskID: createdTask.ID,
+		ListID: t.todoListID,
+		UserID: t.userID,
+		Limit:  1,
+		Offset: 2,
+	})
+	t.Require().NoError(err)
+	t.Require().Len(page, 1)
+	t.Equal(events[2].ID, page[0].ID)
+
+	// Another user cannot read the history
+	otherUserID, err := t.createUserDirect("Other User", "other@example.com")
+	t.Require().NoError(err)
+	others, err := t.store.ListTaskEvents(t.ctx, ListTaskEventsParams{
+		TaskID: createdTask.ID,
+		ListID: t.todoListID,
+		UserID: otherUserID,
+		Limit:  DefaultEventLimit,
+	})
+	t.Require().NoError(err)
+	t.Empty(others)
+}
+
+func (t *TaskTestSuite) TestSearchAllTasks() {
+	// Arrange: Create tasks across two of the user's lists
+	groceries, err := t.createSampleTask("Buy groceries", "Milk, eggs and bread from the market", "pending", time.Now().Add(24*time.Hour), 3)
+	t.Require().NoError(err)
+	_, err = t.createSampleTask("Clean kitchen", "Put the groceries away first", "completed", time.Now().Add(72*time.Hour), 1)
+	t.Require().NoError(err)
+	_, err = t.createSampleTask("Call plumber", "Kitchen sink is leaking", "pending", time.Now().Add(48*time.Hour), 5)
+	t.Require().NoError(err)
+
+	secondListID, err := t.createTodoListDirect(t.userID, "Second List", "Another list")
+	t.Require().NoError(err)
+	market, err := t.store.CreateTask(t.ctx, secondListID, "Farmers market", common.Ptr("Pick up groceries for the week"), common.Ptr("pending"), time.Now().Add(96*time.Hour), 2)
+	t.Require().NoError(err)
+
+	// Act: Search across all lists
+	results, err := t.store.SearchAllTasks(t.ctx, FullTextSearchParams{
+		UserID: t.userID,
+		Query:  "grocery",
+		Limit:  DefaultSearchLimit,
+		Offset: DefaultSearchOffset,
+	})
+
+	// Assert: Stemmed matches are returned from both lists with title hits ranked first
+	t.Require().NoError(err)
+	t.Require().Len(results, 3)
+	t.Equal(groceries.ID, results[0].Task.ID)
+	t.Contains(results[0].TitleSnippet, "<mark>groceries</mark>")
+	t.Greater(results[0].Rank, results[1].Rank)
+
+	var ids []uuid.UUID
+	for _, result := range results {
+		ids = append(ids, result.Task.ID)
+	}
+	t.Contains(ids, market.ID)
+
+	// Markup in task text is escaped in snippets
+	_, err = t.createSampleTask(`<img src=x onerror=alert(1)> plumbing`, "", "pending", time.Now().Add(24*time.Hour), 1)
+	t.Require().NoError(err)
+	escaped, err := t.store.SearchAllTasks(t.ctx, FullTextSearchParams{UserID: t.userID, Query: "plumbing", Limit: DefaultSearchLimit})
+	t.Require().NoError(err)
+	t.Require().Len(escaped, 1)
+	t.Equal("&lt;img src=x onerror=alert(1)&gt; <mark>plumbing</mark>", escaped[0].TitleSnippet)
+
+	// Filters narrow the matches
+	filtered, err := t.store.SearchAllTasks(t.ctx, FullTextSearchParams{
+		UserID:      t.userID,
+		Query:       "groceries OR kitchen",
+		Status:      common.Ptr("pending"),
+		MinPriority: common.Ptr(int32(2)),
+		DueBefore:   common.Ptr(time.Now().Add(60 * time.Hour)),
+		Limit:       DefaultSearchLimit,
+	})
+	t.Require().NoError(err)
+	t.Require().Len(filtered, 2)
+	for _, result := range filtered {
+		t.Equal("pending", *result.Task.Status)
+		t.GreaterOrEqual(*result.Task.Priority, int32(2))
+	}
+
+	// Exclusions are honoured
+	excluded, err := t.store.SearchAllTasks(t.ctx, FullTextSearchParams{
+		UserID: t.userID,
+		Query:  "groceries -milk",
+		Limit:  DefaultSearchLimit,
+	})
+	t.Require().NoError(err)
+	t.Len(excluded, 2)
+
+	// Another user's search does not see these tasks
+	otherUserID, err := t.createUserDirect("Other User", "other@example.com")
+	t.Require().NoError(err)
+	others, err := t.store.SearchAllTasks(t.ctx, FullTextSearchParams{
+		UserID: otherUserID,
+		Query:  "groceries",
+		Limit:  DefaultSearchLimit,
+	})
+	t.Require().NoError(err)
+	t.Empty(others)
+}
+
+func (t *TaskTestSuite) TestQueryTasks() {
+	// Arrange: Create tasks with a mix of statuses, priorities and tags
+	sampleTasks, err := t.createMultipleSampleTasks(4)
+	t.Require().NoError(err)
+
+	_, err = t.store.UpdateTask(t.ctx, UpdateTaskParams{
+		ID:     sampleTasks[0].ID,
+		ListID: t.todoListID,
+		UserID: t.userID,
+		Tags:   []string{"home", "urgent"},
+	})
+	t.Require().NoError(err)
+	_, err = t.store.UpdateTask(t.ctx, UpdateTaskParams{
+		ID:     sampleTasks[2].ID,
+		ListID: t.todoListID,
+		UserID: t.userID,
+		Tags:   []string{"home"},
+	})
+	t.Require().NoError(err)
+	_, err = t.store.MarkTaskCompleted(t.ctx, UpdateTaskParams{
+		ID:     sampleTasks[3].ID,
+		ListID: t.todoListID,
+		UserID: t.userID,
+	})
+	t.Require().NoError(err)
+
+	query := func(q TaskQuery) []uuid.UUID {
+		q.ListID = t.todoListID
+		q.UserID = t.userID
+		if q.Limit == 0 {
+			q.Limit = DefaultListLimit
+		}
+		result, err := t.store.QueryTasks(t.ctx, q)
+		t.Require().NoError(err)
+
+		ids := make([]uuid.UUID, 0, len(result))
+		for _, task := range result {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	// Default ordering is priority then due date, with the completed (unprioritised) task last
+	t.Equal([]uuid.UUID{sampleTasks[0].ID, sampleTasks[1].ID, sampleTasks[2].ID, sampleTasks[3].ID}, query(TaskQuery{}))
+
+	// Tags must all match
+	t.Equal([]uuid.UUID{sampleTasks[0].ID, sampleTasks[2].ID}, query(TaskQuery{Tags: []string{"home"}}))
+	t.Equal([]uuid.UUID{sampleTasks[0].ID}, query(TaskQuery{Tags: []string{"home", "urgent"}}))
+
+	// Status and priority filters combine
+	t.Equal([]uuid.UUID{sampleTasks[2].ID, sampleTasks[1].ID}, query(TaskQuery{
+		Statuses:    []string{"pending"},
+		MinPriority: common.Ptr(int32(2)),
+		Sort:        []SortKey{{Field: SortFieldPriority, Desc: true}},
+	}))
+
+	// Due date window
+	t.Equal([]uuid.UUID{sampleTasks[0].ID, sampleTasks[1].ID}, query(TaskQuery{
+		DueBefore: common.Ptr(time.Now().Add(60 * time.Hour)),
+	}))
+
+	// Completed window
+	t.Equal([]uuid.UUID{sampleTasks[3].ID}, query(TaskQuery{
+		CompletedAfter: common.Ptr(time.Now().Add(-time.Hour)),
+	}))
+
+	// Full-text filter
+	t.Equal([]uuid.UUID{sampleTasks[1].ID}, query(TaskQuery{Text: common.Ptr(`"task number 2"`)}))
+
+	// Paging
+	t.Equal([]uuid.UUID{sampleTasks[1].ID}, query(TaskQuery{Limit: 1, Offset: 1}))
+
+	// Another user sees nothing in this list
+	otherUserID, err := t.createUserDirect("Other User", "other@example.com")
+	t.Require().NoError(err)
+	others, err := t.store.QueryTasks(t.ctx, TaskQuery{ListID: t.todoListID, UserID: otherUserID, Limit: DefaultListLimit})
+	t.Require().NoError(err)
+	t.Empty(others)
+}
+
+func (t *TaskTestSuite) TestTaskStats() {
+	// Arrange: Two pending tasks (one overdue) and one completed task in the suite list,
+	// plus a pending task in a second list
+	_, err := t.createSampleTask("Overdue", "Past due", "pending", time.Now().Add(-24*time.Hour), 1)
+	t.Require().NoError(err)
+	_, err = t.createSampleTask("Upcoming", "Due later", "pending", time.Now().Add(24*time.Hour), 2)
+	t.Require().NoError(err)
+	done, err := t.createSampleTask("Done", "Finished", "pending", time.Now().Add(48*time.Hour), 3)
+	t.Require().NoError(err)
+	_, err = t.store.MarkTaskCompleted(t.ctx, UpdateTaskParams{ID: done.ID, ListID: t.todoListID, UserID: t.userID})
+	t.Require().NoError(err)
+
+	secondListID, err := t.createTodoListDirect(t.userID, "Second List", "Another list")
+	t.Require().NoError(err)
+	_, err = t.store.CreateTask(t.ctx, secondListID, "Elsewhere", nil, common.Ptr("pending"), time.Now().Add(24*time.Hour), 1)
+	t.Require().NoError(err)
+
+	// Act: Stats for the single list
+	listStats, err := t.store.GetTaskStats(t.ctx, TaskStatsParams{UserID: t.userID, ListID: &t.todoListID})
+
+	// Assert
+	t.Require().NoError(err)
+	t.Equal(int64(3), listStats.Total)
+	t.Equal(map[string]int64{"pending": 2, "completed": 1}, listStats.ByStatus)
+	t.Equal(int64(1), listStats.Overdue)
+	t.Equal(int64(1), listStats.Completed)
+	t.GreaterOrEqual(listStats.AvgTimeToComplete, 0.0)
+	t.Require().Len(listStats.CompletionWindows, len(StatsWindows))
+	for i, window := range listStats.CompletionWindows {
+		t.Equal(StatsWindows[i], window.Days)
+		t.Equal(int64(3), window.Created)
+		t.Equal(int64(1), window.Completed)
+		t.InDelta(1.0/3.0, window.CompletionRate, 0.0001)
+	}
+
+	// Stats across all of the user's lists include the second list
+	userStats, err := t.store.GetTaskStats(t.ctx, TaskStatsParams{UserID: t.userID})
+	t.Require().NoError(err)
+	t.Equal(int64(4), userStats.Total)
+	t.Equal(int64(3), userStats.ByStatus["pending"])
+
+	// Counting a single status
+	count, err := t.store.CountTasksByStatus(t.ctx, CountTasksByStatusParams{ListID: t.todoListID, UserID: t.userID, Status: common.Ptr("pending")})
+	t.Require().NoError(err)
+	t.Equal(int64(2), count)
+
+	// Another user sees empty stats
+	otherUserID, err := t.createUserDirect("Other User", "other@example.com")
+	t.Require().NoError(err)
+	otherStats, err := t.store.GetTaskStats(t.ctx, TaskStatsParams{UserID: otherUserID})
+	t.Require().NoError(err)
+	t.Zero(otherStats.Total)
+	t.Empty(otherStats.ByStatus)
+}
+
+func (t *TaskTestSuite) TestListTasksDueBetween() {
+	// Arrange: Tasks due inside and outside the window, plus a completed task inside it
+	now := time.Now()
+	soon, err := t.createSampleTask("Soon", "Due within the hour", "pending", now.Add(30*time.Minute), 1)
+	t.Require().NoError(err)
+	_, err = t.createSampleTask("Later", "Due tomorrow", "pending", now.Add(24*time.Hour), 2)
+	t.Require().NoError(err)
+	_, err = t.createSampleTask("Past", "Already overdue", "pending", now.Add(-time.Hour), 3)
+	t.Require().NoError(err)
+	done, err := t.createSampleTask("Done", "Completed early", "pending", now.Add(45*time.Minute), 4)
+	t.Require().NoError(err)
+	_, err = t.store.MarkTaskCompleted(t.ctx, UpdateTaskParams{ID: done.ID, ListID: t.todoListID, UserID: t.userID})
+	t.Require().NoError(err)
+
+	// Act
+	dueTasks, err := t.store.ListTasksDueBetween(t.ctx, now, now.Add(time.Hour))
+
+	// Assert: Only the open task in the window is returned, with its owner
+	t.Require().NoError(err)
+	t.Require().Len(dueTasks, 1)
+	t.Equal(soon.ID, dueTasks[0].Task.ID)
+	t.Equal(t.userID, dueTasks[0].UserID)
+	t.Equal("test@example.com", dueTasks[0].UserEmail)
+	t.Equal("Test User", dueTasks[0].UserName)
+}
+
+func (t *TaskTestSuite) TestListDigestTasks() {
+	// Arrange: Open tasks before and after the cutoff, a recently completed task and another user's task
+	now := time.Now()
+	dueBefore := now.Add(12 * time.Hour)
+	overdue, err := t.createSampleTask("Overdue", "Past due", "pending", now.Add(-24*time.Hour), 1)
+	t.Require().NoError(err)
+	today, err := t.createSampleTask("Today", "Due later today", "pending", now.Add(6*time.Hour), 2)
+	t.Require().NoError(err)
+	_, err = t.createSampleTask("Tomorrow", "Due after the cutoff", "pending", now.Add(36*time.Hour), 3)
+	t.Require().NoError(err)
+	done, err := t.createSampleTask("Done", "Completed just now", "pending", now.Add(48*time.Hour), 4)
+	t.Require().NoError(err)
+	_, err = t.store.MarkTaskCompleted(t.ctx, UpdateTaskParams{ID: done.ID, ListID: t.todoListID, UserID: t.userID})
+	t.Require().NoError(err)
+
+	otherUserID, err := t.createUserDirect("Other User", "other@example.com")
+	t.Require().NoError(err)
+	otherListID, err := t.createTodoListDirect(otherUserID, "Other List", "Another user's list")
+	t.Require().NoError(err)
+	_, err = t.store.CreateTask(t.ctx, otherListID, "Not mine", nil, common.Ptr("pending"), now.Add(time.Hour), 1)
+	t.Require().NoError(err)
+
+	// Act
+	digestTasks, err := t.store.ListDigestTasks(t.ctx, t.userID, dueBefore, now.Add(-24*time.Hour))
+
+	// Assert: Open tasks due before the cutoff in due order, then the completed task
+	t.Require().NoError(err)
+	ids := make([]uuid.UUID, 0, len(digestTasks))
+	for _, task := range digestTasks {
+		ids = append(ids, task.ID)
+	}
+	t.Equal([]uuid.UUID{overdue.ID, today.ID, done.ID}, ids)
+}
+
+func (t *TaskTestSuite) TestImportTasks() {
+	// Arrange: A subscription to created events, and more tasks than fit in one batch
+	_, err := t.pgt.DB().Exec(t.ctx,
+		"INSERT INTO webhook_subscriptions (user_id, url, secret, event_types) VALUES ($1, 'https://example.com/hook', 'secret', $2)",
+		t.userID, []string{events.TaskCreated})
+	t.Require().NoError(err)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	importTasks := make([]ImportTask, ImportBatchSize+1)
+	for i := range importTasks {
+		importTasks[i] = ImportTask{Row: i + 2, Title: fmt.Sprintf("Imported %d", i), Status: DefaultImportStatus, Tags: []string{}}
+	}
+	importTasks[0] = ImportTask{Row: 2, Title: "Imported 0", Status: StatusCompleted, Priority: 3, DueDate: &due, CompletedAt: &due, Tags: []string{"home"}}
+
+	countRows := func(query string, args ...any) int {
+		var count int
+		t.Require().NoError(t.pgt.DB().QueryRow(t.ctx, query, args...).Scan(&count))
+		return count
+	}
+
+	// Act: Dry run
+	imported, err := t.store.ImportTasks(t.ctx, ImportTasksParams{ListID: t.todoListID, UserID: t.userID, Tasks: importTasks, DryRun: true})
+
+	// Assert: Counted but not kept
+	t.Require().NoError(err)
+	t.Equal(len(importTasks), imported)
+	t.Equal(0, countRows("SELECT count(*) FROM tasks WHERE list_id = $1", t.todoListID))
+
+	// Act: Import
+	imported, err = t.store.ImportTasks(t.ctx, ImportTasksParams{ListID: t.todoListID, UserID: t.userID, Tasks: importTasks})
+
+	// Assert: Every task stored with its events and webhook deliveries
+	t.Require().NoError(err)
+	t.Equal(len(importTasks), imported)
+
+	listed, err := t.store.ListTasks(t.ctx, TaskListParams{ListID: t.todoListID, UserID: t.userID})
+	t.Require().NoError(err)
+	t.Len(listed, len(importTasks))
+	for _, task := range listed {
+		if *task.Title == "Imported 0" {
+			t.Equal(StatusCompleted, *task.Status)
+			t.Equal(int32(3), *task.Priority)
+			t.Equal([]string{"home"}, task.Tags)
+			t.Require().NotNil(task.DueDate)
+			t.True(task.DueDate.Equal(due))
+		}
+	}
+
+	t.Equal(len(importTasks), countRows(
+		"SELECT count(*) FROM outbox_events WHERE event_type = $1 AND aggregate_id IN (SELECT id FROM tasks WHERE list_id = $2)",
+		events.TaskCreated, t.todoListID))
+	t.Equal(len(importTasks), countRows("SELECT count(*) FROM webhook_deliveries WHERE event_type = $1", events.TaskCreated))
+
+	// Act & Assert: Another user's list is reported as missing
+	otherUserID, err := t.createUserDirect("Other User", "other@example.com")
+	t.Require().NoError(err)
+	_, err = t.store.ImportTasks(t.ctx, ImportTasksParams{ListID: t.todoListID, UserID: otherUserID, Tasks: importTasks[:1]})
+	t.ErrorIs(err, common.ErrNotFound)
+}
+
+func (t *TaskTestSuite) TestCreateTaskWithID() {
+	// Arrange
+	id := uuid.New()
+	completedAt := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	params := CreateTaskParams{
+		ListID:      t.todoListID,
+		Title:       common.Ptr("Named by the client"),
+		Status:      common.Ptr(StatusCompleted),
+		Priority:    2,
+		CompletedAt: &completedAt,
+		Tags:        []string{"sync"},
+	}
+
+	// Act
+	created, err := t.store.CreateTaskWithID(t.ctx, id, params)
+
+	// Assert: Stored under the given ID with its completion time and tags
+	t.Require().NoError(err)
+	t.Equal(id, created.ID)
+	t.Equal(StatusCompleted, *created.Status)
+	t.Equal([]string{"sync"}, created.Tags)
+	t.Require().NotNil(created.CompletedAt)
+	t.True(created.CompletedAt.Equal(completedAt))
+
+	// Act & Assert: The ID cannot be reused
+	_, err = t.store.CreateTaskWithID(t.ctx, id, params)
+	t.ErrorIs(err, ErrTaskExists)
+}
+
+func (t *TaskTestSuite) TestStreamTasks() {
+	// Arrange
+	created, err := t.createMultipleSampleTasks(3)
+	t.Require().NoError(err)
+
+	listed, err := t.store.ListTasks(t.ctx, TaskListParams{ListID: t.todoListID, UserID: t.userID})
+	t.Require().NoError(err)
+
+	// Act
+	var streamed []FullTask
+	err = t.store.StreamTasks(t.ctx, TaskListParams{ListID: t.todoListID, UserID: t.userID}, func(task FullTask) error {
+		streamed = append(streamed, task)
+		return nil
+	})
+
+	// Assert: Same tasks in the same order as ListTasks
+	t.Require().NoError(err)
+	t.Len(streamed, len(created))
+	t.Equal(extractTaskIDs(listed), extractTaskIDs(streamed))
+
+	// Act & Assert: Callback errors stop the stream
+	stop := errors.New("stop")
+	calls := 0
+	err = t.store.StreamTasks(t.ctx, TaskListParams{ListID: t.todoListID, UserID: t.userID}, func(FullTask) error {
+		calls++
+		return stop
+	})
+	t.ErrorIs(err, stop)
+	t.Equal(1, calls)
+
+	// Act & Assert: Another user's list streams nothing
+	otherUserID, err := t.createUserDirect("Other User", "other@example.com")
+	t.Require().NoError(err)
+	err = t.store.StreamTasks(t.ctx, TaskListParams{ListID: t.todoListID, UserID: otherUserID}, func(FullTask) error {
+		t.Fail("unexpected task")
+		return nil
+	})
+	t.NoError(err)
+}
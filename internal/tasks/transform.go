@@ -1,11 +1,16 @@
 package tasks
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
+	"strings"
+	"time"
 
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
 	"github.com/henryhall897/golang-todo-app/internal/tasks/gen"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -35,8 +40,64 @@ func toDBCreateTask(params CreateTaskParams) (gen.CreateTaskParams, error) {
 	}, nil
 }
 
-// toFullTask converts a Task (pgtype-based) struct to a FullTask (Go type-based) struct.
-func toFullTask(dbTask gen.Task) (FullTask, error) {
+// toDBCreateTaskWithID converts CreateTaskParams into the parameters of a task inserted under the given ID.
+func toDBCreateTaskWithID(id uuid.UUID, params CreateTaskParams) (gen.CreateTaskWithIDParams, error) {
+	dbID, err := common.ToPgUUID(id)
+	if err != nil {
+		return gen.CreateTaskWithIDParams{}, fmt.Errorf("invalid id: %w", err)
+	}
+
+	dbTask, err := toDBCreateTask(params)
+	if err != nil {
+		return gen.CreateTaskWithIDParams{}, err
+	}
+
+	// The column is NOT NULL; the insert bypasses its default
+	tags := params.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return gen.CreateTaskWithIDParams{
+		ID:          dbID,
+		ListID:      dbTask.ListID,
+		Title:       dbTask.Title,
+		Description: dbTask.Description,
+		Status:      dbTask.Status,
+		DueDate:     dbTask.DueDate,
+		Priority:    dbTask.Priority,
+		CompletedAt: common.ToPgTimestamp(params.CompletedAt),
+		Tags:        tags,
+	}, nil
+}
+
+// taskRow holds the task columns the task queries select: every column but the search vector, which
+// only full-text search reads. The row types of those queries all convert to it.
+type taskRow struct {
+	ID          pgtype.UUID
+	ListID      pgtype.UUID
+	Title       pgtype.Text
+	Description pgtype.Text
+	Status      pgtype.Text
+	Priority    pgtype.Int4
+	DueDate     pgtype.Timestamp
+	CompletedAt pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	Tags        []string
+}
+
+// taskRowTypes are the row types holding exactly the columns of taskRow.
+type taskRowTypes interface {
+	taskRow | gen.CreateTaskRow | gen.CreateTaskWithIDRow | gen.DeleteTasksRow | gen.GetTaskRow | gen.GetTaskForUpdateRow |
+		gen.ListDigestTasksRow | gen.ListOverdueTasksRow | gen.ListTasksRow | gen.ListTasksByStatusRow |
+		gen.SearchTasksRow | gen.UpdateTaskRow
+}
+
+// toFullTask converts a task row (pgtype-based) to a FullTask (Go type-based) struct.
+func toFullTask[T taskRowTypes](row T) (FullTask, error) {
+	dbTask := taskRow(row)
+
 	// Convert ID and ListID using common utility functions
 	id, err := common.FromPgUUID(dbTask.ID)
 	if err != nil {
@@ -70,11 +131,12 @@ func toFullTask(dbTask gen.Task) (FullTask, error) {
 		UpdatedAt:   updatedAt,
 		Priority:    priority,
 		CompletedAt: &completedAt,
+		Tags:        dbTask.Tags,
 	}, nil
 }
 
-// toFullTaskList converts a slice of Tasks (pgtype-based) into a slice of FullTasks (Go type-based).
-func toFullTaskList(dbTasks []gen.Task) ([]FullTask, error) {
+// toFullTaskList converts a slice of task rows (pgtype-based) into a slice of FullTasks (Go type-based).
+func toFullTaskList[T taskRowTypes](dbTasks []T) ([]FullTask, error) {
 	var tasks []FullTask
 	for _, dbTask := range dbTasks {
 		task, err := toFullTask(dbTask)
@@ -129,6 +191,7 @@ func toDBUpdateTaskParams(params UpdateTaskParams) (gen.UpdateTaskParams, error)
 		DueDate:     dbDueDate,
 		Priority:    dbPriority,
 		CompletedAt: dbCompletedAt,
+		Tags:        params.Tags,
 	}, nil
 }
 
@@ -280,3 +343,340 @@ func toDBUpdatePriorityParams(params UpdateTaskParams) (gen.UpdateTaskPriorityPa
 		Priority: dbPriority,
 	}, nil
 }
+
+// toDBGetTaskParams converts GetTaskParams (Go struct) into a pgtype-compatible GetTaskParams struct.
+func toDBGetTaskParams(params GetTaskParams) (gen.GetTaskParams, error) {
+	dbTaskID, err := common.ToPgUUID(params.ID)
+	if err != nil {
+		return gen.GetTaskParams{}, fmt.Errorf("invalid task_id: %w", err)
+	}
+
+	dbListID, err := common.ToPgUUID(params.ListID)
+	if err != nil {
+		return gen.GetTaskParams{}, fmt.Errorf("invalid list_id: %w", err)
+	}
+
+	dbUserID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return gen.GetTaskParams{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return gen.GetTaskParams{
+		ID:     dbTaskID,
+		ListID: dbListID,
+		UserID: dbUserID,
+	}, nil
+}
+
+// toDBGetTaskForUpdateParams converts a task and user ID into a pgtype-compatible GetTaskForUpdateParams struct.
+func toDBGetTaskForUpdateParams(taskID, userID uuid.UUID) (gen.GetTaskForUpdateParams, error) {
+	dbTaskID, err := common.ToPgUUID(taskID)
+	if err != nil {
+		return gen.GetTaskForUpdateParams{}, fmt.Errorf("invalid task_id: %w", err)
+	}
+
+	dbUserID, err := common.ToPgUUID(userID)
+	if err != nil {
+		return gen.GetTaskForUpdateParams{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return gen.GetTaskForUpdateParams{
+		ID:     dbTaskID,
+		UserID: dbUserID,
+	}, nil
+}
+
+// toDBCreateTaskEventParams converts a TaskEvent (Go struct) into a pgtype-compatible CreateTaskEventParams struct.
+func toDBCreateTaskEventParams(event TaskEvent) (gen.CreateTaskEventParams, error) {
+	dbTaskID, err := common.ToPgUUID(event.TaskID)
+	if err != nil {
+		return gen.CreateTaskEventParams{}, fmt.Errorf("invalid task_id: %w", err)
+	}
+
+	dbListID, err := common.ToPgUUID(event.ListID)
+	if err != nil {
+		return gen.CreateTaskEventParams{}, fmt.Errorf("invalid list_id: %w", err)
+	}
+
+	dbActorID, err := common.ToPgUUID(event.ActorID)
+	if err != nil {
+		return gen.CreateTaskEventParams{}, fmt.Errorf("invalid actor_id: %w", err)
+	}
+
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return gen.CreateTaskEventParams{}, fmt.Errorf("failed to marshal changes: %w", err)
+	}
+
+	return gen.CreateTaskEventParams{
+		TaskID:    dbTaskID,
+		ListID:    dbListID,
+		ActorID:   dbActorID,
+		EventType: event.EventType,
+		Changes:   changes,
+	}, nil
+}
+
+// toTaskEvent converts a TaskEvent (pgtype-based) struct to a TaskEvent (Go type-based) struct.
+func toTaskEvent(dbEvent gen.TaskEvent) (TaskEvent, error) {
+	id, err := common.FromPgUUID(dbEvent.ID)
+	if err != nil {
+		return TaskEvent{}, fmt.Errorf("invalid id: %w", err)
+	}
+
+	taskID, err := common.FromPgUUID(dbEvent.TaskID)
+	if err != nil {
+		return TaskEvent{}, fmt.Errorf("invalid task_id: %w", err)
+	}
+
+	listID, err := common.FromPgUUID(dbEvent.ListID)
+	if err != nil {
+		return TaskEvent{}, fmt.Errorf("invalid list_id: %w", err)
+	}
+
+	// actor_id is NULL once the acting user has been deleted, which maps to uuid.Nil
+	actorID, err := common.FromPgUUID(dbEvent.ActorID)
+	if err != nil {
+		return TaskEvent{}, fmt.Errorf("invalid actor_id: %w", err)
+	}
+
+	changes := make(map[string]FieldChange)
+	if len(dbEvent.Changes) > 0 {
+		if err := json.Unmarshal(dbEvent.Changes, &changes); err != nil {
+			return TaskEvent{}, fmt.Errorf("invalid changes: %w", err)
+		}
+	}
+
+	return TaskEvent{
+		ID:        id,
+		TaskID:    taskID,
+		ListID:    listID,
+		ActorID:   actorID,
+		EventType: dbEvent.EventType,
+		Changes:   changes,
+		CreatedAt: dbEvent.CreatedAt.Time,
+	}, nil
+}
+
+// toTaskEventList converts a slice of TaskEvents (pgtype-based) into a slice of TaskEvents (Go type-based).
+func toTaskEventList(dbEvents []gen.TaskEvent) ([]TaskEvent, error) {
+	events := make([]TaskEvent, 0, len(dbEvents))
+	for _, dbEvent := range dbEvents {
+		event, err := toTaskEvent(dbEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform task event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// toDBListTaskEventsParams converts ListTaskEventsParams (Go struct) into a pgtype-compatible ListTaskEventsParams struct.
+func toDBListTaskEventsParams(params ListTaskEventsParams) (gen.ListTaskEventsParams, error) {
+	dbTaskID, err := common.ToPgUUID(params.TaskID)
+	if err != nil {
+		return gen.ListTaskEventsParams{}, fmt.Errorf("invalid task_id: %w", err)
+	}
+
+	dbListID, err := common.ToPgUUID(params.ListID)
+	if err != nil {
+		return gen.ListTaskEventsParams{}, fmt.Errorf("invalid list_id: %w", err)
+	}
+
+	dbUserID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return gen.ListTaskEventsParams{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return gen.ListTaskEventsParams{
+		TaskID: dbTaskID,
+		ListID: dbListID,
+		UserID: dbUserID,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}, nil
+}
+
+// toDBFullTextSearchTasksParams converts FullTextSearchParams (Go struct) into a pgtype-compatible FullTextSearchTasksParams struct.
+func toDBFullTextSearchTasksParams(params FullTextSearchParams) (gen.FullTextSearchTasksParams, error) {
+	dbUserID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return gen.FullTextSearchTasksParams{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return gen.FullTextSearchTasksParams{
+		Query:       params.Query,
+		UserID:      dbUserID,
+		Status:      common.ToPgText(params.Status),
+		MinPriority: optionalInt4(params.MinPriority),
+		MaxPriority: optionalInt4(params.MaxPriority),
+		DueAfter:    common.ToPgTimestamp(params.DueAfter),
+		DueBefore:   common.ToPgTimestamp(params.DueBefore),
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+	}, nil
+}
+
+// toTaskSearchResult converts a FullTextSearchTasksRow into a TaskSearchResult.
+func toTaskSearchResult(row gen.FullTextSearchTasksRow) (TaskSearchResult, error) {
+	task, err := toFullTask(taskRow{
+		ID: row.ID, ListID: row.ListID, Title: row.Title, Description: row.Description,
+		Status: row.Status, Priority: row.Priority, DueDate: row.DueDate,
+		CompletedAt: row.CompletedAt, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt, Tags: row.Tags,
+	})
+	if err != nil {
+		return TaskSearchResult{}, err
+	}
+
+	return TaskSearchResult{
+		Task:               task,
+		Rank:               row.Rank,
+		TitleSnippet:       toSnippetMarkup(row.TitleSnippet),
+		DescriptionSnippet: toSnippetMarkup(row.DescriptionSnippet),
+	}, nil
+}
+
+// Delimiters FullTextSearchTasks wraps matches in, private-use characters that task text has no reason to hold
+const (
+	snippetStartSel = "\uE000"
+	snippetStopSel  = "\uE001"
+)
+
+// snippetMarkup replaces the match delimiters of an escaped snippet with <mark> tags
+var snippetMarkup = strings.NewReplacer(snippetStartSel, "<mark>", snippetStopSel, "</mark>")
+
+// toSnippetMarkup converts a snippet as returned by FullTextSearchTasks into HTML safe to render
+func toSnippetMarkup(snippet string) string {
+	return snippetMarkup.Replace(html.EscapeString(snippet))
+}
+
+// toTaskSearchResultList converts a slice of FullTextSearchTasksRow into a slice of TaskSearchResult.
+func toTaskSearchResultList(rows []gen.FullTextSearchTasksRow) ([]TaskSearchResult, error) {
+	results := make([]TaskSearchResult, 0, len(rows))
+	for _, row := range rows {
+		result, err := toTaskSearchResult(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform search result: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// optionalInt4 converts an optional int32 into a pgtype.Int4, treating nil as NULL.
+func optionalInt4(i *int32) pgtype.Int4 {
+	if i == nil {
+		return pgtype.Int4{}
+	}
+	return common.ToPgInt4(*i)
+}
+
+// toDBCountTasksByStatusParams converts CountTasksByStatusParams (Go struct) into a pgtype-compatible CountTasksByStatusParams struct.
+func toDBCountTasksByStatusParams(params CountTasksByStatusParams) (gen.CountTasksByStatusParams, error) {
+	dbListID, err := common.ToPgUUID(params.ListID)
+	if err != nil {
+		return gen.CountTasksByStatusParams{}, fmt.Errorf("invalid list_id: %w", err)
+	}
+
+	dbUserID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return gen.CountTasksByStatusParams{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return gen.CountTasksByStatusParams{
+		ListID: dbListID,
+		UserID: dbUserID,
+		Status: common.ToPgText(params.Status),
+	}, nil
+}
+
+// toDBStatsScope converts TaskStatsParams into the user and optional list arguments shared by the stats queries.
+func toDBStatsScope(params TaskStatsParams) (pgtype.UUID, pgtype.UUID, error) {
+	dbUserID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	var dbListID pgtype.UUID
+	if params.ListID != nil {
+		dbListID, err = common.ToPgUUID(*params.ListID)
+		if err != nil {
+			return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf("invalid list_id: %w", err)
+		}
+	}
+
+	return dbUserID, dbListID, nil
+}
+
+// toCompletionWindow converts a GetTaskCompletionWindowRow into a CompletionWindow.
+func toCompletionWindow(days int, row gen.GetTaskCompletionWindowRow) CompletionWindow {
+	window := CompletionWindow{
+		Days:      days,
+		Created:   row.Created,
+		Completed: row.Completed,
+	}
+	if row.Created > 0 {
+		window.CompletionRate = float64(row.Completed) / float64(row.Created)
+	}
+	return window
+}
+
+// toDueTask converts a ListTasksDueBetweenRow into a DueTask.
+func toDueTask(row gen.ListTasksDueBetweenRow) (DueTask, error) {
+	task, err := toFullTask(taskRow{
+		ID: row.ID, ListID: row.ListID, Title: row.Title, Description: row.Description,
+		Status: row.Status, Priority: row.Priority, DueDate: row.DueDate,
+		CompletedAt: row.CompletedAt, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt, Tags: row.Tags,
+	})
+	if err != nil {
+		return DueTask{}, err
+	}
+
+	userID, err := common.FromPgUUID(row.UserID)
+	if err != nil {
+		return DueTask{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return DueTask{
+		Task:      task,
+		UserID:    userID,
+		UserEmail: row.UserEmail,
+		UserName:  row.UserName,
+	}, nil
+}
+
+// toDueTaskList converts a slice of ListTasksDueBetweenRow into a slice of DueTask.
+func toDueTaskList(rows []gen.ListTasksDueBetweenRow) ([]DueTask, error) {
+	dueTasks := make([]DueTask, 0, len(rows))
+	for _, row := range rows {
+		dueTask, err := toDueTask(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform due task: %w", err)
+		}
+		dueTasks = append(dueTasks, dueTask)
+	}
+	return dueTasks, nil
+}
+
+// toDBImportTask converts an imported task into its COPY row. Every copied column is set explicitly
+// because COPY only applies defaults to columns it does not list.
+func toDBImportTask(listID pgtype.UUID, task ImportTask, id uuid.UUID, now time.Time) (gen.ImportTasksParams, error) {
+	dbID, err := common.ToPgUUID(id)
+	if err != nil {
+		return gen.ImportTasksParams{}, fmt.Errorf("invalid id: %w", err)
+	}
+
+	return gen.ImportTasksParams{
+		ID:          dbID,
+		ListID:      listID,
+		Title:       common.ToPgText(&task.Title),
+		Description: common.ToPgText(task.Description),
+		Status:      common.ToPgText(&task.Status),
+		Priority:    common.ToPgInt4(task.Priority),
+		DueDate:     common.ToPgTimestamp(task.DueDate),
+		CompletedAt: common.ToPgTimestamp(task.CompletedAt),
+		CreatedAt:   common.ToPgTimestamp(&now),
+		UpdatedAt:   common.ToPgTimestamp(&now),
+		Tags:        task.Tags,
+	}, nil
+}
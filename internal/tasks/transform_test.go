@@ -47,8 +47,8 @@ func TestToFullTask(t *testing.T) {
 	validUUID := uuid.New()
 	validTime := time.Now().UTC()
 
-	// Create a gen.Task struct to use as input
-	genTask := gen.Task{
+	// Create a gen.ListTasksRow struct to use as input
+	genTask := gen.ListTasksRow{
 		ID:          pgtype.UUID{Bytes: validUUID, Valid: true},
 		ListID:      pgtype.UUID{Bytes: validUUID, Valid: true},
 		Title:       pgtype.Text{String: "Sample Task Title", Valid: true},
@@ -61,7 +61,7 @@ func TestToFullTask(t *testing.T) {
 		CompletedAt: pgtype.Timestamp{Valid: false}, // CompletedAt is not set for this task
 	}
 
-	// Act: Transform gen.Task into FullTask
+	// Act: Transform gen.ListTasksRow into FullTask
 	fullTask, err := toFullTask(genTask)
 
 	// Assert: Check that the transformation worked correctly
@@ -90,11 +90,11 @@ func TestToFullTask(t *testing.T) {
 	require.Nil(t, fullTask.CompletedAt)
 }
 func TestToFullTaskList(t *testing.T) {
-	// Arrange: Create multiple gen.Task structs to use as input
+	// Arrange: Create multiple gen.ListTasksRow structs to use as input
 	validUUID := uuid.New()
 	validTime := time.Now()
 
-	genTasks := []gen.Task{
+	genTasks := []gen.ListTasksRow{
 		{
 			ID:          pgtype.UUID{Bytes: validUUID, Valid: true},
 			ListID:      pgtype.UUID{Bytes: validUUID, Valid: true},
@@ -121,7 +121,7 @@ func TestToFullTaskList(t *testing.T) {
 		},
 	}
 
-	// Act: Transform gen.Task slice into FullTask slice
+	// Act: Transform gen.ListTasksRow slice into FullTask slice
 	fullTasks, err := toFullTaskList(genTasks)
 
 	// Assert: Verify the transformation worked correctly
@@ -390,3 +390,137 @@ func TestToDBUpdatePriorityParams(t *testing.T) {
 	require.True(t, result.Priority.Valid)
 	require.Equal(t, priority, result.Priority.Int32)
 }
+
+func TestTaskEventRoundTrip(t *testing.T) {
+	// Arrange: Create a sample event
+	event := TaskEvent{
+		TaskID:    uuid.New(),
+		ListID:    uuid.New(),
+		ActorID:   uuid.New(),
+		EventType: TaskEventUpdated,
+		Changes: map[string]FieldChange{
+			"title": {Old: "Old Title", New: "New Title"},
+		},
+	}
+
+	// Act: Transform to the database struct and back
+	dbParams, err := toDBCreateTaskEventParams(event)
+	require.NoError(t, err)
+
+	createdAt := time.Now()
+	result, err := toTaskEvent(gen.TaskEvent{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		TaskID:    dbParams.TaskID,
+		ListID:    dbParams.ListID,
+		ActorID:   dbParams.ActorID,
+		EventType: dbParams.EventType,
+		Changes:   dbParams.Changes,
+		CreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true},
+	})
+
+	// Assert: The event survives the round trip
+	require.NoError(t, err)
+	require.Equal(t, event.TaskID, result.TaskID)
+	require.Equal(t, event.ListID, result.ListID)
+	require.Equal(t, event.ActorID, result.ActorID)
+	require.Equal(t, event.EventType, result.EventType)
+	require.Equal(t, event.Changes, result.Changes)
+	require.Equal(t, createdAt, result.CreatedAt)
+}
+
+func TestToTaskEventWithoutActor(t *testing.T) {
+	// Arrange: The acting user has been deleted, leaving a NULL actor_id
+	dbEvent := gen.TaskEvent{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		TaskID:    pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ListID:    pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		EventType: TaskEventDeleted,
+		Changes:   []byte(`{}`),
+	}
+
+	// Act
+	result, err := toTaskEvent(dbEvent)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, uuid.Nil, result.ActorID)
+	require.Empty(t, result.Changes)
+}
+
+func TestToDBFullTextSearchTasksParams(t *testing.T) {
+	// Arrange: Only some of the optional filters are set
+	userID := uuid.New()
+	dueBefore := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	params := FullTextSearchParams{
+		UserID:      userID,
+		Query:       "groceries -milk",
+		Status:      common.Ptr("pending"),
+		MinPriority: common.Ptr(int32(2)),
+		DueBefore:   &dueBefore,
+		Limit:       10,
+		Offset:      5,
+	}
+
+	// Act
+	result, err := toDBFullTextSearchTasksParams(params)
+
+	// Assert: Set filters are valid and unset filters are NULL
+	require.NoError(t, err)
+	require.Equal(t, "groceries -milk", result.Query)
+	require.Equal(t, userID[:], result.UserID.Bytes[:])
+	require.True(t, result.Status.Valid)
+	require.Equal(t, "pending", result.Status.String)
+	require.True(t, result.MinPriority.Valid)
+	require.Equal(t, int32(2), result.MinPriority.Int32)
+	require.False(t, result.MaxPriority.Valid)
+	require.False(t, result.DueAfter.Valid)
+	require.True(t, result.DueBefore.Valid)
+	require.Equal(t, dueBefore, result.DueBefore.Time)
+	require.Equal(t, int32(10), result.Limit)
+	require.Equal(t, int32(5), result.Offset)
+}
+
+func TestToTaskSearchResultList(t *testing.T) {
+	// Arrange
+	taskID := uuid.New()
+	rows := []gen.FullTextSearchTasksRow{
+		{
+			ID:           pgtype.UUID{Bytes: taskID, Valid: true},
+			ListID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Title:        pgtype.Text{String: "Buy groceries", Valid: true},
+			Rank:         0.6,
+			TitleSnippet: "Buy " + snippetStartSel + "groceries" + snippetStopSel,
+		},
+	}
+
+	// Act
+	results, err := toTaskSearchResultList(rows)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, taskID, results[0].Task.ID)
+	require.Equal(t, "Buy groceries", *results[0].Task.Title)
+	require.Equal(t, float32(0.6), results[0].Rank)
+	require.Equal(t, "Buy <mark>groceries</mark>", results[0].TitleSnippet)
+}
+
+func TestToCompletionWindow(t *testing.T) {
+	window := toCompletionWindow(7, gen.GetTaskCompletionWindowRow{Created: 4, Completed: 3})
+	require.Equal(t, CompletionWindow{Days: 7, Created: 4, Completed: 3, CompletionRate: 0.75}, window)
+
+	// Nothing created in the window yields a zero rate rather than NaN
+	empty := toCompletionWindow(30, gen.GetTaskCompletionWindowRow{})
+	require.Equal(t, 0.0, empty.CompletionRate)
+}
+
+func TestToSnippetMarkup(t *testing.T) {
+	// Arrange: A title holding markup, with its match delimited as FullTextSearchTasks returns it
+	snippet := `<img src=x onerror=alert(1)> ` + snippetStartSel + `groceries` + snippetStopSel + ` & "milk"`
+
+	// Act
+	result := toSnippetMarkup(snippet)
+
+	// Assert: The task's markup is escaped and only the match is marked up
+	require.Equal(t, `&lt;img src=x onerror=alert(1)&gt; <mark>groceries</mark> &amp; &#34;milk&#34;`, result)
+}
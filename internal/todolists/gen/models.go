@@ -8,17 +8,65 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Task struct {
+type AuditLog struct {
+	ID         pgtype.UUID      `json:"id"`
+	ActorID    pgtype.UUID      `json:"actor_id"`
+	Action     string           `json:"action"`
+	TargetType string           `json:"target_type"`
+	TargetID   string           `json:"target_id"`
+	Before     []byte           `json:"before"`
+	After      []byte           `json:"after"`
+	RequestID  pgtype.Text      `json:"request_id"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type DataExport struct {
 	ID          pgtype.UUID      `json:"id"`
-	ListID      pgtype.UUID      `json:"list_id"`
-	Title       string           `json:"title"`
-	Description pgtype.Text      `json:"description"`
-	Status      pgtype.Text      `json:"status"`
-	Priority    pgtype.Int4      `json:"priority"`
-	DueDate     pgtype.Timestamp `json:"due_date"`
-	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	UserID      pgtype.UUID      `json:"user_id"`
+	Status      string           `json:"status"`
+	FilePath    pgtype.Text      `json:"file_path"`
+	SizeBytes   pgtype.Int8      `json:"size_bytes"`
+	Error       pgtype.Text      `json:"error"`
+	LeaseUntil  pgtype.Timestamp `json:"lease_until"`
 	CreatedAt   pgtype.Timestamp `json:"created_at"`
 	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	ExpiresAt   pgtype.Timestamp `json:"expires_at"`
+}
+
+type OutboxEvent struct {
+	ID            pgtype.UUID      `json:"id"`
+	AggregateType string           `json:"aggregate_type"`
+	AggregateID   pgtype.UUID      `json:"aggregate_id"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	PublishedAt   pgtype.Timestamp `json:"published_at"`
+}
+
+type Task struct {
+	ID           pgtype.UUID      `json:"id"`
+	ListID       pgtype.UUID      `json:"list_id"`
+	Title        string           `json:"title"`
+	Description  pgtype.Text      `json:"description"`
+	Status       pgtype.Text      `json:"status"`
+	Priority     pgtype.Int4      `json:"priority"`
+	DueDate      pgtype.Timestamp `json:"due_date"`
+	CompletedAt  pgtype.Timestamp `json:"completed_at"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	SearchVector interface{}      `json:"search_vector"`
+	Tags         []string         `json:"tags"`
+}
+
+type TaskEvent struct {
+	ID        pgtype.UUID      `json:"id"`
+	TaskID    pgtype.UUID      `json:"task_id"`
+	ListID    pgtype.UUID      `json:"list_id"`
+	ActorID   pgtype.UUID      `json:"actor_id"`
+	EventType string           `json:"event_type"`
+	Changes   []byte           `json:"changes"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
 
 type Todolist struct {
@@ -37,3 +85,38 @@ type User struct {
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 	UpdatedAt pgtype.Timestamp `json:"updated_at"`
 }
+
+type UserPreference struct {
+	UserID        pgtype.UUID      `json:"user_id"`
+	DigestEnabled bool             `json:"digest_enabled"`
+	Timezone      string           `json:"timezone"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	UpdatedAt     pgtype.Timestamp `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             pgtype.UUID      `json:"id"`
+	SubscriptionID pgtype.UUID      `json:"subscription_id"`
+	EventID        pgtype.UUID      `json:"event_id"`
+	EventType      string           `json:"event_type"`
+	Payload        []byte           `json:"payload"`
+	Status         string           `json:"status"`
+	Attempts       int32            `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamp `json:"next_attempt_at"`
+	LastStatusCode pgtype.Int4      `json:"last_status_code"`
+	LastError      pgtype.Text      `json:"last_error"`
+	DeliveredAt    pgtype.Timestamp `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+}
+
+type WebhookSubscription struct {
+	ID         pgtype.UUID      `json:"id"`
+	UserID     pgtype.UUID      `json:"user_id"`
+	Url        string           `json:"url"`
+	Secret     string           `json:"secret"`
+	EventTypes []string         `json:"event_types"`
+	Active     bool             `json:"active"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+}
@@ -0,0 +1,49 @@
+package todolist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+	"github.com/henryhall897/golang-todo-app/internal/todolists/gen"
+	webhooks "github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+)
+
+// listEventData is the data of a todo list domain event.
+type listEventData struct {
+	List TodoList `json:"list"`
+}
+
+// publishListEvent records a domain event in the outbox and queues its webhook deliveries using the
+// given (transaction-bound) queries. Deleted lists must be published before the row is removed.
+func publishListEvent(ctx context.Context, query *gen.Queries, eventType string, list TodoList) error {
+	event, err := events.New(eventType, events.AggregateList, list.ID, listEventData{List: list})
+	if err != nil {
+		return err
+	}
+
+	row, err := events.ToOutboxRow(event)
+	if err != nil {
+		return err
+	}
+
+	if err := query.CreateOutboxEvent(ctx, gen.CreateOutboxEventParams(row)); err != nil {
+		return fmt.Errorf("failed to record outbox event: %w", err)
+	}
+
+	payload, err := json.Marshal(webhooks.NewEvent(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	if _, err := query.EnqueueWebhookDeliveries(ctx, gen.EnqueueWebhookDeliveriesParams{
+		EventID:   row.ID,
+		EventType: event.Type,
+		Payload:   payload,
+		ListID:    row.AggregateID,
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
@@ -33,3 +33,26 @@ SET
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND user_id = $2
 RETURNING *;
+
+-- Lock the todo lists matched by DeleteTodoLists
+-- name: GetTodoListsForDelete :many
+SELECT *
+FROM todolists
+WHERE user_id = $1
+AND ($2::uuid[] IS NULL OR id = ANY($2::uuid[]))
+FOR UPDATE;
+
+-- Queue a webhook delivery for each of the list owner's subscriptions to the event
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT webhook_subscriptions.id, $1, $2, $3
+FROM webhook_subscriptions
+JOIN todolists ON todolists.user_id = webhook_subscriptions.user_id
+WHERE todolists.id = $4
+  AND webhook_subscriptions.active
+  AND $2::text = ANY(webhook_subscriptions.event_types);
+
+-- Record a domain event for the outbox relay
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/events"
 	"github.com/henryhall897/golang-todo-app/internal/todolists/gen"
 
 	"github.com/jackc/pgx/v5"
@@ -22,15 +23,24 @@ func New(pool *pgxpool.Pool) *Store {
 	}
 }
 
+// CreateTodoList inserts a new todo list. Its domain event and webhook deliveries
+// are written within the same transaction.
 func (s *Store) CreateTodoList(ctx context.Context, params CreateTodoListParams) (TodoList, error) {
-	query := gen.New(s.pool)
-
 	// Transform params to database-compatible struct
 	dbTodoList, err := toDBCreateTodoList(params)
 	if err != nil {
 		return TodoList{}, fmt.Errorf("failed to transform todo list: %w", err)
 	}
 
+	// Start a transaction so the list and its events are written together
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return TodoList{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := gen.New(tx)
+
 	// Execute the query
 	todoList, err := query.CreateTodoList(ctx, dbTodoList)
 	if err != nil {
@@ -43,6 +53,15 @@ func (s *Store) CreateTodoList(ctx context.Context, params CreateTodoListParams)
 		return TodoList{}, fmt.Errorf("failed to transform todo list: %w", err)
 	}
 
+	if err := publishListEvent(ctx, query, events.ListCreated, result); err != nil {
+		return TodoList{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return TodoList{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return result, nil
 }
 
@@ -73,15 +92,24 @@ func (s *Store) GetTodoListByID(ctx context.Context, params GetTodoListByIDParam
 	return result, nil
 }
 
+// UpdateTodoList updates a todo list. Its domain event and webhook deliveries
+// are written within the same transaction.
 func (s *Store) UpdateTodoList(ctx context.Context, params UpdateTodoListParams) (TodoList, error) {
-	query := gen.New(s.pool)
-
 	// Transform params to database-compatible struct
 	dbParams, err := toDBTodoListUpdate(params)
 	if err != nil {
 		return TodoList{}, fmt.Errorf("failed to transform todo list for update: %w", err)
 	}
 
+	// Start a transaction so the update and its events are written together
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return TodoList{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := gen.New(tx)
+
 	// Execute the query and get the updated record
 	updatedTodoList, err := query.UpdateTodoList(ctx, dbParams)
 	if err != nil {
@@ -97,6 +125,15 @@ func (s *Store) UpdateTodoList(ctx context.Context, params UpdateTodoListParams)
 		return TodoList{}, fmt.Errorf("failed to transform updated todo list: %w", err)
 	}
 
+	if err := publishListEvent(ctx, query, events.ListUpdated, result); err != nil {
+		return TodoList{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return TodoList{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return result, nil
 }
 
@@ -131,15 +168,39 @@ func (s *Store) ListTodoListsWithPagination(ctx context.Context, params ListTodo
 	return results, nil
 }
 
+// DeleteTodoLists deletes the user's matching todo lists, publishing a domain event for each
+// within the same transaction.
 func (s *Store) DeleteTodoLists(ctx context.Context, params DeleteTodoListsParams) (int64, error) {
-	query := gen.New(s.pool)
-
 	// Transform params to database-compatible struct
 	dbParams, err := toDBDeleteLists(params)
 	if err != nil {
 		return 0, fmt.Errorf("failed to transform delete todo lists params: %w", err)
 	}
 
+	// Start a transaction so the deletion and its events are written together
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := gen.New(tx)
+
+	// Lock the lists being deleted and publish their events while the owner's subscriptions can still be resolved
+	doomed, err := query.GetTodoListsForDelete(ctx, gen.GetTodoListsForDeleteParams(dbParams))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get todo lists for delete: %w", err)
+	}
+	for _, todo := range doomed {
+		list, err := toAppTodoList(todo)
+		if err != nil {
+			return 0, fmt.Errorf("failed to transform todo list: %w", err)
+		}
+		if err := publishListEvent(ctx, query, events.ListDeleted, list); err != nil {
+			return 0, err
+		}
+	}
+
 	// Execute the delete query
 	rowsAffected, err := query.DeleteTodoLists(ctx, dbParams)
 	if err != nil {
@@ -149,5 +210,10 @@ func (s *Store) DeleteTodoLists(ctx context.Context, params DeleteTodoListsParam
 		return 0, fmt.Errorf("failed to delete todo lists: %w", err)
 	}
 
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return rowsAffected, nil
 }
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+	"github.com/henryhall897/golang-todo-app/internal/users/testutils"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// benchmarkCodecs are the codecs compared on pages of users
+var benchmarkCodecs = []redispkg.Codec{redispkg.JSON, redispkg.MsgPack, redispkg.JSONGzip, redispkg.MsgPackGzip}
+
+func TestCodecs_UserPages(t *testing.T) {
+	page := testutils.GenerateMockUsers(3)
+	for _, codec := range benchmarkCodecs {
+		t.Run(codec.Name(), func(t *testing.T) {
+			data, err := codec.Marshal(page)
+			require.NoError(t, err)
+
+			var users []domain.User
+			require.NoError(t, codec.Unmarshal(data, &users))
+			require.Len(t, users, len(page))
+			for i, user := range users {
+				assert.Equal(t, page[i].ID, user.ID)
+				assert.Equal(t, page[i].Email, user.Email)
+				assert.True(t, page[i].CreatedAt.Equal(user.CreatedAt))
+			}
+		})
+	}
+}
+
+// BenchmarkCodecs compares the CPU cost of each codec on pages of users and reports their payload size:
+//
+//	go test ./internal/users/cache -run '^$' -bench Codecs -benchmem
+func BenchmarkCodecs(b *testing.B) {
+	for _, size := range []int{domain.DefaultLimit, 100} {
+		page := testutils.GenerateMockUsers(size)
+
+		for _, codec := range benchmarkCodecs {
+			data, err := codec.Marshal(page)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(fmt.Sprintf("%s/users=%d/marshal", codec.Name(), size), func(b *testing.B) {
+				for b.Loop() {
+					if _, err := codec.Marshal(page); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(data)), "payload-bytes")
+			})
+
+			b.Run(fmt.Sprintf("%s/users=%d/unmarshal", codec.Name(), size), func(b *testing.B) {
+				for b.Loop() {
+					var users []domain.User
+					if err := codec.Unmarshal(data, &users); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(data)), "payload-bytes")
+			})
+		}
+	}
+}
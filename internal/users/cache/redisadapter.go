@@ -2,14 +2,21 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
 	"github.com/henryhall897/golang-todo-app/internal/users/domain"
 	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrCachedMissing is returned by read-through getters when a negative entry records that no user matched
+var ErrCachedMissing = fmt.Errorf("user cached as missing: %w", common.ErrNotFound)
+
 type RedisUser struct {
 	genericCache redispkg.Cache // this is your generic cache interface (Get, Set, Delete)
 }
@@ -19,19 +26,6 @@ func NewRedisUser(generic redispkg.Cache) *RedisUser {
 }
 
 // setting cache functions
-// CacheUserByID caches a user by ID
-func (c *RedisUser) cacheUserByID(ctx context.Context, user domain.User) error {
-	key := domain.CacheKeyByID(user.ID)
-	return c.genericCache.Set(ctx, key, user, domain.RedisTTL)
-}
-
-// CacheUserByEmail stores a pointer from email → user ID and caches the full user by ID
-func (c *RedisUser) cacheEmailPointer(ctx context.Context, user domain.User) error {
-	// Create pointer from email → UUID string
-	pointerKey := domain.CacheKeyByEmail(user.Email)
-	return c.genericCache.SetPointer(ctx, pointerKey, domain.CacheKeyByID(user.ID), domain.RedisTTL)
-}
-
 /*// SetAuthIDPointer sets a pointer to a user by Auth0 ID in the cache
 func (c *RedisUser) cacheAuthIDPointer(ctx context.Context, user domain.User) error {
 	pointerKey := domain.CacheKeyByAuthID(user.AuthID)
@@ -39,24 +33,46 @@ func (c *RedisUser) cacheAuthIDPointer(ctx context.Context, user domain.User) er
 	return c.genericCache.SetPointer(ctx, pointerKey, targetKey, domain.RedisTTL)
 }*/
 
-// CacheUser caches a user by ID, email, and AuthID
+// CacheUser caches a user by ID with a pointer from their email, both written atomically.
+// Email pointers from the user's earlier states are no longer followed.
 func (c *RedisUser) CacheUser(ctx context.Context, user domain.User) error {
-	if err := c.cacheUserByID(ctx, user); err != nil {
-		return fmt.Errorf("failed to cache user by ID: %w", err)
-	}
-	/*if err := c.cacheAuthIDPointer(ctx, user); err != nil {
-		return fmt.Errorf("failed to cache all user pointers: %w", err)
-	}*/
-	if err := c.cacheEmailPointer(ctx, user); err != nil {
-		return fmt.Errorf("failed to cache user by email: %w", err)
+	return c.cacheUser(ctx, user, domain.RedisTTL)
+}
+
+func (c *RedisUser) cacheUser(ctx context.Context, user domain.User, ttl time.Duration) error {
+	pointers := []string{domain.CacheKeyByEmail(user.Email)}
+	if err := c.genericCache.SetWithPointers(ctx, domain.CacheKeyByID(user.ID), user, pointers, ttl); err != nil {
+		return fmt.Errorf("failed to cache user: %w", err)
 	}
 	return nil
 }
 
-// CacheUserByPagination caches a list of users by pagination parameters
-func (c *RedisUser) CacheUserByPagination(ctx context.Context, users []domain.User, params domain.GetUsersParams) error {
-	key := domain.CacheKeyByPagination(params.Limit, params.Offset)
-	return c.genericCache.Set(ctx, key, users, domain.RedisTTL)
+// CacheUserByPagination caches the users of a page by ID, and the page, within the page generation it was
+// read in, as the list of their IDs
+func (c *RedisUser) CacheUserByPagination(ctx context.Context, users []domain.User, params domain.GetUsersParams, generation int64) error {
+	if err := c.genericCache.SetMany(ctx, userEntries(users), domain.RedisTTL); err != nil {
+		return fmt.Errorf("failed to cache page users: %w", err)
+	}
+	key := domain.CacheKeyByPagination(generation, params.Limit, params.Offset)
+	return c.genericCache.Set(ctx, key, userIDs(users), domain.RedisTTL)
+}
+
+// userEntries keys users by their ID cache key, for batch writes
+func userEntries(users []domain.User) map[string]interface{} {
+	entries := make(map[string]interface{}, len(users))
+	for _, user := range users {
+		entries[domain.CacheKeyByID(user.ID)] = user
+	}
+	return entries
+}
+
+// userIDs lists the IDs of users, in order
+func userIDs(users []domain.User) []uuid.UUID {
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
 }
 
 // Get from Cache Functions
@@ -68,9 +84,17 @@ func (c *RedisUser) GetUserByID(ctx context.Context, id uuid.UUID) (domain.User,
 	return user, err
 }
 
-// GetUserByEmail resolves the email → user ID pointer and returns the full cached user
+// GetUserByEmail resolves the email → user ID pointer and returns the full cached user in one round trip.
+// A pointer the user no longer has, after an email change, is a miss.
 func (c *RedisUser) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
-	pointerKey := domain.CacheKeyByEmail(email)
+	var user domain.User
+	err := c.genericCache.GetByPointer(ctx, domain.CacheKeyByEmail(email), &user)
+	return user, err
+}
+
+/*// GetUserByAuthID resolves the Auth0 ID pointer and returns the full cached user
+func (c *RedisUser) GetUserByAuthID(ctx context.Context, authID string) (domain.User, error) {
+	pointerKey := domain.CacheKeyByAuthID(authID)
 
 	idStr, err := c.genericCache.GetPointer(ctx, pointerKey)
 	if err != nil {
@@ -82,38 +106,159 @@ func (c *RedisUser) GetUserByEmail(ctx context.Context, email string) (domain.Us
 
 	userID, err := uuid.Parse(idStr)
 	if err != nil {
-		return domain.User{}, fmt.Errorf("invalid UUID in email pointer: %w", err)
+		return domain.User{}, fmt.Errorf("invalid UUID in auth_id pointer: %w", err)
 	}
 
 	return c.GetUserByID(ctx, userID)
+}*/
+
+// GetUserByPagination retrieves a page of users by pagination parameters from the cache.
+// A page whose users are not all cached is a miss.
+func (c *RedisUser) GetUserByPagination(ctx context.Context, params domain.GetUsersParams, generation int64) ([]domain.User, error) {
+	key := domain.CacheKeyByPagination(generation, params.Limit, params.Offset)
+	var ids []uuid.UUID
+	if err := c.genericCache.Get(ctx, key, &ids); err != nil {
+		return nil, err
+	}
+	return c.resolvePage(ctx, ids)
 }
 
-/*// GetUserByAuthID resolves the Auth0 ID pointer and returns the full cached user
-func (c *RedisUser) GetUserByAuthID(ctx context.Context, authID string) (domain.User, error) {
-	pointerKey := domain.CacheKeyByAuthID(authID)
+// resolvePage reads the cached users of a page in one round trip, returning redis.Nil when any is missing
+func (c *RedisUser) resolvePage(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = domain.CacheKeyByID(id)
+	}
 
-	idStr, err := c.genericCache.GetPointer(ctx, pointerKey)
+	var users []domain.User
+	missing, err := c.genericCache.GetMany(ctx, keys, &users)
 	if err != nil {
-		return domain.User{}, err
+		return nil, err
 	}
-	if idStr == "" {
-		return domain.User{}, redis.Nil // cache miss
+	if len(missing) > 0 {
+		return nil, redis.Nil
 	}
+	return users, nil
+}
 
-	userID, err := uuid.Parse(idStr)
+// PageGeneration returns the current page generation. Readers must look up and store a page
+// in the generation read before querying the database, so a page read before a change is never
+// stored where readers after the change look.
+// An absent generation is seeded from the clock rather than starting at zero,
+// so a lost counter can never bring back pages of an earlier generation.
+func (c *RedisUser) PageGeneration(ctx context.Context) (int64, error) {
+	key := domain.CacheKeyPageGeneration()
+	var generation int64
+	err := c.genericCache.Get(ctx, key, &generation)
+	if err == nil {
+		return generation, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	// Only the first seed is kept when readers race
+	if _, err := c.genericCache.SetCounterIfNotExists(ctx, key, time.Now().UnixNano(), 0); err != nil {
+		return 0, err
+	}
+	err = c.genericCache.Get(ctx, key, &generation)
+	return generation, err
+}
+
+// loadOptions keeps hot users and pages fresh: they are refreshed early or served stale while reloaded
+var loadOptions = redispkg.LoadOptions{TTL: domain.RedisTTL, StaleTTL: domain.RedisStaleTTL, Beta: redispkg.DefaultBeta}
+
+// Read-through Functions
+// LoadUserByID returns a user by ID, loading and caching them on a miss
+func (c *RedisUser) LoadUserByID(ctx context.Context, id uuid.UUID, load func(ctx context.Context) (domain.User, error)) (domain.User, error) {
+	var user domain.User
+	err := c.genericCache.GetOrLoad(ctx, domain.CacheKeyByID(id), &user, loadOptions, func(ctx context.Context) (any, error) {
+		loaded, err := c.loadUnlessMissing(ctx, domain.CacheKeyMissingByID(id), load)
+		if err != nil {
+			return nil, err
+		}
+		// The email pointer is refreshed with the user it points at; a failure only costs a later miss
+		_ = c.cacheUser(ctx, loaded, loadOptions.TTL+loadOptions.StaleTTL)
+		return loaded, nil
+	})
+	return user, err
+}
+
+// LoadUserByEmail resolves the email pointer and returns the user, loading and caching them on a miss.
+// Misses are coalesced per email.
+func (c *RedisUser) LoadUserByEmail(ctx context.Context, email string, load func(ctx context.Context) (domain.User, error)) (domain.User, error) {
+	if user, err := c.GetUserByEmail(ctx, email); err == nil {
+		return user, nil
+	}
+
+	loaded, err := c.genericCache.Coalesce(ctx, domain.CacheKeyByEmail(email), func(ctx context.Context) (any, error) {
+		user, err := c.loadUnlessMissing(ctx, domain.CacheKeyMissingByEmail(email), load)
+		if err != nil {
+			return nil, err
+		}
+		// Caching failures only cost a later miss
+		_ = c.CacheUser(ctx, user)
+		return user, nil
+	})
 	if err != nil {
-		return domain.User{}, fmt.Errorf("invalid UUID in auth_id pointer: %w", err)
+		return domain.User{}, err
 	}
+	return loaded.(domain.User), nil
+}
 
-	return c.GetUserByID(ctx, userID)
-}*/
+// loadUnlessMissing calls load unless the negative entry under missingKey records that no user matched,
+// and records it when load finds no user
+func (c *RedisUser) loadUnlessMissing(ctx context.Context, missingKey string, load func(ctx context.Context) (domain.User, error)) (domain.User, error) {
+	var missing bool
+	if err := c.genericCache.Get(ctx, missingKey, &missing); err == nil && missing {
+		return domain.User{}, ErrCachedMissing
+	}
 
-// GetUserByPagination retrieves a list of users by pagination parameters from the cache
-func (c *RedisUser) GetUserByPagination(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error) {
-	key := domain.CacheKeyByPagination(params.Limit, params.Offset)
-	var users []domain.User
-	err := c.genericCache.Get(ctx, key, &users)
-	return users, err
+	user, err := load(ctx)
+	if errors.Is(err, common.ErrNotFound) {
+		// A failure only costs a later lookup
+		_ = c.genericCache.Set(ctx, missingKey, true, domain.RedisNegativeTTL)
+	}
+	return user, err
+}
+
+// LoadUserByPagination returns a page of users within a page generation, loading and caching it on a miss.
+// Pages hold the IDs of their users, which are cached by ID alongside, so a user is stored once however
+// many pages list them. When a listed user is no longer cached, the page is read again.
+func (c *RedisUser) LoadUserByPagination(ctx context.Context, params domain.GetUsersParams, generation int64, load func(ctx context.Context) ([]domain.User, error)) ([]domain.User, error) {
+	// Users loaded by this call, rather than by a coalesced caller or in the background
+	var loaded atomic.Pointer[[]domain.User]
+	loadPage := func(ctx context.Context) ([]domain.User, error) {
+		users, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The users outlive no page listing them; a failure only costs a later miss
+		_ = c.genericCache.SetMany(ctx, userEntries(users), loadOptions.TTL+loadOptions.StaleTTL)
+		return users, nil
+	}
+
+	key := domain.CacheKeyByPagination(generation, params.Limit, params.Offset)
+	var ids []uuid.UUID
+	err := c.genericCache.GetOrLoad(ctx, key, &ids, loadOptions, func(ctx context.Context) (any, error) {
+		users, err := loadPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		loaded.CompareAndSwap(nil, &users)
+		return userIDs(users), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if users := loaded.Load(); users != nil {
+		return *users, nil
+	}
+
+	if users, err := c.resolvePage(ctx, ids); err == nil {
+		return users, nil
+	}
+	return loadPage(ctx)
 }
 
 // Delete from Cache Functions
@@ -128,3 +273,30 @@ func (c *RedisUser) DeleteUserByEmail(ctx context.Context, email string) error {
 	key := domain.CacheKeyByEmail(email)
 	return c.genericCache.Delete(ctx, key)
 }
+
+// DeleteMissingUser deletes the negative entries for a user's ID and email, so a user just created
+// or given a new email is found by lookups that previously found no one
+func (c *RedisUser) DeleteMissingUser(ctx context.Context, user domain.User) error {
+	return errors.Join(
+		c.genericCache.Delete(ctx, domain.CacheKeyMissingByID(user.ID)),
+		c.genericCache.Delete(ctx, domain.CacheKeyMissingByEmail(user.Email)),
+	)
+}
+
+// InvalidateUserPages atomically moves readers to a new page generation; any cached page may include a changed user.
+// Pages of older generations are no longer read and expire with their TTL.
+func (c *RedisUser) InvalidateUserPages(ctx context.Context) error {
+	// Seed an absent generation first, so the increment cannot restart the count
+	if _, err := c.PageGeneration(ctx); err != nil {
+		return err
+	}
+	_, err := c.genericCache.Incr(ctx, domain.CacheKeyPageGeneration())
+	return err
+}
+
+// DeleteUserPages deletes the cached pages of users of every generation,
+// for when pages must not outlive their TTL, such as after an erasure
+func (c *RedisUser) DeleteUserPages(ctx context.Context) error {
+	_, err := c.genericCache.DeleteByPrefix(ctx, domain.CacheKeyPagePrefix())
+	return err
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+	redispkg "github.com/henryhall897/golang-todo-app/pkg/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPageGeneration_Codecs(t *testing.T) {
+	for _, codec := range benchmarkCodecs {
+		t.Run(codec.Name(), func(t *testing.T) {
+			server := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			defer client.Close()
+			users := NewRedisUser(redispkg.NewCacheWithCodec(client, "user", codec, zap.NewNop().Sugar()))
+			ctx := context.Background()
+
+			seeded, err := users.PageGeneration(ctx)
+			require.NoError(t, err)
+			assert.NotZero(t, seeded)
+
+			// The seed is a plain integer that invalidations increment
+			require.NoError(t, users.InvalidateUserPages(ctx))
+			require.NoError(t, users.InvalidateUserPages(ctx))
+			generation, err := users.PageGeneration(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, seeded+2, generation)
+
+			raw, err := server.Get("user:" + domain.CacheKeyPageGeneration())
+			require.NoError(t, err)
+			assert.Regexp(t, `^[0-9]+$`, raw)
+		})
+	}
+}
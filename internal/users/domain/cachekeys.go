@@ -16,9 +16,30 @@ func CacheKeyByEmail(email string) string {
 	return fmt.Sprintf("%s:%s", RedisEmailPrefix, email)
 }
 
-// CacheKeyByPagination generates a cache key for paginated user results.
-func CacheKeyByPagination(limit, offset int) string {
-	return fmt.Sprintf("page:limit=%d:offset=%d", limit, offset)
+// CacheKeyMissingByID generates the negative cache key recording that no user has an ID.
+func CacheKeyMissingByID(id uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", RedisMissingPrefix, CacheKeyByID(id))
+}
+
+// CacheKeyMissingByEmail generates the negative cache key recording that no user has an email.
+func CacheKeyMissingByEmail(email string) string {
+	return fmt.Sprintf("%s:%s", RedisMissingPrefix, CacheKeyByEmail(email))
+}
+
+// CacheKeyByPagination generates a cache key for paginated user results within a page generation.
+// Bumping the generation moves readers to new keys, invalidating every older page at once.
+func CacheKeyByPagination(generation int64, limit, offset int) string {
+	return fmt.Sprintf("%s:gen=%d:limit=%d:offset=%d", RedisPagePrefix, generation, limit, offset)
+}
+
+// CacheKeyPagePrefix is the common prefix of every paginated user results key, in every generation.
+func CacheKeyPagePrefix() string {
+	return RedisPagePrefix + ":"
+}
+
+// CacheKeyPageGeneration is the cache key of the current page generation.
+func CacheKeyPageGeneration() string {
+	return RedisPageGenerationKey
 }
 
 /*// CacheKeyByAuthID generates a cache key for a user by their AuthID.
@@ -7,5 +7,15 @@ const (
 	DefaultOffset    = 0
 	RedisPrefix      = "user"
 	RedisEmailPrefix = "email"
-	RedisTTL         = 10 * time.Minute
+	RedisPagePrefix  = "page"
+	// RedisMissingPrefix marks negative entries, recording that a lookup found no user
+	RedisMissingPrefix = "missing"
+	// RedisPageGenerationKey holds the generation baked into page keys; it never expires
+	RedisPageGenerationKey = "pagegen"
+	RedisTTL               = 10 * time.Minute
+	// RedisStaleTTL is how long an expired user or page is still served while it is reloaded
+	RedisStaleTTL = time.Minute
+	// RedisNegativeTTL is how long a lookup that found no user is remembered
+	RedisNegativeTTL = 30 * time.Second
+	DefaultTimeZone  = "UTC"
 )
@@ -14,8 +14,12 @@ type Repository interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUsers(ctx context.Context, params GetUsersParams) ([]User, error)
-	UpdateUser(ctx context.Context, updateUserparams UpdateUserParams) (User, error)
-	DeleteUser(ctx context.Context, id uuid.UUID) error
+	ListRecentlyActiveUsers(ctx context.Context, limit int) ([]User, error)
+	UpdateUser(ctx context.Context, updateUserparams UpdateUserParams) (previous User, updated User, err error)
+	DeleteUser(ctx context.Context, id uuid.UUID) (User, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (Preferences, error)
+	UpsertPreferences(ctx context.Context, prefs Preferences) (Preferences, error)
+	ListDigestSubscribers(ctx context.Context) ([]DigestSubscriber, error)
 }
 
 //go:generate moq -out=../../../gen/mocks/usersmock/user_service_mock.go -pkg=usersmock . Service
@@ -26,20 +30,35 @@ type Service interface {
 	GetUsers(ctx context.Context, params GetUsersParams) ([]User, error)
 	UpdateUser(ctx context.Context, params UpdateUserParams) (User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	GetPreferences(ctx context.Context, userID uuid.UUID) (Preferences, error)
+	UpdatePreferences(ctx context.Context, params UpdatePreferencesParams) (Preferences, error)
+	WarmCache(ctx context.Context, limit int) (int, error)
+	GetCachedUser(ctx context.Context, id uuid.UUID) (CachedUser, error)
+	EvictUser(ctx context.Context, id uuid.UUID) error
 }
 
 //go:generate moq -out=../../../gen/mocks/usersmock/user_cache_mock.go -pkg=usersmock . Cache
 type Cache interface {
 	// Setters
 	CacheUser(ctx context.Context, user User) error
-	CacheUserByPagination(ctx context.Context, users []User, params GetUsersParams) error
+	CacheUserByPagination(ctx context.Context, users []User, params GetUsersParams, generation int64) error
 
 	// Getters
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
-	GetUserByPagination(ctx context.Context, params GetUsersParams) ([]User, error)
+	GetUserByPagination(ctx context.Context, params GetUsersParams, generation int64) ([]User, error)
+	PageGeneration(ctx context.Context) (int64, error)
+
+	// Read-through getters: misses call load once per key across concurrent callers, and cache the result.
+	// Lookups finding no user (common.ErrNotFound) are remembered briefly and fail without calling load.
+	LoadUserByID(ctx context.Context, id uuid.UUID, load func(ctx context.Context) (User, error)) (User, error)
+	LoadUserByEmail(ctx context.Context, email string, load func(ctx context.Context) (User, error)) (User, error)
+	LoadUserByPagination(ctx context.Context, params GetUsersParams, generation int64, load func(ctx context.Context) ([]User, error)) ([]User, error)
 
 	// Deleters
 	DeleteUserByID(ctx context.Context, id uuid.UUID) error
 	DeleteUserByEmail(ctx context.Context, email string) error
+	DeleteMissingUser(ctx context.Context, user User) error
+	InvalidateUserPages(ctx context.Context) error
+	DeleteUserPages(ctx context.Context) error
 }
@@ -31,3 +31,35 @@ type UpdateUserParams struct {
 	Name  string    `json:"name"`
 	Email string    `json:"email"`
 }
+
+// Preferences holds a user's notification settings.
+type Preferences struct {
+	UserID        uuid.UUID `json:"user_id"`
+	DigestEnabled bool      `json:"digest_enabled"`
+	TimeZone      string    `json:"timezone"` // IANA time zone name, e.g. "Europe/Berlin"
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// UpdatePreferencesParams represents a partial update of a user's preferences.
+type UpdatePreferencesParams struct {
+	UserID        uuid.UUID `json:"user_id"`
+	DigestEnabled *bool     `json:"digest_enabled"`
+	TimeZone      *string   `json:"timezone"`
+}
+
+// DigestSubscriber is a user who opted in to the daily digest.
+type DigestSubscriber struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	TimeZone string    `json:"timezone"`
+}
+
+// CachedUser holds a user's cache entries: the user cached under their ID and the user their email
+// pointer resolves to. Either is nil when not cached.
+type CachedUser struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Email   string    `json:"email,omitempty"` // The email looked up, when known
+	ByID    *User     `json:"by_id"`
+	ByEmail *User     `json:"by_email"`
+}
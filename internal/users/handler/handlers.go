@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
 	"github.com/henryhall897/golang-todo-app/internal/users/domain"
 	"github.com/henryhall897/golang-todo-app/internal/users/services"
 	"go.uber.org/zap"
@@ -268,3 +269,110 @@ func (h *Handler) DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
 	// Return success response (204 No Content)
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetPreferencesHandler handles retrieving a user's notification preferences
+func (h *Handler) GetPreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	// Only the user themselves may access their preferences
+	userID, ok := h.authorizeUser(w, r, "GetPreferencesHandler")
+	if !ok {
+		return
+	}
+
+	// Call the service layer
+	prefs, err := h.service.GetPreferences(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return the preferences as JSON
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		h.logger.Errorw("GetPreferencesHandler failed: failed to encode response", "user_id", userID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// UpdatePreferencesHandler handles updating a user's notification preferences
+func (h *Handler) UpdatePreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	// Only the user themselves may access their preferences
+	userID, ok := h.authorizeUser(w, r, "UpdatePreferencesHandler")
+	if !ok {
+		return
+	}
+
+	// Parse the request body
+	var payload struct {
+		DigestEnabled *bool   `json:"digest_enabled"`
+		TimeZone      *string `json:"timezone"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.logger.Warnw("UpdatePreferencesHandler failed: invalid request body", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// Ensure at least one field is provided
+	if payload.DigestEnabled == nil && payload.TimeZone == nil {
+		h.logger.Warnw("UpdatePreferencesHandler failed: no fields provided for update")
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// Call the service layer
+	prefs, err := h.service.UpdatePreferences(r.Context(), domain.UpdatePreferencesParams{
+		UserID:        userID,
+		DigestEnabled: payload.DigestEnabled,
+		TimeZone:      payload.TimeZone,
+	})
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		} else if errors.Is(err, services.ErrInvalidTimeZone) {
+			http.Error(w, "Invalid timezone parameter", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return the updated preferences
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		h.logger.Errorw("UpdatePreferencesHandler failed: failed to encode response", "user_id", userID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// authorizeUser returns the user ID from the path once the caller is identified as that user.
+// Otherwise it writes the error response and returns false.
+func (h *Handler) authorizeUser(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	// The caller must be identified to act on their own data
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw(name + " failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+
+	// Extract validated user ID from context
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		h.logger.Errorw(name + " failed: missing or invalid user ID in context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return uuid.Nil, false
+	}
+
+	if userID != callerID {
+		h.logger.Warnw(name+" failed: caller is not the requested user", "caller_id", callerID, "user_id", userID)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/henryhall897/golang-todo-app/gen/mocks/usersmock"
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
 	"github.com/henryhall897/golang-todo-app/internal/users/domain"
 	"github.com/henryhall897/golang-todo-app/internal/users/services"
 	"github.com/henryhall897/golang-todo-app/internal/users/testutils"
@@ -519,3 +520,122 @@ func TestDeleteUserHandler(t *testing.T) {
 		assert.Equal(t, http.StatusText(http.StatusInternalServerError)+"\n", rr.Body.String())
 	})
 }
+
+// TestPreferencesHandlers tests reading and updating a user's notification preferences
+func TestPreferencesHandlers(t *testing.T) {
+	suite := SetupSuite()
+	suite.router.Handle("GET /users/", VerifyUserID(suite.handler.GetPreferencesHandler))
+	suite.router.Handle("PUT /users/", VerifyUserID(suite.handler.UpdatePreferencesHandler))
+
+	sampleUser := testutils.GenerateMockUsers(1)[0]
+	path := "/users/" + sampleUser.ID.String() + "/preferences"
+
+	// asUser identifies the request's caller as userID, as the identity middleware would
+	asUser := func(req *http.Request, userID uuid.UUID) *http.Request {
+		return req.WithContext(middleware.WithUserID(req.Context(), userID))
+	}
+
+	t.Run("success - get preferences", func(t *testing.T) {
+		suite.mockService.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return domain.Preferences{UserID: userID, DigestEnabled: true, TimeZone: "Europe/Berlin"}, nil
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, asUser(httptest.NewRequest(http.MethodGet, path, nil), sampleUser.ID))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var prefs domain.Preferences
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&prefs))
+		assert.Equal(t, sampleUser.ID, prefs.UserID)
+		assert.True(t, prefs.DigestEnabled)
+		assert.Equal(t, "Europe/Berlin", prefs.TimeZone)
+	})
+
+	t.Run("failure - get preferences of unknown user", func(t *testing.T) {
+		suite.mockService.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return domain.Preferences{}, common.ErrNotFound
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, asUser(httptest.NewRequest(http.MethodGet, path, nil), sampleUser.ID))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("success - update preferences", func(t *testing.T) {
+		suite.mockService.UpdatePreferencesFunc = func(ctx context.Context, params domain.UpdatePreferencesParams) (domain.Preferences, error) {
+			assert.Equal(t, sampleUser.ID, params.UserID)
+			require.NotNil(t, params.DigestEnabled)
+			assert.True(t, *params.DigestEnabled)
+			assert.Nil(t, params.TimeZone)
+			return domain.Preferences{UserID: params.UserID, DigestEnabled: true, TimeZone: "UTC"}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{"digest_enabled": true}`))
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, asUser(req, sampleUser.ID))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var prefs domain.Preferences
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&prefs))
+		assert.True(t, prefs.DigestEnabled)
+	})
+
+	t.Run("failure - invalid time zone", func(t *testing.T) {
+		suite.mockService.UpdatePreferencesFunc = func(ctx context.Context, params domain.UpdatePreferencesParams) (domain.Preferences, error) {
+			return domain.Preferences{}, services.ErrInvalidTimeZone
+		}
+
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{"timezone": "Mars/Olympus_Mons"}`))
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, asUser(req, sampleUser.ID))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "Invalid timezone parameter\n", rr.Body.String())
+	})
+
+	t.Run("failure - no fields provided", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{}`))
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, asUser(req, sampleUser.ID))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - missing caller identity", func(t *testing.T) {
+		suite.mockService.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			t.Fatal("service must not be called without a caller")
+			return domain.Preferences{}, nil
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("failure - get another user's preferences", func(t *testing.T) {
+		suite.mockService.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			t.Fatal("service must not be called for another user's preferences")
+			return domain.Preferences{}, nil
+		}
+
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, asUser(httptest.NewRequest(http.MethodGet, path, nil), uuid.New()))
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("failure - update another user's preferences", func(t *testing.T) {
+		suite.mockService.UpdatePreferencesFunc = func(ctx context.Context, params domain.UpdatePreferencesParams) (domain.Preferences, error) {
+			t.Fatal("service must not be called for another user's preferences")
+			return domain.Preferences{}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{"digest_enabled": true}`))
+		rr := httptest.NewRecorder()
+		suite.router.ServeHTTP(rr, asUser(req, uuid.New()))
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
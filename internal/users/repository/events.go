@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/userstore"
+	"github.com/henryhall897/golang-todo-app/internal/events"
+
+	"github.com/google/uuid"
+)
+
+// userEventData is the data of a user domain event. Deleted users carry only their ID.
+type userEventData struct {
+	User interface{} `json:"user"`
+}
+
+// recordUserEvent writes a user domain event to the outbox using the given (transaction-bound) queries.
+func recordUserEvent(ctx context.Context, query *userstore.Queries, eventType string, userID uuid.UUID, user interface{}) error {
+	event, err := events.New(eventType, events.AggregateUser, userID, userEventData{User: user})
+	if err != nil {
+		return err
+	}
+
+	row, err := events.ToOutboxRow(event)
+	if err != nil {
+		return err
+	}
+
+	if err := query.CreateOutboxEvent(ctx, userstore.CreateOutboxEventParams(row)); err != nil {
+		return fmt.Errorf("failed to record outbox event: %w", err)
+	}
+	return nil
+}
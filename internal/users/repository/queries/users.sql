@@ -10,12 +10,32 @@ SELECT *
 FROM users
 WHERE id = $1;
 
+-- Retrieve and lock a user by ID, to record their previous state in an update's transaction
+-- name: GetUserByIDForUpdate :one
+SELECT *
+FROM users
+WHERE id = $1
+FOR UPDATE;
+
 -- Retrieve a user by email
 -- name: GetUserByEmail :one
 SELECT *
 FROM users
 WHERE email = $1;
 
+-- List the users active most recently, by their latest task or profile change
+-- name: ListRecentlyActiveUsers :many
+SELECT users.id, users.name, users.email, users.created_at, users.updated_at
+FROM users
+LEFT JOIN (
+    SELECT todolists.user_id, MAX(tasks.updated_at) AS last_active
+    FROM tasks
+    JOIN todolists ON tasks.list_id = todolists.id
+    GROUP BY todolists.user_id
+) AS activity ON activity.user_id = users.id
+ORDER BY GREATEST(activity.last_active, users.updated_at) DESC
+LIMIT $1;
+
 -- Update user details
 -- name: UpdateUser :one
 UPDATE users
@@ -27,13 +47,44 @@ WHERE id = $1
 RETURNING *;
 
 -- Delete a user by ID
--- name: DeleteUser :execrows
+-- name: DeleteUser :one
 DELETE FROM users
-WHERE id = $1;
+WHERE id = $1
+RETURNING *;
 
 -- Get all users with pagination
 -- name: GetUsers :many
 SELECT *
 FROM users
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
\ No newline at end of file
+LIMIT $1 OFFSET $2;
+
+-- Retrieve a user's notification preferences
+-- name: GetUserPreferences :one
+SELECT *
+FROM user_preferences
+WHERE user_id = $1;
+
+-- Create or replace a user's notification preferences
+-- name: UpsertUserPreferences :one
+INSERT INTO user_preferences (user_id, digest_enabled, timezone)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET 
+    digest_enabled = EXCLUDED.digest_enabled,
+    timezone = EXCLUDED.timezone,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- List users who opted in to the daily digest
+-- name: ListDigestSubscribers :many
+SELECT users.id, users.name, users.email, user_preferences.timezone
+FROM user_preferences
+JOIN users ON user_preferences.user_id = users.id
+WHERE user_preferences.digest_enabled
+ORDER BY users.id;
+
+-- Record a domain event for the outbox relay
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
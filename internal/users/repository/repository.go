@@ -8,6 +8,7 @@ import (
 	"github.com/henryhall897/golang-todo-app/database"
 	"github.com/henryhall897/golang-todo-app/gen/queries/userstore"
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/events"
 	"github.com/henryhall897/golang-todo-app/internal/users/domain"
 
 	"github.com/google/uuid"
@@ -28,11 +29,22 @@ func New(pool *pgxpool.Pool) *repository {
 	}
 }
 
+// CreateUser inserts a user and records its user.created event in the same transaction.
 func (r *repository) CreateUser(ctx context.Context, newUser domain.CreateUserParams) (domain.User, error) {
 	// Convert the CreateUserParams to gen.CreateUserParams
 	pgNewUser := createUserParamsToPG(newUser)
+
+	// Start a transaction so the user and its event are written together
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := r.query.WithTx(tx)
+
 	// Execute the query to create a new user
-	user, err := r.query.CreateUser(ctx, pgNewUser)
+	user, err := query.CreateUser(ctx, pgNewUser)
 	if err != nil {
 		// Check if the error is a unique constraint violation
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" { // 23505 is PostgreSQL's unique violation error code
@@ -48,6 +60,15 @@ func (r *repository) CreateUser(ctx context.Context, newUser domain.CreateUserPa
 		return domain.User{}, err
 	}
 
+	if err := recordUserEvent(ctx, query, events.UserCreated, result.ID, result); err != nil {
+		return domain.User{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return domain.User{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return result, nil
 }
 
@@ -135,41 +156,172 @@ func (r *repository) GetUsers(ctx context.Context, getUserParams domain.GetUsers
 	return results, nil
 }
 
-func (r *repository) UpdateUser(ctx context.Context, updateParams domain.UpdateUserParams) (domain.User, error) {
+// ListRecentlyActiveUsers returns up to limit users, most recently active first.
+// A user's activity is their latest task change, or profile change when later.
+func (r *repository) ListRecentlyActiveUsers(ctx context.Context, limit int) ([]domain.User, error) {
+	// Execute the query to list recently active users
+	users, err := r.query.ListRecentlyActiveUsers(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently active users: %w", err)
+	}
+
+	// Convert the raw database results into the domain.User type
+	results := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		result, err := pgToUsers(u)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert user: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// UpdateUser updates a user and records its user.updated event in the same transaction.
+// The user's previous state is read, and locked, in that transaction and returned with the update.
+func (r *repository) UpdateUser(ctx context.Context, updateParams domain.UpdateUserParams) (domain.User, domain.User, error) {
 	// Transform input to the required database structure Handler checks for valid UUID. can ignore error here
 	arg, _ := updateUserParamsToPG(updateParams)
 
+	// Start a transaction so the previous state, the update and its event are read and written together
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.User{}, domain.User{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := r.query.WithTx(tx)
+
+	// Read the previous state, locking the row until the update commits
+	dbPreviousUser, err := query.GetUserByIDForUpdate(ctx, arg.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.User{}, domain.User{}, common.ErrNotFound
+	} else if err != nil {
+		return domain.User{}, domain.User{}, fmt.Errorf("failed to read user: %w", err)
+	}
+
 	// Execute the update query
-	dbUpdatedUser, err := r.query.UpdateUser(ctx, arg)
+	dbUpdatedUser, err := query.UpdateUser(ctx, arg)
 	if errors.Is(err, pgx.ErrNoRows) {
-		return domain.User{}, common.ErrNotFound
+		return domain.User{}, domain.User{}, common.ErrNotFound
 	} else if err != nil {
-		return domain.User{}, fmt.Errorf("failed to update user: %w", err)
+		return domain.User{}, domain.User{}, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	// Convert the updated user to the application-level model
+	// Convert both states to the application-level model
+	previousUser, err := pgToUsers(dbPreviousUser)
+	if err != nil {
+		return domain.User{}, domain.User{}, fmt.Errorf("failed to convert previous user: %w", err)
+	}
 	updatedUser, err := pgToUsers(dbUpdatedUser)
 	if err != nil {
-		return domain.User{}, fmt.Errorf("failed to convert updated user: %w", err)
+		return domain.User{}, domain.User{}, fmt.Errorf("failed to convert updated user: %w", err)
+	}
+
+	if err := recordUserEvent(ctx, query, events.UserUpdated, updatedUser.ID, updatedUser); err != nil {
+		return domain.User{}, domain.User{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return domain.User{}, domain.User{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return updatedUser, nil
+	return previousUser, updatedUser, nil
 }
 
-func (r *repository) DeleteUser(ctx context.Context, id uuid.UUID) error {
+// DeleteUser deletes a user and records its user.deleted event in the same transaction,
+// returning the deleted user.
+func (r *repository) DeleteUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
 
 	// Convert uuid.UUID to pgtype.UUID - Handler checks for valid UUID. can ignore error here
 	pgId, _ := common.ToPgUUID(id)
 
-	// Execute the delete query
-	rowsAffected, err := r.query.DeleteUser(ctx, pgId)
+	// Start a transaction so the deletion and its event are written together
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // Ensures rollback in case of failure
+
+	query := r.query.WithTx(tx)
+
+	// Execute the delete query and get the removed row
+	dbDeletedUser, err := query.DeleteUser(ctx, pgId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.User{}, common.ErrNotFound
+	} else if err != nil {
+		return domain.User{}, fmt.Errorf("failed to execute delete query: %w", err)
+	}
+
+	deletedUser, err := pgToUsers(dbDeletedUser)
 	if err != nil {
-		return fmt.Errorf("failed to execute delete query: %w", err)
+		return domain.User{}, fmt.Errorf("failed to convert deleted user: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return common.ErrNotFound
+	if err := recordUserEvent(ctx, query, events.UserDeleted, id, map[string]uuid.UUID{"id": id}); err != nil {
+		return domain.User{}, err
+	}
+
+	// Commit the transaction if everything succeeds
+	if err := tx.Commit(ctx); err != nil {
+		return domain.User{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deletedUser, nil
+}
+
+func (r *repository) GetPreferences(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+	// Convert uuid.UUID to pgtype.UUID - Handler checks for valid UUID. can ignore error here
+	pgId, _ := common.ToPgUUID(userID)
+
+	// Execute the query to get the user's preferences
+	prefs, err := r.query.GetUserPreferences(ctx, pgId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Preferences{}, fmt.Errorf("preferences for user %s: %w", userID, common.ErrNotFound)
+	} else if err != nil {
+		return domain.Preferences{}, fmt.Errorf("preferences for user %s: %w", userID, common.ErrInternalServerError)
+	}
+
+	return pgToPreferences(prefs)
+}
+
+func (r *repository) UpsertPreferences(ctx context.Context, prefs domain.Preferences) (domain.Preferences, error) {
+	arg, err := upsertPreferencesParamsToPG(prefs)
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+
+	// Execute the upsert query
+	dbPrefs, err := r.query.UpsertUserPreferences(ctx, arg)
+	if err != nil {
+		// The user must exist for its preferences to be stored
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // 23503 is PostgreSQL's foreign key violation error code
+			return domain.Preferences{}, fmt.Errorf("user %s: %w", prefs.UserID, common.ErrNotFound)
+		}
+		return domain.Preferences{}, fmt.Errorf("failed to upsert preferences: %w", err)
+	}
+
+	return pgToPreferences(dbPrefs)
+}
+
+func (r *repository) ListDigestSubscribers(ctx context.Context) ([]domain.DigestSubscriber, error) {
+	// Execute the query to list opted-in users
+	rows, err := r.query.ListDigestSubscribers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest subscribers: %w", err)
+	}
+
+	// Convert the raw database results into the domain.DigestSubscriber type
+	subscribers := make([]domain.DigestSubscriber, 0, len(rows))
+	for _, row := range rows {
+		subscriber, err := pgToDigestSubscriber(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert digest subscriber: %w", err)
+		}
+		subscribers = append(subscribers, subscriber)
 	}
 
-	return nil
+	return subscribers, nil
 }
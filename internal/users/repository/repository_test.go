@@ -351,10 +351,12 @@ func (u *UserTestSuite) TestUpdateUser() {
 		}
 
 		// Act - Update the user
-		updatedUser, err := u.repository.UpdateUser(ctx, updateParams)
+		previousUser, updatedUser, err := u.repository.UpdateUser(ctx, updateParams)
 
-		// Assert - Verify update
+		// Assert - Verify update, with the previous state read in the same transaction
 		u.Require().NoError(err, "Failed to update user")
+		u.Equal(createdUser.Name, previousUser.Name, "Previous state should hold the old name")
+		u.Equal(createdUser.Email, previousUser.Email, "Previous state should hold the old email")
 		u.Require().NotNil(updatedUser)
 		u.Equal(createdUser.ID, updatedUser.ID, "domain.User ID should remain unchanged")
 		u.Equal(updatedName, updatedUser.Name, "domain.User name should be updated")
@@ -380,10 +382,11 @@ func (u *UserTestSuite) TestUpdateUser() {
 		}
 
 		// Act - Partial update
-		updatedUser, err := u.repository.UpdateUser(ctx, partialUpdateParams)
+		previousUser, updatedUser, err := u.repository.UpdateUser(ctx, partialUpdateParams)
 
 		// Assert - Ensure only the name is updated
 		u.Require().NoError(err)
+		u.Equal(updatedName, previousUser.Name, "Previous state should hold the first update")
 		u.Require().NotNil(updatedUser)
 		u.Equal(partialUpdatedName, updatedUser.Name, "domain.User name should be updated")
 		u.Equal(updatedEmail, updatedUser.Email, "domain.User email should remain unchanged")
@@ -410,10 +413,12 @@ func (u *UserTestSuite) TestDeleteUser() {
 		createdUser := users[0]
 
 		// Act - Delete the user
-		err = u.repository.DeleteUser(ctx, createdUser.ID)
+		deletedUser, err := u.repository.DeleteUser(ctx, createdUser.ID)
 
-		// Assert - Verify deletion
+		// Assert - Verify deletion, returning the removed row
 		u.Require().NoError(err, "Failed to delete user")
+		u.Equal(createdUser.ID, deletedUser.ID)
+		u.Equal(createdUser.Email, deletedUser.Email)
 
 		// Act - Try retrieving the deleted user
 		_, err = u.repository.GetUserByID(ctx, createdUser.ID)
@@ -428,10 +433,100 @@ func (u *UserTestSuite) TestDeleteUser() {
 		nonExistentID := uuid.New()
 
 		// Act - Try deleting a user that doesn't exist
-		err := u.repository.DeleteUser(ctx, nonExistentID)
+		_, err := u.repository.DeleteUser(ctx, nonExistentID)
 
 		// Assert - Should return ErrNotFound
 		u.Require().Error(err)
 		u.ErrorIs(err, common.ErrNotFound, "Expected ErrNotFound for non-existent user")
 	})
 }
+
+func (u *UserTestSuite) TestPreferences() {
+	t := u.T()
+	ctx := u.ctx
+
+	t.Run("Missing Preferences", func(t *testing.T) {
+		users, err := u.CreateSampleUsers(ctx, 1)
+		u.Require().NoError(err)
+
+		_, err = u.repository.GetPreferences(ctx, users[0].ID)
+		u.Require().Error(err)
+		u.ErrorIs(err, common.ErrNotFound)
+	})
+
+	t.Run("Upsert Creates And Replaces Preferences", func(t *testing.T) {
+		users, err := u.CreateSampleUsers(ctx, 1)
+		u.Require().NoError(err)
+		userID := users[0].ID
+
+		created, err := u.repository.UpsertPreferences(ctx, domain.Preferences{UserID: userID, DigestEnabled: true, TimeZone: "Europe/Berlin"})
+		u.Require().NoError(err)
+		u.Equal(userID, created.UserID)
+		u.True(created.DigestEnabled)
+		u.Equal("Europe/Berlin", created.TimeZone)
+
+		updated, err := u.repository.UpsertPreferences(ctx, domain.Preferences{UserID: userID, DigestEnabled: false, TimeZone: "UTC"})
+		u.Require().NoError(err)
+		u.False(updated.DigestEnabled)
+
+		retrieved, err := u.repository.GetPreferences(ctx, userID)
+		u.Require().NoError(err)
+		u.Equal(updated, retrieved)
+	})
+
+	t.Run("Upsert For Non-Existent User", func(t *testing.T) {
+		_, err := u.repository.UpsertPreferences(ctx, domain.Preferences{UserID: uuid.New(), TimeZone: "UTC"})
+		u.Require().Error(err)
+		u.ErrorIs(err, common.ErrNotFound)
+	})
+}
+
+func (u *UserTestSuite) TestListDigestSubscribers() {
+	ctx := u.ctx
+
+	// Arrange - One subscriber, one user who opted out, one without preferences
+	users, err := u.CreateSampleUsers(ctx, 3)
+	u.Require().NoError(err)
+	_, err = u.repository.UpsertPreferences(ctx, domain.Preferences{UserID: users[0].ID, DigestEnabled: true, TimeZone: "Asia/Tokyo"})
+	u.Require().NoError(err)
+	_, err = u.repository.UpsertPreferences(ctx, domain.Preferences{UserID: users[1].ID, DigestEnabled: false, TimeZone: "UTC"})
+	u.Require().NoError(err)
+
+	// Act
+	subscribers, err := u.repository.ListDigestSubscribers(ctx)
+
+	// Assert
+	u.Require().NoError(err)
+	u.Equal([]domain.DigestSubscriber{{
+		ID:       users[0].ID,
+		Name:     users[0].Name,
+		Email:    users[0].Email,
+		TimeZone: "Asia/Tokyo",
+	}}, subscribers)
+}
+
+func (u *UserTestSuite) TestListRecentlyActiveUsers() {
+	ctx := u.ctx
+	db := u.pgt.DB()
+
+	// Arrange - users[0] changed a task last, users[2] their profile, users[1] nothing since long ago
+	users, err := u.CreateSampleUsers(ctx, 3)
+	u.Require().NoError(err)
+	_, err = db.Exec(ctx, "UPDATE users SET updated_at = NOW() - INTERVAL '3 hours'")
+	u.Require().NoError(err)
+	_, err = db.Exec(ctx, "UPDATE users SET updated_at = NOW() - INTERVAL '1 hour' WHERE id = $1", users[2].ID)
+	u.Require().NoError(err)
+	_, err = db.Exec(ctx, `WITH list AS (
+		INSERT INTO todolists (user_id, title) VALUES ($1, 'Recent') RETURNING id
+	) INSERT INTO tasks (list_id, title, updated_at) SELECT id, 'Task', NOW() FROM list`, users[0].ID)
+	u.Require().NoError(err)
+
+	// Act
+	active, err := u.repository.ListRecentlyActiveUsers(ctx, 2)
+
+	// Assert
+	u.Require().NoError(err)
+	u.Require().Len(active, 2)
+	u.Equal(users[0].ID, active[0].ID)
+	u.Equal(users[2].ID, active[1].ID)
+}
@@ -54,3 +54,42 @@ func updateUserParamsToPG(input domain.UpdateUserParams) (userstore.UpdateUserPa
 	}
 	return userUpdate, nil
 }
+
+func pgToPreferences(prefs userstore.UserPreference) (domain.Preferences, error) {
+	userID, err := common.FromPgUUID(prefs.UserID)
+	if err != nil {
+		return domain.Preferences{}, fmt.Errorf("failed to transform uuid %w", err)
+	}
+	return domain.Preferences{
+		UserID:        userID,
+		DigestEnabled: prefs.DigestEnabled,
+		TimeZone:      prefs.Timezone,
+		UpdatedAt:     common.FromPgTimestamp(prefs.UpdatedAt),
+	}, nil
+}
+
+// upsertPreferencesParamsToPG converts Preferences to userstore.UpsertUserPreferencesParams
+func upsertPreferencesParamsToPG(prefs domain.Preferences) (userstore.UpsertUserPreferencesParams, error) {
+	pgId, err := common.ToPgUUID(prefs.UserID)
+	if err != nil {
+		return userstore.UpsertUserPreferencesParams{}, fmt.Errorf("failed to convert UUID: %w", err)
+	}
+	return userstore.UpsertUserPreferencesParams{
+		UserID:        pgId,
+		DigestEnabled: prefs.DigestEnabled,
+		Timezone:      prefs.TimeZone,
+	}, nil
+}
+
+func pgToDigestSubscriber(row userstore.ListDigestSubscribersRow) (domain.DigestSubscriber, error) {
+	userID, err := common.FromPgUUID(row.ID)
+	if err != nil {
+		return domain.DigestSubscriber{}, fmt.Errorf("failed to transform uuid %w", err)
+	}
+	return domain.DigestSubscriber{
+		ID:       userID,
+		Name:     row.Name,
+		Email:    row.Email,
+		TimeZone: row.Timezone,
+	}, nil
+}
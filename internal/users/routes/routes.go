@@ -57,6 +57,23 @@ func RegisterRoutes(router *http.ServeMux, h *handler.Handler) {
 			return
 		}
 
+		// Handle `/users/{id}/preferences`
+		if len(segments) == 3 && segments[2] == "preferences" {
+
+			if r.Method == http.MethodGet {
+				handler.VerifyUserID(h.GetPreferencesHandler).ServeHTTP(w, r)
+				return
+			}
+
+			if r.Method == http.MethodPut {
+				handler.VerifyUserID(h.UpdatePreferencesHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
 		// Return 404 for invalid paths
 		http.NotFound(w, r)
 	}))
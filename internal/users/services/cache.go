@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WarmCache caches up to limit of the most recently active users, so their lookups hit the cache
+// after a deploy, and returns how many were cached. It stops at the first cache failure.
+func (s *service) WarmCache(ctx context.Context, limit int) (int, error) {
+	users, err := s.repo.ListRecentlyActiveUsers(ctx, limit)
+	if err != nil {
+		s.logger.Errorw("WarmCache failed: unexpected error", "error", err)
+		return 0, common.ErrInternalServerError
+	}
+
+	for i, user := range users {
+		if err := s.cache.CacheUser(ctx, user); err != nil {
+			s.logger.Warnw("WarmCache stopped: failed to cache user", "user_id", user.ID, "cached", i, "error", err)
+			return i, fmt.Errorf("%w: %w", ErrCacheUnavailable, err)
+		}
+	}
+
+	s.logger.Infow("User cache warmed", "cached", len(users))
+	return len(users), nil
+}
+
+// GetCachedUser returns a user's cache entries without loading anything into the cache.
+// The user's email is read from the cached user or, failing that, the database.
+func (s *service) GetCachedUser(ctx context.Context, id uuid.UUID) (domain.CachedUser, error) {
+	entries := domain.CachedUser{UserID: id}
+
+	user, err := s.cache.GetUserByID(ctx, id)
+	switch {
+	case err == nil:
+		entries.ByID = &user
+		entries.Email = user.Email
+	case !errors.Is(err, redis.Nil):
+		s.logger.Warnw("GetCachedUser failed: cache unavailable", "user_id", id, "error", err)
+		return domain.CachedUser{}, ErrCacheUnavailable
+	}
+
+	if entries.Email == "" {
+		dbUser, err := s.repo.GetUserByID(ctx, id)
+		if err != nil && !errors.Is(err, common.ErrNotFound) {
+			s.logger.Errorw("GetCachedUser failed: unexpected error", "user_id", id, "error", err)
+			return domain.CachedUser{}, common.ErrInternalServerError
+		}
+		if err != nil {
+			// Without a database record or cached user there is nothing to look up by email
+			return entries, nil
+		}
+		entries.Email = dbUser.Email
+	}
+
+	user, err = s.cache.GetUserByEmail(ctx, entries.Email)
+	switch {
+	case err == nil:
+		entries.ByEmail = &user
+	case !errors.Is(err, redis.Nil):
+		s.logger.Warnw("GetCachedUser failed: cache unavailable", "user_id", id, "error", err)
+		return domain.CachedUser{}, ErrCacheUnavailable
+	}
+	return entries, nil
+}
+
+// EvictUser removes a user's cache entries: the user cached under their ID, their email pointer
+// and the entries remembering them as missing. Pages listing the user hold only their ID and are kept.
+func (s *service) EvictUser(ctx context.Context, id uuid.UUID) error {
+	entries, err := s.GetCachedUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{
+		s.cache.DeleteUserByID(ctx, id),
+		s.cache.DeleteMissingUser(ctx, domain.User{ID: id, Email: entries.Email}),
+	}
+	if entries.Email != "" {
+		errs = append(errs, s.cache.DeleteUserByEmail(ctx, entries.Email))
+	}
+	if err := errors.Join(errs...); err != nil {
+		s.logger.Warnw("EvictUser failed: cache unavailable", "user_id", id, "error", err)
+		return ErrCacheUnavailable
+	}
+
+	s.logger.Infow("User evicted from cache", "user_id", id)
+	return nil
+}
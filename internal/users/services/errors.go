@@ -5,4 +5,6 @@ import "errors"
 // Service-level errors (handler should only see these)
 var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
+	ErrInvalidTimeZone    = errors.New("invalid time zone")
+	ErrCacheUnavailable   = errors.New("cache unavailable")
 )
@@ -4,9 +4,10 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
 )
 
-// clearUserCache fetches a user by ID and removes them from cache
+// clearUserCache fetches a user by ID and removes them from cache.
 func (s *service) clearUserCache(ctx context.Context, id uuid.UUID) {
 	// Try to get user from cache first
 	user, err := s.cache.GetUserByID(ctx, id)
@@ -32,3 +33,19 @@ func (s *service) clearUserCache(ctx context.Context, id uuid.UUID) {
 	s.cache.DeleteUserByID(ctx, user.ID)
 	s.cache.DeleteUserByEmail(ctx, user.Email)
 }
+
+// invalidateUserPages moves readers past every cached page of users after a user changed.
+// Failures are logged; pages then expire with their TTL.
+func (s *service) invalidateUserPages(ctx context.Context, id uuid.UUID) {
+	if err := s.cache.InvalidateUserPages(ctx); err != nil {
+		s.logger.Warnw("Failed to invalidate cached user pages", "user_id", id, "error", err)
+	}
+}
+
+// deleteMissingUser removes the negative cache entries for a user's ID and email.
+// Failures are logged; the entries then expire with their short TTL.
+func (s *service) deleteMissingUser(ctx context.Context, user domain.User) {
+	if err := s.cache.DeleteMissingUser(ctx, user); err != nil {
+		s.logger.Warnw("Failed to delete negative cache entries", "user_id", user.ID, "error", err)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+)
+
+// GetPreferences retrieves a user's notification preferences, falling back to the defaults
+// for users who never stored any
+func (s *service) GetPreferences(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+	prefs, err := s.repo.GetPreferences(ctx, userID)
+	if err == nil {
+		return prefs, nil
+	}
+	if !errors.Is(err, common.ErrNotFound) {
+		s.logger.Errorw("GetPreferences failed: unexpected error",
+			"user_id", userID,
+			"error", err,
+		)
+		return domain.Preferences{}, common.ErrInternalServerError
+	}
+
+	// Only report defaults for users that exist
+	if _, err := s.GetUserByID(ctx, userID); err != nil {
+		return domain.Preferences{}, err
+	}
+
+	return domain.Preferences{
+		UserID:   userID,
+		TimeZone: domain.DefaultTimeZone,
+	}, nil
+}
+
+// UpdatePreferences applies a partial update to a user's notification preferences
+func (s *service) UpdatePreferences(ctx context.Context, params domain.UpdatePreferencesParams) (domain.Preferences, error) {
+	before, err := s.GetPreferences(ctx, params.UserID)
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+
+	prefs := before
+	if params.DigestEnabled != nil {
+		prefs.DigestEnabled = *params.DigestEnabled
+	}
+	if params.TimeZone != nil {
+		// Reject names the digest job could not resolve later
+		if _, err := time.LoadLocation(*params.TimeZone); err != nil || *params.TimeZone == "" {
+			s.logger.Warnw("UpdatePreferences failed: invalid time zone",
+				"user_id", params.UserID,
+				"timezone", *params.TimeZone,
+			)
+			return domain.Preferences{}, ErrInvalidTimeZone
+		}
+		prefs.TimeZone = *params.TimeZone
+	}
+
+	updated, err := s.repo.UpsertPreferences(ctx, prefs)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return domain.Preferences{}, common.ErrNotFound
+		}
+		s.logger.Errorw("UpdatePreferences failed: unexpected internal error",
+			"user_id", params.UserID,
+			"error", err,
+		)
+		return domain.Preferences{}, common.ErrInternalServerError
+	}
+
+	s.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionUserUpdatePreferences,
+		TargetType: auditdomain.TargetUser,
+		TargetID:   params.UserID.String(),
+		Before:     before,
+		After:      updated,
+	})
+
+	s.logger.Infow("User preferences updated successfully",
+		"user_id", params.UserID,
+		"digest_enabled", updated.DigestEnabled,
+		"timezone", updated.TimeZone,
+	)
+	return updated, nil
+}
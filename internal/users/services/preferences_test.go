@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/users/domain"
+	"github.com/henryhall897/golang-todo-app/internal/users/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPreferences(t *testing.T) {
+	testUser := testutils.GenerateMockUsers(1)[0]
+
+	t.Run("success - stored preferences", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Redis.Server.Close()
+
+		stored := domain.Preferences{UserID: testUser.ID, DigestEnabled: true, TimeZone: "Europe/Berlin"}
+		suite.mockRepo.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return stored, nil
+		}
+
+		prefs, err := suite.Service.GetPreferences(suite.ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, stored, prefs)
+	})
+
+	t.Run("success - defaults for an existing user", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Redis.Server.Close()
+
+		suite.mockRepo.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return domain.Preferences{}, common.ErrNotFound
+		}
+		suite.mockRepo.GetUserByIDFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return testUser, nil
+		}
+
+		prefs, err := suite.Service.GetPreferences(suite.ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Preferences{UserID: testUser.ID, TimeZone: domain.DefaultTimeZone}, prefs)
+	})
+
+	t.Run("failure - user not found", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Redis.Server.Close()
+
+		suite.mockRepo.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return domain.Preferences{}, common.ErrNotFound
+		}
+		suite.mockRepo.GetUserByIDFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.User{}, common.ErrNotFound
+		}
+
+		_, err := suite.Service.GetPreferences(suite.ctx, testUser.ID)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+
+	t.Run("failure - internal server error", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Redis.Server.Close()
+
+		suite.mockRepo.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return domain.Preferences{}, errors.New("database timeout")
+		}
+
+		_, err := suite.Service.GetPreferences(suite.ctx, testUser.ID)
+		assert.ErrorIs(t, err, common.ErrInternalServerError)
+	})
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	testUser := testutils.GenerateMockUsers(1)[0]
+	stored := domain.Preferences{UserID: testUser.ID, DigestEnabled: false, TimeZone: "UTC"}
+
+	t.Run("success - partial update", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Redis.Server.Close()
+
+		suite.mockRepo.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return stored, nil
+		}
+		suite.mockRepo.UpsertPreferencesFunc = func(ctx context.Context, prefs domain.Preferences) (domain.Preferences, error) {
+			return prefs, nil
+		}
+
+		prefs, err := suite.Service.UpdatePreferences(suite.ctx, domain.UpdatePreferencesParams{
+			UserID:        testUser.ID,
+			DigestEnabled: common.Ptr(true),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.Preferences{UserID: testUser.ID, DigestEnabled: true, TimeZone: "UTC"}, prefs)
+
+		// The change is recorded with both states
+		calls := suite.mockAuditor.RecordCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, auditdomain.ActionUserUpdatePreferences, calls[0].Params.Action)
+		assert.Equal(t, testUser.ID.String(), calls[0].Params.TargetID)
+		assert.Equal(t, stored, calls[0].Params.Before)
+		assert.Equal(t, prefs, calls[0].Params.After)
+	})
+
+	t.Run("failure - invalid time zone", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Redis.Server.Close()
+
+		suite.mockRepo.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return stored, nil
+		}
+
+		for _, tz := range []string{"Mars/Olympus_Mons", ""} {
+			_, err := suite.Service.UpdatePreferences(suite.ctx, domain.UpdatePreferencesParams{
+				UserID:   testUser.ID,
+				TimeZone: common.Ptr(tz),
+			})
+			assert.ErrorIs(t, err, ErrInvalidTimeZone, tz)
+		}
+		assert.Empty(t, suite.mockRepo.UpsertPreferencesCalls())
+	})
+
+	t.Run("failure - user not found", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Redis.Server.Close()
+
+		suite.mockRepo.GetPreferencesFunc = func(ctx context.Context, userID uuid.UUID) (domain.Preferences, error) {
+			return stored, nil
+		}
+		suite.mockRepo.UpsertPreferencesFunc = func(ctx context.Context, prefs domain.Preferences) (domain.Preferences, error) {
+			return domain.Preferences{}, common.ErrNotFound
+		}
+
+		_, err := suite.Service.UpdatePreferences(suite.ctx, domain.UpdatePreferencesParams{
+			UserID:        testUser.ID,
+			DigestEnabled: common.Ptr(true),
+		})
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.Empty(t, suite.mockAuditor.RecordCalls())
+	})
+}
@@ -7,10 +7,11 @@ import (
 	"testing"
 	"time"
 
-	"github.com/alicebob/miniredis"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
 	"github.com/henryhall897/golang-todo-app/internal/users/domain"
 	"github.com/henryhall897/golang-todo-app/internal/users/testutils"
 
@@ -235,7 +236,9 @@ func TestGetUsers_Cache(t *testing.T) {
 
 	mockUsers := testutils.GenerateMockUsers(3) // Generate test users
 	params := domain.GetUsersParams{Limit: 3, Offset: 0}
-	key := domain.CacheKeyByPagination(params.Limit, params.Offset)
+	generation, err := suite.Service.(*service).cache.PageGeneration(suite.ctx)
+	require.NoError(t, err)
+	key := domain.CacheKeyByPagination(generation, params.Limit, params.Offset)
 	cacheKey := RedisFullKey(key)
 
 	t.Run("success - cache miss, fetch from DB and store in Redis", func(t *testing.T) {
@@ -251,19 +254,22 @@ func TestGetUsers_Cache(t *testing.T) {
 		_, err := suite.Service.GetUsers(suite.ctx, params)
 		require.NoError(t, err)
 
-		// Verify users are now cached in Redis
-		cachedUsersJSON, err := suite.Redis.Server.Get(cacheKey)
-		require.NoError(t, err, "Expected Redis to contain cached users")
-		assert.NotEmpty(t, cachedUsersJSON, "Expected Redis to store users after DB fetch")
+		// Verify the page is now cached in Redis as the IDs of its users
+		cachedPageJSON, err := suite.Redis.Server.Get(cacheKey)
+		require.NoError(t, err, "Expected Redis to contain the cached page")
 
-		// Deserialize cached users
-		var cachedUsers []domain.User
-		err = json.Unmarshal([]byte(cachedUsersJSON), &cachedUsers)
-		require.NoError(t, err, "Failed to deserialize cached users from Redis")
+		var cachedIDs []uuid.UUID
+		require.NoError(t, json.Unmarshal([]byte(cachedPageJSON), &cachedIDs), "Failed to deserialize cached page from Redis")
+		require.Len(t, cachedIDs, len(mockUsers))
 
-		// Ensure cached users match the original users
-		assert.Equal(t, len(mockUsers), len(cachedUsers))
-		for i, user := range cachedUsers {
+		// Ensure each user is cached once, by ID
+		for i, id := range cachedIDs {
+			assert.Equal(t, mockUsers[i].ID, id)
+
+			cachedUserJSON, err := suite.Redis.Server.Get(RedisFullKey(domain.CacheKeyByID(id)))
+			require.NoError(t, err, "Expected Redis to contain the page's users")
+			var user domain.User
+			require.NoError(t, json.Unmarshal([]byte(cachedUserJSON), &user))
 			assert.Equal(t, mockUsers[i].ID, user.ID)
 			assert.Equal(t, mockUsers[i].Name, user.Name)
 			assert.Equal(t, mockUsers[i].Email, user.Email)
@@ -273,9 +279,19 @@ func TestGetUsers_Cache(t *testing.T) {
 	})
 
 	t.Run("success - cache hit", func(t *testing.T) {
-		// Serialize and manually store users in Redis (simulating a previous cache)
-		usersJSON, _ := json.Marshal(mockUsers)
-		suite.Redis.Server.Set(cacheKey, string(usersJSON))
+		// Serialize and manually store the page and its users in Redis (simulating a previous cache)
+		ids := make([]uuid.UUID, len(mockUsers))
+		for i, user := range mockUsers {
+			ids[i] = user.ID
+			userJSON, _ := json.Marshal(user)
+			suite.Redis.Server.Set(RedisFullKey(domain.CacheKeyByID(user.ID)), string(userJSON))
+		}
+		idsJSON, _ := json.Marshal(ids)
+		suite.Redis.Server.Set(cacheKey, string(idsJSON))
+		suite.mockRepo.GetUsersFunc = func(ctx context.Context, p domain.GetUsersParams) ([]domain.User, error) {
+			t.Fatal("cached page should not be read from the database")
+			return nil, nil
+		}
 
 		// Call service method (should retrieve from Redis instead of DB)
 		users, err := suite.Service.GetUsers(suite.ctx, params)
@@ -292,6 +308,21 @@ func TestGetUsers_Cache(t *testing.T) {
 		}
 	})
 
+	t.Run("success - page with an uncached user is read again", func(t *testing.T) {
+		suite.Redis.Server.Del(RedisFullKey(domain.CacheKeyByID(mockUsers[1].ID)))
+		var calls int
+		suite.mockRepo.GetUsersFunc = func(ctx context.Context, p domain.GetUsersParams) ([]domain.User, error) {
+			calls++
+			return mockUsers, nil
+		}
+
+		users, err := suite.Service.GetUsers(suite.ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Len(t, users, len(mockUsers))
+		assert.True(t, suite.Redis.Server.Exists(RedisFullKey(domain.CacheKeyByID(mockUsers[1].ID))), "users read again are cached")
+	})
+
 	t.Run("failure - Redis error, fallback to DB", func(t *testing.T) {
 		// Simulate Redis failure by stopping MiniRedis
 		suite.Redis.Server.Close()
@@ -317,6 +348,78 @@ func TestGetUsers_Cache(t *testing.T) {
 	})
 }
 
+func TestUserPages_Invalidation(t *testing.T) {
+	suite := SetupSuite()            // Load shared test setup
+	defer suite.Redis.Server.Close() // Cleanup Miniredis after test
+
+	mockUsers := testutils.GenerateMockUsers(2)
+	params := domain.GetUsersParams{Limit: 10, Offset: 0}
+	suite.mockRepo.GetUsersFunc = func(ctx context.Context, p domain.GetUsersParams) ([]domain.User, error) {
+		return mockUsers, nil
+	}
+	suite.mockRepo.GetUserByIDFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+		return mockUsers[0], nil
+	}
+
+	// A page cached before any mutation is served from Redis
+	_, err := suite.Service.GetUsers(suite.ctx, params)
+	require.NoError(t, err)
+	_, err = suite.Service.GetUsers(suite.ctx, params)
+	require.NoError(t, err)
+	require.Len(t, suite.mockRepo.GetUsersCalls(), 1, "second read should hit the cache")
+
+	mutations := map[string]func() error{
+		"create": func() error {
+			suite.mockRepo.CreateUserFunc = func(ctx context.Context, p domain.CreateUserParams) (domain.User, error) {
+				return mockUsers[1], nil
+			}
+			_, err := suite.Service.CreateUser(suite.ctx, domain.CreateUserParams{Name: mockUsers[1].Name, Email: mockUsers[1].Email})
+			return err
+		},
+		"update": func() error {
+			suite.mockRepo.UpdateUserFunc = func(ctx context.Context, p domain.UpdateUserParams) (domain.User, domain.User, error) {
+				return mockUsers[0], mockUsers[0], nil
+			}
+			_, err := suite.Service.UpdateUser(suite.ctx, domain.UpdateUserParams{ID: mockUsers[0].ID, Name: "Renamed"})
+			return err
+		},
+		"delete": func() error {
+			suite.mockRepo.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) { return mockUsers[0], nil }
+			return suite.Service.DeleteUser(suite.ctx, mockUsers[0].ID)
+		},
+	}
+	for _, name := range []string{"create", "update", "delete"} {
+		t.Run(name+" invalidates cached pages", func(t *testing.T) {
+			before, err := suite.Service.(*service).cache.PageGeneration(suite.ctx)
+			require.NoError(t, err)
+			calls := len(suite.mockRepo.GetUsersCalls())
+
+			require.NoError(t, mutations[name]())
+
+			after, err := suite.Service.(*service).cache.PageGeneration(suite.ctx)
+			require.NoError(t, err)
+			assert.Greater(t, after, before)
+
+			// The next read goes to the database and is cached in the new generation
+			_, err = suite.Service.GetUsers(suite.ctx, params)
+			require.NoError(t, err)
+			assert.Len(t, suite.mockRepo.GetUsersCalls(), calls+1)
+			assert.True(t, suite.Redis.Server.Exists(RedisFullKey(domain.CacheKeyByPagination(after, params.Limit, params.Offset))))
+		})
+	}
+
+	t.Run("a lost generation is reseeded above the old count", func(t *testing.T) {
+		before, err := suite.Service.(*service).cache.PageGeneration(suite.ctx)
+		require.NoError(t, err)
+		suite.Redis.Server.Del(RedisFullKey(domain.CacheKeyPageGeneration()))
+
+		require.NoError(t, suite.Service.(*service).cache.InvalidateUserPages(suite.ctx))
+		after, err := suite.Service.(*service).cache.PageGeneration(suite.ctx)
+		require.NoError(t, err)
+		assert.Greater(t, after, before)
+	})
+}
+
 func TestGetUserByEmail_Cache(t *testing.T) {
 	suite := SetupSuite()            // Load shared test setup
 	defer suite.Redis.Server.Close() // Cleanup Miniredis after test
@@ -359,9 +462,12 @@ func TestGetUserByEmail_Cache(t *testing.T) {
 	})
 
 	t.Run("success - cache hit", func(t *testing.T) {
-		// Serialize and manually store user in Redis (simulating a previous cache)
-		userJSON, _ := json.Marshal(testUser)
-		suite.Redis.Server.Set(cacheKey, string(userJSON))
+		// The previous subtest cached the user with a pointer from their email
+		require.True(t, suite.Redis.Server.Exists(cacheKey), "Email pointer should be cached")
+		suite.mockRepo.GetUserByEmailFunc = func(ctx context.Context, email string) (domain.User, error) {
+			t.Fatal("DB should not be called on a cache hit")
+			return domain.User{}, nil
+		}
 
 		// Call service method (should retrieve from Redis instead of DB)
 		user, err := suite.Service.GetUserByEmail(suite.ctx, testUser.Email)
@@ -375,6 +481,29 @@ func TestGetUserByEmail_Cache(t *testing.T) {
 		assert.WithinDuration(t, testUser.UpdatedAt, user.UpdatedAt, time.Millisecond)
 	})
 
+	t.Run("success - stale email pointer is not followed", func(t *testing.T) {
+		// The user changes email; a racing writer leaves a pointer from the old one behind
+		renamed := testUser
+		renamed.Email = "renamed@example.com"
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return testUser, renamed, nil
+		}
+		_, err := suite.Service.UpdateUser(suite.ctx, domain.UpdateUserParams{ID: testUser.ID, Email: renamed.Email})
+		require.NoError(t, err)
+		require.NoError(t, suite.Redis.Server.Set(cacheKey, testUser.ID.String()))
+
+		suite.mockRepo.GetUserByEmailFunc = func(ctx context.Context, email string) (domain.User, error) {
+			return domain.User{}, common.ErrNotFound
+		}
+
+		_, err = suite.Service.GetUserByEmail(suite.ctx, testUser.Email)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+
+		user, err := suite.Service.GetUserByEmail(suite.ctx, renamed.Email)
+		require.NoError(t, err)
+		assert.Equal(t, renamed.Email, user.Email)
+	})
+
 	t.Run("failure - Redis error, fallback to DB", func(t *testing.T) {
 		// Simulate Redis failure by stopping MiniRedis
 		suite.Redis.Server.Close()
@@ -397,6 +526,165 @@ func TestGetUserByEmail_Cache(t *testing.T) {
 	})
 }
 
+func TestNegativeCache(t *testing.T) {
+	suite := SetupSuite()            // Load shared test setup
+	defer suite.Redis.Server.Close() // Cleanup Miniredis after test
+
+	testUser := testutils.GenerateMockUsers(1)[0]
+	missingByID := RedisFullKey(domain.CacheKeyMissingByID(testUser.ID))
+	missingByEmail := RedisFullKey(domain.CacheKeyMissingByEmail(testUser.Email))
+
+	var lookups int
+	suite.mockRepo.GetUserByIDFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+		lookups++
+		return domain.User{}, common.ErrNotFound
+	}
+	suite.mockRepo.GetUserByEmailFunc = func(ctx context.Context, email string) (domain.User, error) {
+		lookups++
+		return domain.User{}, common.ErrNotFound
+	}
+
+	t.Run("success - not found lookups are remembered", func(t *testing.T) {
+		for range 3 {
+			_, err := suite.Service.GetUserByID(suite.ctx, testUser.ID)
+			assert.ErrorIs(t, err, common.ErrNotFound)
+			_, err = suite.Service.GetUserByEmail(suite.ctx, testUser.Email)
+			assert.ErrorIs(t, err, common.ErrNotFound)
+		}
+		assert.Equal(t, 2, lookups, "only the first lookup of each key reaches the database")
+
+		// Negative entries are kept apart from users and expire quickly
+		assert.Equal(t, domain.RedisNegativeTTL, suite.Redis.Server.TTL(missingByID))
+		assert.Equal(t, domain.RedisNegativeTTL, suite.Redis.Server.TTL(missingByEmail))
+		assert.False(t, suite.Redis.Server.Exists(RedisFullKey(domain.CacheKeyByID(testUser.ID))))
+		assert.False(t, suite.Redis.Server.Exists(RedisFullKey(domain.CacheKeyByEmail(testUser.Email))))
+	})
+
+	t.Run("success - negative entries expire", func(t *testing.T) {
+		suite.Redis.Server.FastForward(domain.RedisNegativeTTL)
+		lookups = 0
+
+		_, err := suite.Service.GetUserByEmail(suite.ctx, testUser.Email)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.Equal(t, 1, lookups)
+	})
+
+	t.Run("success - creating the user removes negative entries", func(t *testing.T) {
+		_, err := suite.Service.GetUserByID(suite.ctx, testUser.ID)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		require.True(t, suite.Redis.Server.Exists(missingByID))
+		require.True(t, suite.Redis.Server.Exists(missingByEmail))
+
+		suite.mockRepo.CreateUserFunc = func(ctx context.Context, params domain.CreateUserParams) (domain.User, error) {
+			return testUser, nil
+		}
+		_, err = suite.Service.CreateUser(suite.ctx, domain.CreateUserParams{Name: testUser.Name, Email: testUser.Email})
+		require.NoError(t, err)
+		assert.False(t, suite.Redis.Server.Exists(missingByID))
+		assert.False(t, suite.Redis.Server.Exists(missingByEmail))
+
+		// The new user is found even once their cache entries are gone
+		suite.Redis.Server.Del(RedisFullKey(domain.CacheKeyByID(testUser.ID)))
+		suite.Redis.Server.Del(RedisFullKey(domain.CacheKeyByEmail(testUser.Email)))
+		suite.mockRepo.GetUserByEmailFunc = func(ctx context.Context, email string) (domain.User, error) {
+			return testUser, nil
+		}
+		user, err := suite.Service.GetUserByEmail(suite.ctx, testUser.Email)
+		require.NoError(t, err)
+		assert.Equal(t, testUser.ID, user.ID)
+	})
+}
+
+func TestCacheManagement(t *testing.T) {
+	suite := SetupSuite()            // Load shared test setup
+	defer suite.Redis.Server.Close() // Cleanup Miniredis after test
+
+	testUsers := testutils.GenerateMockUsers(3)
+	byID := func(user domain.User) string { return RedisFullKey(domain.CacheKeyByID(user.ID)) }
+	byEmail := func(user domain.User) string { return RedisFullKey(domain.CacheKeyByEmail(user.Email)) }
+
+	suite.mockRepo.GetUserByIDFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+		for _, user := range testUsers {
+			if user.ID == id {
+				return user, nil
+			}
+		}
+		return domain.User{}, common.ErrNotFound
+	}
+
+	t.Run("success - WarmCache caches recently active users", func(t *testing.T) {
+		suite.mockRepo.ListRecentlyActiveUsersFunc = func(ctx context.Context, limit int) ([]domain.User, error) {
+			assert.Equal(t, 2, limit)
+			return testUsers[:2], nil
+		}
+
+		cached, err := suite.Service.WarmCache(suite.ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, cached)
+		for _, user := range testUsers[:2] {
+			assert.True(t, suite.Redis.Server.Exists(byID(user)))
+			assert.True(t, suite.Redis.Server.Exists(byEmail(user)))
+		}
+		assert.False(t, suite.Redis.Server.Exists(byID(testUsers[2])))
+	})
+
+	t.Run("failure - WarmCache repository error", func(t *testing.T) {
+		suite.mockRepo.ListRecentlyActiveUsersFunc = func(ctx context.Context, limit int) ([]domain.User, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+
+		cached, err := suite.Service.WarmCache(suite.ctx, 2)
+		assert.ErrorIs(t, err, common.ErrInternalServerError)
+		assert.Zero(t, cached)
+	})
+
+	t.Run("success - GetCachedUser returns both entries", func(t *testing.T) {
+		entries, err := suite.Service.GetCachedUser(suite.ctx, testUsers[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, testUsers[0].Email, entries.Email)
+		require.NotNil(t, entries.ByID)
+		require.NotNil(t, entries.ByEmail)
+		assert.Equal(t, testUsers[0].ID, entries.ByID.ID)
+		assert.Equal(t, testUsers[0].ID, entries.ByEmail.ID)
+	})
+
+	t.Run("success - GetCachedUser does not load uncached users", func(t *testing.T) {
+		entries, err := suite.Service.GetCachedUser(suite.ctx, testUsers[2].ID)
+		require.NoError(t, err)
+		assert.Equal(t, testUsers[2].Email, entries.Email, "email is read from the database")
+		assert.Nil(t, entries.ByID)
+		assert.Nil(t, entries.ByEmail)
+		assert.False(t, suite.Redis.Server.Exists(byID(testUsers[2])))
+	})
+
+	t.Run("success - EvictUser removes the user's entries", func(t *testing.T) {
+		require.NoError(t, suite.Service.EvictUser(suite.ctx, testUsers[0].ID))
+		assert.False(t, suite.Redis.Server.Exists(byID(testUsers[0])))
+		assert.False(t, suite.Redis.Server.Exists(byEmail(testUsers[0])))
+		assert.True(t, suite.Redis.Server.Exists(byID(testUsers[1])), "other users are kept")
+
+		entries, err := suite.Service.GetCachedUser(suite.ctx, testUsers[0].ID)
+		require.NoError(t, err)
+		assert.Nil(t, entries.ByID)
+		assert.Nil(t, entries.ByEmail)
+	})
+
+	t.Run("failure - cache unavailable", func(t *testing.T) {
+		suite.Redis.Server.Close()
+
+		_, err := suite.Service.GetCachedUser(suite.ctx, testUsers[1].ID)
+		assert.ErrorIs(t, err, ErrCacheUnavailable)
+		assert.ErrorIs(t, suite.Service.EvictUser(suite.ctx, testUsers[1].ID), ErrCacheUnavailable)
+
+		suite.mockRepo.ListRecentlyActiveUsersFunc = func(ctx context.Context, limit int) ([]domain.User, error) {
+			return testUsers, nil
+		}
+		cached, err := suite.Service.WarmCache(suite.ctx, 3)
+		assert.ErrorIs(t, err, ErrCacheUnavailable)
+		assert.Zero(t, cached)
+	})
+}
+
 /*
 func TestGetUserByAuthID_Cache(t *testing.T) {
 	suite := SetupSuite()
@@ -516,8 +804,8 @@ func TestUpdateUser_Cache(t *testing.T) {
 		updatedUser := testUser
 		updatedUser.Name = updateParams.Name
 		updatedUser.Email = updateParams.Email
-		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
-			return updatedUser, nil
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return testUser, updatedUser, nil
 		}
 
 		fmt.Println("Before update: Old email cache exists?", suite.Redis.Server.Exists(cacheKeyByOldEmail))
@@ -557,8 +845,8 @@ func TestUpdateUser_Cache(t *testing.T) {
 		updatedUser := testUser
 		updatedUser.Name = updateParams.Name
 		updatedUser.Email = updateParams.Email
-		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
-			return updatedUser, nil
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return testUser, updatedUser, nil
 		}
 
 		// Call service method
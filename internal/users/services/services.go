@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
 	"github.com/henryhall897/golang-todo-app/internal/users/domain"
 	"github.com/henryhall897/golang-todo-app/internal/users/repository"
@@ -13,16 +14,18 @@ import (
 )
 
 type service struct {
-	repo   domain.Repository
-	cache  domain.Cache
-	logger *zap.SugaredLogger
+	repo    domain.Repository
+	cache   domain.Cache
+	auditor auditdomain.Auditor
+	logger  *zap.SugaredLogger
 }
 
-func New(repo domain.Repository, cache domain.Cache, logger *zap.SugaredLogger) domain.Service {
+func New(repo domain.Repository, cache domain.Cache, auditor auditdomain.Auditor, logger *zap.SugaredLogger) domain.Service {
 	return &service{
-		repo:   repo,
-		cache:  cache,
-		logger: logger,
+		repo:    repo,
+		cache:   cache,
+		auditor: auditor,
+		logger:  logger,
 	}
 }
 
@@ -51,6 +54,16 @@ func (s *service) CreateUser(ctx context.Context, params domain.CreateUserParams
 		"name", user.Name,
 	)
 
+	s.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionUserCreate,
+		TargetType: auditdomain.TargetUser,
+		TargetID:   user.ID.String(),
+		After:      user,
+	})
+
+	// Lookups that found no user with this ID or email must now find them
+	s.deleteMissingUser(ctx, user)
+
 	// Attempt to cache the new user in Redis
 	if err := s.cache.CacheUser(ctx, user); err != nil {
 		s.logger.Warnw("Failed to cache user in Redis",
@@ -59,19 +72,18 @@ func (s *service) CreateUser(ctx context.Context, params domain.CreateUserParams
 		)
 	}
 
+	// Cached pages of users do not list the new user yet
+	s.invalidateUserPages(ctx, user.ID)
+
 	return user, nil
 }
 
 // GetUserByID retrieves a user by ID, using Redis caching when possible
 func (s *service) GetUserByID(ctx context.Context, id uuid.UUID) (domain.User, error) {
-	// Attempt to retrieve from cache
-	if cachedUser, err := s.cache.GetUserByID(ctx, id); err == nil {
-		s.logger.Debugw("Cache hit: Retrieved user from Redis", "user_id", cachedUser.ID)
-		return cachedUser, nil
-	}
-
-	// Cache miss or deserialization failure, proceed to DB lookup
-	user, err := s.repo.GetUserByID(ctx, id)
+	// Read through the cache; concurrent misses share one DB lookup, which is cached
+	user, err := s.cache.LoadUserByID(ctx, id, func(ctx context.Context) (domain.User, error) {
+		return s.repo.GetUserByID(ctx, id)
+	})
 	if err != nil {
 		// Handle repository-level errors
 		if errors.Is(err, repository.ErrInvalidDbUserID) || errors.Is(err, repository.ErrFailedToParseUUID) {
@@ -96,28 +108,15 @@ func (s *service) GetUserByID(ctx context.Context, id uuid.UUID) (domain.User, e
 	}
 
 	s.logger.Debugw("User retrieved successfully", "user_id", user.ID, "name", user.Name)
-
-	// Store retrieved user in cache with an expiration
-	if err := s.cache.CacheUser(ctx, user); err != nil {
-		s.logger.Warnw("Failed to cache user in Redis", "user_id", user.ID, "error", err)
-	}
-
 	return user, nil
 }
 
 // GetUserByEmail retrieves a user by email, utilizing Redis caching
 func (s *service) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
-	// Attempt to retrieve cached user from Redis
-	if cachedUser, err := s.cache.GetUserByEmail(ctx, email); err == nil {
-		s.logger.Debugw("Cache hit: Retrieved user from Redis",
-			"user_id", cachedUser.ID,
-			"email", cachedUser.Email,
-		)
-		return cachedUser, nil
-	}
-
-	// Fetch user from the database
-	user, err := s.repo.GetUserByEmail(ctx, email)
+	// Read through the cache; concurrent misses share one DB lookup, which is cached
+	user, err := s.cache.LoadUserByEmail(ctx, email, func(ctx context.Context) (domain.User, error) {
+		return s.repo.GetUserByEmail(ctx, email)
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrInvalidDbUserID) || errors.Is(err, repository.ErrFailedToParseUUID) {
 			s.logger.Errorw("GetUserByEmail failed: invalid user data in database",
@@ -137,11 +136,6 @@ func (s *service) GetUserByEmail(ctx context.Context, email string) (domain.User
 		return domain.User{}, common.ErrInternalServerError
 	}
 
-	// Store the retrieved user in Redis for future lookups
-	if err := s.cache.CacheUser(ctx, user); err != nil {
-		s.logger.Errorw("Failed to store user in Redis", "email", email, "error", err)
-	}
-
 	s.logger.Debugw("User retrieved successfully", "user_id", user.ID, "email", user.Email)
 	return user, nil
 }
@@ -189,17 +183,21 @@ func (s *service) GetUserByAuthID(ctx context.Context, authID string) (domain.Us
 
 // GetUsers retrieves a list of users with caching
 func (s *service) GetUsers(ctx context.Context, params domain.GetUsersParams) ([]domain.User, error) {
-	// Attempt to retrieve cached users from Redis
-	if cachedUsers, err := s.cache.GetUserByPagination(ctx, params); err == nil {
-		s.logger.Debugw("Cache hit: Retrieved users from Redis",
-			"user_count", len(cachedUsers),
-			"params", params,
-		)
-		return cachedUsers, nil
+	// Pages are read and stored in the generation current before the database is queried,
+	// so a page read before a concurrent change is never served after it.
+	// Without a generation the page is read from the database and not cached.
+	load := func(ctx context.Context) ([]domain.User, error) {
+		return s.repo.GetUsers(ctx, params)
+	}
+	var users []domain.User
+	generation, err := s.cache.PageGeneration(ctx)
+	if err != nil {
+		s.logger.Warnw("Failed to read user page generation from Redis", "error", err)
+		users, err = load(ctx)
+	} else {
+		// Read through the cache; concurrent misses share one DB query, which is cached
+		users, err = s.cache.LoadUserByPagination(ctx, params, generation, load)
 	}
-
-	// Fetch users from the database
-	users, err := s.repo.GetUsers(ctx, params)
 	if err != nil {
 		if errors.Is(err, repository.ErrInvalidDbUserID) || errors.Is(err, repository.ErrFailedToParseUUID) {
 			s.logger.Errorw("GetUsers failed: invalid user data in database",
@@ -219,11 +217,6 @@ func (s *service) GetUsers(ctx context.Context, params domain.GetUsersParams) ([
 		return nil, common.ErrInternalServerError
 	}
 
-	// Store the retrieved users in Redis for future queries
-	if err := s.cache.CacheUserByPagination(ctx, users, params); err != nil {
-		s.logger.Errorw("Failed to store users in Redis", "params", params, "error", err)
-	}
-
 	s.logger.Debugw("Users retrieved successfully", "user_count", len(users), "params", params)
 	return users, nil
 }
@@ -234,8 +227,8 @@ func (s *service) UpdateUser(ctx context.Context, params domain.UpdateUserParams
 	// Clear out old cache entries
 	s.clearUserCache(ctx, params.ID)
 
-	// Update user in the database
-	user, err := s.repo.UpdateUser(ctx, params)
+	// Update user in the database, which returns the previous state for the audit log
+	before, user, err := s.repo.UpdateUser(ctx, params)
 	if err != nil {
 		if errors.Is(err, common.ErrNotFound) {
 			return domain.User{}, common.ErrNotFound
@@ -249,11 +242,25 @@ func (s *service) UpdateUser(ctx context.Context, params domain.UpdateUserParams
 		return domain.User{}, common.ErrInternalServerError
 	}
 
+	// Lookups that found no user with a new email must now find them
+	s.deleteMissingUser(ctx, user)
+
 	// Store updated user in Redis
 	if err := s.cache.CacheUser(ctx, user); err != nil {
 		s.logger.Warnw("Failed to store updated user in Redis", "user_id", user.ID, "error", err)
 	}
 
+	// Cached pages of users may still list the previous state
+	s.invalidateUserPages(ctx, user.ID)
+
+	s.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionUserUpdate,
+		TargetType: auditdomain.TargetUser,
+		TargetID:   user.ID.String(),
+		Before:     before,
+		After:      user,
+	})
+
 	s.logger.Infow("User updated successfully and cache refreshed",
 		"user_id", user.ID,
 		"updated_fields", params,
@@ -267,8 +274,8 @@ func (s *service) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	// Clear out old cache entries
 	s.clearUserCache(ctx, id)
 
-	// Attempt to delete user from the database
-	err := s.repo.DeleteUser(ctx, id)
+	// Attempt to delete user from the database, which returns the deleted row for the audit log
+	before, err := s.repo.DeleteUser(ctx, id)
 	if err != nil {
 		if errors.Is(err, common.ErrNotFound) {
 			return common.ErrNotFound
@@ -281,5 +288,15 @@ func (s *service) DeleteUser(ctx context.Context, id uuid.UUID) error {
 		)
 		return common.ErrInternalServerError
 	}
+
+	// Cached pages of users may still list the deleted user
+	s.invalidateUserPages(ctx, id)
+
+	s.auditor.Record(ctx, auditdomain.RecordParams{
+		Action:     auditdomain.ActionUserDelete,
+		TargetType: auditdomain.TargetUser,
+		TargetID:   id.String(),
+		Before:     before,
+	})
 	return nil
 }
@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 
+	"github.com/henryhall897/golang-todo-app/gen/mocks/auditmock"
 	"github.com/henryhall897/golang-todo-app/gen/mocks/usersmock"
+	auditdomain "github.com/henryhall897/golang-todo-app/internal/audit/domain"
 	"github.com/henryhall897/golang-todo-app/internal/core/common"
 	"github.com/henryhall897/golang-todo-app/internal/users/cache"
 	"github.com/henryhall897/golang-todo-app/internal/users/domain"
@@ -22,10 +25,11 @@ import (
 
 // Global test dependencies
 type ServiceTestSuite struct {
-	mockRepo *usersmock.RepositoryMock
-	Redis    *RedisTestHelper
-	Service  domain.Service
-	ctx      context.Context
+	mockRepo    *usersmock.RepositoryMock
+	mockAuditor *auditmock.AuditorMock
+	Redis       *RedisTestHelper
+	Service     domain.Service
+	ctx         context.Context
 }
 
 // SetupSuite initializes common dependencies
@@ -33,21 +37,33 @@ func SetupSuite() *ServiceTestSuite {
 	logger := zap.NewNop() // No-op logger for tests
 
 	mockRepo := &usersmock.RepositoryMock{}
+	mockAuditor := &auditmock.AuditorMock{
+		RecordFunc: func(ctx context.Context, params auditdomain.RecordParams) {},
+	}
 
 	redis := SetupRedisTest()
 
 	// Initialize Redis cache
 	genericCache := rediswrapper.NewJSONCache(redis.Client, RedisTestPrefix, logger.Sugar())
 	userCache := cache.NewRedisUser(genericCache)
-	Service := New(mockRepo, userCache, logger.Sugar())
+	Service := New(mockRepo, userCache, mockAuditor, logger.Sugar())
 
 	return &ServiceTestSuite{
-		mockRepo: mockRepo,
-		Redis:    redis,
-		Service:  Service,
-		ctx:      context.Background(),
+		mockRepo:    mockRepo,
+		mockAuditor: mockAuditor,
+		Redis:       redis,
+		Service:     Service,
+		ctx:         context.Background(),
 	}
 }
+
+// newAuditor gives the service a fresh mock auditor, so a subtest only sees the entries it records
+func (s *ServiceTestSuite) newAuditor() {
+	s.mockAuditor = &auditmock.AuditorMock{
+		RecordFunc: func(ctx context.Context, params auditdomain.RecordParams) {},
+	}
+	s.Service.(*service).auditor = s.mockAuditor
+}
 func TestCreateUser(t *testing.T) {
 	suite := SetupSuite() // Load shared setup
 	defer suite.Redis.Server.Close()
@@ -62,6 +78,7 @@ func TestCreateUser(t *testing.T) {
 	testUser := testUsers[0]
 
 	t.Run("success - user created", func(t *testing.T) {
+		suite.newAuditor()
 		// Mock successful user creation
 		suite.mockRepo.CreateUserFunc = func(ctx context.Context, params domain.CreateUserParams) (domain.User, error) {
 			return testUser, nil
@@ -73,9 +90,18 @@ func TestCreateUser(t *testing.T) {
 		// Assertions
 		require.NoError(t, err)
 		assert.Equal(t, testUser, user)
+
+		// The creation is recorded in the audit log
+		calls := suite.mockAuditor.RecordCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, auditdomain.ActionUserCreate, calls[0].Params.Action)
+		assert.Equal(t, testUser.ID.String(), calls[0].Params.TargetID)
+		assert.Nil(t, calls[0].Params.Before)
+		assert.Equal(t, testUser, calls[0].Params.After)
 	})
 
 	t.Run("failure - email already exists", func(t *testing.T) {
+		suite.newAuditor()
 		// Mock repository returning ErrEmailAlreadyExists
 		suite.mockRepo.CreateUserFunc = func(ctx context.Context, params domain.CreateUserParams) (domain.User, error) {
 			return domain.User{}, repository.ErrEmailAlreadyExists
@@ -88,6 +114,7 @@ func TestCreateUser(t *testing.T) {
 		require.Error(t, err)
 		assert.True(t, errors.Is(err, ErrEmailAlreadyExists)) // Ensure correct sentinel
 		assert.Equal(t, domain.User{}, user)                  // Should return an empty user
+		assert.Empty(t, suite.mockAuditor.RecordCalls())      // Failed creations are not audited
 	})
 
 	t.Run("failure - internal server error", func(t *testing.T) {
@@ -438,9 +465,18 @@ func TestUpdateUser(t *testing.T) {
 	}
 
 	t.Run("success - user updated", func(t *testing.T) {
+		suite.newAuditor()
+		expected := testUser
+		expected.Name, expected.Email = testUpdateParams.Name, testUpdateParams.Email
+
+		// A stale copy of the user is cached; the previous state comes from the repository's transaction
+		stale := testUser
+		stale.Name = "Stale Name"
+		require.NoError(t, suite.Service.(*service).cache.CacheUser(suite.ctx, stale))
+
 		// Mock successful user update
-		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
-			return testUser, nil
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return testUser, expected, nil
 		}
 
 		// Call the service method
@@ -448,14 +484,21 @@ func TestUpdateUser(t *testing.T) {
 
 		// Assertions
 		require.NoError(t, err)
-		assert.Equal(t, testUser, updatedUser)
+		assert.Equal(t, expected, updatedUser)
+
+		// The update is recorded with the previous state
+		calls := suite.mockAuditor.RecordCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, auditdomain.ActionUserUpdate, calls[0].Params.Action)
+		assert.Equal(t, testUser, calls[0].Params.Before)
+		assert.Equal(t, expected, calls[0].Params.After)
 	})
 
 	t.Run("failure - user not found", func(t *testing.T) {
 		suite.Redis.Server.FlushAll() // Clear Redis cache
 		// Mock repository returning ErrNotFound
-		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
-			return domain.User{}, common.ErrNotFound
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return domain.User{}, domain.User{}, common.ErrNotFound
 		}
 
 		// Call the service method
@@ -470,8 +513,8 @@ func TestUpdateUser(t *testing.T) {
 	t.Run("failure - invalid user data in DB", func(t *testing.T) {
 		suite.Redis.Server.FlushAll() // Clear Redis cache
 		// Mock repository returning ErrInvalidDbUserID
-		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
-			return domain.User{}, repository.ErrInvalidDbUserID
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return domain.User{}, domain.User{}, repository.ErrInvalidDbUserID
 		}
 
 		// Call the service method
@@ -486,8 +529,8 @@ func TestUpdateUser(t *testing.T) {
 	t.Run("failure - failed to parse UUID", func(t *testing.T) {
 		suite.Redis.Server.FlushAll() // Clear Redis cache
 		// Mock repository returning ErrFailedToParseUUID
-		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
-			return domain.User{}, repository.ErrFailedToParseUUID
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return domain.User{}, domain.User{}, repository.ErrFailedToParseUUID
 		}
 
 		// Call the service method
@@ -502,8 +545,8 @@ func TestUpdateUser(t *testing.T) {
 	t.Run("failure - unexpected error", func(t *testing.T) {
 		suite.Redis.Server.FlushAll() // Clear Redis cache
 		// Mock repository returning an unknown error
-		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, error) {
-			return domain.User{}, errors.New("database timeout")
+		suite.mockRepo.UpdateUserFunc = func(ctx context.Context, params domain.UpdateUserParams) (domain.User, domain.User, error) {
+			return domain.User{}, domain.User{}, errors.New("database timeout")
 		}
 
 		// Call the service method
@@ -530,22 +573,48 @@ func TestDeleteUser(t *testing.T) {
 	}
 
 	t.Run("success - user deleted", func(t *testing.T) {
+		suite.newAuditor()
 		// Mock successful user deletion
-		suite.mockRepo.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) error {
-			return nil
+		suite.mockRepo.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return testUser, nil
 		}
 
+		// Cache the user's email pointer and a page listing them
+		emailKey := RedisFullKey(domain.CacheKeyByEmail(testUser.Email))
+		require.NoError(t, suite.Redis.Server.Set(emailKey, domain.CacheKeyByID(testUserID)))
+		pageParams := domain.GetUsersParams{Limit: domain.DefaultLimit, Offset: domain.DefaultOffset}
+		generation, err := suite.Service.(*service).cache.PageGeneration(suite.ctx)
+		require.NoError(t, err)
+		require.NoError(t, suite.Service.(*service).cache.CacheUserByPagination(suite.ctx, []domain.User{testUser}, pageParams, generation))
+
 		// Call the service method
-		err := suite.Service.DeleteUser(suite.ctx, testUserID)
+		err = suite.Service.DeleteUser(suite.ctx, testUserID)
 
 		// Assertions
 		require.NoError(t, err)
+
+		// Every cached entry that could return the user is gone or no longer read
+		assert.False(t, suite.Redis.Server.Exists(emailKey))
+		_, err = suite.Service.(*service).cache.GetUserByPagination(suite.ctx, pageParams, generation+1)
+		assert.ErrorIs(t, err, redis.Nil)
+		current, err := suite.Service.(*service).cache.PageGeneration(suite.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, generation+1, current)
+
+		// The deletion is recorded with the previous state
+		calls := suite.mockAuditor.RecordCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, auditdomain.ActionUserDelete, calls[0].Params.Action)
+		assert.Equal(t, testUserID.String(), calls[0].Params.TargetID)
+		// The previous state is the row the repository deleted
+		assert.Equal(t, testUser, calls[0].Params.Before)
+		assert.Nil(t, calls[0].Params.After)
 	})
 
 	t.Run("failure - user not found", func(t *testing.T) {
 		// Mock repository returning ErrNotFound
-		suite.mockRepo.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) error {
-			return common.ErrNotFound
+		suite.mockRepo.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.User{}, common.ErrNotFound
 		}
 
 		// Call the service method
@@ -558,8 +627,8 @@ func TestDeleteUser(t *testing.T) {
 
 	t.Run("failure - internal server error", func(t *testing.T) {
 		// Mock repository returning an unknown error
-		suite.mockRepo.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) error {
-			return errors.New("database timeout")
+		suite.mockRepo.DeleteUserFunc = func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.User{}, errors.New("database timeout")
 		}
 
 		// Call the service method
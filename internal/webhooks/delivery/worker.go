@@ -0,0 +1,171 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"go.uber.org/zap"
+)
+
+// maxDrainBytes bounds how much of a receiver's response is read before the connection is reused
+const maxDrainBytes = 64 << 10
+
+// Store leases pending deliveries and records the outcome of each attempt.
+type Store interface {
+	ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.PendingDelivery, error)
+	RecordAttempt(ctx context.Context, params domain.RecordAttemptParams) error
+}
+
+// Worker POSTs queued webhook deliveries to their subscribers.
+// Deliveries are leased before sending, so several replicas can run workers against the same queue;
+// a worker that dies mid-batch leaves its leases to expire and the deliveries are retried.
+// A 2xx response completes a delivery, anything else is retried with exponential backoff
+// until the delivery has been attempted domain.MaxAttempts times.
+type Worker struct {
+	store  Store
+	client *http.Client
+	logger *zap.SugaredLogger
+	now    func() time.Time
+}
+
+// NewWorker initializes a Worker that sends deliveries with the given HTTP client.
+// A nil client uses NewClient, which only reaches public addresses.
+func NewWorker(store Store, client *http.Client, logger *zap.SugaredLogger) *Worker {
+	if client == nil {
+		client = NewClient()
+	}
+	return &Worker{
+		store:  store,
+		client: client,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Name identifies the job in logs.
+func (w *Worker) Name() string {
+	return "webhook-deliveries"
+}
+
+// Run sends batches of due deliveries until the queue is drained or ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) error {
+	total := 0
+	for ctx.Err() == nil {
+		now := w.now()
+		batch, err := w.store.ClaimDeliveries(ctx, now, now.Add(domain.LeaseDuration), domain.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		var wg sync.WaitGroup
+		for _, pending := range batch {
+			wg.Add(1)
+			go func(pending domain.PendingDelivery) {
+				defer wg.Done()
+				w.deliver(ctx, pending)
+			}(pending)
+		}
+		wg.Wait()
+
+		total += len(batch)
+	}
+
+	if total > 0 {
+		w.logger.Infow("Webhook deliveries attempted", "count", total)
+	}
+	return ctx.Err()
+}
+
+// deliver sends a single delivery and records the outcome.
+func (w *Worker) deliver(ctx context.Context, pending domain.PendingDelivery) {
+	statusCode, sendErr := w.send(ctx, pending)
+
+	attempt := domain.RecordAttemptParams{
+		ID:         pending.ID,
+		StatusCode: statusCode,
+	}
+	now := w.now()
+	attempts := pending.Attempts + 1
+
+	switch {
+	case sendErr == nil:
+		attempt.Status = domain.StatusSucceeded
+		attempt.NextAttemptAt = now
+		attempt.DeliveredAt = &now
+	case attempts >= domain.MaxAttempts:
+		attempt.Status = domain.StatusFailed
+		attempt.NextAttemptAt = now
+		attempt.Error = sendErr.Error()
+	default:
+		attempt.Status = domain.StatusPending
+		attempt.NextAttemptAt = now.Add(Backoff(attempts))
+		attempt.Error = sendErr.Error()
+	}
+
+	if err := w.store.RecordAttempt(ctx, attempt); err != nil {
+		// The lease expires and the delivery is sent again, so receivers must tolerate duplicates
+		w.logger.Errorw("Webhook delivery failed: could not record attempt", "delivery_id", pending.ID, "error", err)
+		return
+	}
+
+	if sendErr != nil {
+		w.logger.Warnw("Webhook delivery attempt failed",
+			"delivery_id", pending.ID,
+			"event_type", pending.EventType,
+			"attempts", attempts,
+			"status", attempt.Status,
+			"error", sendErr,
+		)
+	}
+}
+
+// send POSTs the signed payload, returning the response status code, if any,
+// and an error unless the receiver answered with a 2xx status.
+func (w *Worker) send(ctx context.Context, pending domain.PendingDelivery) (int, error) {
+	timestamp := strconv.FormatInt(w.now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pending.URL, bytes.NewReader(pending.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(domain.HeaderEvent, pending.EventType)
+	req.Header.Set(domain.HeaderDelivery, pending.ID.String())
+	req.Header.Set(domain.HeaderTimestamp, timestamp)
+	req.Header.Set(domain.HeaderSignature, Sign(pending.Secret, timestamp, pending.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Backoff returns the delay before retrying a delivery that has failed the given number of attempts.
+func Backoff(attempts int) time.Duration {
+	delay := domain.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= domain.MaxBackoff {
+			return domain.MaxBackoff
+		}
+	}
+	return delay
+}
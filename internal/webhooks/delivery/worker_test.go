@@ -0,0 +1,251 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryStore is an in-memory delivery queue that hands out each pending delivery once
+type memoryStore struct {
+	mu        sync.Mutex
+	pending   []domain.PendingDelivery
+	attempts  []domain.RecordAttemptParams
+	claimErr  error
+	recordErr error
+}
+
+func (s *memoryStore) ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.PendingDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claimErr != nil {
+		return nil, s.claimErr
+	}
+	n := min(limit, len(s.pending))
+	batch := s.pending[:n]
+	s.pending = s.pending[n:]
+	return batch, nil
+}
+
+func (s *memoryStore) RecordAttempt(ctx context.Context, params domain.RecordAttemptParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts = append(s.attempts, params)
+	return s.recordErr
+}
+
+func (s *memoryStore) attempt(id uuid.UUID) (domain.RecordAttemptParams, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attempt := range s.attempts {
+		if attempt.ID == id {
+			return attempt, true
+		}
+	}
+	return domain.RecordAttemptParams{}, false
+}
+
+func newPending(url string, attempts int) domain.PendingDelivery {
+	return domain.PendingDelivery{
+		ID:        uuid.New(),
+		EventID:   uuid.New(),
+		EventType: domain.EventTaskCreated,
+		Payload:   []byte(`{"type":"task.created"}`),
+		Attempts:  attempts,
+		URL:       url,
+		Secret:    "s3cret",
+	}
+}
+
+func TestWorkerRun(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success - signed delivery recorded as succeeded", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		pending := newPending(receiver.URL, 0)
+		store := &memoryStore{pending: []domain.PendingDelivery{pending}}
+		worker := NewWorker(store, receiver.Client(), zap.NewNop().Sugar())
+		worker.now = func() time.Time { return now }
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		require.NotNil(t, received)
+		assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+		assert.Equal(t, domain.EventTaskCreated, received.Header.Get(domain.HeaderEvent))
+		assert.Equal(t, pending.ID.String(), received.Header.Get(domain.HeaderDelivery))
+		timestamp := received.Header.Get(domain.HeaderTimestamp)
+		assert.Equal(t, "1792324800", timestamp)
+		assert.True(t, Verify("s3cret", timestamp, body, received.Header.Get(domain.HeaderSignature)))
+		assert.Equal(t, pending.Payload, body)
+
+		attempt, ok := store.attempt(pending.ID)
+		require.True(t, ok)
+		assert.Equal(t, domain.StatusSucceeded, attempt.Status)
+		assert.Equal(t, http.StatusNoContent, attempt.StatusCode)
+		require.NotNil(t, attempt.DeliveredAt)
+		assert.Empty(t, attempt.Error)
+	})
+
+	t.Run("failure - error response retried with backoff", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		pending := newPending(receiver.URL, 2)
+		store := &memoryStore{pending: []domain.PendingDelivery{pending}}
+		worker := NewWorker(store, receiver.Client(), zap.NewNop().Sugar())
+		worker.now = func() time.Time { return now }
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		attempt, ok := store.attempt(pending.ID)
+		require.True(t, ok)
+		assert.Equal(t, domain.StatusPending, attempt.Status)
+		assert.Equal(t, http.StatusInternalServerError, attempt.StatusCode)
+		assert.Equal(t, now.Add(Backoff(3)), attempt.NextAttemptAt)
+		assert.Contains(t, attempt.Error, "500")
+		assert.Nil(t, attempt.DeliveredAt)
+	})
+
+	t.Run("failure - last attempt marks delivery failed", func(t *testing.T) {
+		pending := newPending("http://127.0.0.1:1/unreachable", domain.MaxAttempts-1)
+		store := &memoryStore{pending: []domain.PendingDelivery{pending}}
+		worker := NewWorker(store, nil, zap.NewNop().Sugar())
+		worker.now = func() time.Time { return now }
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		attempt, ok := store.attempt(pending.ID)
+		require.True(t, ok)
+		assert.Equal(t, domain.StatusFailed, attempt.Status)
+		assert.Zero(t, attempt.StatusCode)
+		assert.NotEmpty(t, attempt.Error)
+	})
+
+	t.Run("failure - non-public targets blocked", func(t *testing.T) {
+		hits := 0
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits++
+		}))
+		defer receiver.Close()
+
+		store := &memoryStore{}
+		for _, target := range []string{
+			receiver.URL,
+			"http://10.0.0.1/hook",
+			"http://169.254.169.254/latest/meta-data/",
+			"http://[::1]:9000/hook",
+			"http://[::ffff:127.0.0.1]:9000/hook",
+		} {
+			store.pending = append(store.pending, newPending(target, 0))
+		}
+		worker := NewWorker(store, nil, zap.NewNop().Sugar())
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		assert.Zero(t, hits)
+		require.Len(t, store.attempts, 5)
+		for _, attempt := range store.attempts {
+			assert.Equal(t, domain.StatusPending, attempt.Status)
+			assert.Zero(t, attempt.StatusCode)
+			assert.Contains(t, attempt.Error, ErrBlockedAddress.Error())
+		}
+	})
+
+	t.Run("failure - redirects not followed", func(t *testing.T) {
+		redirected := false
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/internal" {
+				redirected = true
+				return
+			}
+			http.Redirect(w, r, "/internal", http.StatusFound)
+		}))
+		defer receiver.Close()
+
+		// The default client's redirect policy over a transport allowed to reach the test server
+		client := NewClient()
+		client.Transport = receiver.Client().Transport
+
+		pending := newPending(receiver.URL+"/hook", 0)
+		store := &memoryStore{pending: []domain.PendingDelivery{pending}}
+		worker := NewWorker(store, client, zap.NewNop().Sugar())
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		assert.False(t, redirected)
+		attempt, ok := store.attempt(pending.ID)
+		require.True(t, ok)
+		assert.Equal(t, domain.StatusPending, attempt.Status)
+		assert.Equal(t, http.StatusFound, attempt.StatusCode)
+	})
+
+	t.Run("success - queue drained across batches", func(t *testing.T) {
+		var mu sync.Mutex
+		hits := 0
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			hits++
+			mu.Unlock()
+		}))
+		defer receiver.Close()
+
+		store := &memoryStore{}
+		for range domain.BatchSize + 5 {
+			store.pending = append(store.pending, newPending(receiver.URL, 0))
+		}
+		worker := NewWorker(store, receiver.Client(), zap.NewNop().Sugar())
+
+		require.NoError(t, worker.Run(context.Background()))
+
+		assert.Equal(t, domain.BatchSize+5, hits)
+		assert.Len(t, store.attempts, domain.BatchSize+5)
+	})
+
+	t.Run("failure - claim error returned", func(t *testing.T) {
+		store := &memoryStore{claimErr: errors.New("database timeout")}
+		worker := NewWorker(store, nil, zap.NewNop().Sugar())
+
+		assert.Error(t, worker.Run(context.Background()))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, domain.BaseBackoff, Backoff(1))
+	assert.Equal(t, 2*domain.BaseBackoff, Backoff(2))
+	assert.Equal(t, 8*domain.BaseBackoff, Backoff(4))
+	assert.Equal(t, domain.MaxBackoff, Backoff(30))
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := Sign("s3cret", "1700000000", body)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, Verify("s3cret", "1700000000", body, signature))
+	assert.False(t, Verify("other", "1700000000", body, signature))
+	assert.False(t, Verify("s3cret", "1700000001", body, signature))
+	assert.False(t, Verify("s3cret", "1700000000", []byte(`{"id":"2"}`), signature))
+	assert.False(t, Verify("s3cret", "1700000000", body, signature[len("sha256="):]))
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/events"
+)
+
+// Event types delivered to webhook subscribers, the domain events of lists and tasks
+const (
+	EventTaskCreated   = events.TaskCreated
+	EventTaskUpdated   = events.TaskUpdated
+	EventTaskCompleted = events.TaskCompleted
+	EventTaskDeleted   = events.TaskDeleted
+	EventListCreated   = events.ListCreated
+	EventListUpdated   = events.ListUpdated
+	EventListDeleted   = events.ListDeleted
+)
+
+// EventTypes lists every event type a subscription may select.
+var EventTypes = []string{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskCompleted,
+	EventTaskDeleted,
+	EventListCreated,
+	EventListUpdated,
+	EventListDeleted,
+}
+
+// Delivery statuses. A delivery stays pending until it succeeds or exhausts its attempts.
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Headers sent with every delivery. The signature is "sha256=" followed by the hex-encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret.
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+const (
+	DefaultLimit  = 20
+	DefaultOffset = 0
+	MaxLimit      = 100
+)
+
+// Delivery retry policy. Failed attempts back off exponentially from BaseBackoff up to MaxBackoff;
+// a delivery is marked failed once it has been attempted MaxAttempts times.
+const (
+	MaxAttempts    = 8
+	BaseBackoff    = 30 * time.Second
+	MaxBackoff     = 6 * time.Hour
+	LeaseDuration  = time.Minute
+	BatchSize      = 20
+	RequestTimeout = 10 * time.Second
+)
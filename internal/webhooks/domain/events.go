@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"github.com/henryhall897/golang-todo-app/internal/events"
+)
+
+// NewEvent builds the webhook payload of a domain event. The event ID is kept,
+// so subscribers can correlate deliveries with events seen elsewhere.
+func NewEvent(event events.Event) Event {
+	return Event{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.OccurredAt,
+		Data:      event.Data,
+	}
+}
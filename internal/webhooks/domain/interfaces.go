@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the methods required for webhook subscription and delivery storage.
+//
+//go:generate moq -out=../../../gen/mocks/webhooksmock/webhooks_repo_mock.go -pkg=webhooksmock . Repository
+type Repository interface {
+	CreateSubscription(ctx context.Context, params CreateSubscriptionParams) (Subscription, error)
+	GetSubscription(ctx context.Context, id, userID uuid.UUID) (Subscription, error)
+	ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]Subscription, error)
+	DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error
+	ListDeliveries(ctx context.Context, params ListDeliveriesParams) ([]Delivery, error)
+	ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]PendingDelivery, error)
+	RecordAttempt(ctx context.Context, params RecordAttemptParams) error
+}
+
+//go:generate moq -out=../../../gen/mocks/webhooksmock/webhooks_service_mock.go -pkg=webhooksmock . Service
+type Service interface {
+	CreateSubscription(ctx context.Context, params CreateSubscriptionParams) (Subscription, error)
+	GetSubscription(ctx context.Context, id, userID uuid.UUID) (Subscription, error)
+	ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]Subscription, error)
+	DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error
+	ListDeliveries(ctx context.Context, params ListDeliveriesParams) ([]Delivery, error)
+}
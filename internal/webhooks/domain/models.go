@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription is a user's registered webhook endpoint.
+// The secret is only returned when the subscription is created.
+type Subscription struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CreateSubscriptionParams holds the values of a new subscription.
+// An empty secret is generated; empty event types subscribe to every event.
+type CreateSubscriptionParams struct {
+	UserID     uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// Event is the JSON document POSTed to subscribers.
+type Event struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Delivery is a single event queued for a subscription, with the outcome of its latest attempt.
+type Delivery struct {
+	ID             uuid.UUID       `json:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode *int32          `json:"last_status_code"`
+	LastError      *string         `json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// PendingDelivery is a delivery leased to a worker, along with its destination.
+type PendingDelivery struct {
+	ID        uuid.UUID
+	EventID   uuid.UUID
+	EventType string
+	Payload   []byte
+	Attempts  int
+	URL       string
+	Secret    string
+}
+
+// RecordAttemptParams holds the outcome of a delivery attempt.
+// StatusCode is zero when no response was received.
+type RecordAttemptParams struct {
+	ID            uuid.UUID
+	Status        string
+	NextAttemptAt time.Time
+	StatusCode    int
+	Error         string
+	DeliveredAt   *time.Time
+}
+
+// ListDeliveriesParams defines the filters for a subscription's delivery log. An empty status is ignored.
+type ListDeliveriesParams struct {
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	Status         string
+	Limit          int
+	Offset         int
+}
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type Handler struct {
+	service domain.Service
+	logger  *zap.SugaredLogger
+}
+
+// New initializes a new webhooks Handler instance
+func New(service domain.Service, logger *zap.SugaredLogger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreateSubscriptionHandler registers a webhook endpoint for the caller.
+// The response is the only place the subscription's signing secret is returned.
+func (h *Handler) CreateSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	// The caller must be identified to register a webhook
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw("CreateSubscriptionHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Parse the request body
+	var payload struct {
+		URL        string   `json:"url"`
+		Secret     string   `json:"secret"`
+		EventTypes []string `json:"event_types"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.logger.Warnw("CreateSubscriptionHandler failed: invalid request body", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// Call the service layer
+	subscription, err := h.service.CreateSubscription(r.Context(), domain.CreateSubscriptionParams{
+		UserID:     callerID,
+		URL:        payload.URL,
+		Secret:     payload.Secret,
+		EventTypes: payload.EventTypes,
+	})
+	if err != nil {
+		if errors.Is(err, common.ErrValidation) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return the created subscription as JSON
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		h.logger.Errorw("CreateSubscriptionHandler failed: failed to encode response", "id", subscription.ID, "error", err)
+	}
+}
+
+// ListSubscriptionsHandler lists the caller's webhook subscriptions
+func (h *Handler) ListSubscriptionsHandler(w http.ResponseWriter, r *http.Request) {
+	// The caller must be identified to list their webhooks
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw("ListSubscriptionsHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Call the service layer
+	subscriptions, err := h.service.ListSubscriptions(r.Context(), callerID)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Ensure an empty array instead of nil
+	if len(subscriptions) == 0 {
+		subscriptions = []domain.Subscription{}
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(subscriptions); err != nil {
+		h.logger.Errorw("ListSubscriptionsHandler failed: failed to encode response", "user_id", callerID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// GetSubscriptionHandler retrieves one of the caller's webhook subscriptions
+func (h *Handler) GetSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	// The caller must be identified to read a webhook
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw("GetSubscriptionHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated subscription ID from context
+	id, ok := r.Context().Value(subscriptionIDKey).(uuid.UUID)
+	if !ok || id == uuid.Nil {
+		h.logger.Errorw("GetSubscriptionHandler failed: missing or invalid subscription ID in context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Call the service layer
+	subscription, err := h.service.GetSubscription(r.Context(), id, callerID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		h.logger.Errorw("GetSubscriptionHandler failed: failed to encode response", "id", id, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// DeleteSubscriptionHandler removes one of the caller's webhook subscriptions
+func (h *Handler) DeleteSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	// The caller must be identified to delete a webhook
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		h.logger.Warnw("DeleteSubscriptionHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated subscription ID from context
+	id, ok := r.Context().Value(subscriptionIDKey).(uuid.UUID)
+	if !ok || id == uuid.Nil {
+		h.logger.Errorw("DeleteSubscriptionHandler failed: missing or invalid subscription ID in context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Call the service layer
+	if err := h.service.DeleteSubscription(r.Context(), id, callerID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveriesHandler returns the delivery log of one of the caller's webhook subscriptions.
+// Supported filters: status, limit, offset.
+func (h *Handler) ListDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger
+	query := r.URL.Query()
+
+	// The caller must be identified to read a delivery log
+	callerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		logger.Warnw("ListDeliveriesHandler failed: missing caller identity")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// Extract validated subscription ID from context
+	id, ok := r.Context().Value(subscriptionIDKey).(uuid.UUID)
+	if !ok || id == uuid.Nil {
+		logger.Errorw("ListDeliveriesHandler failed: missing or invalid subscription ID in context")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	params := domain.ListDeliveriesParams{
+		SubscriptionID: id,
+		UserID:         callerID,
+		Status:         query.Get("status"),
+		Limit:          domain.DefaultLimit,
+		Offset:         domain.DefaultOffset,
+	}
+
+	// Validate status parameter if provided
+	if params.Status != "" && !slices.Contains([]string{domain.StatusPending, domain.StatusSucceeded, domain.StatusFailed}, params.Status) {
+		logger.Warnw("ListDeliveriesHandler failed: invalid status parameter", "status", params.Status)
+		http.Error(w, "Invalid status parameter", http.StatusBadRequest)
+		return
+	}
+
+	// Parse limit parameter if provided
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > domain.MaxLimit {
+			logger.Warnw("ListDeliveriesHandler failed: invalid limit parameter", "limit", limitStr)
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		params.Limit = parsedLimit
+	}
+
+	// Parse offset parameter if provided
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.ParseInt(offsetStr, 10, 32)
+		if err != nil || parsedOffset < 0 {
+			logger.Warnw("ListDeliveriesHandler failed: invalid offset parameter", "offset", offsetStr)
+			http.Error(w, "Invalid offset parameter", http.StatusBadRequest)
+			return
+		}
+		params.Offset = int(parsedOffset)
+	}
+
+	// Call the service layer
+	deliveries, err := h.service.ListDeliveries(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Ensure an empty array instead of nil
+	if len(deliveries) == 0 {
+		deliveries = []domain.Delivery{}
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		logger.Errorw("ListDeliveriesHandler failed: failed to encode response", "params", params, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
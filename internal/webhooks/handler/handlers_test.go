@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/webhooksmock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/middleware/testutils"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// HandlerTestSuite holds shared test dependencies
+type HandlerTestSuite struct {
+	mockService *webhooksmock.ServiceMock
+	router      http.Handler
+	callerID    uuid.UUID
+}
+
+// SetupSuite wires the handlers behind the identity middleware
+func SetupSuite() *HandlerTestSuite {
+	mockService := &webhooksmock.ServiceMock{}
+	handler := New(mockService, zap.NewNop().Sugar())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhooks", handler.CreateSubscriptionHandler)
+	mux.HandleFunc("GET /webhooks", handler.ListSubscriptionsHandler)
+	mux.HandleFunc("GET /webhooks/{id}", VerifySubscriptionPath(handler.GetSubscriptionHandler))
+	mux.HandleFunc("DELETE /webhooks/{id}", VerifySubscriptionPath(handler.DeleteSubscriptionHandler))
+	mux.HandleFunc("GET /webhooks/{id}/deliveries", VerifySubscriptionPath(handler.ListDeliveriesHandler))
+
+	return &HandlerTestSuite{
+		mockService: mockService,
+		router:      testutils.Identity(mux),
+		callerID:    uuid.New(),
+	}
+}
+
+func (s *HandlerTestSuite) do(method, path string, body io.Reader, callerID uuid.UUID) *httptest.ResponseRecorder {
+	req := testutils.NewRequest(method, path, body, callerID)
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCreateSubscriptionHandler(t *testing.T) {
+	t.Run("success - created with secret", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.CreateSubscriptionFunc = func(ctx context.Context, params domain.CreateSubscriptionParams) (domain.Subscription, error) {
+			assert.Equal(t, suite.callerID, params.UserID)
+			assert.Equal(t, "https://example.com/hook", params.URL)
+			assert.Equal(t, []string{domain.EventTaskCreated}, params.EventTypes)
+			return domain.Subscription{ID: uuid.New(), UserID: params.UserID, URL: params.URL, Secret: "generated", EventTypes: params.EventTypes}, nil
+		}
+
+		rr := suite.do(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"https://example.com/hook","event_types":["task.created"]}`), suite.callerID)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var subscription domain.Subscription
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &subscription))
+		assert.Equal(t, "generated", subscription.Secret)
+	})
+
+	t.Run("failure - validation error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.CreateSubscriptionFunc = func(ctx context.Context, params domain.CreateSubscriptionParams) (domain.Subscription, error) {
+			return domain.Subscription{}, fmt.Errorf("invalid webhook url: %w", common.ErrValidation)
+		}
+
+		rr := suite.do(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"nope"}`), suite.callerID)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure - invalid body", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodPost, "/webhooks", strings.NewReader(`{`), suite.callerID)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, suite.mockService.CreateSubscriptionCalls())
+	})
+
+	t.Run("failure - anonymous caller", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"https://example.com"}`), uuid.Nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestListSubscriptionsHandler(t *testing.T) {
+	t.Run("success - empty result", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.ListSubscriptionsFunc = func(ctx context.Context, userID uuid.UUID) ([]domain.Subscription, error) {
+			assert.Equal(t, suite.callerID, userID)
+			return nil, nil
+		}
+
+		rr := suite.do(http.MethodGet, "/webhooks", nil, suite.callerID)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, "[]", rr.Body.String())
+	})
+}
+
+func TestGetAndDeleteSubscriptionHandler(t *testing.T) {
+	t.Run("success - get", func(t *testing.T) {
+		suite := SetupSuite()
+		id := uuid.New()
+		suite.mockService.GetSubscriptionFunc = func(ctx context.Context, gotID, userID uuid.UUID) (domain.Subscription, error) {
+			assert.Equal(t, id, gotID)
+			assert.Equal(t, suite.callerID, userID)
+			return domain.Subscription{ID: id}, nil
+		}
+
+		rr := suite.do(http.MethodGet, "/webhooks/"+id.String(), nil, suite.callerID)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "secret")
+	})
+
+	t.Run("success - delete", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.DeleteSubscriptionFunc = func(ctx context.Context, id, userID uuid.UUID) error {
+			return nil
+		}
+
+		rr := suite.do(http.MethodDelete, "/webhooks/"+uuid.NewString(), nil, suite.callerID)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("failure - not found", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.GetSubscriptionFunc = func(ctx context.Context, id, userID uuid.UUID) (domain.Subscription, error) {
+			return domain.Subscription{}, common.ErrNotFound
+		}
+		suite.mockService.DeleteSubscriptionFunc = func(ctx context.Context, id, userID uuid.UUID) error {
+			return common.ErrNotFound
+		}
+
+		assert.Equal(t, http.StatusNotFound, suite.do(http.MethodGet, "/webhooks/"+uuid.NewString(), nil, suite.callerID).Code)
+		assert.Equal(t, http.StatusNotFound, suite.do(http.MethodDelete, "/webhooks/"+uuid.NewString(), nil, suite.callerID).Code)
+	})
+
+	t.Run("failure - invalid id", func(t *testing.T) {
+		suite := SetupSuite()
+		rr := suite.do(http.MethodGet, "/webhooks/nope", nil, suite.callerID)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestListDeliveriesHandler(t *testing.T) {
+	t.Run("success - filters passed to service", func(t *testing.T) {
+		suite := SetupSuite()
+		id := uuid.New()
+		suite.mockService.ListDeliveriesFunc = func(ctx context.Context, params domain.ListDeliveriesParams) ([]domain.Delivery, error) {
+			assert.Equal(t, id, params.SubscriptionID)
+			assert.Equal(t, suite.callerID, params.UserID)
+			assert.Equal(t, domain.StatusFailed, params.Status)
+			assert.Equal(t, 5, params.Limit)
+			assert.Equal(t, 10, params.Offset)
+			return []domain.Delivery{{ID: uuid.New(), Status: domain.StatusFailed, Payload: json.RawMessage(`{}`)}}, nil
+		}
+
+		rr := suite.do(http.MethodGet, "/webhooks/"+id.String()+"/deliveries?status=failed&limit=5&offset=10", nil, suite.callerID)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var deliveries []domain.Delivery
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &deliveries))
+		assert.Len(t, deliveries, 1)
+	})
+
+	t.Run("failure - invalid filters", func(t *testing.T) {
+		suite := SetupSuite()
+		for _, query := range []string{"status=lost", "limit=0", "limit=1000", "offset=-1"} {
+			rr := suite.do(http.MethodGet, "/webhooks/"+uuid.NewString()+"/deliveries?"+query, nil, suite.callerID)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+		assert.Empty(t, suite.mockService.ListDeliveriesCalls())
+	})
+
+	t.Run("failure - service error", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.mockService.ListDeliveriesFunc = func(ctx context.Context, params domain.ListDeliveriesParams) ([]domain.Delivery, error) {
+			return nil, errors.New("database timeout")
+		}
+
+		rr := suite.do(http.MethodGet, "/webhooks/"+uuid.NewString()+"/deliveries", nil, suite.callerID)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
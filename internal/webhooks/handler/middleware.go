@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/logging"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const subscriptionIDKey = contextKey("subscriptionID")
+
+// VerifySubscriptionPath extracts and validates the subscription UUID from `/webhooks/{id}/...`
+func VerifySubscriptionPath(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Retrieve logger from context
+		logger := logging.GetLogger(r.Context())
+
+		// Extract ID from URL path
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 2 || segments[0] != "webhooks" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(segments[1])
+		if err != nil || id == uuid.Nil {
+			logger.Warnw("VerifySubscriptionPath failed: invalid subscription ID", "id", segments[1])
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		// Store validated UUID in request context and proceed
+		ctx := context.WithValue(r.Context(), subscriptionIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
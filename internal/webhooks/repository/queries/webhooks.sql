@@ -0,0 +1,65 @@
+-- Register a webhook endpoint for a user
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (user_id, url, secret, event_types)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- Retrieve a webhook subscription, ensuring it belongs to the user
+-- name: GetWebhookSubscription :one
+SELECT *
+FROM webhook_subscriptions
+WHERE id = $1 AND user_id = $2;
+
+-- List a user's webhook subscriptions, newest first
+-- name: ListWebhookSubscriptions :many
+SELECT *
+FROM webhook_subscriptions
+WHERE user_id = $1
+ORDER BY created_at DESC, id;
+
+-- Delete a webhook subscription and its deliveries
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1 AND user_id = $2;
+
+-- Lease due pending deliveries to this worker until lease_until.
+-- Rows locked by another worker are skipped; an expired lease makes a delivery due again.
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg('lease_until'),
+    updated_at = CURRENT_TIMESTAMP
+FROM webhook_subscriptions
+WHERE webhook_deliveries.subscription_id = webhook_subscriptions.id
+  AND webhook_deliveries.id IN (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status = 'pending'
+      AND next_attempt_at <= sqlc.arg('now')
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg('max_deliveries')
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING webhook_deliveries.id, webhook_deliveries.event_id, webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.attempts, webhook_subscriptions.url, webhook_subscriptions.secret;
+
+-- Record the outcome of a delivery attempt
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    next_attempt_at = $3,
+    last_status_code = $4,
+    last_error = $5,
+    delivered_at = $6,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- List a subscription's deliveries, newest first, optionally filtered by status
+-- name: ListWebhookDeliveries :many
+SELECT webhook_deliveries.*
+FROM webhook_deliveries
+JOIN webhook_subscriptions ON webhook_deliveries.subscription_id = webhook_subscriptions.id
+WHERE webhook_deliveries.subscription_id = $1
+  AND webhook_subscriptions.user_id = $2
+  AND ($3::text IS NULL OR webhook_deliveries.status = $3)
+ORDER BY webhook_deliveries.created_at DESC, webhook_deliveries.id DESC
+LIMIT $4 OFFSET $5;
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/webhookstore"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type repository struct {
+	pool  *pgxpool.Pool
+	query *webhookstore.Queries
+}
+
+func New(pool *pgxpool.Pool) *repository {
+	return &repository{
+		pool:  pool,
+		query: webhookstore.New(pool),
+	}
+}
+
+// CreateSubscription registers a webhook endpoint for a user.
+func (r *repository) CreateSubscription(ctx context.Context, params domain.CreateSubscriptionParams) (domain.Subscription, error) {
+	pgParams, err := createSubscriptionParamsToPG(params)
+	if err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to transform subscription params: %w", err)
+	}
+
+	subscription, err := r.query.CreateWebhookSubscription(ctx, pgParams)
+	if err != nil {
+		// 23503 is PostgreSQL's foreign key violation error code: the user does not exist
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			return domain.Subscription{}, fmt.Errorf("user %s: %w", params.UserID, common.ErrNotFound)
+		}
+		return domain.Subscription{}, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return pgToSubscription(subscription)
+}
+
+// GetSubscription retrieves a webhook subscription owned by the user.
+func (r *repository) GetSubscription(ctx context.Context, id, userID uuid.UUID) (domain.Subscription, error) {
+	pgID, _ := common.ToPgUUID(id)
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	subscription, err := r.query.GetWebhookSubscription(ctx, webhookstore.GetWebhookSubscriptionParams{ID: pgID, UserID: pgUserID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Subscription{}, fmt.Errorf("webhook subscription %s: %w", id, common.ErrNotFound)
+	} else if err != nil {
+		return domain.Subscription{}, fmt.Errorf("webhook subscription %s: %w", id, common.ErrInternalServerError)
+	}
+
+	return pgToSubscription(subscription)
+}
+
+// ListSubscriptions retrieves a user's webhook subscriptions, newest first.
+func (r *repository) ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]domain.Subscription, error) {
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	subscriptions, err := r.query.ListWebhookSubscriptions(ctx, pgUserID)
+	if err != nil {
+		return nil, fmt.Errorf("webhook subscriptions: %w", common.ErrInternalServerError)
+	}
+
+	results := make([]domain.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result, err := pgToSubscription(subscription)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// DeleteSubscription removes a webhook subscription owned by the user, along with its deliveries.
+func (r *repository) DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error {
+	pgID, _ := common.ToPgUUID(id)
+	pgUserID, _ := common.ToPgUUID(userID)
+
+	rows, err := r.query.DeleteWebhookSubscription(ctx, webhookstore.DeleteWebhookSubscriptionParams{ID: pgID, UserID: pgUserID})
+	if err != nil {
+		return fmt.Errorf("webhook subscription %s: %w", id, common.ErrInternalServerError)
+	}
+	if rows == 0 {
+		return fmt.Errorf("webhook subscription %s: %w", id, common.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListDeliveries retrieves a subscription's deliveries, newest first.
+func (r *repository) ListDeliveries(ctx context.Context, params domain.ListDeliveriesParams) ([]domain.Delivery, error) {
+	pgParams, err := listDeliveriesParamsToPG(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform list deliveries params: %w", err)
+	}
+
+	deliveries, err := r.query.ListWebhookDeliveries(ctx, pgParams)
+	if err != nil {
+		return nil, fmt.Errorf("webhook deliveries: %w", common.ErrInternalServerError)
+	}
+
+	results := make([]domain.Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result, err := pgToDelivery(delivery)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ClaimDeliveries leases up to limit due pending deliveries until leaseUntil.
+// A delivery whose lease expires without a recorded attempt becomes due again.
+func (r *repository) ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.PendingDelivery, error) {
+	rows, err := r.query.ClaimWebhookDeliveries(ctx, webhookstore.ClaimWebhookDeliveriesParams{
+		LeaseUntil:    common.ToPgTimestamp(&leaseUntil),
+		Now:           common.ToPgTimestamp(&now),
+		MaxDeliveries: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	results := make([]domain.PendingDelivery, 0, len(rows))
+	for _, row := range rows {
+		result, err := pgToPendingDelivery(row)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// RecordAttempt stores the outcome of a delivery attempt and increments its attempt count.
+func (r *repository) RecordAttempt(ctx context.Context, params domain.RecordAttemptParams) error {
+	pgParams, err := recordAttemptParamsToPG(params)
+	if err != nil {
+		return fmt.Errorf("failed to transform record attempt params: %w", err)
+	}
+
+	if err := r.query.RecordWebhookDeliveryAttempt(ctx, pgParams); err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+
+	return nil
+}
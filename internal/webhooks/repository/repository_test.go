@@ -0,0 +1,192 @@
+//go:build unit
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/core/dbpool"
+	todolist "github.com/henryhall897/golang-todo-app/internal/todolists"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+	"github.com/henryhall897/golang-todo-app/pkg/dbtest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type WebhookTestSuite struct {
+	suite.Suite
+	pgt        *dbtest.PostgresTest
+	ctx        context.Context
+	repository *repository
+	lists      *todolist.Store
+	userID     uuid.UUID
+}
+
+func TestWebhooks(t *testing.T) {
+	suite.Run(t, &WebhookTestSuite{})
+}
+
+func (w *WebhookTestSuite) SetupSuite() {
+	w.ctx = context.Background()
+
+	var err error
+	w.pgt, err = dbtest.NewPostgresTest(w.ctx, zap.L(), "../../../database/migrations", &dbpool.Config{
+		Logging:      false,
+		Host:         "localhost",
+		Port:         "5432",
+		User:         "testuser",
+		Password:     "1234",
+		DatabaseName: "webhooktestdb",
+		MaxConns:     1,
+		MinConns:     1,
+	})
+	w.Require().NoError(err)
+
+	err = w.pgt.MigrateUp()
+	w.Require().NoError(err)
+
+	w.repository = New(w.pgt.DB())
+	w.lists = todolist.New(w.pgt.DB())
+}
+
+func (w *WebhookTestSuite) SetupTest() {
+	err := w.pgt.DB().QueryRow(w.ctx, "INSERT INTO users (id, name, email) VALUES (gen_random_uuid(), $1, $2) RETURNING id", "Hook Owner", "hooks@example.com").Scan(&w.userID)
+	w.Require().NoError(err)
+}
+
+func (w *WebhookTestSuite) TearDownSuite() {
+	w.Require().NoError(w.pgt.TearDown())
+}
+
+func (w *WebhookTestSuite) TearDownTest() {
+	_, err := w.pgt.DB().Exec(w.ctx, "TRUNCATE TABLE users CASCADE;")
+	w.Require().NoError(err)
+}
+
+func (w *WebhookTestSuite) createSubscription(eventTypes ...string) domain.Subscription {
+	subscription, err := w.repository.CreateSubscription(w.ctx, domain.CreateSubscriptionParams{
+		UserID:     w.userID,
+		URL:        "https://example.com/hook",
+		Secret:     "s3cret",
+		EventTypes: eventTypes,
+	})
+	w.Require().NoError(err)
+	return subscription
+}
+
+func (w *WebhookTestSuite) TestSubscriptionLifecycle() {
+	created := w.createSubscription(domain.EventListCreated)
+	w.NotEqual(uuid.Nil, created.ID)
+	w.True(created.Active)
+	w.Equal([]string{domain.EventListCreated}, created.EventTypes)
+
+	fetched, err := w.repository.GetSubscription(w.ctx, created.ID, w.userID)
+	w.Require().NoError(err)
+	w.Equal(created.ID, fetched.ID)
+
+	// Another user's subscription is invisible
+	_, err = w.repository.GetSubscription(w.ctx, created.ID, uuid.New())
+	w.ErrorIs(err, common.ErrNotFound)
+
+	listed, err := w.repository.ListSubscriptions(w.ctx, w.userID)
+	w.Require().NoError(err)
+	w.Len(listed, 1)
+
+	w.Require().NoError(w.repository.DeleteSubscription(w.ctx, created.ID, w.userID))
+	w.ErrorIs(w.repository.DeleteSubscription(w.ctx, created.ID, w.userID), common.ErrNotFound)
+}
+
+func (w *WebhookTestSuite) TestCreateSubscriptionUnknownUser() {
+	_, err := w.repository.CreateSubscription(w.ctx, domain.CreateSubscriptionParams{
+		UserID:     uuid.New(),
+		URL:        "https://example.com/hook",
+		Secret:     "s3cret",
+		EventTypes: domain.EventTypes,
+	})
+	w.ErrorIs(err, common.ErrNotFound)
+}
+
+func (w *WebhookTestSuite) TestDeliveryQueue() {
+	subscription := w.createSubscription(domain.EventListCreated, domain.EventListDeleted)
+
+	// Creating a list queues a delivery; updating it does not, as the subscription skips list.updated
+	list, err := w.lists.CreateTodoList(w.ctx, todolist.CreateTodoListParams{UserID: w.userID, Title: "Groceries"})
+	w.Require().NoError(err)
+	_, err = w.lists.UpdateTodoList(w.ctx, todolist.UpdateTodoListParams{ID: list.ID, UserID: w.userID, Title: "Shopping"})
+	w.Require().NoError(err)
+
+	now := time.Now()
+	claimed, err := w.repository.ClaimDeliveries(w.ctx, now, now.Add(domain.LeaseDuration), domain.BatchSize)
+	w.Require().NoError(err)
+	w.Require().Len(claimed, 1)
+	w.Equal(domain.EventListCreated, claimed[0].EventType)
+	w.Equal(subscription.URL, claimed[0].URL)
+	w.Equal("s3cret", claimed[0].Secret)
+
+	var event domain.Event
+	w.Require().NoError(json.Unmarshal(claimed[0].Payload, &event))
+	w.Equal(claimed[0].EventID, event.ID)
+	w.JSONEq(`"`+list.ID.String()+`"`, string(mustField(w, event.Data, "list", "id")))
+
+	// A leased delivery is not handed out again
+	again, err := w.repository.ClaimDeliveries(w.ctx, now, now.Add(domain.LeaseDuration), domain.BatchSize)
+	w.Require().NoError(err)
+	w.Empty(again)
+
+	// A failed attempt is retried once its backoff has passed
+	w.Require().NoError(w.repository.RecordAttempt(w.ctx, domain.RecordAttemptParams{
+		ID:            claimed[0].ID,
+		Status:        domain.StatusPending,
+		NextAttemptAt: now.Add(-time.Second),
+		StatusCode:    500,
+		Error:         "unexpected response status 500",
+	}))
+	retried, err := w.repository.ClaimDeliveries(w.ctx, now, now.Add(domain.LeaseDuration), domain.BatchSize)
+	w.Require().NoError(err)
+	w.Require().Len(retried, 1)
+	w.Equal(1, retried[0].Attempts)
+
+	delivered := now
+	w.Require().NoError(w.repository.RecordAttempt(w.ctx, domain.RecordAttemptParams{
+		ID:            retried[0].ID,
+		Status:        domain.StatusSucceeded,
+		NextAttemptAt: now,
+		StatusCode:    204,
+		DeliveredAt:   &delivered,
+	}))
+
+	// Deleting the list queues list.deleted before the row is gone
+	_, err = w.lists.DeleteTodoLists(w.ctx, todolist.DeleteTodoListsParams{UserID: w.userID, IDs: []uuid.UUID{list.ID}})
+	w.Require().NoError(err)
+
+	deliveries, err := w.repository.ListDeliveries(w.ctx, domain.ListDeliveriesParams{SubscriptionID: subscription.ID, UserID: w.userID, Limit: domain.DefaultLimit})
+	w.Require().NoError(err)
+	w.Require().Len(deliveries, 2)
+	w.Equal(domain.EventListDeleted, deliveries[0].EventType)
+	w.Equal(domain.StatusPending, deliveries[0].Status)
+	w.Equal(domain.StatusSucceeded, deliveries[1].Status)
+	w.Equal(2, deliveries[1].Attempts)
+	w.Require().NotNil(deliveries[1].LastStatusCode)
+	w.Equal(int32(204), *deliveries[1].LastStatusCode)
+	w.NotNil(deliveries[1].DeliveredAt)
+
+	succeeded, err := w.repository.ListDeliveries(w.ctx, domain.ListDeliveriesParams{SubscriptionID: subscription.ID, UserID: w.userID, Status: domain.StatusSucceeded, Limit: domain.DefaultLimit})
+	w.Require().NoError(err)
+	w.Len(succeeded, 1)
+}
+
+// mustField extracts a nested field from a JSON document
+func mustField(w *WebhookTestSuite, raw json.RawMessage, path ...string) json.RawMessage {
+	for _, key := range path {
+		var fields map[string]json.RawMessage
+		w.Require().NoError(json.Unmarshal(raw, &fields))
+		raw = fields[key]
+	}
+	return raw
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/webhookstore"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pgToSubscription converts a webhookstore.WebhookSubscription to a domain.Subscription
+func pgToSubscription(pg webhookstore.WebhookSubscription) (domain.Subscription, error) {
+	id, err := common.FromPgUUID(pg.ID)
+	if err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	userID, err := common.FromPgUUID(pg.UserID)
+	if err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to transform user_id uuid: %w", err)
+	}
+
+	return domain.Subscription{
+		ID:         id,
+		UserID:     userID,
+		URL:        pg.Url,
+		Secret:     pg.Secret,
+		EventTypes: pg.EventTypes,
+		Active:     pg.Active,
+		CreatedAt:  common.FromPgTimestamp(pg.CreatedAt),
+		UpdatedAt:  common.FromPgTimestamp(pg.UpdatedAt),
+	}, nil
+}
+
+// pgToDelivery converts a webhookstore.WebhookDelivery to a domain.Delivery
+func pgToDelivery(pg webhookstore.WebhookDelivery) (domain.Delivery, error) {
+	id, err := common.FromPgUUID(pg.ID)
+	if err != nil {
+		return domain.Delivery{}, fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	subscriptionID, err := common.FromPgUUID(pg.SubscriptionID)
+	if err != nil {
+		return domain.Delivery{}, fmt.Errorf("failed to transform subscription_id uuid: %w", err)
+	}
+
+	eventID, err := common.FromPgUUID(pg.EventID)
+	if err != nil {
+		return domain.Delivery{}, fmt.Errorf("failed to transform event_id uuid: %w", err)
+	}
+
+	var deliveredAt *time.Time
+	if pg.DeliveredAt.Valid {
+		deliveredAt = common.Ptr(common.FromPgTimestamp(pg.DeliveredAt))
+	}
+
+	return domain.Delivery{
+		ID:             id,
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      pg.EventType,
+		Payload:        pg.Payload,
+		Status:         pg.Status,
+		Attempts:       int(pg.Attempts),
+		NextAttemptAt:  common.FromPgTimestamp(pg.NextAttemptAt),
+		LastStatusCode: common.FromPgInt4(pg.LastStatusCode),
+		LastError:      common.FromPgText(pg.LastError),
+		DeliveredAt:    deliveredAt,
+		CreatedAt:      common.FromPgTimestamp(pg.CreatedAt),
+		UpdatedAt:      common.FromPgTimestamp(pg.UpdatedAt),
+	}, nil
+}
+
+// pgToPendingDelivery converts a claimed delivery row to a domain.PendingDelivery
+func pgToPendingDelivery(row webhookstore.ClaimWebhookDeliveriesRow) (domain.PendingDelivery, error) {
+	id, err := common.FromPgUUID(row.ID)
+	if err != nil {
+		return domain.PendingDelivery{}, fmt.Errorf("failed to transform id uuid: %w", err)
+	}
+
+	eventID, err := common.FromPgUUID(row.EventID)
+	if err != nil {
+		return domain.PendingDelivery{}, fmt.Errorf("failed to transform event_id uuid: %w", err)
+	}
+
+	return domain.PendingDelivery{
+		ID:        id,
+		EventID:   eventID,
+		EventType: row.EventType,
+		Payload:   row.Payload,
+		Attempts:  int(row.Attempts),
+		URL:       row.Url,
+		Secret:    row.Secret,
+	}, nil
+}
+
+// createSubscriptionParamsToPG converts a domain.CreateSubscriptionParams to webhookstore.CreateWebhookSubscriptionParams
+func createSubscriptionParamsToPG(params domain.CreateSubscriptionParams) (webhookstore.CreateWebhookSubscriptionParams, error) {
+	userID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return webhookstore.CreateWebhookSubscriptionParams{}, err
+	}
+
+	return webhookstore.CreateWebhookSubscriptionParams{
+		UserID:     userID,
+		Url:        params.URL,
+		Secret:     params.Secret,
+		EventTypes: params.EventTypes,
+	}, nil
+}
+
+// recordAttemptParamsToPG converts a domain.RecordAttemptParams to webhookstore.RecordWebhookDeliveryAttemptParams
+func recordAttemptParamsToPG(params domain.RecordAttemptParams) (webhookstore.RecordWebhookDeliveryAttemptParams, error) {
+	id, err := common.ToPgUUID(params.ID)
+	if err != nil {
+		return webhookstore.RecordWebhookDeliveryAttemptParams{}, err
+	}
+
+	var statusCode pgtype.Int4
+	if params.StatusCode != 0 {
+		statusCode = common.ToPgInt4(int32(params.StatusCode))
+	}
+
+	var lastError pgtype.Text
+	if params.Error != "" {
+		lastError = common.ToPgText(&params.Error)
+	}
+
+	return webhookstore.RecordWebhookDeliveryAttemptParams{
+		ID:             id,
+		Status:         params.Status,
+		NextAttemptAt:  common.ToPgTimestamp(&params.NextAttemptAt),
+		LastStatusCode: statusCode,
+		LastError:      lastError,
+		DeliveredAt:    common.ToPgTimestamp(params.DeliveredAt),
+	}, nil
+}
+
+// listDeliveriesParamsToPG converts a domain.ListDeliveriesParams to webhookstore.ListWebhookDeliveriesParams
+func listDeliveriesParamsToPG(params domain.ListDeliveriesParams) (webhookstore.ListWebhookDeliveriesParams, error) {
+	subscriptionID, err := common.ToPgUUID(params.SubscriptionID)
+	if err != nil {
+		return webhookstore.ListWebhookDeliveriesParams{}, err
+	}
+
+	userID, err := common.ToPgUUID(params.UserID)
+	if err != nil {
+		return webhookstore.ListWebhookDeliveriesParams{}, err
+	}
+
+	var status pgtype.Text
+	if params.Status != "" {
+		status = common.ToPgText(&params.Status)
+	}
+
+	return webhookstore.ListWebhookDeliveriesParams{
+		SubscriptionID: subscriptionID,
+		UserID:         userID,
+		Status:         status,
+		Limit:          int32(params.Limit),
+		Offset:         int32(params.Offset),
+	}, nil
+}
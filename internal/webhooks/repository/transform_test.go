@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/henryhall897/golang-todo-app/gen/queries/webhookstore"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+)
+
+type transformTestSuite struct {
+	suite.Suite
+}
+
+func TestTransform(t *testing.T) {
+	suite.Run(t, new(transformTestSuite))
+}
+
+func (suite *transformTestSuite) TestPGToDelivery() {
+	validTime := time.Now().UTC()
+	pgDelivery := webhookstore.WebhookDelivery{
+		ID:             pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		SubscriptionID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		EventID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		EventType:      domain.EventTaskCreated,
+		Payload:        []byte(`{"type":"task.created"}`),
+		Status:         domain.StatusPending,
+		Attempts:       1,
+		NextAttemptAt:  pgtype.Timestamp{Time: validTime, Valid: true},
+		LastStatusCode: pgtype.Int4{Int32: 503, Valid: true},
+		LastError:      pgtype.Text{String: "unexpected response status 503", Valid: true},
+		CreatedAt:      pgtype.Timestamp{Time: validTime, Valid: true},
+		UpdatedAt:      pgtype.Timestamp{Time: validTime, Valid: true},
+	}
+
+	suite.Run("pending delivery", func() {
+		delivery, err := pgToDelivery(pgDelivery)
+		suite.Require().NoError(err)
+		suite.Equal(uuid.UUID(pgDelivery.ID.Bytes), delivery.ID)
+		suite.Equal(1, delivery.Attempts)
+		suite.Require().NotNil(delivery.LastStatusCode)
+		suite.Equal(int32(503), *delivery.LastStatusCode)
+		suite.Require().NotNil(delivery.LastError)
+		suite.Nil(delivery.DeliveredAt)
+		suite.JSONEq(`{"type":"task.created"}`, string(delivery.Payload))
+	})
+
+	suite.Run("succeeded delivery", func() {
+		succeeded := pgDelivery
+		succeeded.Status = domain.StatusSucceeded
+		succeeded.LastError = pgtype.Text{}
+		succeeded.DeliveredAt = pgtype.Timestamp{Time: validTime, Valid: true}
+
+		delivery, err := pgToDelivery(succeeded)
+		suite.Require().NoError(err)
+		suite.Nil(delivery.LastError)
+		suite.Require().NotNil(delivery.DeliveredAt)
+		suite.True(delivery.DeliveredAt.Equal(validTime))
+	})
+}
+
+func (suite *transformTestSuite) TestRecordAttemptParamsToPG() {
+	now := time.Now()
+
+	suite.Run("success maps delivery time and omits error", func() {
+		params, err := recordAttemptParamsToPG(domain.RecordAttemptParams{
+			ID:            uuid.New(),
+			Status:        domain.StatusSucceeded,
+			NextAttemptAt: now,
+			StatusCode:    200,
+			DeliveredAt:   &now,
+		})
+		suite.Require().NoError(err)
+		suite.Equal(pgtype.Int4{Int32: 200, Valid: true}, params.LastStatusCode)
+		suite.False(params.LastError.Valid)
+		suite.True(params.DeliveredAt.Valid)
+	})
+
+	suite.Run("transport failure leaves status code null", func() {
+		params, err := recordAttemptParamsToPG(domain.RecordAttemptParams{
+			ID:            uuid.New(),
+			Status:        domain.StatusPending,
+			NextAttemptAt: now,
+			Error:         "connection refused",
+		})
+		suite.Require().NoError(err)
+		suite.False(params.LastStatusCode.Valid)
+		suite.Equal(pgtype.Text{String: "connection refused", Valid: true}, params.LastError)
+		suite.False(params.DeliveredAt.Valid)
+	})
+
+	suite.Run("nil id rejected", func() {
+		_, err := recordAttemptParamsToPG(domain.RecordAttemptParams{})
+		suite.Error(err)
+	})
+}
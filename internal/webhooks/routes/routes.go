@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/handler"
+)
+
+// RegisterRoutes sets up webhook subscription routes. Every route acts on the caller's own subscriptions.
+func RegisterRoutes(router *http.ServeMux, h *handler.Handler) {
+	// Handle `/webhooks` (List Subscriptions, Create Subscription)
+	router.HandleFunc("/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			h.ListSubscriptionsHandler(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			h.CreateSubscriptionHandler(w, r)
+			return
+		}
+
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	})
+
+	router.Handle("/webhooks/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Extract path segments
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		// Handle `/webhooks/{id}`
+		if len(segments) == 2 {
+			if r.Method == http.MethodGet {
+				handler.VerifySubscriptionPath(h.GetSubscriptionHandler).ServeHTTP(w, r)
+				return
+			}
+
+			if r.Method == http.MethodDelete {
+				handler.VerifySubscriptionPath(h.DeleteSubscriptionHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Handle `/webhooks/{id}/deliveries`
+		if len(segments) == 3 && segments[2] == "deliveries" {
+			if r.Method == http.MethodGet {
+				handler.VerifySubscriptionPath(h.ListDeliveriesHandler).ServeHTTP(w, r)
+				return
+			}
+
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Return 404 for invalid paths
+		http.NotFound(w, r)
+	}))
+}
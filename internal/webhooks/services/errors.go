@@ -0,0 +1,13 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+)
+
+// Service-level errors (handler should only see these)
+var (
+	ErrInvalidURL       = fmt.Errorf("invalid webhook url: %w", common.ErrValidation)
+	ErrInvalidEventType = fmt.Errorf("invalid webhook event type: %w", common.ErrValidation)
+)
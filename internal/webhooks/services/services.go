@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/delivery"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// secretBytes is the length of a generated signing secret before hex encoding
+const secretBytes = 32
+
+type service struct {
+	repo   domain.Repository
+	logger *zap.SugaredLogger
+}
+
+func New(repo domain.Repository, logger *zap.SugaredLogger) domain.Service {
+	return &service{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateSubscription validates and registers a webhook endpoint.
+// A signing secret is generated when none is given, and an empty event list subscribes to every event.
+func (s *service) CreateSubscription(ctx context.Context, params domain.CreateSubscriptionParams) (domain.Subscription, error) {
+	if err := validateURL(params.URL); err != nil {
+		s.logger.Warnw("CreateSubscription failed: invalid url", "url", params.URL)
+		return domain.Subscription{}, err
+	}
+
+	if len(params.EventTypes) == 0 {
+		params.EventTypes = domain.EventTypes
+	}
+	for _, eventType := range params.EventTypes {
+		if !slices.Contains(domain.EventTypes, eventType) {
+			s.logger.Warnw("CreateSubscription failed: invalid event type", "event_type", eventType)
+			return domain.Subscription{}, fmt.Errorf("%q: %w", eventType, ErrInvalidEventType)
+		}
+	}
+	params.EventTypes = uniqueSorted(params.EventTypes)
+
+	if params.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			s.logger.Errorw("CreateSubscription failed: could not generate secret", "error", err)
+			return domain.Subscription{}, common.ErrInternalServerError
+		}
+		params.Secret = secret
+	}
+
+	subscription, err := s.repo.CreateSubscription(ctx, params)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return domain.Subscription{}, err
+		}
+		s.logger.Errorw("CreateSubscription failed: internal server error", "user_id", params.UserID, "error", err)
+		return domain.Subscription{}, common.ErrInternalServerError
+	}
+
+	s.logger.Infow("Webhook subscription created", "id", subscription.ID, "user_id", subscription.UserID, "event_types", subscription.EventTypes)
+	return subscription, nil
+}
+
+// GetSubscription retrieves a subscription owned by the user, without its secret.
+func (s *service) GetSubscription(ctx context.Context, id, userID uuid.UUID) (domain.Subscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return domain.Subscription{}, err
+		}
+		s.logger.Errorw("GetSubscription failed: internal server error", "id", id, "error", err)
+		return domain.Subscription{}, common.ErrInternalServerError
+	}
+
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// ListSubscriptions retrieves a user's subscriptions, without their secrets.
+func (s *service) ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]domain.Subscription, error) {
+	subscriptions, err := s.repo.ListSubscriptions(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("ListSubscriptions failed: internal server error", "user_id", userID, "error", err)
+		return nil, common.ErrInternalServerError
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes a subscription owned by the user.
+func (s *service) DeleteSubscription(ctx context.Context, id, userID uuid.UUID) error {
+	if err := s.repo.DeleteSubscription(ctx, id, userID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return err
+		}
+		s.logger.Errorw("DeleteSubscription failed: internal server error", "id", id, "error", err)
+		return common.ErrInternalServerError
+	}
+
+	s.logger.Infow("Webhook subscription deleted", "id", id, "user_id", userID)
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log of a subscription owned by the user.
+func (s *service) ListDeliveries(ctx context.Context, params domain.ListDeliveriesParams) ([]domain.Delivery, error) {
+	// Hide other users' subscriptions behind a not found
+	if _, err := s.GetSubscription(ctx, params.SubscriptionID, params.UserID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, params)
+	if err != nil {
+		s.logger.Errorw("ListDeliveries failed: internal server error", "params", params, "error", err)
+		return nil, common.ErrInternalServerError
+	}
+	return deliveries, nil
+}
+
+// validateURL accepts absolute http and https URLs with a host that is not local or a non-public IP.
+// Names are checked again when delivering, since they can resolve to any address.
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrInvalidURL
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrInvalidURL
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !delivery.IsPublicAddr(addr) {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// uniqueSorted returns a sorted copy of values without duplicates
+func uniqueSorted(values []string) []string {
+	result := slices.Clone(values)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// generateSecret returns a random hex-encoded signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/henryhall897/golang-todo-app/gen/mocks/webhooksmock"
+	"github.com/henryhall897/golang-todo-app/internal/core/common"
+	"github.com/henryhall897/golang-todo-app/internal/webhooks/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// echoRepo returns a repository mock that stores subscriptions as given
+func echoRepo() *webhooksmock.RepositoryMock {
+	return &webhooksmock.RepositoryMock{
+		CreateSubscriptionFunc: func(ctx context.Context, params domain.CreateSubscriptionParams) (domain.Subscription, error) {
+			return domain.Subscription{
+				ID:         uuid.New(),
+				UserID:     params.UserID,
+				URL:        params.URL,
+				Secret:     params.Secret,
+				EventTypes: params.EventTypes,
+				Active:     true,
+			}, nil
+		},
+	}
+}
+
+func TestCreateSubscription(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success - secret generated and events defaulted", func(t *testing.T) {
+		mockRepo := echoRepo()
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		subscription, err := svc.CreateSubscription(context.Background(), domain.CreateSubscriptionParams{
+			UserID: userID,
+			URL:    "https://example.com/hook",
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, subscription.Secret, 2*secretBytes)
+		assert.ElementsMatch(t, domain.EventTypes, subscription.EventTypes)
+	})
+
+	t.Run("success - given secret kept and events deduplicated", func(t *testing.T) {
+		mockRepo := echoRepo()
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		subscription, err := svc.CreateSubscription(context.Background(), domain.CreateSubscriptionParams{
+			UserID:     userID,
+			URL:        "http://hooks.example.com:9000/hook",
+			Secret:     "s3cret",
+			EventTypes: []string{domain.EventTaskUpdated, domain.EventTaskCreated, domain.EventTaskUpdated},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", subscription.Secret)
+		assert.Equal(t, []string{domain.EventTaskCreated, domain.EventTaskUpdated}, subscription.EventTypes)
+	})
+
+	t.Run("failure - invalid url", func(t *testing.T) {
+		for _, raw := range []string{
+			"", "example.com/hook", "ftp://example.com", "https://",
+			"http://localhost:9000/hook", "http://127.0.0.1/hook", "http://10.1.2.3/hook",
+			"http://169.254.169.254/latest/meta-data/", "http://[::1]/hook",
+		} {
+			mockRepo := echoRepo()
+			svc := New(mockRepo, zap.NewNop().Sugar())
+
+			_, err := svc.CreateSubscription(context.Background(), domain.CreateSubscriptionParams{UserID: userID, URL: raw})
+
+			assert.ErrorIs(t, err, ErrInvalidURL, raw)
+			assert.ErrorIs(t, err, common.ErrValidation, raw)
+			assert.Empty(t, mockRepo.CreateSubscriptionCalls())
+		}
+	})
+
+	t.Run("failure - unknown event type", func(t *testing.T) {
+		mockRepo := echoRepo()
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		_, err := svc.CreateSubscription(context.Background(), domain.CreateSubscriptionParams{
+			UserID:     userID,
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"task.exploded"},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidEventType)
+		assert.Empty(t, mockRepo.CreateSubscriptionCalls())
+	})
+
+	t.Run("failure - unknown user", func(t *testing.T) {
+		mockRepo := &webhooksmock.RepositoryMock{
+			CreateSubscriptionFunc: func(ctx context.Context, params domain.CreateSubscriptionParams) (domain.Subscription, error) {
+				return domain.Subscription{}, fmt.Errorf("user: %w", common.ErrNotFound)
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		_, err := svc.CreateSubscription(context.Background(), domain.CreateSubscriptionParams{UserID: userID, URL: "https://example.com"})
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+}
+
+func TestGetAndListSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	stored := domain.Subscription{ID: uuid.New(), UserID: userID, URL: "https://example.com", Secret: "s3cret"}
+	mockRepo := &webhooksmock.RepositoryMock{
+		GetSubscriptionFunc: func(ctx context.Context, id, userID uuid.UUID) (domain.Subscription, error) {
+			return stored, nil
+		},
+		ListSubscriptionsFunc: func(ctx context.Context, userID uuid.UUID) ([]domain.Subscription, error) {
+			return []domain.Subscription{stored}, nil
+		},
+	}
+	svc := New(mockRepo, zap.NewNop().Sugar())
+
+	t.Run("success - secret hidden on get", func(t *testing.T) {
+		subscription, err := svc.GetSubscription(context.Background(), stored.ID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored.ID, subscription.ID)
+		assert.Empty(t, subscription.Secret)
+	})
+
+	t.Run("success - secrets hidden on list", func(t *testing.T) {
+		subscriptions, err := svc.ListSubscriptions(context.Background(), userID)
+
+		require.NoError(t, err)
+		require.Len(t, subscriptions, 1)
+		assert.Empty(t, subscriptions[0].Secret)
+	})
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	t.Run("failure - not found passed through", func(t *testing.T) {
+		mockRepo := &webhooksmock.RepositoryMock{
+			DeleteSubscriptionFunc: func(ctx context.Context, id, userID uuid.UUID) error {
+				return fmt.Errorf("webhook subscription: %w", common.ErrNotFound)
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		err := svc.DeleteSubscription(context.Background(), uuid.New(), uuid.New())
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+
+	t.Run("failure - repository error is masked", func(t *testing.T) {
+		mockRepo := &webhooksmock.RepositoryMock{
+			DeleteSubscriptionFunc: func(ctx context.Context, id, userID uuid.UUID) error {
+				return errors.New("database timeout")
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		err := svc.DeleteSubscription(context.Background(), uuid.New(), uuid.New())
+
+		assert.Equal(t, common.ErrInternalServerError, err)
+	})
+}
+
+func TestListDeliveries(t *testing.T) {
+	t.Run("success - deliveries of an owned subscription", func(t *testing.T) {
+		expected := []domain.Delivery{{ID: uuid.New(), Status: domain.StatusPending}}
+		mockRepo := &webhooksmock.RepositoryMock{
+			GetSubscriptionFunc: func(ctx context.Context, id, userID uuid.UUID) (domain.Subscription, error) {
+				return domain.Subscription{ID: id, UserID: userID}, nil
+			},
+			ListDeliveriesFunc: func(ctx context.Context, params domain.ListDeliveriesParams) ([]domain.Delivery, error) {
+				return expected, nil
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		deliveries, err := svc.ListDeliveries(context.Background(), domain.ListDeliveriesParams{SubscriptionID: uuid.New(), UserID: uuid.New()})
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, deliveries)
+	})
+
+	t.Run("failure - subscription of another user", func(t *testing.T) {
+		mockRepo := &webhooksmock.RepositoryMock{
+			GetSubscriptionFunc: func(ctx context.Context, id, userID uuid.UUID) (domain.Subscription, error) {
+				return domain.Subscription{}, fmt.Errorf("webhook subscription: %w", common.ErrNotFound)
+			},
+		}
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		_, err := svc.ListDeliveries(context.Background(), domain.ListDeliveriesParams{SubscriptionID: uuid.New(), UserID: uuid.New()})
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.Empty(t, mockRepo.ListDeliveriesCalls())
+	})
+}
@@ -0,0 +1,153 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GetMany reads the values of several keys in one round trip. dest must point to a slice, which is
+// replaced by one of len(keys) elements, element i holding the value of keys[i]. The indexes of keys
+// that are missing or cannot be deserialized are returned, in order; their elements are left zero.
+func (c *JSONCache) GetMany(ctx context.Context, keys []string, dest interface{}) ([]int, error) {
+	values, err := c.getManyBytes(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeMany(keys, values, dest)
+}
+
+// getManyBytes reads the serialized data stored under several keys in one round trip; missing keys read as nil
+func (c *JSONCache) getManyBytes(ctx context.Context, keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	namespacedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		namespacedKeys[i] = c.prefix + ":" + key
+	}
+	var results []interface{}
+	err := c.call(func() (err error) {
+		results, err = mget(ctx, c.client, namespacedKeys...)
+		return err
+	})
+	if err != nil {
+		c.logger.Warnw("Failed to get data from Redis", "keys", len(keys), "error", err)
+		return nil, err
+	}
+
+	for i, result := range results {
+		if s, ok := result.(string); ok {
+			values[i] = []byte(s)
+		}
+	}
+	return values, nil
+}
+
+// decodeMany deserializes values into the slice dest points to, returning the indexes of nil or unreadable values
+func (c *JSONCache) decodeMany(keys []string, values [][]byte, dest interface{}) ([]int, error) {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("GetMany destination must be a pointer to a slice, got %T", dest)
+	}
+
+	elems := reflect.MakeSlice(target.Elem().Type(), len(values), len(values))
+	var missing []int
+	for i, data := range values {
+		if data == nil {
+			missing = append(missing, i)
+			continue
+		}
+		elem := elems.Index(i)
+		if err := decode(data, elem.Addr().Interface()); err != nil {
+			c.logger.Warnw("Failed to deserialize cached data", "key", c.prefix+":"+keys[i], "error", err)
+			elem.SetZero()
+			missing = append(missing, i)
+		}
+	}
+	target.Elem().Set(elems)
+	return missing, nil
+}
+
+// SetMany stores several values, keyed by cache key, with one TTL in a single pipelined round trip.
+// Nothing is stored when a value cannot be serialized; a failed round trip may leave some values stored.
+func (c *JSONCache) SetMany(ctx context.Context, entries map[string]interface{}, ttl time.Duration) error {
+	data, err := c.encodeMany(entries)
+	if err != nil {
+		return err
+	}
+	return c.setManyBytes(ctx, data, ttl)
+}
+
+// encodeMany serializes several values, keyed by cache key
+func (c *JSONCache) encodeMany(entries map[string]interface{}) (map[string][]byte, error) {
+	data := make(map[string][]byte, len(entries))
+	for key, value := range entries {
+		encoded, err := encode(c.codec, value)
+		if err != nil {
+			c.logger.Errorw("Failed to serialize data", "key", key, "error", err)
+			return nil, err
+		}
+		data[key] = encoded
+	}
+	return data, nil
+}
+
+// setManyBytes stores serialized data under several keys in one pipeline
+func (c *JSONCache) setManyBytes(ctx context.Context, entries map[string][]byte, ttl time.Duration) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	err := c.call(func() error {
+		_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for key, data := range entries {
+				pipe.Set(ctx, c.prefix+":"+key, data, ttl)
+			}
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		c.logger.Errorw("Failed to set data in Redis", "keys", len(entries), "error", err)
+		return err
+	}
+
+	c.logger.Debugw("Data cached", "prefix", c.prefix, "keys", len(entries))
+	return nil
+}
+
+// DeleteMany removes several keys, returning how many existed
+func (c *JSONCache) DeleteMany(ctx context.Context, keys []string) (int64, error) {
+	var deleted int64
+	for start := 0; start < len(keys); start += deleteBatchSize {
+		batch := keys[start:min(start+deleteBatchSize, len(keys))]
+		namespacedKeys := make([]string, len(batch))
+		for i, key := range batch {
+			namespacedKeys[i] = c.prefix + ":" + key
+		}
+
+		var n int64
+		err := c.invalidate(func() (err error) {
+			n, err = del(ctx, c.client, namespacedKeys...)
+			return err
+		}, func(p *pendingInvalidations) {
+			// The later batches are not attempted
+			p.addKeys(namespacedKeys...)
+			for _, key := range keys[start+len(batch):] {
+				p.addKeys(c.prefix + ":" + key)
+			}
+		})
+		if err != nil {
+			c.logger.Warnw("Failed to delete keys from Redis", "prefix", c.prefix, "keys", len(keys), "error", err)
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
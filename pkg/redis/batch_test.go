@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONCache_Batch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SetMany and GetMany - success", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		users := generateTestUsers(3)
+		entries := make(map[string]interface{}, len(users))
+		for _, user := range users {
+			entries[user.ID] = user
+		}
+		require.NoError(t, suite.Cache.SetMany(ctx, entries, time.Minute))
+		assert.Equal(t, time.Minute, suite.Server.TTL("test:user-2"))
+
+		var result []TestUser
+		missing, err := suite.Cache.GetMany(ctx, []string{"user-3", "missing", "user-1"}, &result)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, missing)
+		assert.Equal(t, []TestUser{users[2], {}, users[0]}, result)
+	})
+
+	t.Run("GetMany - unreadable values count as missing", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		require.NoError(t, suite.Server.Set("test:broken", "{not json"))
+		var result []TestUser
+		missing, err := suite.Cache.GetMany(ctx, []string{"broken"}, &result)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0}, missing)
+		assert.Equal(t, []TestUser{{}}, result)
+	})
+
+	t.Run("GetMany - no keys", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		result := []TestUser{{ID: "stale"}}
+		missing, err := suite.Cache.GetMany(ctx, nil, &result)
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+		assert.Empty(t, result)
+	})
+
+	t.Run("GetMany - destination must be a slice pointer", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		var result TestUser
+		_, err := suite.Cache.GetMany(ctx, []string{"user-1"}, &result)
+		assert.Error(t, err)
+	})
+
+	t.Run("DeleteMany - success", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		require.NoError(t, suite.Server.Set("test:a", "1"))
+		require.NoError(t, suite.Server.Set("test:b", "2"))
+		require.NoError(t, suite.Server.Set("test:c", "3"))
+
+		deleted, err := suite.Cache.DeleteMany(ctx, []string{"a", "b", "missing"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+		assert.False(t, suite.Server.Exists("test:a"))
+		assert.True(t, suite.Server.Exists("test:c"))
+	})
+
+	t.Run("Redis errors are returned", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.Server.Close()
+
+		var result []TestUser
+		_, err := suite.Cache.GetMany(ctx, []string{"user-1"}, &result)
+		assert.Error(t, err)
+		assert.Error(t, suite.Cache.SetMany(ctx, map[string]interface{}{"user-1": generateTestUsers(1)[0]}, time.Minute))
+		_, err = suite.Cache.DeleteMany(ctx, []string{"user-1"})
+		assert.Error(t, err)
+	})
+}
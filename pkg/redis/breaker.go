@@ -0,0 +1,222 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned by cache calls made while their breaker is open, without calling Redis.
+var ErrCircuitOpen = errors.New("redis circuit breaker is open")
+
+// BreakerState is whether a Breaker lets calls through to Redis.
+type BreakerState int32
+
+const (
+	// BreakerClosed lets calls through; Redis is assumed available.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails calls with ErrCircuitOpen until a probe reaches Redis.
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	if s == BreakerOpen {
+		return "open"
+	}
+	return "closed"
+}
+
+// MarshalText writes the state by name, as in health check responses
+func (s BreakerState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Breaker is a circuit breaker for Redis shared by the caches using one client. After threshold
+// consecutive failed calls it opens, and cache calls fail fast with ErrCircuitOpen, which callers
+// treat as a miss, until Run's probe reaches Redis again. Replies from Redis, misses and cancelled
+// calls are not failures. Invalidations that did not reach Redis are replayed once it is reachable,
+// so caches do not serve what they should have removed. A nil *Breaker lets every call through.
+type Breaker struct {
+	client    redis.UniversalClient
+	threshold int
+	interval  time.Duration
+	logger    *zap.SugaredLogger
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int          // Consecutive failed calls
+	openedAt time.Time    // When the breaker last opened
+	caches   []*JSONCache // Guarded caches, whose dropped invalidations are replayed on recovery
+}
+
+// NewBreaker initializes a closed breaker opening after threshold consecutive failures,
+// probing client every interval while open.
+func NewBreaker(client redis.UniversalClient, threshold int, interval time.Duration, logger *zap.SugaredLogger) *Breaker {
+	return &Breaker{
+		client:    client,
+		threshold: max(threshold, 1),
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run probes Redis every interval while the breaker is open, closing it once a probe succeeds,
+// until ctx is cancelled. While it is closed, invalidations that failed since the last interval are replayed.
+func (b *Breaker) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if b.State() == BreakerOpen {
+				_ = b.Probe(ctx)
+			} else {
+				b.replay(ctx)
+			}
+		}
+	}
+}
+
+// Probe pings Redis, bypassing the breaker, and records the result: the breaker closes on success,
+// replaying the invalidations dropped meanwhile, and opens on failure.
+func (b *Breaker) Probe(ctx context.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx, b.interval)
+	defer cancel()
+
+	err := b.client.Ping(pingCtx).Err()
+	if isNeutral(err) {
+		return err
+	}
+
+	b.mu.Lock()
+	if err != nil {
+		b.failures = b.threshold
+		b.open()
+		b.mu.Unlock()
+		return err
+	}
+	b.close()
+	b.mu.Unlock()
+
+	b.replay(ctx)
+	return nil
+}
+
+// replay replays the invalidations of the guarded caches that did not reach Redis
+func (b *Breaker) replay(ctx context.Context) {
+	b.mu.Lock()
+	caches := slices.Clone(b.caches)
+	b.mu.Unlock()
+
+	for _, cache := range caches {
+		cache.replayInvalidations(ctx)
+	}
+}
+
+// State returns whether the breaker is open
+func (b *Breaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// OpenedAt returns when the breaker last opened, or the zero time if it never has
+func (b *Breaker) OpenedAt() time.Time {
+	if b == nil {
+		return time.Time{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openedAt
+}
+
+// allow reports whether a call may go to Redis
+func (b *Breaker) allow() bool {
+	return b.State() == BreakerClosed
+}
+
+// record counts the outcome of a call let through by allow
+func (b *Breaker) record(err error) {
+	if b == nil || isNeutral(err) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !isFailure(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.open()
+	}
+}
+
+// open opens the breaker; b.mu must be held
+func (b *Breaker) open() {
+	if b.state == BreakerOpen {
+		return
+	}
+	b.state = BreakerOpen
+	b.openedAt = time.Now()
+	b.logger.Warnw("Redis circuit breaker opened; cache calls fail fast until Redis recovers", "failures", b.failures)
+}
+
+// close closes the breaker; b.mu must be held
+func (b *Breaker) close() {
+	b.failures = 0
+	if b.state == BreakerClosed {
+		return
+	}
+	b.state = BreakerClosed
+	b.logger.Infow("Redis circuit breaker closed; Redis is reachable again", "open_for", time.Since(b.openedAt))
+}
+
+// isNeutral reports whether a call's error says nothing about Redis: the caller cancelled it
+func isNeutral(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// isFailure reports whether a call's error means Redis could not be reached.
+// Replies from Redis, including misses, show it is available.
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var reply redis.Error
+	return !errors.As(err, &reply)
+}
+
+// call runs one Redis round trip through c's breaker: fn is skipped with ErrCircuitOpen while the
+// breaker is open, and its error is recorded otherwise
+func (c *JSONCache) call(fn func() error) error {
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+	err := fn()
+	c.breaker.record(err)
+	return err
+}
+
+// WithBreaker guards c's calls to Redis with breaker, which caches on one client should share, and returns c
+func (c *JSONCache) WithBreaker(breaker *Breaker) *JSONCache {
+	c.breaker = breaker
+	if breaker != nil {
+		breaker.mu.Lock()
+		breaker.caches = append(breaker.caches, c)
+		breaker.mu.Unlock()
+	}
+	return c
+}
@@ -0,0 +1,182 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newBreakerCache returns a cache guarded by a breaker opening after two failures
+func newBreakerCache(t *testing.T, interval time.Duration) (*miniredis.Miniredis, *JSONCache, *Breaker) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	logger := zap.NewNop().Sugar()
+	breaker := NewBreaker(client, 2, interval, logger)
+	return mr, NewJSONCache(client, "test", logger).WithBreaker(breaker), breaker
+}
+
+func TestBreaker(t *testing.T) {
+	ctx := context.Background()
+	user := generateTestUsers(1)[0]
+
+	t.Run("opens after consecutive failures and fails fast", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, time.Hour)
+		require.NoError(t, cache.Set(ctx, "user-1", user, time.Minute))
+
+		mr.Close()
+		var result TestUser
+		for range 2 {
+			err := cache.Get(ctx, "user-1", &result)
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, ErrCircuitOpen)
+		}
+		assert.Equal(t, BreakerOpen, breaker.State())
+		assert.False(t, breaker.OpenedAt().IsZero())
+
+		assert.ErrorIs(t, cache.Get(ctx, "user-1", &result), ErrCircuitOpen)
+		assert.ErrorIs(t, cache.Set(ctx, "user-1", user, time.Minute), ErrCircuitOpen)
+		_, err := cache.DeleteByPrefix(ctx, "user-")
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+	})
+
+	t.Run("misses, replies and cancelled calls are not failures", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, time.Hour)
+		mr.HSet("test:hash", "field", "value")
+
+		var result TestUser
+		for range 3 {
+			assert.ErrorIs(t, cache.Get(ctx, "missing", &result), redis.Nil)
+			assert.Error(t, cache.Get(ctx, "hash", &result)) // WRONGTYPE reply
+		}
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		for range 3 {
+			assert.Error(t, cache.Get(cancelled, "user-1", &result))
+		}
+		assert.Equal(t, BreakerClosed, breaker.State())
+	})
+
+	t.Run("a success resets the failure count", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, time.Hour)
+
+		var result TestUser
+		mr.Close()
+		assert.Error(t, cache.Get(ctx, "user-1", &result))
+		require.NoError(t, mr.Restart())
+		assert.ErrorIs(t, cache.Get(ctx, "user-1", &result), redis.Nil)
+		mr.Close()
+		assert.Error(t, cache.Get(ctx, "user-1", &result))
+		assert.Equal(t, BreakerClosed, breaker.State())
+	})
+
+	t.Run("Probe opens and closes the breaker", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, time.Second)
+
+		mr.Close()
+		assert.Error(t, breaker.Probe(ctx))
+		assert.Equal(t, BreakerOpen, breaker.State())
+
+		require.NoError(t, mr.Restart())
+		require.NoError(t, breaker.Probe(ctx))
+		assert.Equal(t, BreakerClosed, breaker.State())
+		assert.NoError(t, cache.Set(ctx, "user-1", user, time.Minute))
+	})
+
+	t.Run("Run probes while open", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, 10*time.Millisecond)
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go breaker.Run(runCtx)
+
+		mr.Close()
+		var result TestUser
+		for range 2 {
+			_ = cache.Get(ctx, "user-1", &result)
+		}
+		require.Equal(t, BreakerOpen, breaker.State())
+
+		require.NoError(t, mr.Restart())
+		require.Eventually(t, func() bool { return breaker.State() == BreakerClosed }, time.Second, 5*time.Millisecond)
+		assert.ErrorIs(t, cache.Get(ctx, "user-1", &result), redis.Nil)
+	})
+
+	t.Run("invalidations dropped while open are replayed on recovery", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, time.Second)
+		require.NoError(t, cache.Set(ctx, "user-1", user, time.Minute))
+		require.NoError(t, cache.Set(ctx, "page:1:10:0", []string{"user-1"}, time.Minute))
+		_, err := cache.SetCounterIfNotExists(ctx, "generation", 41, 0)
+		require.NoError(t, err)
+
+		mr.Close()
+		assert.Error(t, breaker.Probe(ctx))
+		require.Equal(t, BreakerOpen, breaker.State())
+
+		assert.ErrorIs(t, cache.Delete(ctx, "user-1"), ErrCircuitOpen)
+		_, err = cache.DeleteByPrefix(ctx, "page:")
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		_, err = cache.Incr(ctx, "generation")
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		// The entries survived the outage in Redis and are removed once it is reachable
+		require.NoError(t, mr.Restart())
+		require.True(t, mr.Exists("test:user-1"))
+		require.NoError(t, breaker.Probe(ctx))
+
+		assert.False(t, mr.Exists("test:user-1"))
+		assert.False(t, mr.Exists("test:page:1:10:0"))
+		generation, err := mr.Get("test:generation")
+		require.NoError(t, err)
+		assert.Equal(t, "42", generation)
+
+		// Replayed invalidations are not replayed again
+		require.NoError(t, cache.Set(ctx, "user-1", user, time.Minute))
+		require.NoError(t, breaker.Probe(ctx))
+		assert.True(t, mr.Exists("test:user-1"))
+	})
+
+	t.Run("Run replays invalidations that failed while closed", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, 10*time.Millisecond)
+		require.NoError(t, cache.Set(ctx, "user-1", user, time.Minute))
+
+		mr.Close()
+		assert.Error(t, cache.Delete(ctx, "user-1"))
+		require.NoError(t, mr.Restart())
+		require.Equal(t, BreakerClosed, breaker.State())
+
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go breaker.Run(runCtx)
+		require.Eventually(t, func() bool { return !mr.Exists("test:user-1") }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("too many dropped invalidations flush the namespace", func(t *testing.T) {
+		mr, cache, breaker := newBreakerCache(t, time.Second)
+		require.NoError(t, cache.Set(ctx, "user-1", user, time.Minute))
+		mr.Set("other:user-1", "kept")
+
+		mr.Close()
+		assert.Error(t, breaker.Probe(ctx))
+		keys := make([]string, maxPendingInvalidations+1)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("missing-%d", i)
+		}
+		_, err := cache.DeleteMany(ctx, keys)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		require.NoError(t, mr.Restart())
+		require.NoError(t, breaker.Probe(ctx))
+		assert.False(t, mr.Exists("test:user-1"))
+		assert.True(t, mr.Exists("other:user-1"))
+	})
+}
@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Cluster runs a multi-key command only when every key hashes to the same slot, and SCAN only
+// covers the node it is sent to. The cache's multi-key operations go through these helpers, which
+// split the work per key and per node when the client is a *redis.ClusterClient.
+
+// isCluster reports whether client spreads keys over a Redis Cluster
+func isCluster(client redis.UniversalClient) bool {
+	_, ok := client.(*redis.ClusterClient)
+	return ok
+}
+
+// forEachNode runs fn on every node holding keys: each master of a cluster, concurrently, or the client itself
+func forEachNode(ctx context.Context, client redis.UniversalClient, fn func(ctx context.Context, node redis.UniversalClient) error) error {
+	if cluster, ok := client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return fn(ctx, node)
+		})
+	}
+	return fn(ctx, client)
+}
+
+// mget reads several keys in one round trip, nil for missing keys. A cluster pipelines a GET per key,
+// which the client sends to each key's node.
+func mget(ctx context.Context, client redis.UniversalClient, keys ...string) ([]interface{}, error) {
+	if !isCluster(client) {
+		return client.MGet(ctx, keys...).Result()
+	}
+
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, cmd := range cmds {
+		val, err := cmd.Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[i] = val
+	}
+	return values, nil
+}
+
+// del removes several keys, returning how many existed. A cluster pipelines a DEL per key.
+func del(ctx context.Context, client redis.UniversalClient, keys ...string) (int64, error) {
+	if !isCluster(client) {
+		return client.Del(ctx, keys...).Result()
+	}
+
+	cmds := make([]*redis.IntCmd, len(keys))
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Del(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, cmd := range cmds {
+		deleted += cmd.Val()
+	}
+	return deleted, nil
+}
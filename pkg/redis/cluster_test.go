@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newClusterCache returns a cache over a cluster client; miniredis serves every slot from one node
+func newClusterCache(t *testing.T) (*miniredis.Miniredis, *JSONCache) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, NewJSONCache(client, "test", zap.NewNop().Sugar())
+}
+
+func TestJSONCache_Cluster(t *testing.T) {
+	ctx := context.Background()
+	users := generateTestUsers(3)
+
+	t.Run("GetMany and DeleteMany", func(t *testing.T) {
+		mr, cache := newClusterCache(t)
+
+		require.NoError(t, cache.SetMany(ctx, map[string]interface{}{"user-1": users[0], "user-3": users[2]}, time.Minute))
+
+		var result []TestUser
+		missing, err := cache.GetMany(ctx, []string{"user-1", "user-2", "user-3"}, &result)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, missing)
+		assert.Equal(t, []TestUser{users[0], {}, users[2]}, result)
+
+		deleted, err := cache.DeleteMany(ctx, []string{"user-1", "user-2", "user-3"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+		assert.False(t, mr.Exists("test:user-1"))
+	})
+
+	t.Run("DeleteByPrefix", func(t *testing.T) {
+		mr, cache := newClusterCache(t)
+
+		for _, user := range users {
+			require.NoError(t, cache.Set(ctx, user.ID, user, time.Minute))
+		}
+		require.NoError(t, cache.Set(ctx, "other", users[0], time.Minute))
+
+		deleted, err := cache.DeleteByPrefix(ctx, "user-")
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(users)), deleted)
+		assert.True(t, mr.Exists("test:other"))
+	})
+
+	t.Run("SetWithPointers and GetByPointer", func(t *testing.T) {
+		mr, cache := newClusterCache(t)
+
+		oldPointer, newPointer := "email:"+users[0].Email, "email:renamed@example.com"
+		require.NoError(t, cache.SetWithPointers(ctx, "user-1", users[0], []string{oldPointer}, time.Minute))
+
+		var result TestUser
+		require.NoError(t, cache.GetByPointer(ctx, oldPointer, &result))
+		assert.Equal(t, users[0], result)
+
+		// Rewriting the value without the pointer makes it stale
+		require.NoError(t, cache.SetWithPointers(ctx, "user-1", users[0], []string{newPointer}, time.Minute))
+		assert.ErrorIs(t, cache.GetByPointer(ctx, oldPointer, &result), redis.Nil)
+		assert.False(t, mr.Exists("test:"+oldPointer), "stale pointers are deleted")
+		require.NoError(t, cache.GetByPointer(ctx, newPointer, &result))
+
+		// A pointer whose target expired is kept
+		mr.Del("test:user-1")
+		assert.ErrorIs(t, cache.GetByPointer(ctx, newPointer, &result), redis.Nil)
+		assert.True(t, mr.Exists("test:"+newPointer))
+		assert.ErrorIs(t, cache.GetByPointer(ctx, "email:missing@example.com", &result), redis.Nil)
+	})
+}
@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec serializes cached values.
+type Codec interface {
+	// ID identifies the codec in the envelope of stored values; it must never be reused for another format.
+	ID() byte
+	// Name is how the codec is selected in configuration.
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// Codec IDs. Gzip-compressed variants set gzipFlag on the ID of the codec they wrap.
+const (
+	CodecIDJSON    byte = 1
+	CodecIDMsgPack byte = 2
+	gzipFlag       byte = 0x80
+)
+
+// Stored values other than JSON start with an envelope header: envelopeMagic, envelopeVersion and the codec ID.
+// JSON is stored bare, as before codecs existed, so existing entries, counters and values read with
+// redis-cli stay readable; no JSON text starts with envelopeMagic.
+const (
+	envelopeMagic   byte = 0x00
+	envelopeVersion byte = 1
+	envelopeSize         = 3
+)
+
+var (
+	// ErrUnknownCodec is returned when a stored value's envelope names a codec or version this build cannot read.
+	ErrUnknownCodec = errors.New("unknown cache codec")
+
+	// JSON is the default codec, readable by every other consumer of the cache.
+	JSON Codec = jsonCodec{}
+	// MsgPack encodes values as MessagePack, using their json struct tags.
+	MsgPack Codec = msgpackCodec{}
+	// JSONGzip and MsgPackGzip compress the output of JSON and MsgPack, trading CPU for memory on large values.
+	JSONGzip    Codec = gzipCodec{inner: JSON}
+	MsgPackGzip Codec = gzipCodec{inner: MsgPack}
+)
+
+// codecs holds every codec values can be read with, by ID
+var codecs = map[byte]Codec{
+	JSON.ID():        JSON,
+	MsgPack.ID():     MsgPack,
+	JSONGzip.ID():    JSONGzip,
+	MsgPackGzip.ID(): MsgPackGzip,
+}
+
+// CodecByName returns the codec configured by name: "json", "msgpack", "json+gzip" or "msgpack+gzip".
+func CodecByName(name string) (Codec, error) {
+	for _, codec := range codecs {
+		if codec.Name() == name {
+			return codec, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownCodec, name)
+}
+
+// encode serializes a value with codec, in the envelope when the codec needs one
+func encode(codec Codec, v any) ([]byte, error) {
+	data, err := codec.Marshal(v)
+	if err != nil || codec.ID() == CodecIDJSON {
+		return data, err
+	}
+	return append([]byte{envelopeMagic, envelopeVersion, codec.ID()}, data...), nil
+}
+
+// decode deserializes a stored value with the codec named in its envelope, or as JSON without one.
+// Values are read whichever codec the cache writes with, so codecs can be switched without flushing Redis.
+func decode(data []byte, v any) error {
+	if len(data) == 0 || data[0] != envelopeMagic {
+		return JSON.Unmarshal(data, v)
+	}
+	if len(data) < envelopeSize {
+		return fmt.Errorf("%w: truncated envelope", ErrUnknownCodec)
+	}
+	if data[1] != envelopeVersion {
+		return fmt.Errorf("%w: envelope version %d", ErrUnknownCodec, data[1])
+	}
+	codec, ok := codecs[data[2]]
+	if !ok {
+		return fmt.Errorf("%w: id %d", ErrUnknownCodec, data[2])
+	}
+	return codec.Unmarshal(data[envelopeSize:], v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ID() byte                           { return CodecIDJSON }
+func (jsonCodec) Name() string                       { return "json" }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) ID() byte     { return CodecIDMsgPack }
+func (msgpackCodec) Name() string { return "msgpack" }
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+// gzipWriters reuses compressors, which are costly to allocate
+var gzipWriters = sync.Pool{New: func() any { return gzip.NewWriter(nil) }}
+
+// gzipCodec compresses the output of another codec
+type gzipCodec struct {
+	inner Codec
+}
+
+func (c gzipCodec) ID() byte     { return c.inner.ID() | gzipFlag }
+func (c gzipCodec) Name() string { return c.inner.Name() + "+gzip" }
+
+func (c gzipCodec) Marshal(v any) ([]byte, error) {
+	data, err := c.inner.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(zw)
+	zw.Reset(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c gzipCodec) Unmarshal(data []byte, v any) error {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return err
+	}
+	return c.inner.Unmarshal(raw, v)
+}
@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type codecTestValue struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func TestCodecs(t *testing.T) {
+	value := codecTestValue{
+		ID:        uuid.New(),
+		Name:      "Ada",
+		Tags:      []string{"a", "b"},
+		CreatedAt: time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC),
+	}
+
+	for _, codec := range []Codec{JSON, MsgPack, JSONGzip, MsgPackGzip} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			data, err := encode(codec, value)
+			require.NoError(t, err)
+			if codec == JSON {
+				assert.Equal(t, byte('{'), data[0], "JSON is stored bare")
+			} else {
+				assert.Equal(t, []byte{envelopeMagic, envelopeVersion, codec.ID()}, data[:envelopeSize])
+			}
+
+			var decoded codecTestValue
+			require.NoError(t, decode(data, &decoded))
+			assert.Equal(t, value.ID, decoded.ID)
+			assert.Equal(t, value.Name, decoded.Name)
+			assert.Equal(t, value.Tags, decoded.Tags)
+			assert.True(t, value.CreatedAt.Equal(decoded.CreatedAt))
+
+			named, err := CodecByName(codec.Name())
+			require.NoError(t, err)
+			assert.Equal(t, codec, named)
+		})
+	}
+
+	t.Run("unknown codecs are rejected", func(t *testing.T) {
+		var decoded codecTestValue
+		assert.ErrorIs(t, decode([]byte{envelopeMagic, envelopeVersion, 0x7f}, &decoded), ErrUnknownCodec)
+		assert.ErrorIs(t, decode([]byte{envelopeMagic, envelopeVersion + 1, CodecIDJSON}, &decoded), ErrUnknownCodec)
+		assert.ErrorIs(t, decode([]byte{envelopeMagic}, &decoded), ErrUnknownCodec)
+
+		_, err := CodecByName("xml")
+		assert.ErrorIs(t, err, ErrUnknownCodec)
+	})
+}
+
+func TestJSONCache_SwitchCodec(t *testing.T) {
+	suite := SetupSuite()
+	defer suite.Server.Close()
+	ctx := context.Background()
+
+	users := generateTestUsers(2)
+	packed := NewCacheWithCodec(suite.Cache.client, "test", MsgPackGzip, suite.logger)
+
+	// Entries written before and after the switch are both read
+	require.NoError(t, suite.Cache.Set(ctx, "user-1", users[0], time.Minute))
+	require.NoError(t, packed.Set(ctx, "user-2", users[1], time.Minute))
+
+	for _, cache := range []*JSONCache{suite.Cache, packed} {
+		var first, second TestUser
+		require.NoError(t, cache.Get(ctx, "user-1", &first))
+		require.NoError(t, cache.Get(ctx, "user-2", &second))
+		assert.Equal(t, users[0], first)
+		assert.Equal(t, users[1], second)
+	}
+
+	// Counters stay plain integers whatever the codec
+	_, err := packed.Incr(ctx, "counter")
+	require.NoError(t, err)
+	var counter int64
+	require.NoError(t, packed.Get(ctx, "counter", &counter))
+	assert.Equal(t, int64(1), counter)
+}
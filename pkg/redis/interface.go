@@ -10,6 +10,18 @@ type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Get(ctx context.Context, key string, dest interface{}) error
 	Delete(ctx context.Context, key string) error
+	GetMany(ctx context.Context, keys []string, dest interface{}) ([]int, error)
+	SetMany(ctx context.Context, entries map[string]interface{}, ttl time.Duration) error
+	DeleteMany(ctx context.Context, keys []string) (int64, error)
+	SetIfNotExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
 	SetPointer(ctx context.Context, key string, targetKey string, ttl time.Duration) error
 	GetPointer(ctx context.Context, key string) (string, error)
+	SetWithPointers(ctx context.Context, key string, value interface{}, pointers []string, ttl time.Duration) error
+	GetByPointer(ctx context.Context, pointer string, dest interface{}) error
+	DeleteByPrefix(ctx context.Context, prefix string) (int64, error)
+	CountByPrefix(ctx context.Context, prefix string) (int64, error)
+	SetCounterIfNotExists(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error)
+	Incr(ctx context.Context, key string) (int64, error)
+	GetOrLoad(ctx context.Context, key string, dest interface{}, opts LoadOptions, load func(ctx context.Context) (any, error)) error
+	Coalesce(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error)
 }
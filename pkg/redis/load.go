@@ -0,0 +1,189 @@
+package redis
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultBeta is the usual weight of probabilistic early expiration; higher values refresh earlier.
+const DefaultBeta = 1.0
+
+// loadTimeout bounds a load shared by coalesced callers or run in the background,
+// as it outlives the request that started it.
+const loadTimeout = 10 * time.Second
+
+// LoadOptions controls how GetOrLoad keeps a value fresh.
+type LoadOptions struct {
+	// TTL is how long a loaded value is fresh.
+	TTL time.Duration
+	// StaleTTL is how long a value is still served after TTL while it is reloaded in the background.
+	// Zero disables stale-while-revalidate: values expire after TTL.
+	StaleTTL time.Duration
+	// Beta weighs probabilistic early expiration (XFetch): fresh values are reloaded in the background
+	// with a probability rising as expiry nears, scaled by how long loads take. Zero disables it.
+	Beta float64
+}
+
+// Coalesce runs fn once for concurrent callers sharing a key within this process, and gives each the result.
+// fn runs detached from the first caller's cancellation, bounded by loadTimeout;
+// each caller still stops waiting when its own context is done.
+func (c *JSONCache) Coalesce(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	namespacedKey := c.prefix + ":" + key
+	ch := c.group.DoChan(namespacedKey, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		return fn(loadCtx)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Shared {
+			c.logger.Debugw("Coalesced load", "key", namespacedKey)
+		}
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GetOrLoad reads a value into dest, loading and caching it on a miss. Concurrent misses on a key
+// share one load (see Coalesce), and values nearing or past expiry are reloaded in the background
+// while the cached value is served (see LoadOptions). Values are stored as Set stores them, so
+// keys written by Set are read too. Load errors are returned as-is and not cached.
+// When Redis is unavailable, or the breaker is open, every call loads.
+func (c *JSONCache) GetOrLoad(ctx context.Context, key string, dest interface{}, opts LoadOptions, load func(ctx context.Context) (any, error)) error {
+	namespacedKey := c.prefix + ":" + key
+
+	// Read the value and its remaining lifetime in one round trip
+	var (
+		getCmd *redis.StringCmd
+		ttlCmd *redis.DurationCmd
+	)
+	err := c.call(func() error {
+		_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			getCmd = pipe.Get(ctx, namespacedKey)
+			ttlCmd = pipe.PTTL(ctx, namespacedKey)
+			return nil
+		})
+		return err
+	})
+	switch {
+	case err == nil:
+		if err := decode([]byte(getCmd.Val()), dest); err != nil {
+			c.logger.Warnw("Failed to deserialize cached data", "key", namespacedKey, "error", err)
+			break
+		}
+		if c.shouldRefresh(ttlCmd.Val(), opts) {
+			c.refresh(ctx, key, opts, load)
+		}
+		return nil
+	case err != redis.Nil:
+		c.logger.Warnw("Failed to get data from Redis", "key", namespacedKey, "error", err)
+	}
+
+	loaded, err := c.load(ctx, key, opts, load)
+	if err != nil {
+		return err
+	}
+
+	// Hand over the loaded value itself when dest can hold it, as it was before serialization
+	if target := reflect.ValueOf(dest); target.Kind() == reflect.Pointer && !target.IsNil() {
+		if value := reflect.ValueOf(loaded.value); value.IsValid() && value.Type().AssignableTo(target.Elem().Type()) {
+			target.Elem().Set(value)
+			return nil
+		}
+	}
+	return decode(loaded.data, dest)
+}
+
+// loadedValue is a loaded value and its serialized form
+type loadedValue struct {
+	value any
+	data  []byte
+}
+
+// shouldRefresh reports whether a cached value with the given remaining lifetime should be reloaded.
+// Values without an expiry are never reloaded.
+func (c *JSONCache) shouldRefresh(remaining time.Duration, opts LoadOptions) bool {
+	if remaining < 0 {
+		return false
+	}
+
+	// Past TTL, a value is only still cached during the stale window
+	fresh := remaining - opts.StaleTTL
+	if fresh <= 0 {
+		return true
+	}
+
+	// XFetch: refresh when delta * beta * -ln(rand) reaches the time left, where delta is the load time
+	delta := c.loadTime.Load()
+	if opts.Beta <= 0 || delta <= 0 {
+		return false
+	}
+	return float64(delta)*opts.Beta*-math.Log(rand.Float64()) >= float64(fresh)
+}
+
+// refresh reloads a value in the background; a key is refreshed by one goroutine at a time
+func (c *JSONCache) refresh(ctx context.Context, key string, opts LoadOptions, load func(ctx context.Context) (any, error)) {
+	namespacedKey := c.prefix + ":" + key
+	if _, running := c.refreshing.LoadOrStore(namespacedKey, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer c.refreshing.Delete(namespacedKey)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		if _, err := c.load(ctx, key, opts, load); err != nil {
+			c.logger.Warnw("Failed to refresh cached data", "key", namespacedKey, "error", err)
+			return
+		}
+		c.logger.Debugw("Cached data refreshed", "key", namespacedKey)
+	}()
+}
+
+// load runs a coalesced load and caches its serialized result for TTL plus the stale window
+func (c *JSONCache) load(ctx context.Context, key string, opts LoadOptions, load func(ctx context.Context) (any, error)) (loadedValue, error) {
+	namespacedKey := c.prefix + ":" + key
+	val, err := c.Coalesce(ctx, key, func(ctx context.Context) (any, error) {
+		start := time.Now()
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.observeLoad(time.Since(start))
+
+		data, err := encode(c.codec, value)
+		if err != nil {
+			c.logger.Errorw("Failed to serialize data", "key", namespacedKey, "error", err)
+			return nil, err
+		}
+
+		// A failed write still returns the loaded value; the next read loads again
+		if err := c.call(func() error { return c.client.Set(ctx, namespacedKey, data, opts.TTL+opts.StaleTTL).Err() }); err != nil {
+			c.logger.Warnw("Failed to set data in Redis", "key", namespacedKey, "error", err)
+		}
+		return loadedValue{value: value, data: data}, nil
+	})
+	if err != nil {
+		return loadedValue{}, err
+	}
+	return val.(loadedValue), nil
+}
+
+// observeLoad folds a load duration into the moving average used for early expiration.
+// Concurrent updates may drop a sample, which only makes the average slightly less smooth.
+func (c *JSONCache) observeLoad(d time.Duration) {
+	prev := c.loadTime.Load()
+	if prev == 0 {
+		c.loadTime.Store(int64(d))
+		return
+	}
+	c.loadTime.Store((7*prev + int64(d)) / 8)
+}
@@ -0,0 +1,169 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONCache_GetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	opts := LoadOptions{TTL: time.Minute, StaleTTL: 30 * time.Second}
+
+	t.Run("miss loads and caches for TTL plus the stale window", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		users := generateTestUsers(1)
+		var result TestUser
+		err := suite.Cache.GetOrLoad(ctx, "user-1", &result, opts, func(ctx context.Context) (any, error) {
+			return users[0], nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, users[0], result)
+		assert.Equal(t, 90*time.Second, suite.Server.TTL("test:user-1"))
+
+		// Values stored by GetOrLoad are read by Get
+		var read TestUser
+		require.NoError(t, suite.Cache.Get(ctx, "user-1", &read))
+		assert.Equal(t, users[0], read)
+	})
+
+	t.Run("hit does not load", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		users := generateTestUsers(1)
+		require.NoError(t, suite.Cache.Set(ctx, "user-1", users[0], time.Hour))
+
+		var result TestUser
+		err := suite.Cache.GetOrLoad(ctx, "user-1", &result, opts, func(ctx context.Context) (any, error) {
+			t.Fatal("fresh value should not be loaded")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, users[0], result)
+	})
+
+	t.Run("load errors are returned and not cached", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		errLoad := errors.New("not found")
+		var result TestUser
+		err := suite.Cache.GetOrLoad(ctx, "missing", &result, opts, func(ctx context.Context) (any, error) {
+			return nil, errLoad
+		})
+		assert.ErrorIs(t, err, errLoad)
+		assert.False(t, suite.Server.Exists("test:missing"))
+	})
+
+	t.Run("concurrent misses share one load", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		var loads atomic.Int32
+		release := make(chan struct{})
+		load := func(ctx context.Context) (any, error) {
+			loads.Add(1)
+			<-release
+			return generateTestUsers(1)[0], nil
+		}
+
+		const callers = 20
+		var wg sync.WaitGroup
+		results := make([]TestUser, callers)
+		for i := range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, suite.Cache.GetOrLoad(ctx, "hot", &results[i], opts, load))
+			}()
+		}
+
+		// Let every caller reach the shared load before it completes
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), loads.Load())
+		for _, result := range results {
+			assert.Equal(t, "user-1", result.ID)
+		}
+	})
+
+	t.Run("stale value is served while it is refreshed", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		users := generateTestUsers(2)
+		require.NoError(t, suite.Cache.Set(ctx, "user", users[0], opts.TTL+opts.StaleTTL))
+		suite.Server.FastForward(opts.TTL + time.Second) // Inside the stale window
+
+		refreshed := make(chan struct{})
+		var result TestUser
+		err := suite.Cache.GetOrLoad(ctx, "user", &result, opts, func(ctx context.Context) (any, error) {
+			defer close(refreshed)
+			return users[1], nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, users[0], result, "stale value is served")
+
+		<-refreshed
+		require.Eventually(t, func() bool {
+			var read TestUser
+			return suite.Cache.Get(ctx, "user", &read) == nil && read == users[1]
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("values near expiry are refreshed early", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		users := generateTestUsers(2)
+		early := LoadOptions{TTL: time.Minute, Beta: DefaultBeta}
+		require.NoError(t, suite.Cache.Set(ctx, "user", users[0], time.Millisecond))
+		suite.Cache.observeLoad(time.Hour) // Loads slower than the time left always refresh
+
+		refreshed := make(chan struct{})
+		var result TestUser
+		err := suite.Cache.GetOrLoad(ctx, "user", &result, early, func(ctx context.Context) (any, error) {
+			defer close(refreshed)
+			return users[1], nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, users[0], result)
+		<-refreshed
+	})
+
+	t.Run("Redis errors fall back to loading", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.Server.Close()
+
+		var result TestUser
+		err := suite.Cache.GetOrLoad(ctx, "user", &result, opts, func(ctx context.Context) (any, error) {
+			return generateTestUsers(1)[0], nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", result.ID)
+	})
+}
+
+func TestJSONCache_ShouldRefresh(t *testing.T) {
+	suite := SetupSuite()
+	defer suite.Server.Close()
+	opts := LoadOptions{TTL: time.Minute, StaleTTL: time.Minute, Beta: DefaultBeta}
+
+	assert.False(t, suite.Cache.shouldRefresh(-1, opts), "values without expiry are kept")
+	assert.True(t, suite.Cache.shouldRefresh(30*time.Second, opts), "values in the stale window are refreshed")
+	assert.False(t, suite.Cache.shouldRefresh(90*time.Second, opts), "no early refresh before a load was timed")
+
+	suite.Cache.observeLoad(time.Millisecond)
+	assert.False(t, suite.Cache.shouldRefresh(time.Hour, opts), "fast loads are not refreshed long before expiry")
+}
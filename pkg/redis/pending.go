@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"context"
+	"sync"
+)
+
+// maxPendingInvalidations bounds the invalidations a cache keeps to replay. Past it, the cache's
+// whole namespace is flushed on recovery instead.
+const maxPendingInvalidations = 10000
+
+// pendingInvalidations are the invalidations of a cache that did not reach Redis, because its breaker
+// was open or the call failed. They are replayed once Redis is reachable, so the entries they should
+// have removed are not served until their TTL runs out.
+type pendingInvalidations struct {
+	mu       sync.Mutex
+	keys     map[string]struct{} // Namespaced keys to delete
+	prefixes map[string]struct{} // Prefixes within the namespace to delete
+	counters map[string]struct{} // Counters within the namespace to increment, once however often they were
+	flush    bool                // Too many were dropped to keep: flush the namespace
+}
+
+// addKeys queues namespaced keys to delete
+func (p *pendingInvalidations) addKeys(keys ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, key := range keys {
+		p.keys = addPending(p, p.keys, key)
+	}
+}
+
+// addPrefix queues a prefix to delete
+func (p *pendingInvalidations) addPrefix(prefix string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prefixes = addPending(p, p.prefixes, prefix)
+}
+
+// addCounter queues a counter to increment
+func (p *pendingInvalidations) addCounter(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counters = addPending(p, p.counters, key)
+}
+
+// addPending adds value to set, or gives up on keeping invalidations once there are too many; p.mu must be held
+func addPending(p *pendingInvalidations, set map[string]struct{}, value string) map[string]struct{} {
+	if p.flush {
+		return set
+	}
+	if len(p.keys)+len(p.prefixes)+len(p.counters) >= maxPendingInvalidations {
+		p.keys, p.prefixes, p.counters, p.flush = nil, nil, nil, true
+		return nil
+	}
+	if set == nil {
+		set = make(map[string]struct{})
+	}
+	set[value] = struct{}{}
+	return set
+}
+
+// take returns the queued invalidations and clears the queue
+func (p *pendingInvalidations) take() (keys, prefixes, counters map[string]struct{}, flush bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keys, prefixes, counters, flush = p.keys, p.prefixes, p.counters, p.flush
+	p.keys, p.prefixes, p.counters, p.flush = nil, nil, nil, false
+	return keys, prefixes, counters, flush
+}
+
+// invalidate runs an invalidation through c's breaker like call, queuing it with queue when it does not
+// reach Redis. Without a breaker there is no recovery to replay it on, so nothing is queued.
+func (c *JSONCache) invalidate(fn func() error, queue func(p *pendingInvalidations)) error {
+	err := c.call(fn)
+	if c.breaker != nil && isFailure(err) {
+		queue(&c.pending)
+	}
+	return err
+}
+
+// replayInvalidations replays the invalidations that did not reach Redis. Those failing again are
+// queued again, to be replayed on the next recovery.
+func (c *JSONCache) replayInvalidations(ctx context.Context) {
+	keys, prefixes, counters, flush := c.pending.take()
+	if flush {
+		c.logger.Warnw("Flushing cache namespace: too many invalidations were dropped while Redis was unreachable", "prefix", c.prefix)
+		_, _ = c.DeleteByPrefix(ctx, "")
+		return
+	}
+	if len(keys)+len(prefixes)+len(counters) == 0 {
+		return
+	}
+
+	c.logger.Infow("Replaying cache invalidations dropped while Redis was unreachable",
+		"prefix", c.prefix, "keys", len(keys), "prefixes", len(prefixes), "counters", len(counters))
+
+	namespacedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		namespacedKeys = append(namespacedKeys, key)
+	}
+	for start := 0; start < len(namespacedKeys); start += deleteBatchSize {
+		batch := namespacedKeys[start:min(start+deleteBatchSize, len(namespacedKeys))]
+		err := c.invalidate(func() error {
+			_, err := del(ctx, c.client, batch...)
+			return err
+		}, func(p *pendingInvalidations) { p.addKeys(batch...) })
+		if err != nil {
+			c.logger.Warnw("Failed to replay cache invalidations", "prefix", c.prefix, "error", err)
+		}
+	}
+	for prefix := range prefixes {
+		_, _ = c.DeleteByPrefix(ctx, prefix)
+	}
+	for key := range counters {
+		_, _ = c.Incr(ctx, key)
+	}
+}
@@ -0,0 +1,153 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// derefScript resolves a pointer key to the value of its target in one atomic step.
+// The target's back-references, the pointer keys written with it by SetWithPointers, must include
+// the pointer; otherwise the pointer is stale, as the target was rewritten without it, and is deleted.
+// KEYS[1] is the namespaced pointer key; ARGV[1] the namespace and ARGV[2] the pointer key.
+// The target key is derived from the pointer's value, which Redis Cluster does not allow; there,
+// getByPointerBytes resolves pointers in two steps instead.
+var derefScript = redis.NewScript(`
+local target = redis.call('GET', KEYS[1])
+if not target then
+	return false
+end
+local key = ARGV[1] .. ':' .. target
+local value = redis.call('GET', key)
+if not value then
+	return false
+end
+if redis.call('SISMEMBER', '{' .. key .. '}:refs', ARGV[2]) == 0 then
+	redis.call('DEL', KEYS[1])
+	return false
+end
+return value
+`)
+
+// staleDeleteScript deletes the pointer KEYS[1] if it still refers to ARGV[1]
+var staleDeleteScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// refsKey is the set of pointer keys referring to a namespaced key. The hash tag keeps it on the key's slot.
+func refsKey(namespacedKey string) string {
+	return "{" + namespacedKey + "}:refs"
+}
+
+// SetWithPointers stores a value and pointers to it in one transaction, so neither is ever written
+// without the other. The pointers become the value's only back-references: pointers written with an
+// earlier version of the value and not listed again are stale, and GetByPointer no longer follows them.
+// On a cluster the transaction is split per slot: the value and its back-references are written together,
+// but a pointer may be written apart from them, and GetByPointer ignores it until they are.
+func (c *JSONCache) SetWithPointers(ctx context.Context, key string, value interface{}, pointers []string, ttl time.Duration) error {
+	data, err := encode(c.codec, value)
+	if err != nil {
+		c.logger.Errorw("Failed to serialize data", "key", key, "error", err)
+		return err
+	}
+
+	namespacedKey := c.prefix + ":" + key
+	refs := refsKey(namespacedKey)
+	err = c.call(func() error {
+		_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, namespacedKey, data, ttl)
+			pipe.Del(ctx, refs)
+			for _, pointer := range pointers {
+				pipe.Set(ctx, c.prefix+":"+pointer, key, ttl)
+				pipe.SAdd(ctx, refs, pointer)
+			}
+			if len(pointers) > 0 && ttl > 0 {
+				pipe.Expire(ctx, refs, ttl)
+			}
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		c.logger.Errorw("Failed to set data with pointers in Redis", "key", namespacedKey, "pointers", pointers, "error", err)
+		return err
+	}
+
+	c.logger.Debugw("Data cached with pointers", "key", namespacedKey, "pointers", pointers)
+	return nil
+}
+
+// GetByPointer reads the value a pointer key refers to in one round trip, returning redis.Nil
+// when the pointer or its target is missing, or the pointer is stale (see SetWithPointers).
+func (c *JSONCache) GetByPointer(ctx context.Context, pointer string, dest interface{}) error {
+	data, err := c.getByPointerBytes(ctx, pointer)
+	if err != nil {
+		return err
+	}
+
+	if err := decode(data, dest); err != nil {
+		c.logger.Warnw("Failed to deserialize cached data", "pointer", c.prefix+":"+pointer, "error", err)
+		return err
+	}
+	return nil
+}
+
+// getByPointerBytes runs derefScript, returning the serialized target value
+func (c *JSONCache) getByPointerBytes(ctx context.Context, pointer string) ([]byte, error) {
+	namespacedPointer := c.prefix + ":" + pointer
+	var data []byte
+	err := c.call(func() (err error) {
+		if isCluster(c.client) {
+			data, err = c.derefCluster(ctx, pointer)
+			return err
+		}
+		text, err := derefScript.Run(ctx, c.client, []string{namespacedPointer}, c.prefix, pointer).Text()
+		data = []byte(text)
+		return err
+	})
+	if err != nil {
+		if err == redis.Nil {
+			return nil, err // missing or stale pointer
+		}
+		c.logger.Warnw("Failed to resolve pointer in Redis", "pointer", namespacedPointer, "error", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// derefCluster follows derefScript in two round trips: the pointer is read first, then its target
+// with the target's back-references, which share the target's slot. A pointer found stale is
+// deleted unless it was rewritten meanwhile.
+func (c *JSONCache) derefCluster(ctx context.Context, pointer string) ([]byte, error) {
+	namespacedPointer := c.prefix + ":" + pointer
+	target, err := c.client.Get(ctx, namespacedPointer).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	key := c.prefix + ":" + target
+	var value *redis.StringCmd
+	var referenced *redis.BoolCmd
+	_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		value = pipe.Get(ctx, key)
+		referenced = pipe.SIsMember(ctx, refsKey(key), pointer)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	if value.Err() == redis.Nil {
+		return nil, redis.Nil
+	}
+	if !referenced.Val() {
+		if err := staleDeleteScript.Run(ctx, c.client, []string{namespacedPointer}, target).Err(); err != nil && err != redis.Nil {
+			c.logger.Warnw("Failed to delete stale pointer from Redis", "pointer", namespacedPointer, "error", err)
+		}
+		return nil, redis.Nil
+	}
+	return value.Bytes()
+}
@@ -0,0 +1,87 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONCache_Pointers(t *testing.T) {
+	ctx := context.Background()
+	users := generateTestUsers(2)
+	renamed := TestUser{ID: users[0].ID, Email: "renamed@example.com"}
+
+	t.Run("SetWithPointers and GetByPointer - success", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		require.NoError(t, suite.Cache.SetWithPointers(ctx, "user-1", users[0], []string{"email:" + users[0].Email}, time.Minute))
+		assert.Equal(t, time.Minute, suite.Server.TTL("test:user-1"))
+		assert.Equal(t, time.Minute, suite.Server.TTL("test:email:"+users[0].Email))
+		assert.Equal(t, time.Minute, suite.Server.TTL("{test:user-1}:refs"))
+
+		var result TestUser
+		require.NoError(t, suite.Cache.GetByPointer(ctx, "email:"+users[0].Email, &result))
+		assert.Equal(t, users[0], result)
+	})
+
+	t.Run("GetByPointer - pointers dropped by a rewrite are stale", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		oldPointer, newPointer := "email:"+users[0].Email, "email:"+renamed.Email
+		require.NoError(t, suite.Cache.SetWithPointers(ctx, "user-1", users[0], []string{oldPointer}, time.Minute))
+		require.NoError(t, suite.Cache.SetWithPointers(ctx, "user-1", renamed, []string{newPointer}, time.Minute))
+
+		var result TestUser
+		assert.ErrorIs(t, suite.Cache.GetByPointer(ctx, oldPointer, &result), redis.Nil)
+		assert.False(t, suite.Server.Exists("test:"+oldPointer), "stale pointers are deleted")
+
+		require.NoError(t, suite.Cache.GetByPointer(ctx, newPointer, &result))
+		assert.Equal(t, renamed, result)
+	})
+
+	t.Run("GetByPointer - misses", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		var result TestUser
+		assert.ErrorIs(t, suite.Cache.GetByPointer(ctx, "email:missing@example.com", &result), redis.Nil)
+
+		// A pointer whose target expired is kept for when the target is cached again
+		require.NoError(t, suite.Cache.SetWithPointers(ctx, "user-2", users[1], []string{"email:" + users[1].Email}, time.Minute))
+		suite.Server.Del("test:user-2")
+		assert.ErrorIs(t, suite.Cache.GetByPointer(ctx, "email:"+users[1].Email, &result), redis.Nil)
+		assert.True(t, suite.Server.Exists("test:email:"+users[1].Email))
+	})
+
+	t.Run("Delete removes back-references", func(t *testing.T) {
+		suite := SetupSuite()
+		defer suite.Server.Close()
+
+		pointer := "email:" + users[0].Email
+		require.NoError(t, suite.Cache.SetWithPointers(ctx, "user-1", users[0], []string{pointer}, time.Minute))
+		require.NoError(t, suite.Cache.Delete(ctx, "user-1"))
+		assert.False(t, suite.Server.Exists("{test:user-1}:refs"))
+
+		// A value stored again without the pointer does not revive it
+		require.NoError(t, suite.Cache.Set(ctx, "user-1", renamed, time.Minute))
+		var result TestUser
+		assert.ErrorIs(t, suite.Cache.GetByPointer(ctx, pointer, &result), redis.Nil)
+	})
+
+	t.Run("Redis errors are returned", func(t *testing.T) {
+		suite := SetupSuite()
+		suite.Server.Close()
+
+		assert.Error(t, suite.Cache.SetWithPointers(ctx, "user-1", users[0], []string{"email:" + users[0].Email}, time.Minute))
+		var result TestUser
+		err := suite.Cache.GetByPointer(ctx, "email:"+users[0].Email, &result)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, redis.Nil)
+	})
+}
@@ -2,38 +2,66 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 var _ Cache = (*JSONCache)(nil) // compile-time interface check
 
+// deleteBatchSize bounds the keys requested per SCAN and removed per DEL
+const deleteBatchSize = 500
+
+// globEscaper escapes the characters SCAN MATCH patterns treat specially
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// JSONCache stores values under a key prefix, serialized with its codec (JSON unless chosen otherwise).
+// Values written with any codec are read back, so the codec can change while entries are cached.
 type JSONCache struct {
-	client *redis.Client
-	prefix string
-	logger *zap.SugaredLogger
+	client  redis.UniversalClient
+	prefix  string
+	codec   Codec
+	logger  *zap.SugaredLogger
+	breaker *Breaker             // Guards calls to Redis; nil lets every call through
+	pending pendingInvalidations // Invalidations to replay once Redis is reachable
+
+	group      singleflight.Group // Coalesces concurrent loads per key
+	refreshing sync.Map           // Keys being refreshed in the background
+	loadTime   atomic.Int64       // Moving average of load durations, in nanoseconds
+}
+
+func NewJSONCache(client redis.UniversalClient, prefix string, logger *zap.SugaredLogger) *JSONCache {
+	return NewCacheWithCodec(client, prefix, JSON, logger)
 }
 
-func NewJSONCache(client *redis.Client, prefix string, logger *zap.SugaredLogger) *JSONCache {
+// NewCacheWithCodec initializes a cache writing values with codec.
+func NewCacheWithCodec(client redis.UniversalClient, prefix string, codec Codec, logger *zap.SugaredLogger) *JSONCache {
 	return &JSONCache{
 		client: client,
 		prefix: prefix,
+		codec:  codec,
 		logger: logger,
 	}
 }
 
 func (c *JSONCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	data, err := json.Marshal(value)
+	data, err := encode(c.codec, value)
 	if err != nil {
 		c.logger.Errorw("Failed to serialize data", "key", key, "error", err)
 		return err
 	}
+	return c.setBytes(ctx, key, data, ttl)
+}
 
+// setBytes stores serialized data under a key
+func (c *JSONCache) setBytes(ctx context.Context, key string, data []byte, ttl time.Duration) error {
 	namespacedKey := c.prefix + ":" + key
-	if err := c.client.Set(ctx, namespacedKey, data, ttl).Err(); err != nil {
+	if err := c.call(func() error { return c.client.Set(ctx, namespacedKey, data, ttl).Err() }); err != nil {
 		c.logger.Errorw("Failed to set data in Redis", "key", namespacedKey, "error", err)
 		return err
 	}
@@ -43,37 +71,77 @@ func (c *JSONCache) Set(ctx context.Context, key string, value interface{}, ttl
 }
 
 func (c *JSONCache) Get(ctx context.Context, key string, dest interface{}) error {
-	namespacedKey := c.prefix + ":" + key
-	data, err := c.client.Get(ctx, namespacedKey).Result()
+	data, err := c.getBytes(ctx, key)
 	if err != nil {
-		if err == redis.Nil {
-			return err // cache miss is expected
-		}
-		c.logger.Warnw("Failed to get data from Redis", "key", namespacedKey, "error", err)
 		return err
 	}
 
-	if err := json.Unmarshal([]byte(data), dest); err != nil {
-		c.logger.Warnw("Failed to deserialize cached data", "key", namespacedKey, "error", err)
+	if err := decode(data, dest); err != nil {
+		c.logger.Warnw("Failed to deserialize cached data", "key", c.prefix+":"+key, "error", err)
 		return err
 	}
 
 	return nil
 }
 
+// getBytes reads the serialized data stored under a key, returning redis.Nil on a miss
+func (c *JSONCache) getBytes(ctx context.Context, key string) ([]byte, error) {
+	namespacedKey := c.prefix + ":" + key
+	var data []byte
+	err := c.call(func() (err error) {
+		data, err = c.client.Get(ctx, namespacedKey).Bytes()
+		return err
+	})
+	if err != nil {
+		if err == redis.Nil {
+			return nil, err // cache miss is expected
+		}
+		c.logger.Warnw("Failed to get data from Redis", "key", namespacedKey, "error", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// Delete removes a key, with the back-references of pointers to it (see SetWithPointers)
 func (c *JSONCache) Delete(ctx context.Context, key string) error {
 	namespacedKey := c.prefix + ":" + key
-	if err := c.client.Del(ctx, namespacedKey).Err(); err != nil {
+	err := c.invalidate(func() error {
+		return c.client.Del(ctx, namespacedKey, refsKey(namespacedKey)).Err()
+	}, func(p *pendingInvalidations) { p.addKeys(namespacedKey, refsKey(namespacedKey)) })
+	if err != nil {
 		c.logger.Warnw("Failed to delete key from Redis", "key", namespacedKey, "error", err)
 		return err
 	}
 	return nil
 }
 
+// SetIfNotExists stores a value only when the key is absent, reporting whether it was stored
+func (c *JSONCache) SetIfNotExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	data, err := encode(c.codec, value)
+	if err != nil {
+		c.logger.Errorw("Failed to serialize data", "key", key, "error", err)
+		return false, err
+	}
+
+	namespacedKey := c.prefix + ":" + key
+	var stored bool
+	err = c.call(func() (err error) {
+		stored, err = c.client.SetNX(ctx, namespacedKey, data, ttl).Result()
+		return err
+	})
+	if err != nil {
+		c.logger.Errorw("Failed to set data in Redis", "key", namespacedKey, "error", err)
+		return false, err
+	}
+
+	c.logger.Debugw("Conditional set", "key", namespacedKey, "stored", stored)
+	return stored, nil
+}
+
 // SetPointer sets a Redis string pointer from one key to another
 func (c *JSONCache) SetPointer(ctx context.Context, key string, targetKey string, ttl time.Duration) error {
 	namespacedKey := c.prefix + ":" + key
-	if err := c.client.Set(ctx, namespacedKey, targetKey, ttl).Err(); err != nil {
+	if err := c.call(func() error { return c.client.Set(ctx, namespacedKey, targetKey, ttl).Err() }); err != nil {
 		c.logger.Errorw("Failed to set pointer in Redis", "key", namespacedKey, "target", targetKey, "error", err)
 		return err
 	}
@@ -84,7 +152,11 @@ func (c *JSONCache) SetPointer(ctx context.Context, key string, targetKey string
 // GetPointer retrieves the pointer value (e.g., a UUID string)
 func (c *JSONCache) GetPointer(ctx context.Context, key string) (string, error) {
 	namespacedKey := c.prefix + ":" + key
-	val, err := c.client.Get(ctx, namespacedKey).Result()
+	var val string
+	err := c.call(func() (err error) {
+		val, err = c.client.Get(ctx, namespacedKey).Result()
+		return err
+	})
 	if err != nil {
 		if err == redis.Nil {
 			return "", nil // cache miss — expected sometimes
@@ -94,3 +166,111 @@ func (c *JSONCache) GetPointer(ctx context.Context, key string) (string, error)
 	}
 	return val, nil
 }
+
+// DeleteByPrefix removes every key in the namespace starting with prefix, with their back-references,
+// returning how many were removed. Keys are found with SCAN, on every node of a cluster, so it does not
+// block Redis, but keys written meanwhile may survive.
+func (c *JSONCache) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	var deleted atomic.Int64
+	err := c.invalidate(func() error {
+		return c.scanPrefix(ctx, prefix, func(keys []string) error {
+			n, err := del(ctx, c.client, keys...)
+			if err != nil {
+				c.logger.Warnw("Failed to delete keys from Redis", "prefix", c.prefix+":"+prefix, "error", err)
+				return err
+			}
+			deleted.Add(n)
+			return nil
+		})
+	}, func(p *pendingInvalidations) { p.addPrefix(prefix) })
+	if err != nil {
+		return deleted.Load(), err
+	}
+
+	c.logger.Debugw("Keys deleted by prefix", "prefix", c.prefix+":"+prefix, "deleted", deleted.Load())
+	return deleted.Load(), nil
+}
+
+// CountByPrefix counts the keys in the namespace starting with prefix, including pointers and
+// back-references. Keys are found with SCAN, as for DeleteByPrefix, so the count is approximate
+// while keys are written.
+func (c *JSONCache) CountByPrefix(ctx context.Context, prefix string) (int64, error) {
+	var count atomic.Int64
+	err := c.call(func() error {
+		return c.scanPrefix(ctx, prefix, func(keys []string) error {
+			count.Add(int64(len(keys)))
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count.Load(), nil
+}
+
+// scanPrefix passes the namespaced keys starting with prefix, and the back-references of those keys,
+// to fn in batches of up to deleteBatchSize. The nodes of a cluster are scanned concurrently.
+func (c *JSONCache) scanPrefix(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	pattern := globEscaper.Replace(c.prefix+":"+prefix) + "*"
+	patterns := []string{pattern, "{" + pattern} // Back-references are named {key}:refs
+
+	return forEachNode(ctx, c.client, func(ctx context.Context, node redis.UniversalClient) error {
+		keys := make([]string, 0, deleteBatchSize)
+		for _, pattern := range patterns {
+			iter := node.Scan(ctx, 0, pattern, deleteBatchSize).Iterator()
+			for iter.Next(ctx) {
+				keys = append(keys, iter.Val())
+				if len(keys) == deleteBatchSize {
+					if err := fn(keys); err != nil {
+						return err
+					}
+					keys = keys[:0]
+				}
+			}
+			if err := iter.Err(); err != nil {
+				c.logger.Warnw("Failed to scan keys in Redis", "pattern", pattern, "error", err)
+				return err
+			}
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		return fn(keys)
+	})
+}
+
+// SetCounterIfNotExists seeds an integer counter at value only when the key is absent, reporting whether
+// it was stored. Counters are written as plain integers rather than with the codec, so Incr can increment them.
+func (c *JSONCache) SetCounterIfNotExists(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+	namespacedKey := c.prefix + ":" + key
+	var stored bool
+	err := c.call(func() (err error) {
+		stored, err = c.client.SetNX(ctx, namespacedKey, value, ttl).Result()
+		return err
+	})
+	if err != nil {
+		c.logger.Errorw("Failed to seed counter in Redis", "key", namespacedKey, "error", err)
+		return false, err
+	}
+
+	c.logger.Debugw("Counter seeded", "key", namespacedKey, "stored", stored)
+	return stored, nil
+}
+
+// Incr atomically increments an integer counter, creating it at 1 when absent, and returns the new value.
+// Counters must be seeded with SetCounterIfNotExists rather than Set, which encodes values with the codec;
+// their plain integers decode as JSON, so they can be read with Get.
+func (c *JSONCache) Incr(ctx context.Context, key string) (int64, error) {
+	namespacedKey := c.prefix + ":" + key
+	var val int64
+	err := c.invalidate(func() (err error) {
+		val, err = c.client.Incr(ctx, namespacedKey).Result()
+		return err
+	}, func(p *pendingInvalidations) { p.addCounter(key) })
+	if err != nil {
+		c.logger.Errorw("Failed to increment counter in Redis", "key", namespacedKey, "error", err)
+		return 0, err
+	}
+	c.logger.Debugw("Counter incremented", "key", namespacedKey, "value", val)
+	return val, nil
+}
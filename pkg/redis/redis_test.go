@@ -7,7 +7,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/alicebob/miniredis"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,6 +111,93 @@ func TestJSONCache_Behavior(t *testing.T) {
 		// Confirm deletion
 		assert.False(t, suite.Server.Exists(fullKey), "Key should be removed after deletion")
 	})
+
+	t.Run("SetIfNotExists - only the first write wins", func(t *testing.T) {
+		users := generateTestUsers(2)
+		key := "once"
+
+		stored, err := suite.Cache.SetIfNotExists(ctx, key, users[0], time.Minute)
+		require.NoError(t, err)
+		assert.True(t, stored, "First write should be stored")
+
+		stored, err = suite.Cache.SetIfNotExists(ctx, key, users[1], time.Minute)
+		require.NoError(t, err)
+		assert.False(t, stored, "Second write should be rejected")
+
+		var result TestUser
+		require.NoError(t, suite.Cache.Get(ctx, key, &result))
+		assert.Equal(t, users[0], result)
+		assert.Equal(t, time.Minute, suite.Server.TTL("test:"+key))
+	})
+
+	t.Run("DeleteByPrefix - removes only matching keys in the namespace", func(t *testing.T) {
+		users := generateTestUsers(1)
+		for i := 0; i < deleteBatchSize+1; i++ {
+			require.NoError(t, suite.Cache.Set(ctx, fmt.Sprintf("page:%d", i), users, time.Minute))
+		}
+		require.NoError(t, suite.Cache.Set(ctx, "page*glob", users, time.Minute))
+		require.NoError(t, suite.Cache.Set(ctx, "pages", users, time.Minute))
+		require.NoError(t, suite.Server.Set("other:page:1", "kept"))
+
+		deleted, err := suite.Cache.DeleteByPrefix(ctx, "page:")
+		require.NoError(t, err)
+		assert.Equal(t, int64(deleteBatchSize+1), deleted)
+		assert.False(t, suite.Server.Exists("test:page:0"))
+		assert.True(t, suite.Server.Exists("test:page*glob"))
+		assert.True(t, suite.Server.Exists("test:pages"))
+		assert.True(t, suite.Server.Exists("other:page:1"))
+
+		// Glob characters in the prefix match literally
+		deleted, err = suite.Cache.DeleteByPrefix(ctx, "page*")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		assert.True(t, suite.Server.Exists("test:pages"))
+	})
+
+	t.Run("CountByPrefix and DeleteByPrefix - include back-references", func(t *testing.T) {
+		users := generateTestUsers(2)
+		require.NoError(t, suite.Cache.SetWithPointers(ctx, "user:1", users[0], []string{"email:" + users[0].Email}, time.Minute))
+		require.NoError(t, suite.Cache.Set(ctx, "user:2", users[1], time.Minute))
+		require.NoError(t, suite.Server.Set("{other:user:1}:refs", "kept"))
+
+		count, err := suite.Cache.CountByPrefix(ctx, "user:")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count, "two values and one set of back-references")
+
+		count, err = suite.Cache.CountByPrefix(ctx, "")
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, count, int64(4), "the namespace includes the email pointer")
+
+		deleted, err := suite.Cache.DeleteByPrefix(ctx, "user:")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+		assert.False(t, suite.Server.Exists("{test:user:1}:refs"))
+		assert.True(t, suite.Server.Exists("{other:user:1}:refs"))
+
+		count, err = suite.Cache.CountByPrefix(ctx, "user:")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("Incr - counts from an absent or seeded counter", func(t *testing.T) {
+		value, err := suite.Cache.Incr(ctx, "counter")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), value)
+
+		stored, err := suite.Cache.SetCounterIfNotExists(ctx, "seeded", 41, 0)
+		require.NoError(t, err)
+		assert.True(t, stored)
+		stored, err = suite.Cache.SetCounterIfNotExists(ctx, "seeded", 7, 0)
+		require.NoError(t, err)
+		assert.False(t, stored)
+		value, err = suite.Cache.Incr(ctx, "seeded")
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), value)
+
+		var read int64
+		require.NoError(t, suite.Cache.Get(ctx, "seeded", &read))
+		assert.Equal(t, int64(42), read)
+	})
 }
 
 func TestJSONCache_PointerBehavior(t *testing.T) {
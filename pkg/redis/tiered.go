@@ -0,0 +1,454 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+var _ Cache = (*TieredCache)(nil) // compile-time interface check
+
+// InvalidationChannel is the pub/sub channel on which tiered caches announce writes,
+// so every replica evicts its local copy of the keys written.
+const InvalidationChannel = "cache:invalidate"
+
+// TieredCache keeps recently read values in process memory in front of a JSONCache.
+// Writes go to Redis and are announced on InvalidationChannel, so the local copies on every
+// replica running Run are evicted. Announcements missed while a replica is disconnected
+// are not replayed: the local TTL bounds how long such a copy can be served.
+type TieredCache struct {
+	remote *JSONCache
+	local  *lru
+	ttl    time.Duration
+	origin string // Identifies this instance's own announcements
+	logger *zap.SugaredLogger
+
+	localHits  atomic.Uint64
+	remoteHits atomic.Uint64
+	misses     atomic.Uint64
+}
+
+// CacheStats counts the lookups served by each tier of a TieredCache.
+type CacheStats struct {
+	LocalHits  uint64 `json:"localHits"`
+	RemoteHits uint64 `json:"remoteHits"`
+	Misses     uint64 `json:"misses"`
+	Entries    int    `json:"entries"` // Values held in process memory
+}
+
+// LocalHitRate is the share of lookups served from process memory.
+func (s CacheStats) LocalHitRate() float64 {
+	return s.rate(s.LocalHits)
+}
+
+// RemoteHitRate is the share of lookups served from Redis.
+func (s CacheStats) RemoteHitRate() float64 {
+	return s.rate(s.RemoteHits)
+}
+
+// MarshalJSON includes the hit rates alongside the counts.
+func (s CacheStats) MarshalJSON() ([]byte, error) {
+	type counts CacheStats
+	return json.Marshal(struct {
+		counts
+		LocalHitRate  float64 `json:"localHitRate"`
+		RemoteHitRate float64 `json:"remoteHitRate"`
+	}{counts(s), s.LocalHitRate(), s.RemoteHitRate()})
+}
+
+func (s CacheStats) rate(hits uint64) float64 {
+	total := s.LocalHits + s.RemoteHits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
+
+// invalidation announces a write to the keys of a namespace
+type invalidation struct {
+	Origin    string   `json:"origin"`
+	Namespace string   `json:"namespace"`
+	Keys      []string `json:"keys"`
+	ByPrefix  bool     `json:"byPrefix,omitempty"` // Keys are prefixes of the keys written
+}
+
+// NewTieredCache initializes a TieredCache holding up to size values in memory for at most ttl.
+func NewTieredCache(remote *JSONCache, size int, ttl time.Duration) *TieredCache {
+	return &TieredCache{
+		remote: remote,
+		local:  newLRU(size),
+		ttl:    ttl,
+		origin: uuid.NewString(),
+		logger: remote.logger,
+	}
+}
+
+// Run evicts the local copies of keys written by other replicas until ctx is done.
+func (c *TieredCache) Run(ctx context.Context) error {
+	pubsub := c.remote.client.Subscribe(ctx, InvalidationChannel)
+	defer func() { _ = pubsub.Close() }()
+
+	// Wait for the subscription to be confirmed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to cache invalidations: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			c.handleInvalidation(msg)
+		}
+	}
+}
+
+// handleInvalidation evicts the local copies of the keys announced in a message
+func (c *TieredCache) handleInvalidation(msg *redis.Message) {
+	var inv invalidation
+	if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+		c.logger.Warnw("Ignoring invalid cache invalidation", "error", err)
+		return
+	}
+	if inv.Origin == c.origin || inv.Namespace != c.remote.prefix {
+		return
+	}
+
+	for _, key := range inv.Keys {
+		if inv.ByPrefix {
+			c.local.removePrefix(key)
+		} else {
+			c.local.remove(key)
+		}
+	}
+	c.logger.Debugw("Local cache invalidated", "namespace", inv.Namespace, "keys", inv.Keys, "byPrefix", inv.ByPrefix)
+}
+
+// announce publishes a write so other replicas evict their local copies.
+// A failed announcement is logged rather than returned, as the write itself succeeded.
+func (c *TieredCache) announce(ctx context.Context, byPrefix bool, keys ...string) {
+	payload, err := json.Marshal(invalidation{Origin: c.origin, Namespace: c.remote.prefix, Keys: keys, ByPrefix: byPrefix})
+	if err != nil {
+		c.logger.Errorw("Failed to serialize cache invalidation", "keys", keys, "error", err)
+		return
+	}
+	if err := c.remote.call(func() error { return c.remote.client.Publish(ctx, InvalidationChannel, payload).Err() }); err != nil {
+		c.logger.Warnw("Failed to publish cache invalidation", "keys", keys, "error", err)
+	}
+}
+
+// Stats returns the lookup counts since the cache was created.
+func (c *TieredCache) Stats() CacheStats {
+	return CacheStats{
+		LocalHits:  c.localHits.Load(),
+		RemoteHits: c.remoteHits.Load(),
+		Misses:     c.misses.Load(),
+		Entries:    c.local.len(),
+	}
+}
+
+// localTTL caps a value's time in memory at the cache's TTL and its TTL in Redis
+func (c *TieredCache) localTTL(ttl time.Duration) time.Duration {
+	if ttl > 0 && ttl < c.ttl {
+		return ttl
+	}
+	return c.ttl
+}
+
+func (c *TieredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := encode(c.remote.codec, value)
+	if err != nil {
+		c.logger.Errorw("Failed to serialize data", "key", key, "error", err)
+		return err
+	}
+	if err := c.remote.setBytes(ctx, key, data, ttl); err != nil {
+		c.local.remove(key)
+		return err
+	}
+
+	c.local.add(key, data, c.localTTL(ttl))
+	c.announce(ctx, false, key)
+	return nil
+}
+
+func (c *TieredCache) Get(ctx context.Context, key string, dest interface{}) error {
+	data, err := c.getBytes(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err := decode(data, dest); err != nil {
+		c.logger.Warnw("Failed to deserialize cached data", "key", key, "error", err)
+		c.local.remove(key)
+		return err
+	}
+	return nil
+}
+
+// getBytes reads serialized data from memory, then from Redis, returning redis.Nil on a miss
+func (c *TieredCache) getBytes(ctx context.Context, key string) ([]byte, error) {
+	if data, ok := c.local.get(key); ok {
+		c.localHits.Add(1)
+		return data, nil
+	}
+
+	data, err := c.remote.getBytes(ctx, key)
+	if err != nil {
+		if err == redis.Nil {
+			c.misses.Add(1)
+		}
+		return nil, err
+	}
+
+	c.remoteHits.Add(1)
+	c.local.add(key, data, c.ttl)
+	return data, nil
+}
+
+func (c *TieredCache) Delete(ctx context.Context, key string) error {
+	c.local.remove(key)
+	if err := c.remote.Delete(ctx, key); err != nil {
+		return err
+	}
+	c.announce(ctx, false, key)
+	return nil
+}
+
+// SetIfNotExists stores a value only when the key is absent in Redis, reporting whether it was stored
+func (c *TieredCache) SetIfNotExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	stored, err := c.remote.SetIfNotExists(ctx, key, value, ttl)
+	if err != nil || !stored {
+		return stored, err
+	}
+
+	// A local copy of an expired value may still be held here or elsewhere
+	c.local.remove(key)
+	c.announce(ctx, false, key)
+	return true, nil
+}
+
+// SetPointer sets a pointer from one key to another
+func (c *TieredCache) SetPointer(ctx context.Context, key string, targetKey string, ttl time.Duration) error {
+	if err := c.remote.SetPointer(ctx, key, targetKey, ttl); err != nil {
+		c.local.remove(key)
+		return err
+	}
+
+	c.local.add(key, []byte(targetKey), c.localTTL(ttl))
+	c.announce(ctx, false, key)
+	return nil
+}
+
+// GetPointer retrieves the pointer value, or "" when it is absent
+func (c *TieredCache) GetPointer(ctx context.Context, key string) (string, error) {
+	data, err := c.getBytes(ctx, key)
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil // cache miss — expected sometimes
+		}
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DeleteByPrefix removes every key in the namespace starting with prefix, here and on every replica
+func (c *TieredCache) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	c.local.removePrefix(prefix)
+	deleted, err := c.remote.DeleteByPrefix(ctx, prefix)
+
+	// Keys may have been deleted before a failure, so replicas are told either way
+	c.announce(ctx, true, prefix)
+	return deleted, err
+}
+
+// CountByPrefix counts the keys in the namespace starting with prefix in Redis
+func (c *TieredCache) CountByPrefix(ctx context.Context, prefix string) (int64, error) {
+	return c.remote.CountByPrefix(ctx, prefix)
+}
+
+// SetCounterIfNotExists seeds an integer counter in Redis only when the key is absent, reporting whether it was stored
+func (c *TieredCache) SetCounterIfNotExists(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+	stored, err := c.remote.SetCounterIfNotExists(ctx, key, value, ttl)
+	if err != nil || !stored {
+		return stored, err
+	}
+
+	c.local.remove(key)
+	c.announce(ctx, false, key)
+	return true, nil
+}
+
+// Incr atomically increments an integer counter in Redis and returns the new value
+func (c *TieredCache) Incr(ctx context.Context, key string) (int64, error) {
+	c.local.remove(key)
+	val, err := c.remote.Incr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	c.announce(ctx, false, key)
+	return val, nil
+}
+
+// GetOrLoad reads a value from memory, then through JSONCache.GetOrLoad, keeping it in memory.
+// Values refreshed in Redis in the background reach memory once the local copy expires.
+func (c *TieredCache) GetOrLoad(ctx context.Context, key string, dest interface{}, opts LoadOptions, load func(ctx context.Context) (any, error)) error {
+	if data, ok := c.local.get(key); ok {
+		if err := decode(data, dest); err == nil {
+			c.localHits.Add(1)
+			return nil
+		}
+		c.local.remove(key)
+	}
+
+	var loaded atomic.Bool
+	err := c.remote.GetOrLoad(ctx, key, dest, opts, func(ctx context.Context) (any, error) {
+		loaded.Store(true)
+		return load(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	if loaded.Load() {
+		c.misses.Add(1)
+	} else {
+		c.remoteHits.Add(1)
+	}
+
+	data, err := encode(c.remote.codec, dest)
+	if err != nil {
+		c.logger.Errorw("Failed to serialize data", "key", key, "error", err)
+		return nil
+	}
+	c.local.add(key, data, c.localTTL(opts.TTL))
+	return nil
+}
+
+// Coalesce runs fn once for concurrent callers sharing a key within this process
+func (c *TieredCache) Coalesce(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	return c.remote.Coalesce(ctx, key, fn)
+}
+
+// GetMany reads several values from memory, then the rest from Redis in one round trip (see JSONCache.GetMany)
+func (c *TieredCache) GetMany(ctx context.Context, keys []string, dest interface{}) ([]int, error) {
+	values := make([][]byte, len(keys))
+	var remoteKeys []string
+	var remoteIndexes []int
+	for i, key := range keys {
+		if data, ok := c.local.get(key); ok {
+			c.localHits.Add(1)
+			values[i] = data
+			continue
+		}
+		remoteKeys = append(remoteKeys, key)
+		remoteIndexes = append(remoteIndexes, i)
+	}
+
+	if len(remoteKeys) > 0 {
+		remoteValues, err := c.remote.getManyBytes(ctx, remoteKeys)
+		if err != nil {
+			return nil, err
+		}
+		for j, data := range remoteValues {
+			if data == nil {
+				c.misses.Add(1)
+				continue
+			}
+			c.remoteHits.Add(1)
+			c.local.add(remoteKeys[j], data, c.ttl)
+			values[remoteIndexes[j]] = data
+		}
+	}
+
+	missing, err := c.remote.decodeMany(keys, values, dest)
+	for _, i := range missing {
+		c.local.remove(keys[i])
+	}
+	return missing, err
+}
+
+// SetMany stores several values in Redis and in memory, announcing them in one message
+func (c *TieredCache) SetMany(ctx context.Context, entries map[string]interface{}, ttl time.Duration) error {
+	data, err := c.remote.encodeMany(entries)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	if err := c.remote.setManyBytes(ctx, data, ttl); err != nil {
+		for _, key := range keys {
+			c.local.remove(key)
+		}
+		return err
+	}
+
+	for key, encoded := range data {
+		c.local.add(key, encoded, c.localTTL(ttl))
+	}
+	if len(keys) > 0 {
+		c.announce(ctx, false, keys...)
+	}
+	return nil
+}
+
+// DeleteMany removes several keys here and on every replica, returning how many existed in Redis
+func (c *TieredCache) DeleteMany(ctx context.Context, keys []string) (int64, error) {
+	for _, key := range keys {
+		c.local.remove(key)
+	}
+	deleted, err := c.remote.DeleteMany(ctx, keys)
+
+	// Keys may have been deleted before a failure, so replicas are told either way
+	if len(keys) > 0 {
+		c.announce(ctx, false, keys...)
+	}
+	return deleted, err
+}
+
+// SetWithPointers stores a value and pointers to it in one transaction (see JSONCache.SetWithPointers),
+// keeping the value in memory
+func (c *TieredCache) SetWithPointers(ctx context.Context, key string, value interface{}, pointers []string, ttl time.Duration) error {
+	keys := append([]string{key}, pointers...)
+	for _, k := range keys {
+		c.local.remove(k)
+	}
+	if err := c.remote.SetWithPointers(ctx, key, value, pointers, ttl); err != nil {
+		return err
+	}
+
+	if data, err := encode(c.remote.codec, value); err == nil {
+		c.local.add(key, data, c.localTTL(ttl))
+	}
+	c.announce(ctx, false, keys...)
+	return nil
+}
+
+// GetByPointer reads the value a pointer key refers to from Redis, where the pointer is validated atomically
+func (c *TieredCache) GetByPointer(ctx context.Context, pointer string, dest interface{}) error {
+	data, err := c.remote.getByPointerBytes(ctx, pointer)
+	if err != nil {
+		if err == redis.Nil {
+			c.misses.Add(1)
+		}
+		return err
+	}
+	c.remoteHits.Add(1)
+
+	if err := decode(data, dest); err != nil {
+		c.logger.Warnw("Failed to deserialize cached data", "pointer", pointer, "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newReplicas returns tiered caches sharing one miniredis, as on separate replicas, each running Run
+func newReplicas(t *testing.T, prefixes ...string) (*miniredis.Miniredis, []*TieredCache) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	replicas := make([]*TieredCache, len(prefixes))
+	for i, prefix := range prefixes {
+		replicas[i] = NewTieredCache(NewJSONCache(client, prefix, zap.NewNop().Sugar()), 100, time.Minute)
+		go func() { _ = replicas[i].Run(ctx) }()
+	}
+
+	require.Eventually(t, func() bool {
+		return client.PubSubNumSub(context.Background(), InvalidationChannel).Val()[InvalidationChannel] == int64(len(prefixes))
+	}, time.Second, 5*time.Millisecond)
+	return mr, replicas
+}
+
+func TestTieredCache_Get(t *testing.T) {
+	ctx := context.Background()
+	mr, replicas := newReplicas(t, "test", "test")
+	a, b := replicas[0], replicas[1]
+	user := generateTestUsers(1)[0]
+
+	require.NoError(t, a.Set(ctx, "user-1", user, time.Hour))
+
+	// The first read on another replica comes from Redis, later ones from memory
+	var result TestUser
+	require.NoError(t, b.Get(ctx, "user-1", &result))
+	assert.Equal(t, user, result)
+	mr.Del("test:user-1")
+	require.NoError(t, b.Get(ctx, "user-1", &result))
+	assert.Equal(t, user, result)
+
+	assert.ErrorIs(t, b.Get(ctx, "missing", &result), redis.Nil)
+
+	stats := b.Stats()
+	assert.Equal(t, CacheStats{LocalHits: 1, RemoteHits: 1, Misses: 1, Entries: 1}, stats)
+	assert.InDelta(t, 1.0/3, stats.LocalHitRate(), 0.001)
+	assert.InDelta(t, 1.0/3, stats.RemoteHitRate(), 0.001)
+
+	data, err := json.Marshal(stats)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"localHits":1,"remoteHits":1,"misses":1,"entries":1,"localHitRate":0.3333333333333333,"remoteHitRate":0.3333333333333333}`, string(data))
+}
+
+func TestTieredCache_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	users := generateTestUsers(2)
+
+	t.Run("Delete evicts the entry on every replica", func(t *testing.T) {
+		_, replicas := newReplicas(t, "test", "test")
+		a, b := replicas[0], replicas[1]
+		require.NoError(t, a.Set(ctx, "user-1", users[0], time.Hour))
+		var result TestUser
+		require.NoError(t, b.Get(ctx, "user-1", &result))
+
+		require.NoError(t, a.Delete(ctx, "user-1"))
+		assert.Eventually(t, func() bool {
+			return b.Get(ctx, "user-1", &result) == redis.Nil
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Set replaces stale copies on other replicas", func(t *testing.T) {
+		_, replicas := newReplicas(t, "test", "test")
+		a, b := replicas[0], replicas[1]
+		require.NoError(t, a.Set(ctx, "user", users[0], time.Hour))
+		var result TestUser
+		require.NoError(t, b.Get(ctx, "user", &result))
+
+		require.NoError(t, a.Set(ctx, "user", users[1], time.Hour))
+		assert.Eventually(t, func() bool {
+			return b.Get(ctx, "user", &result) == nil && result == users[1]
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("DeleteByPrefix evicts matching entries on every replica", func(t *testing.T) {
+		_, replicas := newReplicas(t, "test", "test")
+		a, b := replicas[0], replicas[1]
+		require.NoError(t, a.Set(ctx, "page:1", users, time.Hour))
+		require.NoError(t, a.Set(ctx, "user-1", users[0], time.Hour))
+		var page []TestUser
+		var user TestUser
+		require.NoError(t, b.Get(ctx, "page:1", &page))
+		require.NoError(t, b.Get(ctx, "user-1", &user))
+
+		deleted, err := a.DeleteByPrefix(ctx, "page:")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		assert.Eventually(t, func() bool {
+			return b.Get(ctx, "page:1", &page) == redis.Nil
+		}, time.Second, 5*time.Millisecond)
+		assert.NoError(t, b.Get(ctx, "user-1", &user))
+	})
+
+	t.Run("own and other namespaces' announcements are ignored", func(t *testing.T) {
+		_, replicas := newReplicas(t, "test")
+		c := replicas[0]
+		require.NoError(t, c.Set(ctx, "user-1", users[0], time.Hour))
+
+		c.handleInvalidation(&redis.Message{Payload: `{"origin":"` + c.origin + `","namespace":"test","keys":["user-1"]}`})
+		c.handleInvalidation(&redis.Message{Payload: `{"origin":"other","namespace":"other","keys":["user-1"]}`})
+		assert.Equal(t, 1, c.Stats().Entries)
+
+		c.handleInvalidation(&redis.Message{Payload: `{"origin":"other","namespace":"test","keys":["user-1"]}`})
+		assert.Equal(t, 0, c.Stats().Entries)
+	})
+
+	t.Run("Incr evicts cached counters", func(t *testing.T) {
+		_, replicas := newReplicas(t, "test", "test")
+		a, b := replicas[0], replicas[1]
+		_, err := a.Incr(ctx, "gen")
+		require.NoError(t, err)
+		var gen int64
+		require.NoError(t, b.Get(ctx, "gen", &gen))
+		assert.Equal(t, int64(1), gen)
+
+		_, err = a.Incr(ctx, "gen")
+		require.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			return b.Get(ctx, "gen", &gen) == nil && gen == 2
+		}, time.Second, 5*time.Millisecond)
+	})
+}
+
+func TestTieredCache_Pointers(t *testing.T) {
+	ctx := context.Background()
+	mr, replicas := newReplicas(t, "test")
+	c := replicas[0]
+
+	require.NoError(t, c.SetPointer(ctx, "email:a@example.com", "id:1", time.Hour))
+	mr.Del("test:email:a@example.com")
+	target, err := c.GetPointer(ctx, "email:a@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "id:1", target, "pointers are served from memory")
+
+	target, err = c.GetPointer(ctx, "email:missing@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, target)
+}
+
+func TestTieredCache_GetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	_, replicas := newReplicas(t, "test", "test")
+	a, b := replicas[0], replicas[1]
+	user := generateTestUsers(1)[0]
+	opts := LoadOptions{TTL: time.Hour}
+
+	loads := 0
+	load := func(ctx context.Context) (any, error) {
+		loads++
+		return user, nil
+	}
+
+	var result TestUser
+	require.NoError(t, a.GetOrLoad(ctx, "user-1", &result, opts, load))
+	require.NoError(t, a.GetOrLoad(ctx, "user-1", &result, opts, load))
+	require.NoError(t, b.GetOrLoad(ctx, "user-1", &result, opts, load))
+	assert.Equal(t, user, result)
+	assert.Equal(t, 1, loads)
+
+	assert.Equal(t, CacheStats{LocalHits: 1, Misses: 1, Entries: 1}, a.Stats())
+	assert.Equal(t, CacheStats{RemoteHits: 1, Entries: 1}, b.Stats())
+}
+
+func TestLRU(t *testing.T) {
+	now := time.Now()
+	l := newLRU(2)
+	l.now = func() time.Time { return now }
+
+	l.add("a", []byte("1"), time.Minute)
+	l.add("b", []byte("2"), time.Minute)
+	_, _ = l.get("a") // b is now least recently used
+	l.add("c", []byte("3"), time.Minute)
+
+	_, ok := l.get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+	data, ok := l.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), data)
+
+	now = now.Add(time.Minute)
+	_, ok = l.get("a")
+	assert.False(t, ok, "entries expire after their TTL")
+	assert.Equal(t, 1, l.len())
+}
+
+func TestTieredCache_Batch(t *testing.T) {
+	ctx := context.Background()
+	mr, replicas := newReplicas(t, "test", "test")
+	a, b := replicas[0], replicas[1]
+	users := generateTestUsers(3)
+
+	require.NoError(t, a.SetMany(ctx, map[string]interface{}{"user-1": users[0], "user-2": users[1]}, time.Hour))
+	require.NoError(t, b.Set(ctx, "user-3", users[2], time.Hour))
+
+	// b holds user-3 in memory and reads the others from Redis
+	var result []TestUser
+	missing, err := b.GetMany(ctx, []string{"user-1", "user-2", "user-3", "missing"}, &result)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, missing)
+	assert.Equal(t, users, result[:3])
+	assert.Equal(t, CacheStats{LocalHits: 1, RemoteHits: 2, Misses: 1, Entries: 3}, b.Stats())
+
+	// Values read once are then served from memory
+	mr.Del("test:user-1")
+	_, err = b.GetMany(ctx, []string{"user-1"}, &result)
+	require.NoError(t, err)
+	assert.Equal(t, users[:1], result)
+
+	// DeleteMany evicts the entries on every replica
+	_, err = a.DeleteMany(ctx, []string{"user-1", "user-2"})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		missing, err := b.GetMany(ctx, []string{"user-1", "user-2"}, &result)
+		return err == nil && len(missing) == 2
+	}, time.Second, 5*time.Millisecond)
+}